@@ -1,23 +1,48 @@
 package access
 
-import "git.sr.ht/~loges/teammate/internal/access/application/services"
+import (
+	"git.sr.ht/~loges/teammate/internal/access/application/services"
+	"git.sr.ht/~loges/teammate/internal/access/infrastructure/memory"
+	"git.sr.ht/~loges/teammate/internal/eventstore"
+)
 
 // AccessApplication holds all services related to access management.
 type AccessApplication struct {
-	registrationService *services.RegistrationService
+	registrationService  *services.RegistrationService
+	authorizationService *services.AuthorizationService
+	exportSource         *services.ExportSource
 }
 
-// NewAccessApplication intitializes the access application.
-func NewAccessApplication() (*AccessApplication, error) {
-	rs, err := services.NewRegistrationService()
+// NewAccessApplication intitializes the access application. The given
+// configs are passed on to the user repository.
+func NewAccessApplication(cfgs ...memory.Configuration) (*AccessApplication, error) {
+	cfgs = append([]memory.Configuration{memory.WithKeyStore(eventstore.NewMemoryKeyStore())}, cfgs...)
+	users := memory.NewMemoryUserRepository(cfgs...)
+
+	rs, err := services.NewRegistrationService(services.WithUserRepository(users), services.WithKeyStore(memory.KeyStore(cfgs...)))
 	if err != nil {
 		return &AccessApplication{}, services.ErrInvalidRegistrationConfig
 	}
 
-	return &AccessApplication{registrationService: rs}, nil
+	return &AccessApplication{
+		registrationService:  rs,
+		authorizationService: services.NewAuthorizationService(users),
+		exportSource:         services.NewExportSource(users),
+	}, nil
 }
 
-// GetRosterService returns the roster service from the app.
+// GetRegistrationService returns the registration service from the app.
 func (a *AccessApplication) GetRegistrationService() *services.RegistrationService {
 	return a.registrationService
 }
+
+// GetAuthorizationService returns the authorization service from the app.
+func (a *AccessApplication) GetAuthorizationService() *services.AuthorizationService {
+	return a.authorizationService
+}
+
+// GetExportSource returns the source of access data for subject access
+// requests.
+func (a *AccessApplication) GetExportSource() *services.ExportSource {
+	return a.exportSource
+}
@@ -4,12 +4,18 @@ import (
 	"testing"
 
 	"git.sr.ht/~loges/teammate/internal/access/application/services"
+	"git.sr.ht/~loges/teammate/internal/access/domain/model"
+	"git.sr.ht/~loges/teammate/internal/access/infrastructure/memory"
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"git.sr.ht/~loges/teammate/internal/eventstore"
+	"github.com/google/uuid"
 	"github.com/matryer/is"
 )
 
 var (
 	exampleName  = "John"
 	exampleEmail = "john@teammate.com"
+	exampleGroup = &entity.Group{ID: uuid.MustParse("a45e93f8-c952-11ed-afa1-0242ac120002"), Name: "Lions"}
 )
 
 func withInvalidMemoryConfig() services.RegistrationConfiguration {
@@ -46,4 +52,23 @@ func TestMemoryApplication(t *testing.T) {
 		err = rs.RegisterUser(exampleName, exampleEmail)
 		is.NoErr(err)
 	})
+
+	t.Run("Authorization service shares registered users", func(t *testing.T) {
+		is := is.New(t)
+		store := eventstore.NewMemoryStore()
+		admin := &entity.Person{ID: uuid.New(), Name: "Ann"}
+		u, _ := model.NewUser(admin, "ann@teammate.com")
+		is.NoErr(u.GrantRole(model.RoleClubAdmin, nil))
+		is.NoErr(memory.NewMemoryUserRepository(memory.WithEventStore(store)).Add(u))
+
+		aa, err := NewAccessApplication(memory.WithEventStore(store))
+		is.NoErr(err)
+
+		err = aa.GetRegistrationService().RegisterUser(exampleName, exampleEmail)
+		is.NoErr(err)
+
+		as := aa.GetAuthorizationService()
+		is.Equal(as.GrantRole(exampleEmail, model.RoleCoach, exampleGroup), services.ErrNotAuthorized)
+		is.NoErr(as.As(admin).GrantRole(exampleEmail, model.RoleCoach, exampleGroup))
+	})
 }
@@ -0,0 +1,133 @@
+package projections
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"git.sr.ht/~loges/teammate/internal/access/domain/event"
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"github.com/google/uuid"
+)
+
+var ErrUserNotFound = errors.New("projections: the user was not found")
+
+// UserEntry is the read model of a user in the directory.
+type UserEntry struct {
+	ID        uuid.UUID
+	Name      string
+	Email     string
+	Activated bool
+	PlayerID  uuid.UUID
+}
+
+// UserDirectoryProjection maintains a directory of every user.
+type UserDirectoryProjection struct {
+	users map[uuid.UUID]*UserEntry
+	sync.RWMutex
+}
+
+// NewUserDirectoryProjection initializes an empty user directory.
+func NewUserDirectoryProjection() *UserDirectoryProjection {
+	return &UserDirectoryProjection{
+		users: make(map[uuid.UUID]*UserEntry),
+	}
+}
+
+// Name identifies the projection.
+func (p *UserDirectoryProjection) Name() string {
+	return "user_directory"
+}
+
+// Apply updates the directory with user events.
+func (p *UserDirectoryProjection) Apply(e any) error {
+	p.Lock()
+	defer p.Unlock()
+
+	switch ue := e.(type) {
+	case *event.UserRegistered:
+		p.users[ue.ID] = &UserEntry{
+			ID:        ue.ID,
+			Name:      ue.Name,
+			Email:     ue.Email,
+			Activated: true,
+		}
+
+	case *event.UserNameChanged:
+		if u, ok := p.users[ue.ID]; ok {
+			u.Name = ue.Name
+		}
+
+	case *event.UserEmailChanged:
+		if u, ok := p.users[ue.ID]; ok {
+			u.Email = ue.Email
+		}
+
+	case *event.UserActivated:
+		if u, ok := p.users[ue.ID]; ok {
+			u.Activated = true
+		}
+
+	case *event.UserDeactivated:
+		if u, ok := p.users[ue.ID]; ok {
+			u.Activated = false
+		}
+
+	case *event.PlayerLinkedToUser:
+		if u, ok := p.users[ue.ID]; ok {
+			u.PlayerID = ue.PlayerId
+		}
+
+	case *event.PlayerUnlinkedFromUser:
+		if u, ok := p.users[ue.ID]; ok {
+			u.PlayerID = uuid.Nil
+		}
+
+	case *event.UserErased:
+		if u, ok := p.users[ue.ID]; ok {
+			u.Name = entity.ErasedName
+			u.Email = ""
+			u.Activated = false
+		}
+	}
+
+	return nil
+}
+
+// Reset clears the directory.
+func (p *UserDirectoryProjection) Reset() error {
+	p.Lock()
+	defer p.Unlock()
+
+	p.users = make(map[uuid.UUID]*UserEntry)
+	return nil
+}
+
+// GetByEmail returns the directory entry of the user registered with email.
+func (p *UserDirectoryProjection) GetByEmail(email string) (*UserEntry, error) {
+	p.RLock()
+	defer p.RUnlock()
+
+	for _, u := range p.users {
+		if u.Email == email {
+			entry := *u
+			return &entry, nil
+		}
+	}
+	return &UserEntry{}, ErrUserNotFound
+}
+
+// List returns every user ordered by name.
+func (p *UserDirectoryProjection) List() (users []*UserEntry) {
+	p.RLock()
+	defer p.RUnlock()
+
+	for _, u := range p.users {
+		entry := *u
+		users = append(users, &entry)
+	}
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].Name < users[j].Name
+	})
+	return users
+}
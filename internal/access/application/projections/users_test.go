@@ -0,0 +1,72 @@
+package projections
+
+import (
+	"testing"
+
+	"git.sr.ht/~loges/teammate/internal/access/domain/event"
+	"github.com/google/uuid"
+	"github.com/matryer/is"
+)
+
+var (
+	exampleUUID     = uuid.MustParse("f55e93f8-c952-11ed-afa1-0242ac120002")
+	anotherUUID     = uuid.MustParse("f47ac10b-58cc-0372-8567-0e02b2c3d479")
+	examplePlayer   = uuid.MustParse("c15e93f8-c952-11ed-afa1-0242ac120002")
+	userRegistered  = &event.UserRegistered{ID: exampleUUID, Name: "Mike Ditka", Email: "ditka@teammate.com"}
+	anotherUser     = &event.UserRegistered{ID: anotherUUID, Name: "Joe Gibbs", Email: "gibbs@teammate.com"}
+	nameChanged     = &event.UserNameChanged{ID: exampleUUID, Name: "Michael Ditka"}
+	emailChanged    = &event.UserEmailChanged{ID: exampleUUID, Email: "mike@teammate.com"}
+	userDeactivated = &event.UserDeactivated{ID: exampleUUID}
+	playerLinked    = &event.PlayerLinkedToUser{ID: exampleUUID, PlayerId: examplePlayer}
+)
+
+func TestUserDirectoryProjection_GetByEmail(t *testing.T) {
+	testCases := []struct {
+		test              string
+		events            []any
+		email             string
+		expectedName      string
+		expectedActivated bool
+		expectedPlayer    uuid.UUID
+		expectedErr       error
+	}{
+		{"User not found", []any{anotherUser}, "ditka@teammate.com", "", false, uuid.Nil, ErrUserNotFound},
+		{"User registered", []any{userRegistered}, "ditka@teammate.com", "Mike Ditka", true, uuid.Nil, nil},
+		{"Name changed", []any{userRegistered, nameChanged}, "ditka@teammate.com", "Michael Ditka", true, uuid.Nil, nil},
+		{"Email changed", []any{userRegistered, emailChanged}, "mike@teammate.com", "Mike Ditka", true, uuid.Nil, nil},
+		{"User deactivated", []any{userRegistered, userDeactivated}, "ditka@teammate.com", "Mike Ditka", false, uuid.Nil, nil},
+		{"Player linked", []any{userRegistered, playerLinked}, "ditka@teammate.com", "Mike Ditka", true, examplePlayer, nil},
+		{"User erased", []any{userRegistered, &event.UserErased{ID: exampleUUID}}, "ditka@teammate.com", "", false, uuid.Nil, ErrUserNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			p := NewUserDirectoryProjection()
+			for _, e := range tc.events {
+				is.NoErr(p.Apply(e))
+			}
+
+			u, err := p.GetByEmail(tc.email)
+
+			is.Equal(err, tc.expectedErr)
+			is.Equal(u.Name, tc.expectedName)
+			is.Equal(u.Activated, tc.expectedActivated)
+			is.Equal(u.PlayerID, tc.expectedPlayer)
+		})
+	}
+}
+
+func TestUserDirectoryProjection_List(t *testing.T) {
+	is := is.New(t)
+	p := NewUserDirectoryProjection()
+	_ = p.Apply(userRegistered)
+	_ = p.Apply(anotherUser)
+
+	users := p.List()
+	is.Equal(len(users), 2)
+	is.Equal(users[0].Name, "Joe Gibbs")
+
+	is.NoErr(p.Reset())
+	is.Equal(len(p.List()), 0)
+}
@@ -0,0 +1,138 @@
+package services
+
+import (
+	"errors"
+	"sync"
+
+	"git.sr.ht/~loges/teammate/internal/access/domain/model"
+	"git.sr.ht/~loges/teammate/internal/access/domain/policy"
+	"git.sr.ht/~loges/teammate/internal/access/domain/repository"
+	"git.sr.ht/~loges/teammate/internal/entity"
+)
+
+var ErrNotAuthorized = errors.New("services: actor is not authorized")
+
+// AuthorizationConfiguration is a function that modifies the service.
+type AuthorizationConfiguration func(s *AuthorizationService)
+
+// WithHierarchy applies roles held within a group to the groups below it,
+// so a coach of a division may manage the rosters of its teams.
+func WithHierarchy(h policy.Hierarchy) AuthorizationConfiguration {
+	return func(s *AuthorizationService) {
+		s.policy.set(policy.NewScopedRolePolicy(h))
+	}
+}
+
+// sharedPolicy is the policy of a service and its copies, which the
+// application may replace while actions are authorized.
+type sharedPolicy struct {
+	policy policy.Policy
+	sync.RWMutex
+}
+
+func (p *sharedPolicy) get() policy.Policy {
+	p.RLock()
+	defer p.RUnlock()
+
+	return p.policy
+}
+
+func (p *sharedPolicy) set(policy policy.Policy) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.policy = policy
+}
+
+// AuthorizationService manages user roles and authorizes actions.
+type AuthorizationService struct {
+	users  repository.UserRepository
+	policy *sharedPolicy
+	actor  *entity.Person
+}
+
+// NewAuthorizationService returns a role based authorization service
+// backed by the given user repository.
+func NewAuthorizationService(users repository.UserRepository, cfgs ...AuthorizationConfiguration) *AuthorizationService {
+	s := &AuthorizationService{
+		users:  users,
+		policy: &sharedPolicy{policy: policy.NewRolePolicy()},
+	}
+	for _, cfg := range cfgs {
+		cfg(s)
+	}
+	return s
+}
+
+// As returns a copy of the service acting on behalf of the actor.
+func (s *AuthorizationService) As(actor *entity.Person) *AuthorizationService {
+	c := *s
+	c.actor = actor
+	return &c
+}
+
+// SetHierarchy applies roles held within a group to the groups below it
+// from now on. The application sets it once the hierarchy is known, when
+// it could not be configured up front.
+func (s *AuthorizationService) SetHierarchy(h entity.Hierarchy) {
+	s.policy.set(policy.NewScopedRolePolicy(h))
+}
+
+// GrantRole grants the user registered with email a role within the
+// group. The actor has to be allowed to manage the roles of the group, or
+// of the organization for a nil group.
+func (s *AuthorizationService) GrantRole(email string, role model.Role, group *entity.Group) error {
+	if err := s.Authorize(s.actor, entity.PermissionManageRoles, group); err != nil {
+		return err
+	}
+
+	u, err := s.users.GetByEmail(email)
+	if err != nil {
+		return err
+	}
+
+	if err = u.GrantRole(role, group); err != nil {
+		return err
+	}
+
+	return s.users.Update(u)
+}
+
+// RevokeRole revokes a role within the group from the user registered
+// with email. The actor has to be allowed to manage the roles of the
+// group, or of the organization for a nil group.
+func (s *AuthorizationService) RevokeRole(email string, role model.Role, group *entity.Group) error {
+	if err := s.Authorize(s.actor, entity.PermissionManageRoles, group); err != nil {
+		return err
+	}
+
+	u, err := s.users.GetByEmail(email)
+	if err != nil {
+		return err
+	}
+
+	if err = u.RevokeRole(role, group); err != nil {
+		return err
+	}
+
+	return s.users.Update(u)
+}
+
+// Authorize returns an error if the actor is not allowed the permission
+// within the group.
+func (s *AuthorizationService) Authorize(actor *entity.Person, p entity.Permission, group *entity.Group) error {
+	if actor == nil {
+		return ErrNotAuthorized
+	}
+
+	u, err := s.users.Get(actor)
+	if err != nil {
+		return ErrNotAuthorized
+	}
+
+	if !s.policy.get().Allows(u, p, group) {
+		return ErrNotAuthorized
+	}
+
+	return nil
+}
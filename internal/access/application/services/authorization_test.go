@@ -0,0 +1,172 @@
+package services
+
+import (
+	"testing"
+
+	"git.sr.ht/~loges/teammate/internal/access/domain/model"
+	"git.sr.ht/~loges/teammate/internal/access/domain/repository"
+	"git.sr.ht/~loges/teammate/internal/access/infrastructure/memory"
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"github.com/google/uuid"
+	"github.com/matryer/is"
+)
+
+var (
+	examplePerson = &entity.Person{ID: uuid.MustParse("f47ac10b-58cc-0372-8567-0e02b2c3d479"), Name: name}
+	exampleGroup  = &entity.Group{ID: uuid.MustParse("f55e93f8-c952-11ed-afa1-0242ac120002"), Name: "Tigers"}
+	anotherGroup  = &entity.Group{ID: uuid.MustParse("adbe93f8-c952-11ed-afa1-0242ac120002"), Name: "Bears"}
+	admin         = &entity.Person{ID: uuid.MustParse("ad7ac10b-58cc-0372-8567-0e02b2c3d479"), Name: "Ann"}
+)
+
+// newAuthorizationService returns a service acting on behalf of an admin
+// of the organization.
+func newAuthorizationService() *AuthorizationService {
+	users := memory.NewMemoryUserRepository()
+	u, _ := model.NewUser(examplePerson, email)
+	users.Add(u)
+	a, _ := model.NewUser(admin, "ann@teammate.com")
+	_ = a.GrantRole(model.RoleClubAdmin, nil)
+	users.Add(a)
+	return NewAuthorizationService(users).As(admin)
+}
+
+func TestAuthorizationService_GrantRole(t *testing.T) {
+	testCases := []struct {
+		test        string
+		email       string
+		role        model.Role
+		expectedErr error
+	}{
+		{"User not found", otherEmail, model.RoleCoach, repository.ErrUserNotFound},
+		{"Unknown role", email, model.Role("referee"), model.ErrInvalidRole},
+		{"Role already granted", email, model.RolePlayer, model.ErrUserUpdateFailed},
+		{"Role granted", email, model.RoleCoach, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			s := newAuthorizationService()
+			_ = s.GrantRole(email, model.RolePlayer, exampleGroup)
+
+			err := s.GrantRole(tc.email, tc.role, exampleGroup)
+
+			is.Equal(err, tc.expectedErr)
+		})
+	}
+}
+
+func TestAuthorizationService_RevokeRole(t *testing.T) {
+	testCases := []struct {
+		test        string
+		email       string
+		role        model.Role
+		expectedErr error
+	}{
+		{"User not found", otherEmail, model.RolePlayer, repository.ErrUserNotFound},
+		{"Role not granted", email, model.RoleCoach, model.ErrUserUpdateFailed},
+		{"Role revoked", email, model.RolePlayer, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			s := newAuthorizationService()
+			_ = s.GrantRole(email, model.RolePlayer, exampleGroup)
+
+			err := s.RevokeRole(tc.email, tc.role, exampleGroup)
+
+			is.Equal(err, tc.expectedErr)
+		})
+	}
+}
+
+func TestAuthorizationService_ManageRoles(t *testing.T) {
+	testCases := []struct {
+		test        string
+		actor       *entity.Person
+		group       *entity.Group
+		expectedErr error
+	}{
+		{"No actor", nil, exampleGroup, ErrNotAuthorized},
+		{"User grants themself a role", examplePerson, exampleGroup, ErrNotAuthorized},
+		{"Admin of another group", examplePerson, anotherGroup, ErrNotAuthorized},
+		{"Admin of the group", examplePerson, exampleGroup, nil},
+		{"Admin of a group grants organization roles", examplePerson, nil, ErrNotAuthorized},
+		{"Admin of the organization", admin, nil, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			s := newAuthorizationService()
+			if tc.expectedErr == nil && tc.actor == examplePerson {
+				is.NoErr(s.GrantRole(email, model.RoleClubAdmin, tc.group))
+			} else if tc.actor == examplePerson && tc.group == anotherGroup {
+				is.NoErr(s.GrantRole(email, model.RoleClubAdmin, exampleGroup))
+			}
+
+			err := s.As(tc.actor).GrantRole(email, model.RoleCoach, tc.group)
+
+			is.Equal(err, tc.expectedErr)
+			is.Equal(s.As(tc.actor).RevokeRole(email, model.RoleCoach, tc.group), tc.expectedErr)
+		})
+	}
+}
+
+func TestAuthorizationService_Authorize(t *testing.T) {
+	testCases := []struct {
+		test        string
+		actor       *entity.Person
+		permission  entity.Permission
+		group       *entity.Group
+		expectedErr error
+	}{
+		{"No actor", nil, entity.PermissionManageRoster, exampleGroup, ErrNotAuthorized},
+		{"Unknown actor", &entity.Person{ID: uuid.New(), Name: otherName}, entity.PermissionManageRoster, exampleGroup, ErrNotAuthorized},
+		{"Coach of other team", examplePerson, entity.PermissionManageRoster, anotherGroup, ErrNotAuthorized},
+		{"Coach lacks permission", examplePerson, entity.PermissionManageTeams, exampleGroup, ErrNotAuthorized},
+		{"Coach manages roster", examplePerson, entity.PermissionManageRoster, exampleGroup, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			s := newAuthorizationService()
+			_ = s.GrantRole(email, model.RoleCoach, exampleGroup)
+
+			err := s.Authorize(tc.actor, tc.permission, tc.group)
+
+			is.Equal(err, tc.expectedErr)
+		})
+	}
+}
+
+// club places the example group within the another group.
+type club struct{}
+
+func (club) GetAncestors(g *entity.Group) ([]*entity.Group, error) {
+	if g.ID == exampleGroup.ID {
+		return []*entity.Group{anotherGroup}, nil
+	}
+	return nil, nil
+}
+
+func TestAuthorizationService_AuthorizeWithHierarchy(t *testing.T) {
+	is := is.New(t)
+	users := memory.NewMemoryUserRepository()
+	u, _ := model.NewUser(examplePerson, email)
+	is.NoErr(u.GrantRole(model.RoleCoach, anotherGroup))
+	is.NoErr(users.Add(u))
+	s := NewAuthorizationService(users, WithHierarchy(club{}))
+
+	is.NoErr(s.Authorize(examplePerson, entity.PermissionManageRoster, exampleGroup))
+	is.NoErr(s.Authorize(examplePerson, entity.PermissionManageRoster, anotherGroup))
+	is.Equal(NewAuthorizationService(users).Authorize(examplePerson, entity.PermissionManageRoster, exampleGroup), ErrNotAuthorized)
+
+	later := NewAuthorizationService(users)
+	acting := later.As(examplePerson)
+	later.SetHierarchy(club{})
+	is.NoErr(later.Authorize(examplePerson, entity.PermissionManageRoster, exampleGroup))
+	is.NoErr(acting.Authorize(examplePerson, entity.PermissionManageRoster, exampleGroup)) // copies share the hierarchy
+}
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"git.sr.ht/~loges/teammate/internal/access/domain/repository"
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"git.sr.ht/~loges/teammate/internal/export"
+	"github.com/google/uuid"
+)
+
+// UserData is the data the access context holds about a user.
+type UserData struct {
+	ID        uuid.UUID      `json:"id"`
+	Name      string         `json:"name"`
+	Email     string         `json:"email"`
+	Activated bool           `json:"activated"`
+	PlayerID  uuid.UUID      `json:"player_id"`
+	Roles     []RoleData     `json:"roles"`
+	Events    []export.Event `json:"events"`
+}
+
+// RoleData is a role the user holds within a group.
+type RoleData struct {
+	Role    string    `json:"role"`
+	GroupID uuid.UUID `json:"group_id"`
+}
+
+// ExportSource gathers the data the access context holds about a person
+// for subject access requests.
+type ExportSource struct {
+	users repository.UserRepository
+}
+
+// NewExportSource returns an export source backed by the given user
+// repository.
+func NewExportSource(users repository.UserRepository) *ExportSource {
+	return &ExportSource{users: users}
+}
+
+// Name identifies the access section of the archive.
+func (s *ExportSource) Name() string {
+	return "access"
+}
+
+// Export returns the user record, roles and stored events of the person,
+// or nil if the person never registered.
+func (s *ExportSource) Export(subject *entity.Person) (any, error) {
+	u, err := s.users.Get(subject)
+	if err == repository.ErrUserNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := s.users.GetHistory(subject)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &UserData{
+		ID:        u.GetID(),
+		Name:      u.GetName(),
+		Email:     u.GetEmail(),
+		Activated: u.IsActivated(),
+		PlayerID:  u.GetPlayerID(),
+		Roles:     []RoleData{},
+		Events:    make([]export.Event, len(changes)),
+	}
+	for _, g := range u.GetRoleGrants() {
+		data.Roles = append(data.Roles, RoleData{Role: string(g.Role), GroupID: g.GroupID})
+	}
+	for i, c := range changes {
+		data.Events[i] = export.NewEvent(c.Version, c.RecordedAt, c.Event)
+	}
+	return data, nil
+}
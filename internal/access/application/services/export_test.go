@@ -0,0 +1,41 @@
+package services
+
+import (
+	"testing"
+
+	"git.sr.ht/~loges/teammate/internal/access/domain/model"
+	"git.sr.ht/~loges/teammate/internal/access/infrastructure/memory"
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"github.com/google/uuid"
+	"github.com/matryer/is"
+)
+
+func TestExportSource_Export(t *testing.T) {
+	registered := &entity.Person{ID: uuid.MustParse("f55e93f8-c952-11ed-afa1-0242ac120002"), Name: name}
+	testCases := []struct {
+		test     string
+		subject  *entity.Person
+		expected bool
+	}{
+		{"Person never registered", &entity.Person{ID: uuid.New()}, false},
+		{"Registered user", registered, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			users := memory.NewMemoryUserRepository()
+			u, _ := model.NewUser(registered, email)
+			_ = users.Add(u)
+			s := NewExportSource(users)
+
+			data, err := s.Export(tc.subject)
+
+			is.NoErr(err)
+			is.Equal(data != nil, tc.expected)
+			if tc.expected {
+				is.Equal(data.(*UserData).Email, email)
+			}
+		})
+	}
+}
@@ -7,10 +7,24 @@ import (
 	"git.sr.ht/~loges/teammate/internal/access/domain/repository"
 	"git.sr.ht/~loges/teammate/internal/access/infrastructure/memory"
 	"git.sr.ht/~loges/teammate/internal/entity"
+	"git.sr.ht/~loges/teammate/internal/eventstore"
 	"github.com/google/uuid"
 )
 
-var ErrInvalidRegistrationConfig = errors.New("services: invalid registration configuration")
+var (
+	ErrInvalidRegistrationConfig = errors.New("services: invalid registration configuration")
+	ErrPlayerClaimUnavailable    = errors.New("services: player profiles cannot be claimed")
+)
+
+// PlayerClaimer claims a player profile on behalf of a user.
+type PlayerClaimer interface {
+	ClaimPlayer(code string, user *entity.Person) (*entity.Person, error)
+}
+
+// UserEraser erases the personal data of a user held by another context.
+type UserEraser interface {
+	EraseUser(user *entity.Person) error
+}
 
 // RegistrationConfigs defines the configurations to intialize the service with.
 var RegistrationConfigs = []RegistrationConfiguration{
@@ -21,23 +35,66 @@ var RegistrationConfigs = []RegistrationConfiguration{
 type RegistrationConfiguration func(s *RegistrationService) error
 
 // WithMemoryRepositories attaches in memory repostories to service.
-func WithMemoryRepositories() RegistrationConfiguration {
+func WithMemoryRepositories(cfgs ...memory.Configuration) RegistrationConfiguration {
+	return func(s *RegistrationService) error {
+		cfgs := append([]memory.Configuration{memory.WithKeyStore(eventstore.NewMemoryKeyStore())}, cfgs...)
+		s.users = memory.NewMemoryUserRepository(cfgs...)
+		s.keys = memory.KeyStore(cfgs...)
+		return nil
+	}
+}
+
+// WithKeyStore erases personal data copied from other contexts by
+// erasing its key in ks, which the user repository encrypts with.
+func WithKeyStore(ks eventstore.KeyStore) RegistrationConfiguration {
 	return func(s *RegistrationService) error {
-		s.users = memory.NewMemoryUserRepository()
+		s.keys = ks
+		return nil
+	}
+}
+
+// WithUserEraser erases the personal data of users held by another
+// context whenever a user is erased.
+func WithUserEraser(e UserEraser) RegistrationConfiguration {
+	return func(s *RegistrationService) error {
+		s.eraser = e
+		return nil
+	}
+}
+
+// WithUserRepository attaches the given user repository to service. Pass
+// the key store the repository encrypts with to WithKeyStore as well.
+func WithUserRepository(users repository.UserRepository) RegistrationConfiguration {
+	return func(s *RegistrationService) error {
+		s.users = users
+		s.keys = nil
+		return nil
+	}
+}
+
+// WithPlayerClaimer allows users to claim player profiles with an invite.
+func WithPlayerClaimer(c PlayerClaimer) RegistrationConfiguration {
+	return func(s *RegistrationService) error {
+		s.claimer = c
 		return nil
 	}
 }
 
 // RegistrationService is a implementation of the RegistrationService.
 type RegistrationService struct {
-	users repository.UserRepository
+	users   repository.UserRepository
+	claimer PlayerClaimer
+	eraser  UserEraser
+	keys    eventstore.KeyStore
 }
 
-// NewRegistrationService accepts configs and returns a new service.
-func NewRegistrationService() (*RegistrationService, error) {
+// NewRegistrationService accepts configs and returns a new service. The
+// given configs are applied after the default RegistrationConfigs.
+func NewRegistrationService(cfgs ...RegistrationConfiguration) (*RegistrationService, error) {
 	s := &RegistrationService{}
 
-	for _, cfg := range RegistrationConfigs {
+	configs := append([]RegistrationConfiguration{}, RegistrationConfigs...)
+	for _, cfg := range append(configs, cfgs...) {
 		err := cfg(s)
 		if err != nil {
 			return nil, err
@@ -59,3 +116,83 @@ func (s *RegistrationService) RegisterUser(name, email string) error {
 
 	return nil
 }
+
+// RegisterUserWithInvite registers a user and links the player profile
+// the invite code was issued for. The user is registered before the
+// player is claimed, so if claiming fails the user stays registered and
+// can claim the player again with ClaimPlayer.
+func (s *RegistrationService) RegisterUserWithInvite(name, email, code string) error {
+	if s.claimer == nil {
+		return ErrPlayerClaimUnavailable
+	}
+
+	u, err := model.NewUser(&entity.Person{ID: uuid.New(), Name: name}, email)
+	if err != nil {
+		return err
+	}
+
+	if err = s.users.Add(u); err != nil {
+		return err
+	}
+
+	return s.ClaimPlayer(email, code)
+}
+
+// ClaimPlayer links the player profile the invite code was issued for
+// to the user registered with email.
+func (s *RegistrationService) ClaimPlayer(email, code string) error {
+	if s.claimer == nil {
+		return ErrPlayerClaimUnavailable
+	}
+
+	u, err := s.users.GetByEmail(email)
+	if err != nil {
+		return err
+	}
+
+	if u.GetPlayerID() != uuid.Nil {
+		return model.ErrUserUpdateFailed
+	}
+
+	player, err := s.claimer.ClaimPlayer(code, &entity.Person{ID: u.GetID(), Name: u.GetName()})
+	if err != nil {
+		return err
+	}
+
+	if err = u.LinkPlayer(player); err != nil {
+		return err
+	}
+
+	return s.users.Update(u)
+}
+
+// EraseUser erases the personal data of the user registered with email,
+// who loses access for good. The copies held by the user eraser are
+// erased first, so a failed erasure can be retried.
+func (s *RegistrationService) EraseUser(email string) error {
+	u, err := s.users.GetByEmail(email)
+	if err != nil {
+		return err
+	}
+
+	if err = u.Erase(); err != nil {
+		return err
+	}
+	if s.eraser != nil {
+		if err = s.eraser.EraseUser(&entity.Person{ID: u.GetID()}); err != nil {
+			return err
+		}
+	}
+
+	return s.users.Erase(u)
+}
+
+// ErasePlayer erases the personal data of the player copied into access
+// events, such as the player's name on the linked user. The team context
+// calls it when the player is erased.
+func (s *RegistrationService) ErasePlayer(player *entity.Person) error {
+	if s.keys == nil {
+		return nil
+	}
+	return s.keys.Erase(player.ID)
+}
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"errors"
 	"testing"
 
 	"git.sr.ht/~loges/teammate/internal/access/domain/model"
@@ -15,8 +16,24 @@ const (
 	email      = "mark@teammate.com"
 	otherName  = "Janet"
 	otherEmail = "janet@teammate.com"
+	inviteCode = "a1b2c3"
 )
 
+var (
+	errUnknownInvite = errors.New("unknown invite")
+	invitedPlayer    = &entity.Person{ID: uuid.MustParse("c15e93f8-c952-11ed-afa1-0242ac120002"), Name: otherName}
+)
+
+// stubClaimer hands out the invited player for the known invite code.
+type stubClaimer struct{}
+
+func (stubClaimer) ClaimPlayer(code string, user *entity.Person) (*entity.Person, error) {
+	if code != inviteCode {
+		return nil, errUnknownInvite
+	}
+	return invitedPlayer, nil
+}
+
 func TestNewRegistrationService(t *testing.T) {
 	t.Run("Create service with defaults", func(t *testing.T) {
 		is := is.New(t)
@@ -68,3 +85,151 @@ func TestRegistrationService_RegisterUser(t *testing.T) {
 		})
 	}
 }
+
+func TestRegistrationService_RegisterUserWithInvite(t *testing.T) {
+	testCases := []struct {
+		test        string
+		name        string
+		email       string
+		code        string
+		claimer     PlayerClaimer
+		expectedErr error
+	}{
+		{"Claiming not configured", otherName, otherEmail, inviteCode, nil, ErrPlayerClaimUnavailable},
+		{"Email already registered", name, email, inviteCode, stubClaimer{}, repository.ErrUserAlreadyExists},
+		{"Name missing", "", otherEmail, inviteCode, stubClaimer{}, model.ErrInputIsEmpty},
+		{"Unknown invite", otherName, otherEmail, "d4e5f6", stubClaimer{}, errUnknownInvite},
+		{"User registered and linked", otherName, otherEmail, inviteCode, stubClaimer{}, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			s, _ := NewRegistrationService(WithPlayerClaimer(tc.claimer))
+			u, _ := model.NewUser(&entity.Person{ID: uuid.New(), Name: name}, email)
+			s.users.Add(u)
+
+			err := s.RegisterUserWithInvite(tc.name, tc.email, tc.code)
+
+			is.Equal(err, tc.expectedErr)
+			if tc.expectedErr == nil {
+				registered, _ := s.users.GetByEmail(tc.email)
+				is.Equal(registered.GetPlayerID(), invitedPlayer.ID)
+			}
+		})
+	}
+
+	t.Run("User stays registered to claim again", func(t *testing.T) {
+		is := is.New(t)
+		s, _ := NewRegistrationService(WithPlayerClaimer(stubClaimer{}))
+
+		is.Equal(s.RegisterUserWithInvite(otherName, otherEmail, "d4e5f6"), errUnknownInvite)
+
+		is.NoErr(s.ClaimPlayer(otherEmail, inviteCode))
+		registered, _ := s.users.GetByEmail(otherEmail)
+		is.Equal(registered.GetPlayerID(), invitedPlayer.ID)
+	})
+}
+
+func TestRegistrationService_ClaimPlayer(t *testing.T) {
+	testCases := []struct {
+		test        string
+		email       string
+		code        string
+		claimer     PlayerClaimer
+		expectedErr error
+	}{
+		{"Claiming not configured", email, inviteCode, nil, ErrPlayerClaimUnavailable},
+		{"User not found", otherEmail, inviteCode, stubClaimer{}, repository.ErrUserNotFound},
+		{"Unknown invite", email, "d4e5f6", stubClaimer{}, errUnknownInvite},
+		{"Player claimed", email, inviteCode, stubClaimer{}, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			s, _ := NewRegistrationService(WithPlayerClaimer(tc.claimer))
+			u, _ := model.NewUser(&entity.Person{ID: uuid.New(), Name: name}, email)
+			s.users.Add(u)
+
+			err := s.ClaimPlayer(tc.email, tc.code)
+
+			is.Equal(err, tc.expectedErr)
+		})
+	}
+
+	t.Run("User already linked", func(t *testing.T) {
+		is := is.New(t)
+		s, _ := NewRegistrationService(WithPlayerClaimer(stubClaimer{}))
+		_ = s.RegisterUserWithInvite(otherName, otherEmail, inviteCode)
+
+		err := s.ClaimPlayer(otherEmail, inviteCode)
+
+		is.Equal(err, model.ErrUserUpdateFailed)
+	})
+}
+
+var errEraserUnavailable = errors.New("eraser unavailable")
+
+// failingEraser fails until it has recovered.
+type failingEraser struct {
+	recovered bool
+	erased    []*entity.Person
+}
+
+func (e *failingEraser) EraseUser(user *entity.Person) error {
+	if !e.recovered {
+		return errEraserUnavailable
+	}
+	e.erased = append(e.erased, user)
+	return nil
+}
+
+func TestRegistrationService_EraseUser(t *testing.T) {
+	testCases := []struct {
+		test        string
+		email       string
+		expectedErr error
+	}{
+		{"User not found", otherEmail, repository.ErrUserNotFound},
+		{"User erased", email, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			s, _ := NewRegistrationService()
+			_ = s.RegisterUser(name, email)
+
+			err := s.EraseUser(tc.email)
+
+			is.Equal(err, tc.expectedErr)
+			_, err = s.users.GetByEmail(email)
+			is.Equal(err == nil, tc.expectedErr != nil)
+		})
+	}
+
+	t.Run("Copies held by other contexts are erased first", func(t *testing.T) {
+		is := is.New(t)
+		eraser := &failingEraser{}
+		s, _ := NewRegistrationService(WithUserEraser(eraser))
+		_ = s.RegisterUser(name, email)
+
+		is.Equal(s.EraseUser(email), errEraserUnavailable)
+		_, err := s.users.GetByEmail(email)
+		is.NoErr(err)
+
+		eraser.recovered = true
+		is.NoErr(s.EraseUser(email))
+		is.Equal(len(eraser.erased), 1)
+	})
+
+	t.Run("Email can be registered again", func(t *testing.T) {
+		is := is.New(t)
+		s, _ := NewRegistrationService()
+		_ = s.RegisterUser(name, email)
+		is.NoErr(s.EraseUser(email))
+
+		is.NoErr(s.RegisterUser(name, email))
+	})
+}
@@ -54,3 +54,57 @@ type UserDeactivated struct {
 func (e UserDeactivated) eventName() string {
 	return reflect.TypeOf(e).Name()
 }
+
+// RoleGranted event.
+type RoleGranted struct {
+	ID        uuid.UUID `json:"id"`
+	Role      string    `json:"role"`
+	GroupId   uuid.UUID `json:"group_id"`
+	GroupName string    `json:"group_name"`
+}
+
+func (e RoleGranted) eventName() string {
+	return reflect.TypeOf(e).Name()
+}
+
+// RoleRevoked event.
+type RoleRevoked struct {
+	ID        uuid.UUID `json:"id"`
+	Role      string    `json:"role"`
+	GroupId   uuid.UUID `json:"group_id"`
+	GroupName string    `json:"group_name"`
+}
+
+func (e RoleRevoked) eventName() string {
+	return reflect.TypeOf(e).Name()
+}
+
+// PlayerLinkedToUser event.
+type PlayerLinkedToUser struct {
+	ID         uuid.UUID `json:"id"`
+	PlayerId   uuid.UUID `json:"player_id"`
+	PlayerName string    `json:"player_name"`
+}
+
+func (e PlayerLinkedToUser) eventName() string {
+	return reflect.TypeOf(e).Name()
+}
+
+// PlayerUnlinkedFromUser event.
+type PlayerUnlinkedFromUser struct {
+	ID       uuid.UUID `json:"id"`
+	PlayerId uuid.UUID `json:"player_id"`
+}
+
+func (e PlayerUnlinkedFromUser) eventName() string {
+	return reflect.TypeOf(e).Name()
+}
+
+// UserErased event.
+type UserErased struct {
+	ID uuid.UUID `json:"id"`
+}
+
+func (e UserErased) eventName() string {
+	return reflect.TypeOf(e).Name()
+}
@@ -17,6 +17,11 @@ func TestEvent(t *testing.T) {
 		{"UserEmailChanged event name", &UserEmailChanged{}, "UserEmailChanged"},
 		{"UserActivated event name", &UserActivated{}, "UserActivated"},
 		{"UserDeactivated event name", &UserDeactivated{}, "UserDeactivated"},
+		{"RoleGranted event name", &RoleGranted{}, "RoleGranted"},
+		{"RoleRevoked event name", &RoleRevoked{}, "RoleRevoked"},
+		{"PlayerLinkedToUser event name", &PlayerLinkedToUser{}, "PlayerLinkedToUser"},
+		{"PlayerUnlinkedFromUser event name", &PlayerUnlinkedFromUser{}, "PlayerUnlinkedFromUser"},
+		{"UserErased event name", &UserErased{}, "UserErased"},
 	}
 
 	for _, tc := range testCases {
@@ -0,0 +1,35 @@
+package model
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidRole = errors.New("model: role is not valid")
+
+// Role is a set of responsibilities a user holds within a group.
+type Role string
+
+const (
+	RoleClubAdmin   Role = "club_admin"
+	RoleCoach       Role = "coach"
+	RoleTeamManager Role = "team_manager"
+	RolePlayer      Role = "player"
+	RoleGuardian    Role = "guardian"
+)
+
+// IsValid returns whether the role is a known role.
+func (r Role) IsValid() bool {
+	switch r {
+	case RoleClubAdmin, RoleCoach, RoleTeamManager, RolePlayer, RoleGuardian:
+		return true
+	}
+	return false
+}
+
+// RoleGrant is a role held within a group.
+type RoleGrant struct {
+	Role    Role
+	GroupID uuid.UUID
+}
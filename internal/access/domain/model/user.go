@@ -2,6 +2,7 @@ package model
 
 import (
 	"errors"
+	"sort"
 
 	"git.sr.ht/~loges/teammate/internal/access/domain/event"
 	"git.sr.ht/~loges/teammate/internal/entity"
@@ -18,6 +19,9 @@ type User struct {
 	person    *entity.Person
 	email     string
 	activated bool
+	roles     map[uuid.UUID]map[Role]bool
+	playerId  uuid.UUID
+	erased    bool
 
 	changes []event.Event
 	version int
@@ -67,11 +71,63 @@ func (u *User) GetEmail() string {
 	return u.email
 }
 
+// GetPlayerID returns the ID of the player linked to the user, if any.
+func (u *User) GetPlayerID() uuid.UUID {
+	return u.playerId
+}
+
 // IsActivated returns whether the user is activated.
 func (u *User) IsActivated() bool {
 	return u.activated
 }
 
+// IsErased returns whether the personal data of the user was erased.
+func (u *User) IsErased() bool {
+	return u.erased
+}
+
+// HasRole returns whether the user holds the role within the group. A nil
+// group stands for the organization.
+func (u *User) HasRole(r Role, g *entity.Group) bool {
+	return u.roles[orOrganization(g).ID][r]
+}
+
+// HasRoleInAnyGroup returns whether the user holds the role within any group.
+func (u *User) HasRoleInAnyGroup(r Role) bool {
+	for _, roles := range u.roles {
+		if roles[r] {
+			return true
+		}
+	}
+	return false
+}
+
+// GetRoles returns the roles the user holds within the group. A nil group
+// stands for the organization.
+func (u *User) GetRoles(g *entity.Group) (roles []Role) {
+	for role := range u.roles[orOrganization(g).ID] {
+		roles = append(roles, role)
+	}
+	return roles
+}
+
+// GetRoleGrants returns every role the user holds, ordered by group.
+func (u *User) GetRoleGrants() []RoleGrant {
+	grants := []RoleGrant{}
+	for group, roles := range u.roles {
+		for role := range roles {
+			grants = append(grants, RoleGrant{Role: role, GroupID: group})
+		}
+	}
+	sort.Slice(grants, func(i, j int) bool {
+		if grants[i].GroupID != grants[j].GroupID {
+			return grants[i].GroupID.String() < grants[j].GroupID.String()
+		}
+		return grants[i].Role < grants[j].Role
+	})
+	return grants
+}
+
 // UpdateName updates the user's name.
 func (u *User) UpdateName(name string) error {
 	if u.person.Name == name {
@@ -126,6 +182,89 @@ func (u *User) Deactivate() error {
 	return nil
 }
 
+// GrantRole grants the user a role within the group. A nil group stands
+// for the organization.
+func (u *User) GrantRole(r Role, g *entity.Group) error {
+	g = orOrganization(g)
+	if !r.IsValid() {
+		return ErrInvalidRole
+	}
+	if u.HasRole(r, g) {
+		return ErrUserUpdateFailed
+	}
+
+	u.register(&event.RoleGranted{
+		ID:        u.person.ID,
+		Role:      string(r),
+		GroupId:   g.ID,
+		GroupName: g.Name,
+	})
+
+	return nil
+}
+
+// RevokeRole revokes a role the user holds within the group. A nil group
+// stands for the organization.
+func (u *User) RevokeRole(r Role, g *entity.Group) error {
+	g = orOrganization(g)
+	if !u.HasRole(r, g) {
+		return ErrUserUpdateFailed
+	}
+
+	u.register(&event.RoleRevoked{
+		ID:        u.person.ID,
+		Role:      string(r),
+		GroupId:   g.ID,
+		GroupName: g.Name,
+	})
+
+	return nil
+}
+
+// LinkPlayer links the user to the player profile.
+func (u *User) LinkPlayer(p *entity.Person) error {
+	if u.playerId != uuid.Nil {
+		return ErrUserUpdateFailed
+	}
+
+	u.register(&event.PlayerLinkedToUser{
+		ID:         u.person.ID,
+		PlayerId:   p.ID,
+		PlayerName: p.Name,
+	})
+
+	return nil
+}
+
+// UnlinkPlayer removes the link between the user and its player profile.
+func (u *User) UnlinkPlayer() error {
+	if u.playerId == uuid.Nil {
+		return ErrUserUpdateFailed
+	}
+
+	u.register(&event.PlayerUnlinkedFromUser{
+		ID:       u.person.ID,
+		PlayerId: u.playerId,
+	})
+
+	return nil
+}
+
+// Erase erases the personal data of the user, who loses access for good.
+// The personal data in stored events becomes unreadable once the key of
+// the user is destroyed.
+func (u *User) Erase() error {
+	if u.erased {
+		return ErrUserUpdateFailed
+	}
+
+	u.register(&event.UserErased{
+		ID: u.person.ID,
+	})
+
+	return nil
+}
+
 // Apply applies user events to the user aggregate.
 func (u *User) Apply(e event.Event, new bool) {
 	switch ue := e.(type) {
@@ -136,6 +275,7 @@ func (u *User) Apply(e event.Event, new bool) {
 		}
 		u.email = ue.Email
 		u.activated = true
+		u.roles = make(map[uuid.UUID]map[Role]bool)
 
 	case *event.UserNameChanged:
 		u.person.Name = ue.Name
@@ -148,6 +288,31 @@ func (u *User) Apply(e event.Event, new bool) {
 
 	case *event.UserActivated:
 		u.activated = true
+
+	case *event.RoleGranted:
+		if _, ok := u.roles[ue.GroupId]; !ok {
+			u.roles[ue.GroupId] = make(map[Role]bool)
+		}
+		u.roles[ue.GroupId][Role(ue.Role)] = true
+
+	case *event.RoleRevoked:
+		delete(u.roles[ue.GroupId], Role(ue.Role))
+		if len(u.roles[ue.GroupId]) == 0 {
+			delete(u.roles, ue.GroupId)
+		}
+
+	case *event.PlayerLinkedToUser:
+		u.playerId = ue.PlayerId
+
+	case *event.PlayerUnlinkedFromUser:
+		u.playerId = uuid.Nil
+
+	case *event.UserErased:
+		u.person.Name = entity.ErasedName
+		u.email = ""
+		u.activated = false
+		u.roles = make(map[uuid.UUID]map[Role]bool)
+		u.erased = true
 	}
 
 	if !new {
@@ -169,3 +334,11 @@ func (u *User) register(event event.Event) {
 	u.changes = append(u.changes, event)
 	u.Apply(event, true)
 }
+
+// orOrganization returns the group, or the organization for a nil group.
+func orOrganization(g *entity.Group) *entity.Group {
+	if g == nil {
+		return entity.Organization
+	}
+	return g
+}
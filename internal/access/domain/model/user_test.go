@@ -17,6 +17,11 @@ var (
 	anotherEmail    = "gibbs@teammate.com"
 	userRegistered  = &event.UserRegistered{ID: exampleUUID, Name: exampleName, Email: exampleEmail}
 	userDeactivated = &event.UserDeactivated{ID: exampleUUID}
+	exampleGroup    = &entity.Group{ID: uuid.MustParse("a1be93f8-c952-11ed-afa1-0242ac120002"), Name: "Lions"}
+	roleGranted     = &event.RoleGranted{ID: exampleUUID, Role: string(RoleCoach), GroupId: exampleGroup.ID, GroupName: exampleGroup.Name}
+	roleRevoked     = &event.RoleRevoked{ID: exampleUUID, Role: string(RoleCoach), GroupId: exampleGroup.ID, GroupName: exampleGroup.Name}
+	examplePlayer   = &entity.Person{ID: uuid.MustParse("c15e93f8-c952-11ed-afa1-0242ac120002"), Name: exampleName}
+	playerLinked    = &event.PlayerLinkedToUser{ID: exampleUUID, PlayerId: examplePlayer.ID, PlayerName: examplePlayer.Name}
 )
 
 func TestUser_NewUser(t *testing.T) {
@@ -178,6 +183,160 @@ func TestUser_Deactivate(t *testing.T) {
 	}
 }
 
+func TestUser_GrantRole(t *testing.T) {
+	testCases := []struct {
+		test        string
+		user        *User
+		role        Role
+		expectedErr error
+	}{
+		{
+			"Grant role",
+			NewUserFromEvents([]event.Event{userRegistered}),
+			RoleCoach,
+			nil,
+		},
+		{
+			"Grant role that is already held",
+			NewUserFromEvents([]event.Event{userRegistered, roleGranted}),
+			RoleCoach,
+			ErrUserUpdateFailed,
+		},
+		{
+			"Grant unknown role",
+			NewUserFromEvents([]event.Event{userRegistered}),
+			Role("referee"),
+			ErrInvalidRole,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			err := tc.user.GrantRole(tc.role, exampleGroup)
+			is.Equal(err, tc.expectedErr)
+		})
+	}
+}
+
+func TestUser_RevokeRole(t *testing.T) {
+	testCases := []struct {
+		test        string
+		user        *User
+		expectedErr error
+	}{
+		{
+			"Revoke role",
+			NewUserFromEvents([]event.Event{userRegistered, roleGranted}),
+			nil,
+		},
+		{
+			"Revoke role that is not held",
+			NewUserFromEvents([]event.Event{userRegistered, roleGranted, roleRevoked}),
+			ErrUserUpdateFailed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			err := tc.user.RevokeRole(RoleCoach, exampleGroup)
+			is.Equal(err, tc.expectedErr)
+			is.Equal(tc.user.HasRole(RoleCoach, exampleGroup), false)
+		})
+	}
+}
+
+func TestUser_GetRoles(t *testing.T) {
+	testCases := []struct {
+		test      string
+		events    []event.Event
+		roleCount int
+	}{
+		{"No roles granted", []event.Event{userRegistered}, 0},
+		{"Role granted", []event.Event{userRegistered, roleGranted}, 1},
+		{"Role granted and revoked", []event.Event{userRegistered, roleGranted, roleRevoked}, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			u := NewUserFromEvents(tc.events)
+			is.Equal(len(u.GetRoles(exampleGroup)), tc.roleCount)
+			is.Equal(len(u.GetRoleGrants()), tc.roleCount)
+			is.Equal(u.HasRoleInAnyGroup(RoleCoach), tc.roleCount > 0)
+		})
+	}
+}
+
+func TestUser_OrganizationRoles(t *testing.T) {
+	is := is.New(t)
+	u := NewUserFromEvents([]event.Event{userRegistered})
+
+	is.NoErr(u.GrantRole(RoleClubAdmin, nil))
+
+	is.True(u.HasRole(RoleClubAdmin, nil))
+	is.True(u.HasRole(RoleClubAdmin, entity.Organization))
+	is.Equal(u.GetRoles(nil), []Role{RoleClubAdmin})
+	is.Equal(u.GrantRole(RoleClubAdmin, entity.Organization), ErrUserUpdateFailed)
+	is.NoErr(u.RevokeRole(RoleClubAdmin, nil))
+	is.Equal(u.HasRole(RoleClubAdmin, entity.Organization), false)
+}
+
+func TestUser_LinkPlayer(t *testing.T) {
+	testCases := []struct {
+		test        string
+		user        *User
+		expectedErr error
+	}{
+		{"Link player", NewUserFromEvents([]event.Event{userRegistered}), nil},
+		{"Link player to already linked user", NewUserFromEvents([]event.Event{userRegistered, playerLinked}), ErrUserUpdateFailed},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			err := tc.user.LinkPlayer(examplePlayer)
+			is.Equal(err, tc.expectedErr)
+			is.Equal(tc.user.GetPlayerID(), examplePlayer.ID)
+		})
+	}
+}
+
+func TestUser_UnlinkPlayer(t *testing.T) {
+	testCases := []struct {
+		test        string
+		user        *User
+		expectedErr error
+	}{
+		{"Unlink player", NewUserFromEvents([]event.Event{userRegistered, playerLinked}), nil},
+		{"Unlink user without player", NewUserFromEvents([]event.Event{userRegistered}), ErrUserUpdateFailed},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			err := tc.user.UnlinkPlayer()
+			is.Equal(err, tc.expectedErr)
+			is.Equal(tc.user.GetPlayerID(), uuid.Nil)
+		})
+	}
+}
+
+func TestUser_Erase(t *testing.T) {
+	is := is.New(t)
+	u := NewUserFromEvents([]event.Event{userRegistered, roleGranted, playerLinked})
+
+	is.NoErr(u.Erase())
+	is.Equal(u.Erase(), ErrUserUpdateFailed)
+
+	is.True(u.IsErased())
+	is.Equal(u.GetName(), entity.ErasedName)
+	is.Equal(u.GetEmail(), "")
+	is.Equal(u.IsActivated(), false)
+	is.Equal(u.HasRole(RoleCoach, exampleGroup), false)
+}
+
 func TestUser_Events(t *testing.T) {
 	t.Run("Event log is populated", func(t *testing.T) {
 		is := is.New(t)
@@ -0,0 +1,108 @@
+package policy
+
+import (
+	"git.sr.ht/~loges/teammate/internal/access/domain/model"
+	"git.sr.ht/~loges/teammate/internal/entity"
+)
+
+// Policy decides whether a user is allowed to act within a group.
+type Policy interface {
+	Allows(u *model.User, p entity.Permission, g *entity.Group) bool
+}
+
+// Hierarchy is what a scoped policy looks up the groups above a group in.
+type Hierarchy = entity.Hierarchy
+
+// flat is the hierarchy of groups that are not placed within others.
+type flat struct{}
+
+func (flat) GetAncestors(*entity.Group) ([]*entity.Group, error) {
+	return nil, nil
+}
+
+// rolePermissions defines the permissions each role grants.
+var rolePermissions = map[model.Role][]entity.Permission{
+	model.RoleClubAdmin: {
+		entity.PermissionManageTeams,
+		entity.PermissionManagePlayers,
+		entity.PermissionManageRoster,
+		entity.PermissionViewRoster,
+		entity.PermissionViewSensitivePlayerData,
+		entity.PermissionManageRoles,
+	},
+	model.RoleCoach: {
+		entity.PermissionManageRoster,
+		entity.PermissionViewRoster,
+		entity.PermissionViewSensitivePlayerData,
+	},
+	model.RoleTeamManager: {
+		entity.PermissionManageRoster,
+		entity.PermissionViewRoster,
+	},
+	model.RolePlayer: {
+		entity.PermissionViewRoster,
+	},
+	model.RoleGuardian: {
+		entity.PermissionViewRoster,
+	},
+}
+
+// RolePolicy allows actions based on the roles a user holds.
+type RolePolicy struct {
+	hierarchy Hierarchy
+}
+
+// NewRolePolicy initializes a role based policy.
+func NewRolePolicy() *RolePolicy {
+	return &RolePolicy{hierarchy: flat{}}
+}
+
+// NewScopedRolePolicy initializes a role based policy where a role held
+// within a group also applies to the groups below it in the hierarchy.
+func NewScopedRolePolicy(h Hierarchy) *RolePolicy {
+	return &RolePolicy{hierarchy: h}
+}
+
+// Allows returns whether the user holds a role granting the permission
+// within the group, a group above it or the organization. A nil group
+// stands for the organization, so only roles held within the organization
+// allow actions that are not within a group.
+func (rp *RolePolicy) Allows(u *model.User, p entity.Permission, g *entity.Group) bool {
+	if !u.IsActivated() {
+		return false
+	}
+
+	for _, group := range rp.scope(g) {
+		for _, role := range u.GetRoles(group) {
+			if grants(role, p) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// scope returns the group, the groups above it and the organization. The
+// group is its own scope within the organization if its ancestors cannot
+// be found.
+func (rp *RolePolicy) scope(g *entity.Group) []*entity.Group {
+	if g == nil || g.ID == entity.Organization.ID {
+		return []*entity.Group{entity.Organization}
+	}
+	ancestors, err := rp.hierarchy.GetAncestors(g)
+	if err != nil {
+		return []*entity.Group{g, entity.Organization}
+	}
+	scope := append([]*entity.Group{g}, ancestors...)
+	return append(scope, entity.Organization)
+}
+
+func grants(r model.Role, p entity.Permission) bool {
+	for _, permission := range rolePermissions[r] {
+		if permission == p {
+			return true
+		}
+	}
+	return false
+}
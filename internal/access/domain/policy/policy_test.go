@@ -0,0 +1,170 @@
+package policy
+
+import (
+	"testing"
+
+	"git.sr.ht/~loges/teammate/internal/access/domain/event"
+	"git.sr.ht/~loges/teammate/internal/access/domain/model"
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"github.com/google/uuid"
+	"github.com/matryer/is"
+)
+
+var (
+	exampleUUID     = uuid.MustParse("f55e93f8-c952-11ed-afa1-0242ac120002")
+	exampleGroup    = &entity.Group{ID: uuid.MustParse("a1be93f8-c952-11ed-afa1-0242ac120002"), Name: "Lions"}
+	anotherGroup    = &entity.Group{ID: uuid.MustParse("b2ce93f8-c952-11ed-afa1-0242ac120002"), Name: "Bears"}
+	userRegistered  = &event.UserRegistered{ID: exampleUUID, Name: "Mike Ditka", Email: "ditka@teammate.com"}
+	userDeactivated = &event.UserDeactivated{ID: exampleUUID}
+)
+
+func roleGranted(r model.Role, g *entity.Group) *event.RoleGranted {
+	return &event.RoleGranted{ID: exampleUUID, Role: string(r), GroupId: g.ID, GroupName: g.Name}
+}
+
+func TestRolePolicy_Allows(t *testing.T) {
+	testCases := []struct {
+		test       string
+		events     []event.Event
+		permission entity.Permission
+		group      *entity.Group
+		expected   bool
+	}{
+		{
+			"User without roles",
+			[]event.Event{userRegistered},
+			entity.PermissionViewRoster,
+			exampleGroup,
+			false,
+		},
+		{
+			"Coach manages roster of own team",
+			[]event.Event{userRegistered, roleGranted(model.RoleCoach, exampleGroup)},
+			entity.PermissionManageRoster,
+			exampleGroup,
+			true,
+		},
+		{
+			"Coach cannot manage roster of other team",
+			[]event.Event{userRegistered, roleGranted(model.RoleCoach, exampleGroup)},
+			entity.PermissionManageRoster,
+			anotherGroup,
+			false,
+		},
+		{
+			"Coach cannot manage teams",
+			[]event.Event{userRegistered, roleGranted(model.RoleCoach, exampleGroup)},
+			entity.PermissionManageTeams,
+			exampleGroup,
+			false,
+		},
+		{
+			"Coach views sensitive player data of own team",
+			[]event.Event{userRegistered, roleGranted(model.RoleCoach, exampleGroup)},
+			entity.PermissionViewSensitivePlayerData,
+			exampleGroup,
+			true,
+		},
+		{
+			"Team manager cannot view sensitive player data",
+			[]event.Event{userRegistered, roleGranted(model.RoleTeamManager, exampleGroup)},
+			entity.PermissionViewSensitivePlayerData,
+			exampleGroup,
+			false,
+		},
+		{
+			"Player views roster",
+			[]event.Event{userRegistered, roleGranted(model.RolePlayer, exampleGroup)},
+			entity.PermissionViewRoster,
+			exampleGroup,
+			true,
+		},
+		{
+			"Club admin manages teams of own group",
+			[]event.Event{userRegistered, roleGranted(model.RoleClubAdmin, exampleGroup)},
+			entity.PermissionManageTeams,
+			exampleGroup,
+			true,
+		},
+		{
+			"Club admin cannot manage teams of other group",
+			[]event.Event{userRegistered, roleGranted(model.RoleClubAdmin, exampleGroup)},
+			entity.PermissionManageTeams,
+			anotherGroup,
+			false,
+		},
+		{
+			"Club admin of a group cannot manage players without a group",
+			[]event.Event{userRegistered, roleGranted(model.RoleClubAdmin, exampleGroup)},
+			entity.PermissionManagePlayers,
+			nil,
+			false,
+		},
+		{
+			"Organization admin manages players without a group",
+			[]event.Event{userRegistered, roleGranted(model.RoleClubAdmin, entity.Organization)},
+			entity.PermissionManagePlayers,
+			nil,
+			true,
+		},
+		{
+			"Organization admin manages teams in any group",
+			[]event.Event{userRegistered, roleGranted(model.RoleClubAdmin, entity.Organization)},
+			entity.PermissionManageTeams,
+			anotherGroup,
+			true,
+		},
+		{
+			"Deactivated user",
+			[]event.Event{userRegistered, roleGranted(model.RoleClubAdmin, exampleGroup), userDeactivated},
+			entity.PermissionViewRoster,
+			exampleGroup,
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			u := model.NewUserFromEvents(tc.events)
+			is.Equal(NewRolePolicy().Allows(u, tc.permission, tc.group), tc.expected)
+		})
+	}
+}
+
+// divisions places groups within the divisions they belong to.
+type divisions map[uuid.UUID][]*entity.Group
+
+func (d divisions) GetAncestors(g *entity.Group) ([]*entity.Group, error) {
+	return d[g.ID], nil
+}
+
+func TestScopedRolePolicy_Allows(t *testing.T) {
+	division := &entity.Group{ID: uuid.MustParse("c3de93f8-c952-11ed-afa1-0242ac120002"), Name: "U14"}
+	hierarchy := divisions{exampleGroup.ID: {division}}
+	testCases := []struct {
+		test     string
+		events   []event.Event
+		group    *entity.Group
+		expected bool
+	}{
+		{"Coach of the division manages roster of its team", []event.Event{userRegistered, roleGranted(model.RoleCoach, division)}, exampleGroup, true},
+		{"Coach of the division manages roster of the division", []event.Event{userRegistered, roleGranted(model.RoleCoach, division)}, division, true},
+		{"Coach of the division cannot manage roster of team outside it", []event.Event{userRegistered, roleGranted(model.RoleCoach, division)}, anotherGroup, false},
+		{"Coach of a team cannot manage roster of its division", []event.Event{userRegistered, roleGranted(model.RoleCoach, exampleGroup)}, division, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			u := model.NewUserFromEvents(tc.events)
+			is.Equal(NewScopedRolePolicy(hierarchy).Allows(u, entity.PermissionManageRoster, tc.group), tc.expected)
+		})
+	}
+
+	t.Run("Roles do not apply downwards without a hierarchy", func(t *testing.T) {
+		is := is.New(t)
+		u := model.NewUserFromEvents([]event.Event{userRegistered, roleGranted(model.RoleCoach, division)})
+		is.True(!NewRolePolicy().Allows(u, entity.PermissionManageRoster, exampleGroup))
+	})
+}
@@ -2,19 +2,33 @@ package repository
 
 import (
 	"errors"
+	"time"
 
+	"git.sr.ht/~loges/teammate/internal/access/domain/event"
 	"git.sr.ht/~loges/teammate/internal/access/domain/model"
+	"git.sr.ht/~loges/teammate/internal/entity"
 )
 
 var (
 	ErrUserNotFound      = errors.New("repository: the user was not found")
 	ErrUserAlreadyExists = errors.New("repository: user already exists")
 	ErrUserHasNoUpdates  = errors.New("repository: failed to update user")
+	ErrUserConflict      = errors.New("repository: user was changed concurrently")
 )
 
+// Change is an event stored for a user, as it was recorded.
+type Change struct {
+	Version    int
+	RecordedAt time.Time
+	Event      event.Event
+}
+
 // UserRepository defines the interface for the user repository.
 type UserRepository interface {
+	Get(*entity.Person) (*model.User, error)
 	GetByEmail(string) (*model.User, error)
+	GetHistory(*entity.Person) ([]Change, error)
 	Add(*model.User) error
 	Update(*model.User) error
+	Erase(*model.User) error
 }
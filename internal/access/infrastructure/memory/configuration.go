@@ -0,0 +1,46 @@
+package memory
+
+import (
+	"git.sr.ht/~loges/teammate/internal/access/domain/event"
+	"git.sr.ht/~loges/teammate/internal/access/domain/repository"
+	"git.sr.ht/~loges/teammate/internal/eventstore"
+	"git.sr.ht/~loges/teammate/internal/persistence"
+)
+
+// Configuration is a function that modifies an in-memory repository.
+type Configuration = persistence.Configuration
+
+var (
+	WithPublisher  = persistence.WithPublisher
+	WithOutbox     = persistence.WithOutbox
+	WithEventStore = persistence.WithEventStore
+	WithKeyStore   = persistence.WithKeyStore
+	WithTenant     = persistence.WithTenant
+	KeyStore       = persistence.KeyStore
+	Tenant         = persistence.Tenant
+)
+
+// events is the event storage of a repository.
+type events = persistence.Events[event.Event]
+
+func newEvents(cfgs []Configuration) events {
+	return persistence.New[event.Event](Schemas, cfgs...)
+}
+
+// history returns the events of the stream as they were recorded.
+func history(e events, s eventstore.Stream) ([]repository.Change, error) {
+	records, err := e.Records(s)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]repository.Change, len(records))
+	for i, r := range records {
+		changes[i] = repository.Change{
+			Version:    r.Version,
+			RecordedAt: r.RecordedAt,
+			Event:      r.Event.(event.Event),
+		}
+	}
+	return changes, nil
+}
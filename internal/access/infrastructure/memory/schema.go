@@ -0,0 +1,23 @@
+package memory
+
+import (
+	"git.sr.ht/~loges/teammate/internal/access/domain/event"
+	"git.sr.ht/~loges/teammate/internal/eventstore"
+)
+
+// Schemas lists how every access event is stored. When the fields of an
+// event change, bump its version and append an upcaster from the
+// previous version, and add a fixture of the new version to the tests.
+// Fields holding personal data are listed with the person they are about.
+var Schemas = []eventstore.Schema{
+	{Version: 1, New: func() any { return &event.UserRegistered{} }, Personal: []eventstore.PersonalData{{Subject: "id", Fields: []string{"email"}, Names: []string{"name"}}}},
+	{Version: 1, New: func() any { return &event.UserNameChanged{} }, Personal: []eventstore.PersonalData{{Subject: "id", Names: []string{"name"}}}},
+	{Version: 1, New: func() any { return &event.UserEmailChanged{} }, Personal: []eventstore.PersonalData{{Subject: "id", Fields: []string{"email"}}}},
+	{Version: 1, New: func() any { return &event.UserActivated{} }},
+	{Version: 1, New: func() any { return &event.UserDeactivated{} }},
+	{Version: 1, New: func() any { return &event.RoleGranted{} }},
+	{Version: 1, New: func() any { return &event.RoleRevoked{} }},
+	{Version: 1, New: func() any { return &event.PlayerLinkedToUser{} }, Personal: []eventstore.PersonalData{{Subject: "player_id", Names: []string{"player_name"}}}},
+	{Version: 1, New: func() any { return &event.PlayerUnlinkedFromUser{} }},
+	{Version: 1, New: func() any { return &event.UserErased{} }},
+}
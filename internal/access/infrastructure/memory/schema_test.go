@@ -0,0 +1,109 @@
+package memory
+
+import (
+	"strings"
+	"testing"
+
+	"git.sr.ht/~loges/teammate/internal/access/domain/event"
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"git.sr.ht/~loges/teammate/internal/eventstore"
+	"github.com/google/uuid"
+	"github.com/matryer/is"
+)
+
+var (
+	fixtureUserId   = `"f55e93f8-c952-11ed-afa1-0242ac120002"`
+	fixtureGroupId  = `"a55e93f8-c952-11ed-afa1-0242ac120002"`
+	fixturePlayerId = `"f47ac10b-58cc-0372-8567-0e02b2c3d479"`
+	fixtureGroup    = uuid.MustParse("a55e93f8-c952-11ed-afa1-0242ac120002")
+	fixturePlayer   = uuid.MustParse("f47ac10b-58cc-0372-8567-0e02b2c3d479")
+)
+
+// TestSchemas replays a stored fixture of every historical version of
+// every event.
+func TestSchemas(t *testing.T) {
+	testCases := []struct {
+		test     string
+		payload  *eventstore.Payload
+		expected any
+	}{
+		{
+			"UserRegistered version 1",
+			&eventstore.Payload{Type: "UserRegistered", SchemaVersion: 1, Data: []byte(`{"id":` + fixtureUserId + `,"name":"Logan","email":"logan@teammate.com"}`)},
+			&event.UserRegistered{ID: exampleUUID, Name: "Logan", Email: "logan@teammate.com"},
+		},
+		{
+			"UserNameChanged version 1",
+			&eventstore.Payload{Type: "UserNameChanged", SchemaVersion: 1, Data: []byte(`{"id":` + fixtureUserId + `,"name":"Emily"}`)},
+			&event.UserNameChanged{ID: exampleUUID, Name: "Emily"},
+		},
+		{
+			"UserEmailChanged version 1",
+			&eventstore.Payload{Type: "UserEmailChanged", SchemaVersion: 1, Data: []byte(`{"id":` + fixtureUserId + `,"email":"emily@teammate.com"}`)},
+			&event.UserEmailChanged{ID: exampleUUID, Email: "emily@teammate.com"},
+		},
+		{
+			"UserActivated version 1",
+			&eventstore.Payload{Type: "UserActivated", SchemaVersion: 1, Data: []byte(`{"id":` + fixtureUserId + `}`)},
+			&event.UserActivated{ID: exampleUUID},
+		},
+		{
+			"UserDeactivated version 1",
+			&eventstore.Payload{Type: "UserDeactivated", SchemaVersion: 1, Data: []byte(`{"id":` + fixtureUserId + `}`)},
+			&event.UserDeactivated{ID: exampleUUID},
+		},
+		{
+			"RoleGranted version 1",
+			&eventstore.Payload{Type: "RoleGranted", SchemaVersion: 1, Data: []byte(`{"id":` + fixtureUserId + `,"role":"coach","group_id":` + fixtureGroupId + `,"group_name":"Tigers"}`)},
+			&event.RoleGranted{ID: exampleUUID, Role: "coach", GroupId: fixtureGroup, GroupName: "Tigers"},
+		},
+		{
+			"RoleRevoked version 1",
+			&eventstore.Payload{Type: "RoleRevoked", SchemaVersion: 1, Data: []byte(`{"id":` + fixtureUserId + `,"role":"coach","group_id":` + fixtureGroupId + `,"group_name":"Tigers"}`)},
+			&event.RoleRevoked{ID: exampleUUID, Role: "coach", GroupId: fixtureGroup, GroupName: "Tigers"},
+		},
+		{
+			"PlayerLinkedToUser version 1",
+			&eventstore.Payload{Type: "PlayerLinkedToUser", SchemaVersion: 1, Data: []byte(`{"id":` + fixtureUserId + `,"player_id":` + fixturePlayerId + `,"player_name":"Logan"}`)},
+			&event.PlayerLinkedToUser{ID: exampleUUID, PlayerId: fixturePlayer, PlayerName: "Logan"},
+		},
+		{
+			"PlayerUnlinkedFromUser version 1",
+			&eventstore.Payload{Type: "PlayerUnlinkedFromUser", SchemaVersion: 1, Data: []byte(`{"id":` + fixtureUserId + `,"player_id":` + fixturePlayerId + `}`)},
+			&event.PlayerUnlinkedFromUser{ID: exampleUUID, PlayerId: fixturePlayer},
+		},
+		{
+			"UserErased version 1",
+			&eventstore.Payload{Type: "UserErased", SchemaVersion: 1, Data: []byte(`{"id":` + fixtureUserId + `}`)},
+			&event.UserErased{ID: exampleUUID},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			c := eventstore.NewCodec(Schemas...)
+
+			e, err := c.Decode(tc.payload)
+
+			is.NoErr(err)
+			is.Equal(e, tc.expected)
+		})
+	}
+}
+
+func TestSchemas_Erasure(t *testing.T) {
+	is := is.New(t)
+	keys := eventstore.NewMemoryKeyStore()
+	c := eventstore.NewEncryptingCodec(keys, Schemas...)
+	registered := &event.UserRegistered{ID: exampleUUID, Name: "Matt", Email: "matt@example.com"}
+
+	p, err := c.Encode(registered)
+	is.NoErr(err)
+	is.True(!strings.Contains(string(p.Data), registered.Email))
+
+	is.NoErr(keys.Erase(exampleUUID))
+	e, err := c.Decode(p)
+	is.NoErr(err)
+	is.Equal(e, &event.UserRegistered{ID: exampleUUID, Name: entity.ErasedName})
+}
@@ -3,50 +3,86 @@ package memory
 import (
 	"sync"
 
-	"git.sr.ht/~loges/teammate/internal/access/domain/event"
 	"git.sr.ht/~loges/teammate/internal/access/domain/model"
 	"git.sr.ht/~loges/teammate/internal/access/domain/repository"
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"git.sr.ht/~loges/teammate/internal/eventstore"
+	"github.com/google/uuid"
 )
 
+// userCategory is the event store category of user streams.
+const userCategory = "user"
+
 // MemoryUserRepository is an in-memory user repository.
 type MemoryUserRepository struct {
-	users map[string][]event.Event
+	emails map[string]uuid.UUID
+	events events
 	sync.Mutex
 }
 
 // NewMemoryUserRepository intializes an in-memory user repository.
-func NewMemoryUserRepository() *MemoryUserRepository {
+func NewMemoryUserRepository(cfgs ...Configuration) *MemoryUserRepository {
 	return &MemoryUserRepository{
-		users: make(map[string][]event.Event),
+		emails: make(map[string]uuid.UUID),
+		events: newEvents(cfgs),
 	}
 }
 
 // Get retrieves a user by ID.
-func (r *MemoryUserRepository) GetByEmail(email string) (*model.User, error) {
-	if events, ok := r.users[email]; ok {
+func (r *MemoryUserRepository) Get(p *entity.Person) (*model.User, error) {
+	if events, err := r.events.Load(r.events.Stream(userCategory, p.ID)); err == nil {
 		return model.NewUserFromEvents(events), nil
 	}
 
 	return &model.User{}, repository.ErrUserNotFound
 }
 
+// GetByEmail retrieves a user by email.
+func (r *MemoryUserRepository) GetByEmail(email string) (*model.User, error) {
+	r.Lock()
+	id, ok := r.emails[email]
+	r.Unlock()
+
+	if !ok {
+		return &model.User{}, repository.ErrUserNotFound
+	}
+	return r.Get(&entity.Person{ID: id})
+}
+
+// GetHistory retrieves the events stored for the user.
+func (r *MemoryUserRepository) GetHistory(p *entity.Person) ([]repository.Change, error) {
+	if changes, err := history(r.events, r.events.Stream(userCategory, p.ID)); err == nil {
+		return changes, nil
+	}
+
+	return []repository.Change{}, repository.ErrUserNotFound
+}
+
 // Add stores a new user in the repository.
 func (r *MemoryUserRepository) Add(p *model.User) error {
-	if _, ok := r.users[p.GetEmail()]; ok {
+	r.Lock()
+	_, ok := r.emails[p.GetEmail()]
+	r.Unlock()
+
+	if ok || r.events.Exists(r.events.Stream(userCategory, p.GetID())) {
 		return repository.ErrUserAlreadyExists
 	}
 
-	r.Lock()
-	r.users[p.GetEmail()] = p.Events()
-	defer r.Unlock()
+	err := r.events.Commit(r, r.events.Stream(userCategory, p.GetID()), 0, p.Events())
+	if err == eventstore.ErrConcurrencyConflict {
+		return repository.ErrUserAlreadyExists
+	}
+	if err != nil {
+		return err
+	}
 
+	r.index(p)
 	return nil
 }
 
 // Update appends changes to user in the repository.
 func (r *MemoryUserRepository) Update(p *model.User) error {
-	storedEvents, ok := r.users[p.GetEmail()]
-	if !ok {
+	if !r.events.Exists(r.events.Stream(userCategory, p.GetID())) {
 		return repository.ErrUserNotFound
 	}
 
@@ -55,9 +91,41 @@ func (r *MemoryUserRepository) Update(p *model.User) error {
 		return repository.ErrUserHasNoUpdates
 	}
 
+	err := r.events.Commit(r, r.events.Stream(userCategory, p.GetID()), p.Version(), newEvents)
+	if err == eventstore.ErrConcurrencyConflict {
+		return repository.ErrUserConflict
+	}
+	if err != nil {
+		return err
+	}
+
+	r.index(p)
+	return nil
+}
+
+// Erase stores the erasure of the user and destroys the key the personal
+// data of the user is encrypted with.
+func (r *MemoryUserRepository) Erase(p *model.User) error {
+	if !p.IsErased() {
+		return repository.ErrUserHasNoUpdates
+	}
+	if err := r.Update(p); err != nil {
+		return err
+	}
+	return r.events.Keys().Erase(p.GetID())
+}
+
+// index points the user's current email at the user.
+func (r *MemoryUserRepository) index(p *model.User) {
 	r.Lock()
-	r.users[p.GetEmail()] = append(storedEvents, newEvents...)
 	defer r.Unlock()
 
-	return nil
+	for email, id := range r.emails {
+		if id == p.GetID() {
+			delete(r.emails, email)
+		}
+	}
+	if !p.IsErased() {
+		r.emails[p.GetEmail()] = p.GetID()
+	}
 }
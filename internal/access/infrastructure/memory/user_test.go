@@ -6,6 +6,9 @@ import (
 	"git.sr.ht/~loges/teammate/internal/access/domain/event"
 	"git.sr.ht/~loges/teammate/internal/access/domain/model"
 	"git.sr.ht/~loges/teammate/internal/access/domain/repository"
+	"git.sr.ht/~loges/teammate/internal/bus"
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"git.sr.ht/~loges/teammate/internal/tenantid"
 	"github.com/google/uuid"
 	"github.com/matryer/is"
 )
@@ -19,6 +22,39 @@ var (
 	anotherEmail = "gibbs@teammate.com"
 )
 
+func TestMemoryAccessRepository_Get(t *testing.T) {
+	type testCase struct {
+		test        string
+		id          uuid.UUID
+		expectedErr error
+	}
+
+	testCases := []testCase{
+		{
+			test:        "No user with this entity",
+			id:          anotherUUID,
+			expectedErr: repository.ErrUserNotFound,
+		},
+		{
+			test:        "User found",
+			id:          exampleUUID,
+			expectedErr: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			repo := NewMemoryUserRepository()
+			seedUser(repo, &event.UserRegistered{ID: exampleUUID, Name: exampleName, Email: exampleEmail})
+
+			_, err := repo.Get(&entity.Person{ID: tc.id})
+
+			is.Equal(err, tc.expectedErr)
+		})
+	}
+}
+
 func TestMemoryAccessRepository_GetByEmail(t *testing.T) {
 	type testCase struct {
 		test        string
@@ -43,9 +79,7 @@ func TestMemoryAccessRepository_GetByEmail(t *testing.T) {
 		t.Run(tc.test, func(t *testing.T) {
 			is := is.New(t)
 			repo := NewMemoryUserRepository()
-			repo.users[exampleEmail] = []event.Event{
-				&event.UserRegistered{ID: exampleUUID, Name: exampleName, Email: exampleEmail},
-			}
+			seedUser(repo, &event.UserRegistered{ID: exampleUUID, Name: exampleName, Email: exampleEmail})
 
 			_, err := repo.GetByEmail(tc.email)
 
@@ -87,7 +121,7 @@ func TestMemoryAccessRepository_Add(t *testing.T) {
 			u := model.NewUserFromEvents([]event.Event{
 				&event.UserRegistered{ID: tc.id, Name: tc.name, Email: tc.email},
 			})
-			r.users[exampleEmail] = u.Events()
+			seedUser(r, &event.UserRegistered{ID: exampleUUID, Name: exampleName, Email: exampleEmail})
 
 			err := r.Add(u)
 
@@ -133,7 +167,7 @@ func TestMemoryAccessRepository_Update(t *testing.T) {
 				&event.UserRegistered{ID: exampleUUID, Name: exampleName, Email: exampleEmail},
 			})
 			if tc.register {
-				r.users[exampleEmail] = u.Events()
+				seedUser(r, &event.UserRegistered{ID: exampleUUID, Name: exampleName, Email: exampleEmail})
 			}
 			if tc.deactivate {
 				u.Deactivate()
@@ -145,3 +179,58 @@ func TestMemoryAccessRepository_Update(t *testing.T) {
 		})
 	}
 }
+
+func TestMemoryAccessRepository_GetHistory(t *testing.T) {
+	is := is.New(t)
+	r := NewMemoryUserRepository()
+	seedUser(r, &event.UserRegistered{ID: exampleUUID, Name: exampleName, Email: exampleEmail})
+
+	changes, err := r.GetHistory(&entity.Person{ID: exampleUUID})
+	is.NoErr(err)
+	is.Equal(len(changes), 1)
+	is.Equal(changes[0].Version, 1)
+
+	_, err = r.GetHistory(&entity.Person{ID: anotherUUID})
+	is.Equal(err, repository.ErrUserNotFound)
+}
+
+func TestMemoryAccessRepository_Erase(t *testing.T) {
+	is := is.New(t)
+	r := NewMemoryUserRepository()
+	u, _ := model.NewUser(&entity.Person{ID: exampleUUID, Name: exampleName}, exampleEmail)
+	is.NoErr(r.Add(u))
+	u, _ = r.Get(&entity.Person{ID: exampleUUID})
+	is.Equal(r.Erase(u), repository.ErrUserHasNoUpdates)
+
+	is.NoErr(u.Erase())
+	is.NoErr(r.Erase(u))
+
+	_, err := r.GetByEmail(exampleEmail)
+	is.Equal(err, repository.ErrUserNotFound)
+	records, _ := r.events.Store().ReadStream(r.events.Stream(userCategory, exampleUUID))
+	is.Equal(records[0].Event, &event.UserRegistered{ID: exampleUUID, Name: entity.ErasedName})
+}
+
+func TestMemoryAccessRepository_Publish(t *testing.T) {
+	is := is.New(t)
+	b := bus.New()
+	var registered []*event.UserRegistered
+	bus.Subscribe(b, tenantid.None, func(e *event.UserRegistered) error {
+		registered = append(registered, e)
+		return nil
+	})
+	r := NewMemoryUserRepository(WithPublisher(b))
+	u, _ := model.NewUser(&entity.Person{ID: exampleUUID, Name: exampleName}, exampleEmail)
+
+	is.NoErr(r.Add(u))
+	is.Equal(r.Add(u), repository.ErrUserAlreadyExists)
+
+	is.Equal(len(registered), 1)
+	is.Equal(registered[0].Email, exampleEmail)
+}
+
+// seedUser stores the registration as if the user had been added before.
+func seedUser(r *MemoryUserRepository, e *event.UserRegistered) {
+	_ = r.events.Store().Append(r.events.Stream(userCategory, e.ID), 0, e)
+	r.emails[e.Email] = e.ID
+}
@@ -0,0 +1,241 @@
+package bus
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"git.sr.ht/~loges/teammate/internal/tenantid"
+)
+
+var (
+	ErrDeliveryFailed = errors.New("bus: event delivery failed")
+	ErrBusClosed      = errors.New("bus: bus is closed")
+)
+
+// Mode defines how the bus delivers events to handlers.
+type Mode int
+
+const (
+	// Synchronous delivers events to every handler before Publish returns.
+	Synchronous Mode = iota
+	// Asynchronous queues events and delivers them in order on a separate
+	// goroutine. Publish never blocks, so handlers may publish events too.
+	Asynchronous
+)
+
+// Publisher publishes the committed domain events of a tenant.
+type Publisher interface {
+	Publish(t tenantid.ID, events ...any) error
+}
+
+// Discard is a publisher that drops every event.
+var Discard Publisher = discard{}
+
+type discard struct{}
+
+func (discard) Publish(tenantid.ID, ...any) error {
+	return nil
+}
+
+// HandlerError is reported when a handler fails to handle an event.
+type HandlerError struct {
+	Event any
+	Err   error
+}
+
+func (e *HandlerError) Error() string {
+	return fmt.Sprintf("bus: handler for %T failed: %v", e.Event, e.Err)
+}
+
+func (e *HandlerError) Unwrap() error {
+	return e.Err
+}
+
+// Configuration is a function that modifies the bus.
+type Configuration func(b *Bus)
+
+// WithMode sets the delivery mode of the bus.
+func WithMode(m Mode) Configuration {
+	return func(b *Bus) {
+		b.mode = m
+	}
+}
+
+// WithErrorHandler reports every handler failure to fn.
+func WithErrorHandler(fn func(error)) Configuration {
+	return func(b *Bus) {
+		b.onError = fn
+	}
+}
+
+type subscription struct {
+	tenant  tenantid.ID
+	typ     reflect.Type
+	handler func(any) error
+}
+
+// batch is a set of events published together by a tenant.
+type batch struct {
+	tenant tenantid.ID
+	events []any
+}
+
+// Bus is an in-process publish/subscribe event bus. Handlers are isolated
+// from each other: a failing or panicking handler does not stop delivery
+// to the remaining handlers and never reaches the publisher's aggregate.
+// Handlers only receive the events of the tenant they subscribed for, so
+// tenants can share a bus.
+type Bus struct {
+	mode          Mode
+	onError       func(error)
+	subscriptions []subscription
+	queue         []batch
+	queued        *sync.Cond
+	done          chan struct{}
+	closed        bool
+	mu            sync.RWMutex
+	queueMu       sync.Mutex
+}
+
+// New initializes a bus with the given configs.
+func New(cfgs ...Configuration) *Bus {
+	b := &Bus{
+		mode:    Synchronous,
+		onError: func(error) {},
+	}
+	b.queued = sync.NewCond(&b.queueMu)
+
+	for _, cfg := range cfgs {
+		cfg(b)
+	}
+
+	if b.mode == Asynchronous {
+		b.done = make(chan struct{})
+		go b.run()
+	}
+
+	return b
+}
+
+// Subscribe registers a handler for events of type E published by the
+// tenant. If E is an interface type, the handler receives every event
+// implementing it.
+func Subscribe[E any](b *Bus, t tenantid.ID, handler func(E) error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.subscriptions = append(b.subscriptions, subscription{
+		tenant: t,
+		typ:    reflect.TypeOf((*E)(nil)).Elem(),
+		handler: func(e any) error {
+			return handler(e.(E))
+		},
+	})
+}
+
+// Publish delivers the events of the tenant to their handlers. A
+// synchronous bus returns ErrDeliveryFailed if any handler failed; an
+// asynchronous bus only reports failures to its error handler.
+func (b *Bus) Publish(t tenantid.ID, events ...any) error {
+	if b.mode == Asynchronous {
+		b.queueMu.Lock()
+		defer b.queueMu.Unlock()
+
+		if b.closed {
+			return ErrBusClosed
+		}
+		b.queue = append(b.queue, batch{tenant: t, events: events})
+		b.queued.Signal()
+		return nil
+	}
+
+	return b.deliver(batch{tenant: t, events: events})
+}
+
+// Close stops an asynchronous bus after delivering every queued event.
+func (b *Bus) Close() {
+	b.queueMu.Lock()
+	if b.closed || b.mode != Asynchronous {
+		b.closed = true
+		b.queueMu.Unlock()
+		return
+	}
+	b.closed = true
+	b.queued.Broadcast()
+	b.queueMu.Unlock()
+
+	<-b.done
+}
+
+func (b *Bus) run() {
+	defer close(b.done)
+
+	for {
+		next, ok := b.next()
+		if !ok {
+			return
+		}
+		_ = b.deliver(next)
+	}
+}
+
+// next waits for the oldest queued events. It returns false once the bus
+// is closed and every event is delivered.
+func (b *Bus) next() (batch, bool) {
+	b.queueMu.Lock()
+	defer b.queueMu.Unlock()
+
+	for len(b.queue) == 0 && !b.closed {
+		b.queued.Wait()
+	}
+	if len(b.queue) == 0 {
+		return batch{}, false
+	}
+	next := b.queue[0]
+	b.queue[0] = batch{}
+	b.queue = b.queue[1:]
+	return next, true
+}
+
+func (b *Bus) deliver(next batch) (err error) {
+	for _, e := range next.events {
+		for _, s := range b.handlersFor(next.tenant, e) {
+			if herr := call(s.handler, e); herr != nil {
+				b.onError(&HandlerError{Event: e, Err: herr})
+				err = ErrDeliveryFailed
+			}
+		}
+	}
+	return err
+}
+
+func (b *Bus) handlersFor(tenant tenantid.ID, e any) (subscriptions []subscription) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	t := reflect.TypeOf(e)
+	if t == nil {
+		return nil
+	}
+	for _, s := range b.subscriptions {
+		if s.tenant != tenant {
+			continue
+		}
+		if s.typ == t || (s.typ.Kind() == reflect.Interface && t.Implements(s.typ)) {
+			subscriptions = append(subscriptions, s)
+		}
+	}
+	return subscriptions
+}
+
+func call(handler func(any) error, e any) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	return handler(e)
+}
@@ -0,0 +1,191 @@
+package bus
+
+import (
+	"errors"
+	"testing"
+
+	"git.sr.ht/~loges/teammate/internal/tenantid"
+	"github.com/matryer/is"
+)
+
+type named interface {
+	name() string
+}
+
+type created struct{ ID int }
+
+func (created) name() string { return "created" }
+
+type deleted struct{ ID int }
+
+func (deleted) name() string { return "deleted" }
+
+var errHandler = errors.New("handler failed")
+
+func TestBus_Subscribe(t *testing.T) {
+	testCases := []struct {
+		test          string
+		events        []any
+		createdCount  int
+		interfaceSeen int
+	}{
+		{"No events", []any{}, 0, 0},
+		{"Typed handler only receives its type", []any{&created{1}, &deleted{1}}, 1, 2},
+		{"Unrelated events are ignored", []any{"event", nil}, 0, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			b := New()
+			createdCount, interfaceSeen := 0, 0
+			Subscribe(b, tenantid.None, func(e *created) error {
+				createdCount++
+				return nil
+			})
+			Subscribe(b, tenantid.None, func(e named) error {
+				interfaceSeen++
+				return nil
+			})
+
+			err := b.Publish(tenantid.None, tc.events...)
+
+			is.NoErr(err)
+			is.Equal(createdCount, tc.createdCount)
+			is.Equal(interfaceSeen, tc.interfaceSeen)
+		})
+	}
+}
+
+func TestBus_Tenant(t *testing.T) {
+	is := is.New(t)
+	b := New()
+	var riverside, untenanted []int
+	Subscribe(b, "riverside", func(e *created) error {
+		riverside = append(riverside, e.ID)
+		return nil
+	})
+	Subscribe(b, tenantid.None, func(e *created) error {
+		untenanted = append(untenanted, e.ID)
+		return nil
+	})
+
+	is.NoErr(b.Publish("riverside", &created{1}))
+	is.NoErr(b.Publish("lakeside", &created{2}))
+	is.NoErr(b.Publish(tenantid.None, &created{3}))
+
+	is.Equal(riverside, []int{1})
+	is.Equal(untenanted, []int{3})
+}
+
+func TestBus_HandlerIsolation(t *testing.T) {
+	testCases := []struct {
+		test    string
+		handler func(*created) error
+	}{
+		{"Handler returns error", func(*created) error { return errHandler }},
+		{"Handler panics", func(*created) error { panic("boom") }},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			var reported []error
+			b := New(WithErrorHandler(func(err error) { reported = append(reported, err) }))
+			delivered := false
+			Subscribe(b, tenantid.None, tc.handler)
+			Subscribe(b, tenantid.None, func(*created) error {
+				delivered = true
+				return nil
+			})
+
+			err := b.Publish(tenantid.None, &created{1})
+
+			is.Equal(err, ErrDeliveryFailed)
+			is.True(delivered)
+			is.Equal(len(reported), 1)
+			var herr *HandlerError
+			is.True(errors.As(reported[0], &herr))
+		})
+	}
+}
+
+func TestBus_Asynchronous(t *testing.T) {
+	t.Run("Events are delivered in order", func(t *testing.T) {
+		is := is.New(t)
+		b := New(WithMode(Asynchronous))
+		var ids []int
+		Subscribe(b, tenantid.None, func(e *created) error {
+			ids = append(ids, e.ID)
+			return nil
+		})
+
+		for i := 1; i <= 5; i++ {
+			is.NoErr(b.Publish(tenantid.None, &created{i}))
+		}
+		b.Close()
+
+		is.Equal(ids, []int{1, 2, 3, 4, 5})
+	})
+
+	t.Run("Handlers publish events", func(t *testing.T) {
+		is := is.New(t)
+		b := New(WithMode(Asynchronous))
+		var ids []int
+		delivered := make(chan struct{})
+		Subscribe(b, tenantid.None, func(e *created) error {
+			for i := 0; i < 100; i++ {
+				if err := b.Publish(tenantid.None, &deleted{e.ID*100 + i}); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		Subscribe(b, tenantid.None, func(e *deleted) error {
+			if ids = append(ids, e.ID); len(ids) == 300 {
+				close(delivered)
+			}
+			return nil
+		})
+
+		for i := 1; i <= 3; i++ {
+			is.NoErr(b.Publish(tenantid.None, &created{i}))
+		}
+		<-delivered
+		b.Close()
+
+		is.Equal(len(ids), 300)
+		is.Equal(ids[0], 100)
+		is.Equal(ids[299], 399)
+	})
+
+	t.Run("Handler errors are reported", func(t *testing.T) {
+		is := is.New(t)
+		var reported []error
+		b := New(WithMode(Asynchronous), WithErrorHandler(func(err error) { reported = append(reported, err) }))
+		Subscribe(b, tenantid.None, func(*created) error { return errHandler })
+
+		err := b.Publish(tenantid.None, &created{1})
+		b.Close()
+
+		is.NoErr(err)
+		is.Equal(len(reported), 1)
+		is.True(errors.Is(reported[0], errHandler))
+	})
+
+	t.Run("Publish after close", func(t *testing.T) {
+		is := is.New(t)
+		b := New(WithMode(Asynchronous))
+		b.Close()
+		b.Close()
+
+		err := b.Publish(tenantid.None, &created{1})
+
+		is.Equal(err, ErrBusClosed)
+	})
+}
+
+func TestDiscard(t *testing.T) {
+	is := is.New(t)
+	is.NoErr(Discard.Publish(tenantid.None, &created{1}))
+}
@@ -0,0 +1,44 @@
+package application
+
+import (
+	"git.sr.ht/~loges/teammate/internal/club/application/services"
+	"git.sr.ht/~loges/teammate/internal/entity"
+)
+
+// Authorizer authorizes changes to the organization. Roles held within a
+// club or division apply to the groups below it, so the authorizer is
+// given the organization as its hierarchy.
+type Authorizer interface {
+	services.Authorizer
+	SetHierarchy(h entity.Hierarchy)
+}
+
+// ClubApplication holds all services related to the organization of clubs.
+type ClubApplication struct {
+	organizationService *services.OrganizationService
+}
+
+// NewClubApplication intitializes the club application. Every change to
+// the organization is authorized by the authorizer, which applies roles
+// along the organization from then on. The given configs are passed on to
+// the organization service.
+func NewClubApplication(a Authorizer, cfgs ...services.OrganizationConfiguration) (*ClubApplication, error) {
+	if a == nil {
+		return &ClubApplication{}, services.ErrInvalidOrganizationConfig
+	}
+
+	orgs, err := services.NewOrganizationService(append([]services.OrganizationConfiguration{services.WithAuthorizer(a)}, cfgs...)...)
+	if err != nil {
+		return &ClubApplication{}, services.ErrInvalidOrganizationConfig
+	}
+	a.SetHierarchy(orgs)
+
+	return &ClubApplication{
+		organizationService: orgs,
+	}, nil
+}
+
+// GetOrganizationService returns the organization service from the app.
+func (a *ClubApplication) GetOrganizationService() *services.OrganizationService {
+	return a.organizationService
+}
@@ -0,0 +1,81 @@
+package application
+
+import (
+	"testing"
+
+	"git.sr.ht/~loges/teammate/internal/club/application/services"
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"github.com/google/uuid"
+	"github.com/matryer/is"
+)
+
+var (
+	exampleClub     = &entity.Group{ID: uuid.MustParse("c15e93f8-c952-11ed-afa1-0242ac120002"), Name: "Riverside FC"}
+	exampleDivision = &entity.Group{ID: uuid.MustParse("d15e93f8-c952-11ed-afa1-0242ac120002"), Name: "U14"}
+	exampleTeam     = &entity.Group{ID: uuid.MustParse("a45e93f8-c952-11ed-afa1-0242ac120002"), Name: "Lions"}
+)
+
+// allowAll allows every change and keeps the hierarchy it is given.
+type allowAll struct {
+	hierarchy entity.Hierarchy
+}
+
+func (*allowAll) Authorize(*entity.Person, entity.Permission, *entity.Group) error {
+	return nil
+}
+
+func (a *allowAll) SetHierarchy(h entity.Hierarchy) {
+	a.hierarchy = h
+}
+
+func withInvalidConfig() services.OrganizationConfiguration {
+	return func(s *services.OrganizationService) error {
+		return services.ErrInvalidOrganizationConfig
+	}
+}
+
+func TestClubApplication(t *testing.T) {
+	t.Run("Init club app", func(t *testing.T) {
+		is := is.New(t)
+		_, err := NewClubApplication(&allowAll{})
+		is.NoErr(err)
+	})
+
+	t.Run("Init failure without an authorizer", func(t *testing.T) {
+		is := is.New(t)
+		_, err := NewClubApplication(nil)
+		is.Equal(err, services.ErrInvalidOrganizationConfig)
+	})
+
+	t.Run("Init failure due to bad organization service config", func(t *testing.T) {
+		is := is.New(t)
+		originalConfigs := services.OrganizationConfigs
+		services.OrganizationConfigs = []services.OrganizationConfiguration{withInvalidConfig()}
+
+		_, err := NewClubApplication(&allowAll{})
+
+		is.Equal(err, services.ErrInvalidOrganizationConfig)
+		// clean up configs
+		services.OrganizationConfigs = originalConfigs
+	})
+
+	t.Run("Organization service workflow", func(t *testing.T) {
+		is := is.New(t)
+		a := &allowAll{}
+		ca, err := NewClubApplication(a)
+		is.NoErr(err)
+		orgs := ca.GetOrganizationService()
+
+		is.NoErr(orgs.AddClub(exampleClub))
+		is.NoErr(orgs.AddDivision(exampleClub, exampleDivision))
+		is.NoErr(orgs.AddTeam(exampleClub, exampleTeam))
+		is.NoErr(orgs.AddTeamToDivision(exampleDivision, exampleTeam))
+
+		teams, err := orgs.GetClubTeams(exampleClub)
+		is.NoErr(err)
+		is.Equal(teams, []*entity.Group{exampleTeam})
+		ancestors, err := a.hierarchy.GetAncestors(exampleTeam)
+		is.NoErr(err)
+		is.Equal(ancestors, []*entity.Group{exampleDivision, exampleClub})
+	})
+}
@@ -0,0 +1,265 @@
+package services
+
+import (
+	"errors"
+
+	"git.sr.ht/~loges/teammate/internal/club/domain/model"
+	"git.sr.ht/~loges/teammate/internal/club/domain/repository"
+	"git.sr.ht/~loges/teammate/internal/club/infrastructure/memory"
+	"git.sr.ht/~loges/teammate/internal/entity"
+)
+
+var (
+	ErrInvalidOrganizationConfig = errors.New("services: invalid organization configuration")
+	ErrTeamInAnotherDivision     = errors.New("services: team is already in another division")
+	ErrTeamInAnotherClub         = errors.New("services: team belongs to another club")
+	ErrTeamNotFound              = errors.New("services: the team does not belong to a club")
+	ErrNoAuthorizer              = errors.New("services: no authorizer is configured")
+)
+
+// Authorizer decides whether an actor is allowed to act within a group.
+type Authorizer interface {
+	Authorize(actor *entity.Person, p entity.Permission, g *entity.Group) error
+}
+
+// denyAll is the authorizer used when none is configured, so no change is
+// allowed until one is.
+type denyAll struct{}
+
+func (denyAll) Authorize(*entity.Person, entity.Permission, *entity.Group) error {
+	return ErrNoAuthorizer
+}
+
+// OrganizationConfigs defines the configurations to intialize the service with.
+var OrganizationConfigs = []OrganizationConfiguration{
+	WithMemoryRepositories(),
+}
+
+// OrganizationConfiguration is a function that modifies the service.
+type OrganizationConfiguration func(s *OrganizationService) error
+
+// WithMemoryRepositories attaches in memory repostories to service.
+func WithMemoryRepositories(cfgs ...memory.Configuration) OrganizationConfiguration {
+	return func(s *OrganizationService) error {
+		s.clubs = memory.NewMemoryClubRepository(cfgs...)
+		s.divisions = memory.NewMemoryDivisionRepository(cfgs...)
+		return nil
+	}
+}
+
+// WithAuthorizer consults the authorizer before every change to the
+// organization.
+func WithAuthorizer(a Authorizer) OrganizationConfiguration {
+	return func(s *OrganizationService) error {
+		s.authorizer = a
+		return nil
+	}
+}
+
+// OrganizationService manages the hierarchy of clubs, their divisions and
+// the teams competing in them.
+type OrganizationService struct {
+	clubs      repository.ClubRepository
+	divisions  repository.DivisionRepository
+	authorizer Authorizer
+	actor      *entity.Person
+}
+
+// NewOrganizationService accepts configs and returns a new service. The
+// given configs are applied after the default OrganizationConfigs.
+func NewOrganizationService(cfgs ...OrganizationConfiguration) (*OrganizationService, error) {
+	s := &OrganizationService{authorizer: denyAll{}}
+
+	configs := append([]OrganizationConfiguration{}, OrganizationConfigs...)
+	for _, cfg := range append(configs, cfgs...) {
+		err := cfg(s)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// As returns a copy of the service acting on behalf of the actor.
+func (s *OrganizationService) As(actor *entity.Person) *OrganizationService {
+	c := *s
+	c.actor = actor
+	return &c
+}
+
+// AddClub initializes a new club to the repository if valid. Clubs are
+// added to the organization, so the actor has to be allowed to manage the
+// teams of the organization.
+func (s *OrganizationService) AddClub(club *entity.Group) error {
+	if err := s.authorizer.Authorize(s.actor, entity.PermissionManageTeams, nil); err != nil {
+		return err
+	}
+
+	c, err := model.NewClub(club)
+	if err != nil {
+		return err
+	}
+
+	return s.clubs.Add(c)
+}
+
+// AddDivision initializes a new division of the club to the repository if
+// valid.
+func (s *OrganizationService) AddDivision(club *entity.Group, division *entity.Group) error {
+	if err := s.authorizer.Authorize(s.actor, entity.PermissionManageTeams, club); err != nil {
+		return err
+	}
+
+	c, err := s.clubs.Get(club)
+	if err != nil {
+		return err
+	}
+	d, err := model.NewDivision(division, c)
+	if err != nil {
+		return err
+	}
+
+	return s.divisions.Add(d)
+}
+
+// AddTeam makes the team one of the club's teams. A team belongs to one
+// club.
+func (s *OrganizationService) AddTeam(club *entity.Group, team *entity.Group) error {
+	if err := s.authorizer.Authorize(s.actor, entity.PermissionManageTeams, club); err != nil {
+		return err
+	}
+
+	c, err := s.clubs.Get(club)
+	if err != nil {
+		return err
+	}
+	if current, err := s.clubs.GetByTeam(team); err == nil && current.GetID() != c.GetID() {
+		return ErrTeamInAnotherClub
+	}
+	if err = c.AddTeam(team); err != nil {
+		return err
+	}
+
+	return s.clubs.Update(c)
+}
+
+// AddTeamToDivision places one of the club's teams in a division of the
+// club. A team competes in one division at a time.
+func (s *OrganizationService) AddTeamToDivision(division *entity.Group, team *entity.Group) error {
+	if err := s.authorizer.Authorize(s.actor, entity.PermissionManageTeams, division); err != nil {
+		return err
+	}
+
+	d, err := s.divisions.Get(division)
+	if err != nil {
+		return err
+	}
+	c, err := s.clubs.GetByTeam(team)
+	if err == repository.ErrClubNotFound {
+		return ErrTeamNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if c.GetID() != d.GetClubID() {
+		return ErrTeamInAnotherClub
+	}
+	if current, err := s.divisions.GetByTeam(team); err == nil && current.GetID() != d.GetID() {
+		return ErrTeamInAnotherDivision
+	}
+	if err = d.AddTeam(team); err != nil {
+		return err
+	}
+
+	return s.divisions.Update(d)
+}
+
+// RemoveTeamFromDivision takes the team out of the division.
+func (s *OrganizationService) RemoveTeamFromDivision(division *entity.Group, team *entity.Group) error {
+	if err := s.authorizer.Authorize(s.actor, entity.PermissionManageTeams, division); err != nil {
+		return err
+	}
+
+	d, err := s.divisions.Get(division)
+	if err != nil {
+		return err
+	}
+	if err = d.RemoveTeam(team); err != nil {
+		return err
+	}
+
+	return s.divisions.Update(d)
+}
+
+// GetDivisions returns the divisions of the club ordered by name.
+func (s *OrganizationService) GetDivisions(club *entity.Group) ([]*entity.Group, error) {
+	if _, err := s.clubs.Get(club); err != nil {
+		return nil, err
+	}
+
+	divisions, err := s.divisions.GetByClub(club)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]*entity.Group, len(divisions))
+	for i, d := range divisions {
+		groups[i] = d.GetGroup()
+	}
+	return groups, nil
+}
+
+// GetDivisionTeams returns the teams in the division ordered by name.
+func (s *OrganizationService) GetDivisionTeams(division *entity.Group) ([]*entity.Group, error) {
+	d, err := s.divisions.Get(division)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.GetTeams(), nil
+}
+
+// GetClubTeams returns the teams in every division of the club, ordered by
+// division and then by name.
+func (s *OrganizationService) GetClubTeams(club *entity.Group) ([]*entity.Group, error) {
+	if _, err := s.clubs.Get(club); err != nil {
+		return nil, err
+	}
+
+	divisions, err := s.divisions.GetByClub(club)
+	if err != nil {
+		return nil, err
+	}
+
+	teams := []*entity.Group{}
+	for _, d := range divisions {
+		teams = append(teams, d.GetTeams()...)
+	}
+	return teams, nil
+}
+
+// GetAncestors returns the groups above the group in the hierarchy,
+// nearest first: the division and club of a team, or the club of a
+// division. Clubs and groups outside the hierarchy have none.
+func (s *OrganizationService) GetAncestors(group *entity.Group) ([]*entity.Group, error) {
+	if d, err := s.divisions.Get(group); err == nil {
+		c, err := s.clubs.Get(&entity.Group{ID: d.GetClubID()})
+		if err != nil {
+			return nil, err
+		}
+		return []*entity.Group{c.GetGroup()}, nil
+	}
+
+	d, err := s.divisions.GetByTeam(group)
+	if err == repository.ErrDivisionNotFound {
+		return []*entity.Group{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	c, err := s.clubs.Get(&entity.Group{ID: d.GetClubID()})
+	if err != nil {
+		return nil, err
+	}
+	return []*entity.Group{d.GetGroup(), c.GetGroup()}, nil
+}
@@ -0,0 +1,242 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"git.sr.ht/~loges/teammate/internal/club/domain/model"
+	"git.sr.ht/~loges/teammate/internal/club/domain/repository"
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"github.com/google/uuid"
+	"github.com/matryer/is"
+)
+
+var (
+	exampleClub     = &entity.Group{ID: uuid.MustParse("c15e93f8-c952-11ed-afa1-0242ac120002"), Name: "Riverside FC"}
+	anotherClub     = &entity.Group{ID: uuid.MustParse("c25e93f8-c952-11ed-afa1-0242ac120002"), Name: "Lakeside FC"}
+	exampleDivision = &entity.Group{ID: uuid.MustParse("d15e93f8-c952-11ed-afa1-0242ac120002"), Name: "U14"}
+	anotherDivision = &entity.Group{ID: uuid.MustParse("d25e93f8-c952-11ed-afa1-0242ac120002"), Name: "U12"}
+	exampleTeam     = &entity.Group{ID: uuid.MustParse("f55e93f8-c952-11ed-afa1-0242ac120002"), Name: "Tigers"}
+	anotherTeam     = &entity.Group{ID: uuid.MustParse("adbe93f8-c952-11ed-afa1-0242ac120002"), Name: "Bears"}
+	examplePerson   = &entity.Person{ID: uuid.MustParse("f47ac10b-58cc-0372-8567-0e02b2c3d479"), Name: "Matt"}
+	errDenied       = errors.New("denied")
+)
+
+// allowAll allows every change.
+type allowAll struct{}
+
+func (allowAll) Authorize(*entity.Person, entity.Permission, *entity.Group) error {
+	return nil
+}
+
+// divisionAuthorizer only allows the example person to manage the example division.
+type divisionAuthorizer struct{}
+
+func (divisionAuthorizer) Authorize(actor *entity.Person, p entity.Permission, g *entity.Group) error {
+	if actor != examplePerson || p != entity.PermissionManageTeams || g == nil || g.ID != exampleDivision.ID {
+		return errDenied
+	}
+	return nil
+}
+
+// newOrganization returns a service with the example club, its U12 and U14
+// divisions, its example and another team, and the example team in the
+// U14 division.
+func newOrganization(is *is.I, cfgs ...OrganizationConfiguration) *OrganizationService {
+	s, err := NewOrganizationService(append([]OrganizationConfiguration{WithAuthorizer(allowAll{})}, cfgs...)...)
+	is.NoErr(err)
+	is.NoErr(s.AddClub(exampleClub))
+	is.NoErr(s.AddDivision(exampleClub, exampleDivision))
+	is.NoErr(s.AddDivision(exampleClub, anotherDivision))
+	is.NoErr(s.AddTeam(exampleClub, exampleTeam))
+	is.NoErr(s.AddTeam(exampleClub, anotherTeam))
+	is.NoErr(s.AddTeamToDivision(exampleDivision, exampleTeam))
+	return s
+}
+
+func TestNewOrganizationService(t *testing.T) {
+	t.Run("Create service with defaults", func(t *testing.T) {
+		is := is.New(t)
+		_, err := NewOrganizationService()
+		is.NoErr(err)
+	})
+
+	t.Run("Changes are denied without an authorizer", func(t *testing.T) {
+		is := is.New(t)
+		s, _ := NewOrganizationService()
+		is.Equal(s.AddClub(exampleClub), ErrNoAuthorizer)
+	})
+
+	t.Run("Create service with bad config", func(t *testing.T) {
+		is := is.New(t)
+		withInvalidConfig := func() OrganizationConfiguration {
+			return func(s *OrganizationService) error {
+				return ErrInvalidOrganizationConfig
+			}
+		}
+		originalConfigs := OrganizationConfigs
+		OrganizationConfigs = []OrganizationConfiguration{withInvalidConfig()}
+
+		_, err := NewOrganizationService()
+
+		is.Equal(err, ErrInvalidOrganizationConfig)
+		// clean up configs
+		OrganizationConfigs = originalConfigs
+	})
+}
+
+func TestOrganizationService_AddDivision(t *testing.T) {
+	testCases := []struct {
+		test        string
+		club        *entity.Group
+		division    *entity.Group
+		expectedErr error
+	}{
+		{"Division added", exampleClub, &entity.Group{ID: uuid.New(), Name: "U16"}, nil},
+		{"Division name required", exampleClub, &entity.Group{ID: uuid.New()}, model.ErrInvalidGroup},
+		{"Division already exists", exampleClub, exampleDivision, repository.ErrDivisionAlreadyExists},
+		{"Club not found", anotherClub, &entity.Group{ID: uuid.New(), Name: "U16"}, repository.ErrClubNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			s := newOrganization(is)
+
+			err := s.AddDivision(tc.club, tc.division)
+
+			is.Equal(err, tc.expectedErr)
+		})
+	}
+}
+
+func TestOrganizationService_AddTeam(t *testing.T) {
+	testCases := []struct {
+		test        string
+		club        *entity.Group
+		team        *entity.Group
+		expectedErr error
+	}{
+		{"Team added", exampleClub, &entity.Group{ID: uuid.New(), Name: "Wolves"}, nil},
+		{"Team name required", exampleClub, &entity.Group{ID: uuid.New()}, model.ErrInvalidGroup},
+		{"Team already in the club", exampleClub, exampleTeam, model.ErrClubUpdateFailed},
+		{"Team in another club", anotherClub, exampleTeam, ErrTeamInAnotherClub},
+		{"Club not found", &entity.Group{ID: uuid.New(), Name: "Hillside FC"}, anotherTeam, repository.ErrClubNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			s := newOrganization(is)
+			is.NoErr(s.AddClub(anotherClub))
+
+			err := s.AddTeam(tc.club, tc.team)
+
+			is.Equal(err, tc.expectedErr)
+		})
+	}
+}
+
+func TestOrganizationService_AddTeamToDivision(t *testing.T) {
+	otherDivision := &entity.Group{ID: uuid.New(), Name: "U14"}
+	otherTeam := &entity.Group{ID: uuid.New(), Name: "Sharks"}
+	testCases := []struct {
+		test        string
+		division    *entity.Group
+		team        *entity.Group
+		expectedErr error
+	}{
+		{"Team added", exampleDivision, anotherTeam, nil},
+		{"Team already in the division", exampleDivision, exampleTeam, model.ErrDivisionUpdateFailed},
+		{"Team in another division", anotherDivision, exampleTeam, ErrTeamInAnotherDivision},
+		{"Division not found", &entity.Group{ID: uuid.New(), Name: "U16"}, anotherTeam, repository.ErrDivisionNotFound},
+		{"Unknown team", exampleDivision, &entity.Group{ID: uuid.New(), Name: "Wolves"}, ErrTeamNotFound},
+		{"Team of another club", exampleDivision, otherTeam, ErrTeamInAnotherClub},
+		{"Division of another club", otherDivision, anotherTeam, ErrTeamInAnotherClub},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			s := newOrganization(is)
+			is.NoErr(s.AddClub(anotherClub))
+			is.NoErr(s.AddDivision(anotherClub, otherDivision))
+			is.NoErr(s.AddTeam(anotherClub, otherTeam))
+
+			err := s.AddTeamToDivision(tc.division, tc.team)
+
+			is.Equal(err, tc.expectedErr)
+		})
+	}
+}
+
+func TestOrganizationService_RemoveTeamFromDivision(t *testing.T) {
+	is := is.New(t)
+	s := newOrganization(is)
+
+	is.NoErr(s.RemoveTeamFromDivision(exampleDivision, exampleTeam))
+
+	is.Equal(s.RemoveTeamFromDivision(exampleDivision, exampleTeam), model.ErrDivisionUpdateFailed)
+	is.NoErr(s.AddTeamToDivision(anotherDivision, exampleTeam))
+}
+
+func TestOrganizationService_Queries(t *testing.T) {
+	is := is.New(t)
+	s := newOrganization(is)
+	is.NoErr(s.AddTeamToDivision(anotherDivision, anotherTeam))
+
+	divisions, err := s.GetDivisions(exampleClub)
+	is.NoErr(err)
+	is.Equal(divisions, []*entity.Group{anotherDivision, exampleDivision})
+
+	teams, err := s.GetDivisionTeams(exampleDivision)
+	is.NoErr(err)
+	is.Equal(teams, []*entity.Group{exampleTeam})
+
+	teams, err = s.GetClubTeams(exampleClub)
+	is.NoErr(err)
+	is.Equal(teams, []*entity.Group{anotherTeam, exampleTeam})
+
+	_, err = s.GetClubTeams(anotherClub)
+	is.Equal(err, repository.ErrClubNotFound)
+}
+
+func TestOrganizationService_GetAncestors(t *testing.T) {
+	testCases := []struct {
+		test     string
+		group    *entity.Group
+		expected []*entity.Group
+	}{
+		{"Team in a division", exampleTeam, []*entity.Group{exampleDivision, exampleClub}},
+		{"Division of a club", exampleDivision, []*entity.Group{exampleClub}},
+		{"Club", exampleClub, []*entity.Group{}},
+		{"Team outside the hierarchy", anotherTeam, []*entity.Group{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			s := newOrganization(is)
+
+			ancestors, err := s.GetAncestors(tc.group)
+
+			is.NoErr(err)
+			is.Equal(ancestors, tc.expected)
+		})
+	}
+}
+
+func TestOrganizationService_Authorization(t *testing.T) {
+	is := is.New(t)
+	s := newOrganization(is)
+	restricted, err := NewOrganizationService(WithAuthorizer(divisionAuthorizer{}))
+	is.NoErr(err)
+	restricted.clubs, restricted.divisions = s.clubs, s.divisions
+
+	is.NoErr(restricted.As(examplePerson).AddTeamToDivision(exampleDivision, anotherTeam))
+	is.Equal(restricted.As(examplePerson).AddTeamToDivision(anotherDivision, anotherTeam), errDenied)
+	is.Equal(restricted.As(examplePerson).AddDivision(exampleClub, &entity.Group{ID: uuid.New(), Name: "U16"}), errDenied)
+	is.Equal(restricted.AddTeamToDivision(exampleDivision, anotherTeam), errDenied)
+	is.Equal(restricted.As(examplePerson).AddClub(anotherClub), errDenied) // clubs are added to the organization
+	is.Equal(restricted.As(examplePerson).AddTeam(exampleClub, &entity.Group{ID: uuid.New(), Name: "Wolves"}), errDenied)
+}
@@ -0,0 +1,28 @@
+package event
+
+import (
+	"reflect"
+
+	"github.com/google/uuid"
+)
+
+// ClubCreated event.
+type ClubCreated struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+}
+
+func (e ClubCreated) eventName() string {
+	return reflect.TypeOf(e).Name()
+}
+
+// TeamAddedToClub event.
+type TeamAddedToClub struct {
+	ID       uuid.UUID `json:"id"`
+	TeamId   uuid.UUID `json:"team_id"`
+	TeamName string    `json:"team_name"`
+}
+
+func (e TeamAddedToClub) eventName() string {
+	return reflect.TypeOf(e).Name()
+}
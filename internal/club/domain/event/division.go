@@ -0,0 +1,39 @@
+package event
+
+import (
+	"reflect"
+
+	"github.com/google/uuid"
+)
+
+// DivisionCreated event.
+type DivisionCreated struct {
+	ID     uuid.UUID `json:"id"`
+	Name   string    `json:"name"`
+	ClubId uuid.UUID `json:"club_id"`
+}
+
+func (e DivisionCreated) eventName() string {
+	return reflect.TypeOf(e).Name()
+}
+
+// TeamAddedToDivision event.
+type TeamAddedToDivision struct {
+	ID       uuid.UUID `json:"id"`
+	TeamId   uuid.UUID `json:"team_id"`
+	TeamName string    `json:"team_name"`
+}
+
+func (e TeamAddedToDivision) eventName() string {
+	return reflect.TypeOf(e).Name()
+}
+
+// TeamRemovedFromDivision event.
+type TeamRemovedFromDivision struct {
+	ID     uuid.UUID `json:"id"`
+	TeamId uuid.UUID `json:"team_id"`
+}
+
+func (e TeamRemovedFromDivision) eventName() string {
+	return reflect.TypeOf(e).Name()
+}
@@ -0,0 +1,26 @@
+package event
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestDivisionEvent(t *testing.T) {
+	testCases := []struct {
+		test     string
+		event    Event
+		expected string
+	}{
+		{"DivisionCreated event name", &DivisionCreated{}, "DivisionCreated"},
+		{"TeamAddedToDivision event name", &TeamAddedToDivision{}, "TeamAddedToDivision"},
+		{"TeamRemovedFromDivision event name", &TeamRemovedFromDivision{}, "TeamRemovedFromDivision"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			is.Equal(tc.event.eventName(), tc.expected)
+		})
+	}
+}
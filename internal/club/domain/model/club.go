@@ -0,0 +1,126 @@
+package model
+
+import (
+	"errors"
+
+	"git.sr.ht/~loges/teammate/internal/club/domain/event"
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidGroup     = errors.New("model: club and division have to be valid groups")
+	ErrClubUpdateFailed = errors.New("model: club update failed")
+)
+
+// Club is a aggregate that represents the organization at the top of the
+// hierarchy, which runs divisions of its teams.
+type Club struct {
+	group *entity.Group
+	teams map[uuid.UUID]*entity.Group
+
+	changes []event.Event
+	version int
+}
+
+// NewClub is a factory to create a new Club aggregate.
+func NewClub(g *entity.Group) (*Club, error) {
+	c := &Club{}
+
+	if g.Name == "" {
+		return c, ErrInvalidGroup
+	}
+
+	c.register(&event.ClubCreated{
+		ID:   g.ID,
+		Name: g.Name,
+	})
+
+	return c, nil
+}
+
+// NewClubFromEvents is a helper method that creates a new club from a
+// series of events.
+func NewClubFromEvents(events []event.Event) *Club {
+	c := &Club{}
+
+	for _, event := range events {
+		c.Apply(event, false)
+	}
+
+	return c
+}
+
+// GetID returns the club root entity ID.
+func (c *Club) GetID() uuid.UUID {
+	return c.group.ID
+}
+
+// GetName returns the name of the club.
+func (c *Club) GetName() string {
+	return c.group.Name
+}
+
+// GetGroup returns the group of the club.
+func (c *Club) GetGroup() *entity.Group {
+	return &entity.Group{ID: c.group.ID, Name: c.group.Name}
+}
+
+// HasTeam returns whether the team belongs to the club.
+func (c *Club) HasTeam(teamId uuid.UUID) bool {
+	_, ok := c.teams[teamId]
+	return ok
+}
+
+// AddTeam makes the team one of the club's teams, which its divisions can
+// take in.
+func (c *Club) AddTeam(t *entity.Group) error {
+	if t.Name == "" {
+		return ErrInvalidGroup
+	}
+	if c.HasTeam(t.ID) {
+		return ErrClubUpdateFailed
+	}
+
+	c.register(&event.TeamAddedToClub{
+		ID:       c.group.ID,
+		TeamId:   t.ID,
+		TeamName: t.Name,
+	})
+
+	return nil
+}
+
+// Apply applies club events to the club aggregate.
+func (c *Club) Apply(e event.Event, new bool) {
+	switch ce := e.(type) {
+	case *event.ClubCreated:
+		c.group = &entity.Group{
+			ID:   ce.ID,
+			Name: ce.Name,
+		}
+		c.teams = make(map[uuid.UUID]*entity.Group)
+
+	case *event.TeamAddedToClub:
+		c.teams[ce.TeamId] = &entity.Group{ID: ce.TeamId, Name: ce.TeamName}
+	}
+
+	if !new {
+		c.version++
+	}
+}
+
+// Events returns the uncommitted events from the club aggregate.
+func (c Club) Events() []event.Event {
+	return c.changes
+}
+
+// Version returns the last version of the aggregate before changes.
+func (c Club) Version() int {
+	return c.version
+}
+
+func (c *Club) register(event event.Event) {
+	c.changes = append(c.changes, event)
+	c.Apply(event, true)
+}
@@ -0,0 +1,75 @@
+package model
+
+import (
+	"testing"
+
+	"git.sr.ht/~loges/teammate/internal/club/domain/event"
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"github.com/google/uuid"
+	"github.com/matryer/is"
+)
+
+var (
+	exampleClubUUID = uuid.MustParse("c15e93f8-c952-11ed-afa1-0242ac120002")
+	exampleClubName = "Riverside FC"
+	clubCreated     = &event.ClubCreated{ID: exampleClubUUID, Name: exampleClubName}
+)
+
+func TestClub_NewClub(t *testing.T) {
+	testCases := []struct {
+		test        string
+		group       *entity.Group
+		expectedErr error
+	}{
+		{"Empty name validation", &entity.Group{ID: exampleClubUUID, Name: ""}, ErrInvalidGroup},
+		{"Valid name", &entity.Group{ID: exampleClubUUID, Name: exampleClubName}, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			c, err := NewClub(tc.group)
+			is.Equal(err, tc.expectedErr)
+			if err == nil {
+				is.Equal(c.GetGroup(), tc.group)
+				is.Equal(len(c.Events()), 1)
+			}
+		})
+	}
+}
+
+func TestClub_AddTeam(t *testing.T) {
+	team := &entity.Group{ID: uuid.MustParse("f55e93f8-c952-11ed-afa1-0242ac120002"), Name: "Tigers"}
+	teamAdded := &event.TeamAddedToClub{ID: exampleClubUUID, TeamId: team.ID, TeamName: team.Name}
+	testCases := []struct {
+		test        string
+		events      []event.Event
+		team        *entity.Group
+		expectedErr error
+	}{
+		{"Team added", []event.Event{clubCreated}, team, nil},
+		{"Team name required", []event.Event{clubCreated}, &entity.Group{ID: team.ID}, ErrInvalidGroup},
+		{"Team already in the club", []event.Event{clubCreated, teamAdded}, team, ErrClubUpdateFailed},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			c := NewClubFromEvents(tc.events)
+
+			err := c.AddTeam(tc.team)
+
+			is.Equal(err, tc.expectedErr)
+			is.Equal(c.HasTeam(team.ID), err != ErrInvalidGroup)
+		})
+	}
+}
+
+func TestClub_NewEvents(t *testing.T) {
+	is := is.New(t)
+	c := NewClubFromEvents([]event.Event{clubCreated})
+
+	is.Equal(c.GetID(), exampleClubUUID)
+	is.Equal(c.GetName(), exampleClubName)
+	is.Equal(c.Version(), 1)
+}
@@ -0,0 +1,161 @@
+package model
+
+import (
+	"errors"
+	"sort"
+
+	"git.sr.ht/~loges/teammate/internal/club/domain/event"
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"github.com/google/uuid"
+)
+
+var ErrDivisionUpdateFailed = errors.New("model: division update failed")
+
+// Division is a aggregate that represents an age group of a club and the
+// teams competing in it.
+type Division struct {
+	group  *entity.Group
+	clubId uuid.UUID
+	teams  map[uuid.UUID]*entity.Group
+
+	changes []event.Event
+	version int
+}
+
+// NewDivision is a factory to create a new Division aggregate within the
+// club.
+func NewDivision(g *entity.Group, c *Club) (*Division, error) {
+	d := &Division{}
+
+	if g.Name == "" {
+		return d, ErrInvalidGroup
+	}
+
+	d.register(&event.DivisionCreated{
+		ID:     g.ID,
+		Name:   g.Name,
+		ClubId: c.GetID(),
+	})
+
+	return d, nil
+}
+
+// NewDivisionFromEvents is a helper method that creates a new division
+// from a series of events.
+func NewDivisionFromEvents(events []event.Event) *Division {
+	d := &Division{}
+
+	for _, event := range events {
+		d.Apply(event, false)
+	}
+
+	return d
+}
+
+// GetID returns the division root entity ID.
+func (d *Division) GetID() uuid.UUID {
+	return d.group.ID
+}
+
+// GetName returns the name of the division.
+func (d *Division) GetName() string {
+	return d.group.Name
+}
+
+// GetGroup returns the group of the division.
+func (d *Division) GetGroup() *entity.Group {
+	return &entity.Group{ID: d.group.ID, Name: d.group.Name}
+}
+
+// GetClubID returns the ID of the club the division belongs to.
+func (d *Division) GetClubID() uuid.UUID {
+	return d.clubId
+}
+
+// GetTeams returns the teams in the division ordered by name.
+func (d *Division) GetTeams() []*entity.Group {
+	teams := make([]*entity.Group, 0, len(d.teams))
+	for _, team := range d.teams {
+		teams = append(teams, team)
+	}
+	sort.Slice(teams, func(i, j int) bool {
+		return teams[i].Name < teams[j].Name
+	})
+	return teams
+}
+
+// HasTeam returns whether the team is in the division.
+func (d *Division) HasTeam(teamId uuid.UUID) bool {
+	_, ok := d.teams[teamId]
+	return ok
+}
+
+// AddTeam adds the team to the division.
+func (d *Division) AddTeam(t *entity.Group) error {
+	if t.Name == "" {
+		return ErrInvalidGroup
+	}
+	if d.HasTeam(t.ID) {
+		return ErrDivisionUpdateFailed
+	}
+
+	d.register(&event.TeamAddedToDivision{
+		ID:       d.group.ID,
+		TeamId:   t.ID,
+		TeamName: t.Name,
+	})
+
+	return nil
+}
+
+// RemoveTeam removes the team from the division.
+func (d *Division) RemoveTeam(t *entity.Group) error {
+	if !d.HasTeam(t.ID) {
+		return ErrDivisionUpdateFailed
+	}
+
+	d.register(&event.TeamRemovedFromDivision{
+		ID:     d.group.ID,
+		TeamId: t.ID,
+	})
+
+	return nil
+}
+
+// Apply applies division events to the division aggregate.
+func (d *Division) Apply(e event.Event, new bool) {
+	switch de := e.(type) {
+	case *event.DivisionCreated:
+		d.group = &entity.Group{
+			ID:   de.ID,
+			Name: de.Name,
+		}
+		d.clubId = de.ClubId
+		d.teams = make(map[uuid.UUID]*entity.Group)
+
+	case *event.TeamAddedToDivision:
+		d.teams[de.TeamId] = &entity.Group{ID: de.TeamId, Name: de.TeamName}
+
+	case *event.TeamRemovedFromDivision:
+		delete(d.teams, de.TeamId)
+	}
+
+	if !new {
+		d.version++
+	}
+}
+
+// Events returns the uncommitted events from the division aggregate.
+func (d Division) Events() []event.Event {
+	return d.changes
+}
+
+// Version returns the last version of the aggregate before changes.
+func (d Division) Version() int {
+	return d.version
+}
+
+func (d *Division) register(event event.Event) {
+	d.changes = append(d.changes, event)
+	d.Apply(event, true)
+}
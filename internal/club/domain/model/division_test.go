@@ -0,0 +1,105 @@
+package model
+
+import (
+	"testing"
+
+	"git.sr.ht/~loges/teammate/internal/club/domain/event"
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"github.com/google/uuid"
+	"github.com/matryer/is"
+)
+
+var (
+	exampleDivisionUUID = uuid.MustParse("d15e93f8-c952-11ed-afa1-0242ac120002")
+	exampleDivisionName = "U14"
+	exampleTeam         = &entity.Group{ID: uuid.MustParse("f55e93f8-c952-11ed-afa1-0242ac120002"), Name: "Tigers"}
+	anotherTeam         = &entity.Group{ID: uuid.MustParse("adbe93f8-c952-11ed-afa1-0242ac120002"), Name: "Bears"}
+	divisionCreated     = &event.DivisionCreated{ID: exampleDivisionUUID, Name: exampleDivisionName, ClubId: exampleClubUUID}
+	teamAdded           = &event.TeamAddedToDivision{ID: exampleDivisionUUID, TeamId: exampleTeam.ID, TeamName: exampleTeam.Name}
+)
+
+func TestDivision_NewDivision(t *testing.T) {
+	testCases := []struct {
+		test        string
+		group       *entity.Group
+		expectedErr error
+	}{
+		{"Empty name validation", &entity.Group{ID: exampleDivisionUUID, Name: ""}, ErrInvalidGroup},
+		{"Valid name", &entity.Group{ID: exampleDivisionUUID, Name: exampleDivisionName}, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			club := NewClubFromEvents([]event.Event{clubCreated})
+
+			d, err := NewDivision(tc.group, club)
+
+			is.Equal(err, tc.expectedErr)
+			if err == nil {
+				is.Equal(d.GetGroup(), tc.group)
+				is.Equal(d.GetClubID(), exampleClubUUID)
+			}
+		})
+	}
+}
+
+func TestDivision_AddTeam(t *testing.T) {
+	testCases := []struct {
+		test        string
+		events      []event.Event
+		team        *entity.Group
+		expectedErr error
+	}{
+		{"Add team", []event.Event{divisionCreated}, exampleTeam, nil},
+		{"Team without name", []event.Event{divisionCreated}, &entity.Group{ID: exampleTeam.ID}, ErrInvalidGroup},
+		{"Team already in division", []event.Event{divisionCreated, teamAdded}, exampleTeam, ErrDivisionUpdateFailed},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			d := NewDivisionFromEvents(tc.events)
+
+			err := d.AddTeam(tc.team)
+
+			is.Equal(err, tc.expectedErr)
+			is.Equal(d.HasTeam(tc.team.ID), err != ErrInvalidGroup)
+		})
+	}
+}
+
+func TestDivision_RemoveTeam(t *testing.T) {
+	testCases := []struct {
+		test        string
+		events      []event.Event
+		expectedErr error
+	}{
+		{"Remove team", []event.Event{divisionCreated, teamAdded}, nil},
+		{"Team not in division", []event.Event{divisionCreated}, ErrDivisionUpdateFailed},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			d := NewDivisionFromEvents(tc.events)
+
+			err := d.RemoveTeam(exampleTeam)
+
+			is.Equal(err, tc.expectedErr)
+			is.True(!d.HasTeam(exampleTeam.ID))
+		})
+	}
+}
+
+func TestDivision_GetTeams(t *testing.T) {
+	is := is.New(t)
+	d := NewDivisionFromEvents([]event.Event{divisionCreated, teamAdded})
+	is.NoErr(d.AddTeam(anotherTeam))
+
+	teams := d.GetTeams()
+
+	is.Equal(teams, []*entity.Group{anotherTeam, exampleTeam})
+	is.Equal(d.Version(), 2)
+	is.Equal(len(d.Events()), 1)
+}
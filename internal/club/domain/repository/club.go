@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"errors"
+
+	"git.sr.ht/~loges/teammate/internal/club/domain/model"
+	"git.sr.ht/~loges/teammate/internal/entity"
+)
+
+var (
+	ErrClubNotFound      = errors.New("repository: the club was not found")
+	ErrClubAlreadyExists = errors.New("repository: club already exists")
+	ErrClubHasNoUpdates  = errors.New("repository: failed to update club")
+	ErrClubConflict      = errors.New("repository: club was changed concurrently")
+)
+
+// ClubRepository defines the interface for the club repository.
+type ClubRepository interface {
+	Get(*entity.Group) (*model.Club, error)
+	GetByTeam(*entity.Group) (*model.Club, error)
+	Add(*model.Club) error
+	Update(*model.Club) error
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"errors"
+
+	"git.sr.ht/~loges/teammate/internal/club/domain/model"
+	"git.sr.ht/~loges/teammate/internal/entity"
+)
+
+var (
+	ErrDivisionNotFound      = errors.New("repository: the division was not found")
+	ErrDivisionAlreadyExists = errors.New("repository: division already exists")
+	ErrDivisionHasNoUpdates  = errors.New("repository: failed to update division")
+	ErrDivisionConflict      = errors.New("repository: division was changed concurrently")
+)
+
+// DivisionRepository defines the interface for the division repository.
+type DivisionRepository interface {
+	Get(*entity.Group) (*model.Division, error)
+	GetByClub(*entity.Group) ([]*model.Division, error)
+	GetByTeam(*entity.Group) (*model.Division, error)
+	Add(*model.Division) error
+	Update(*model.Division) error
+}
@@ -0,0 +1,81 @@
+package memory
+
+import (
+	"sync"
+
+	"git.sr.ht/~loges/teammate/internal/club/domain/model"
+	"git.sr.ht/~loges/teammate/internal/club/domain/repository"
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"git.sr.ht/~loges/teammate/internal/eventstore"
+)
+
+// clubCategory is the event store category of club streams.
+const clubCategory = "club"
+
+// MemoryClubRepository is an in-memory club repository.
+type MemoryClubRepository struct {
+	events events
+	sync.Mutex
+}
+
+// NewMemoryClubRepository intializes an in-memory club repository.
+func NewMemoryClubRepository(cfgs ...Configuration) *MemoryClubRepository {
+	return &MemoryClubRepository{
+		events: newEvents(cfgs),
+	}
+}
+
+// Get retrieves a club by ID.
+func (r *MemoryClubRepository) Get(g *entity.Group) (*model.Club, error) {
+	if events, err := r.events.Load(r.events.Stream(clubCategory, g.ID)); err == nil {
+		return model.NewClubFromEvents(events), nil
+	}
+
+	return &model.Club{}, repository.ErrClubNotFound
+}
+
+// GetByTeam retrieves the club the team belongs to.
+func (r *MemoryClubRepository) GetByTeam(t *entity.Group) (*model.Club, error) {
+	all, err := r.events.LoadCategory(clubCategory)
+	if err != nil {
+		return &model.Club{}, err
+	}
+
+	for _, events := range all {
+		if c := model.NewClubFromEvents(events); c.HasTeam(t.ID) {
+			return c, nil
+		}
+	}
+	return &model.Club{}, repository.ErrClubNotFound
+}
+
+// Add stores a new club in the repository.
+func (r *MemoryClubRepository) Add(c *model.Club) error {
+	if r.events.Exists(r.events.Stream(clubCategory, c.GetID())) {
+		return repository.ErrClubAlreadyExists
+	}
+
+	err := r.events.Commit(r, r.events.Stream(clubCategory, c.GetID()), 0, c.Events())
+	if err == eventstore.ErrConcurrencyConflict {
+		return repository.ErrClubAlreadyExists
+	}
+	return err
+}
+
+// Update appends changes to club in the repository.
+func (r *MemoryClubRepository) Update(c *model.Club) error {
+	if !r.events.Exists(r.events.Stream(clubCategory, c.GetID())) {
+		return repository.ErrClubNotFound
+	}
+
+	newEvents := c.Events()
+	if len(newEvents) == 0 {
+		return repository.ErrClubHasNoUpdates
+	}
+
+	err := r.events.Commit(r, r.events.Stream(clubCategory, c.GetID()), c.Version(), newEvents)
+	if err == eventstore.ErrConcurrencyConflict {
+		return repository.ErrClubConflict
+	}
+	return err
+}
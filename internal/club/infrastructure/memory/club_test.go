@@ -0,0 +1,98 @@
+package memory
+
+import (
+	"testing"
+
+	"git.sr.ht/~loges/teammate/internal/club/domain/event"
+	"git.sr.ht/~loges/teammate/internal/club/domain/model"
+	"git.sr.ht/~loges/teammate/internal/club/domain/repository"
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"github.com/google/uuid"
+	"github.com/matryer/is"
+)
+
+var (
+	exampleClub = &entity.Group{ID: uuid.MustParse("c15e93f8-c952-11ed-afa1-0242ac120002"), Name: "Riverside FC"}
+	anotherClub = &entity.Group{ID: uuid.MustParse("c25e93f8-c952-11ed-afa1-0242ac120002"), Name: "Lakeside FC"}
+	clubCreated = &event.ClubCreated{ID: exampleClub.ID, Name: exampleClub.Name}
+)
+
+func TestMemoryClubRepository_Get(t *testing.T) {
+	testCases := []struct {
+		test        string
+		group       *entity.Group
+		expectedErr error
+	}{
+		{"Club found", exampleClub, nil},
+		{"No club found with this group", anotherClub, repository.ErrClubNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			r := NewMemoryClubRepository()
+			seedClub(r, exampleClub.ID, clubCreated)
+
+			c, err := r.Get(tc.group)
+
+			is.Equal(err, tc.expectedErr)
+			if err == nil {
+				is.Equal(c.GetGroup(), exampleClub)
+			}
+		})
+	}
+}
+
+func TestMemoryClubRepository_Add(t *testing.T) {
+	testCases := []struct {
+		test        string
+		group       *entity.Group
+		expectedErr error
+	}{
+		{"Successfully add a club", anotherClub, nil},
+		{"Club already exists error", exampleClub, repository.ErrClubAlreadyExists},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			r := NewMemoryClubRepository()
+			seedClub(r, exampleClub.ID, clubCreated)
+			c, _ := model.NewClub(tc.group)
+
+			err := r.Add(c)
+
+			is.Equal(err, tc.expectedErr)
+		})
+	}
+}
+
+func TestMemoryClubRepository_Update(t *testing.T) {
+	is := is.New(t)
+	r := NewMemoryClubRepository()
+	seedClub(r, exampleClub.ID, clubCreated)
+	seedClub(r, anotherClub.ID, &event.ClubCreated{ID: anotherClub.ID, Name: anotherClub.Name})
+	team := &entity.Group{ID: uuid.New(), Name: "Tigers"}
+	c, _ := r.Get(exampleClub)
+	stale, _ := r.Get(exampleClub)
+
+	is.Equal(r.Update(c), repository.ErrClubHasNoUpdates)
+	is.NoErr(c.AddTeam(team))
+	is.NoErr(r.Update(c))
+	is.NoErr(stale.AddTeam(&entity.Group{ID: uuid.New(), Name: "Bears"}))
+	is.Equal(r.Update(stale), repository.ErrClubConflict)
+
+	found, err := r.GetByTeam(team)
+	is.NoErr(err)
+	is.Equal(found.GetGroup(), exampleClub)
+	_, err = r.GetByTeam(&entity.Group{ID: uuid.New()})
+	is.Equal(err, repository.ErrClubNotFound)
+}
+
+func seedClub(r *MemoryClubRepository, id uuid.UUID, events ...event.Event) {
+	stored := make([]any, len(events))
+	for i, e := range events {
+		stored[i] = e
+	}
+	_ = r.events.Store().Append(r.events.Stream(clubCategory, id), 0, stored...)
+}
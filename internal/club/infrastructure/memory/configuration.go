@@ -0,0 +1,26 @@
+package memory
+
+import (
+	"git.sr.ht/~loges/teammate/internal/club/domain/event"
+	"git.sr.ht/~loges/teammate/internal/persistence"
+)
+
+// Configuration is a function that modifies an in-memory repository.
+type Configuration = persistence.Configuration
+
+var (
+	WithPublisher  = persistence.WithPublisher
+	WithOutbox     = persistence.WithOutbox
+	WithEventStore = persistence.WithEventStore
+	WithKeyStore   = persistence.WithKeyStore
+	WithTenant     = persistence.WithTenant
+	KeyStore       = persistence.KeyStore
+	Tenant         = persistence.Tenant
+)
+
+// events is the event storage of a repository.
+type events = persistence.Events[event.Event]
+
+func newEvents(cfgs []Configuration) events {
+	return persistence.New[event.Event](Schemas, cfgs...)
+}
@@ -0,0 +1,108 @@
+package memory
+
+import (
+	"sort"
+	"sync"
+
+	"git.sr.ht/~loges/teammate/internal/club/domain/model"
+	"git.sr.ht/~loges/teammate/internal/club/domain/repository"
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"git.sr.ht/~loges/teammate/internal/eventstore"
+)
+
+// divisionCategory is the event store category of division streams.
+const divisionCategory = "division"
+
+// MemoryDivisionRepository is an in-memory division repository.
+type MemoryDivisionRepository struct {
+	events events
+	sync.Mutex
+}
+
+// NewMemoryDivisionRepository intializes an in-memory division repository.
+func NewMemoryDivisionRepository(cfgs ...Configuration) *MemoryDivisionRepository {
+	return &MemoryDivisionRepository{
+		events: newEvents(cfgs),
+	}
+}
+
+// Get retrieves a division by ID.
+func (r *MemoryDivisionRepository) Get(g *entity.Group) (*model.Division, error) {
+	if events, err := r.events.Load(r.events.Stream(divisionCategory, g.ID)); err == nil {
+		return model.NewDivisionFromEvents(events), nil
+	}
+
+	return &model.Division{}, repository.ErrDivisionNotFound
+}
+
+// GetByClub retrieves the divisions of the club ordered by name.
+func (r *MemoryDivisionRepository) GetByClub(c *entity.Group) ([]*model.Division, error) {
+	divisions, err := r.filter(func(d *model.Division) bool {
+		return d.GetClubID() == c.ID
+	})
+	sort.Slice(divisions, func(i, j int) bool {
+		return divisions[i].GetName() < divisions[j].GetName()
+	})
+	return divisions, err
+}
+
+// GetByTeam retrieves the division the team is in.
+func (r *MemoryDivisionRepository) GetByTeam(t *entity.Group) (*model.Division, error) {
+	divisions, err := r.filter(func(d *model.Division) bool {
+		return d.HasTeam(t.ID)
+	})
+	if err != nil {
+		return &model.Division{}, err
+	}
+	if len(divisions) == 0 {
+		return &model.Division{}, repository.ErrDivisionNotFound
+	}
+	return divisions[0], nil
+}
+
+// Add stores a new division in the repository.
+func (r *MemoryDivisionRepository) Add(d *model.Division) error {
+	if r.events.Exists(r.events.Stream(divisionCategory, d.GetID())) {
+		return repository.ErrDivisionAlreadyExists
+	}
+
+	err := r.events.Commit(r, r.events.Stream(divisionCategory, d.GetID()), 0, d.Events())
+	if err == eventstore.ErrConcurrencyConflict {
+		return repository.ErrDivisionAlreadyExists
+	}
+	return err
+}
+
+// Update appends changes to division in the repository.
+func (r *MemoryDivisionRepository) Update(d *model.Division) error {
+	if !r.events.Exists(r.events.Stream(divisionCategory, d.GetID())) {
+		return repository.ErrDivisionNotFound
+	}
+
+	newEvents := d.Events()
+	if len(newEvents) == 0 {
+		return repository.ErrDivisionHasNoUpdates
+	}
+
+	err := r.events.Commit(r, r.events.Stream(divisionCategory, d.GetID()), d.Version(), newEvents)
+	if err == eventstore.ErrConcurrencyConflict {
+		return repository.ErrDivisionConflict
+	}
+	return err
+}
+
+// filter returns every division matching the predicate.
+func (r *MemoryDivisionRepository) filter(match func(d *model.Division) bool) ([]*model.Division, error) {
+	all, err := r.events.LoadCategory(divisionCategory)
+	if err != nil {
+		return []*model.Division{}, err
+	}
+
+	divisions := []*model.Division{}
+	for _, events := range all {
+		if d := model.NewDivisionFromEvents(events); match(d) {
+			divisions = append(divisions, d)
+		}
+	}
+	return divisions, nil
+}
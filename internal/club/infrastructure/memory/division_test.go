@@ -0,0 +1,177 @@
+package memory
+
+import (
+	"testing"
+
+	"git.sr.ht/~loges/teammate/internal/club/domain/event"
+	"git.sr.ht/~loges/teammate/internal/club/domain/model"
+	"git.sr.ht/~loges/teammate/internal/club/domain/repository"
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"github.com/google/uuid"
+	"github.com/matryer/is"
+)
+
+var (
+	exampleDivision = &entity.Group{ID: uuid.MustParse("d15e93f8-c952-11ed-afa1-0242ac120002"), Name: "U14"}
+	anotherDivision = &entity.Group{ID: uuid.MustParse("d25e93f8-c952-11ed-afa1-0242ac120002"), Name: "U12"}
+	exampleTeam     = &entity.Group{ID: uuid.MustParse("f55e93f8-c952-11ed-afa1-0242ac120002"), Name: "Tigers"}
+	divisionCreated = &event.DivisionCreated{ID: exampleDivision.ID, Name: exampleDivision.Name, ClubId: exampleClub.ID}
+	anotherCreated  = &event.DivisionCreated{ID: anotherDivision.ID, Name: anotherDivision.Name, ClubId: exampleClub.ID}
+	teamAdded       = &event.TeamAddedToDivision{ID: exampleDivision.ID, TeamId: exampleTeam.ID, TeamName: exampleTeam.Name}
+)
+
+func TestMemoryDivisionRepository_Get(t *testing.T) {
+	testCases := []struct {
+		test        string
+		group       *entity.Group
+		expectedErr error
+	}{
+		{"Division found", exampleDivision, nil},
+		{"No division found with this group", anotherDivision, repository.ErrDivisionNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			r := NewMemoryDivisionRepository()
+			seedDivision(r, exampleDivision.ID, divisionCreated)
+
+			_, err := r.Get(tc.group)
+
+			is.Equal(err, tc.expectedErr)
+		})
+	}
+}
+
+func TestMemoryDivisionRepository_GetByClub(t *testing.T) {
+	testCases := []struct {
+		test     string
+		club     *entity.Group
+		expected []string
+	}{
+		{"Divisions ordered by name", exampleClub, []string{"U12", "U14"}},
+		{"Club without divisions", anotherClub, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			r := NewMemoryDivisionRepository()
+			seedDivision(r, exampleDivision.ID, divisionCreated)
+			seedDivision(r, anotherDivision.ID, anotherCreated)
+
+			divisions, err := r.GetByClub(tc.club)
+
+			is.NoErr(err)
+			var names []string
+			for _, d := range divisions {
+				names = append(names, d.GetName())
+			}
+			is.Equal(names, tc.expected)
+		})
+	}
+}
+
+func TestMemoryDivisionRepository_GetByTeam(t *testing.T) {
+	testCases := []struct {
+		test        string
+		team        *entity.Group
+		expectedErr error
+	}{
+		{"Division of the team", exampleTeam, nil},
+		{"Team in no division", &entity.Group{ID: uuid.New(), Name: "Bears"}, repository.ErrDivisionNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			r := NewMemoryDivisionRepository()
+			seedDivision(r, exampleDivision.ID, divisionCreated, teamAdded)
+			seedDivision(r, anotherDivision.ID, anotherCreated)
+
+			d, err := r.GetByTeam(tc.team)
+
+			is.Equal(err, tc.expectedErr)
+			if err == nil {
+				is.Equal(d.GetID(), exampleDivision.ID)
+			}
+		})
+	}
+}
+
+func TestMemoryDivisionRepository_Add(t *testing.T) {
+	testCases := []struct {
+		test        string
+		group       *entity.Group
+		expectedErr error
+	}{
+		{"Successfully add a division", anotherDivision, nil},
+		{"Division already exists error", exampleDivision, repository.ErrDivisionAlreadyExists},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			r := NewMemoryDivisionRepository()
+			seedDivision(r, exampleDivision.ID, divisionCreated)
+			d, _ := model.NewDivision(tc.group, model.NewClubFromEvents([]event.Event{clubCreated}))
+
+			err := r.Add(d)
+
+			is.Equal(err, tc.expectedErr)
+		})
+	}
+}
+
+func TestMemoryDivisionRepository_Update(t *testing.T) {
+	testCases := []struct {
+		test        string
+		register    bool
+		addTeam     bool
+		expectedErr error
+	}{
+		{"Update division", true, true, nil},
+		{"Division has no changes", true, false, repository.ErrDivisionHasNoUpdates},
+		{"Division not found", false, true, repository.ErrDivisionNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			r := NewMemoryDivisionRepository()
+			d := model.NewDivisionFromEvents([]event.Event{divisionCreated})
+			if tc.register {
+				seedDivision(r, exampleDivision.ID, divisionCreated)
+			}
+			if tc.addTeam {
+				is.NoErr(d.AddTeam(exampleTeam))
+			}
+
+			err := r.Update(d)
+
+			is.Equal(err, tc.expectedErr)
+		})
+	}
+}
+
+func TestMemoryDivisionRepository_UpdateConflict(t *testing.T) {
+	is := is.New(t)
+	r := NewMemoryDivisionRepository()
+	seedDivision(r, exampleDivision.ID, divisionCreated)
+	first, _ := r.Get(exampleDivision)
+	second, _ := r.Get(exampleDivision)
+
+	is.NoErr(first.AddTeam(exampleTeam))
+	is.NoErr(second.AddTeam(exampleTeam))
+
+	is.NoErr(r.Update(first))
+	is.Equal(r.Update(second), repository.ErrDivisionConflict)
+}
+
+func seedDivision(r *MemoryDivisionRepository, id uuid.UUID, events ...event.Event) {
+	stored := make([]any, len(events))
+	for i, e := range events {
+		stored[i] = e
+	}
+	_ = r.events.Store().Append(r.events.Stream(divisionCategory, id), 0, stored...)
+}
@@ -0,0 +1,17 @@
+package memory
+
+import (
+	"git.sr.ht/~loges/teammate/internal/club/domain/event"
+	"git.sr.ht/~loges/teammate/internal/eventstore"
+)
+
+// Schemas lists how every club event is stored. When the fields of an
+// event change, bump its version and append an upcaster from the
+// previous version, and add a fixture of the new version to the tests.
+var Schemas = []eventstore.Schema{
+	{Version: 1, New: func() any { return &event.ClubCreated{} }},
+	{Version: 1, New: func() any { return &event.TeamAddedToClub{} }},
+	{Version: 1, New: func() any { return &event.DivisionCreated{} }},
+	{Version: 1, New: func() any { return &event.TeamAddedToDivision{} }},
+	{Version: 1, New: func() any { return &event.TeamRemovedFromDivision{} }},
+}
@@ -0,0 +1,63 @@
+package memory
+
+import (
+	"testing"
+
+	"git.sr.ht/~loges/teammate/internal/club/domain/event"
+	"git.sr.ht/~loges/teammate/internal/eventstore"
+	"github.com/matryer/is"
+)
+
+var (
+	fixtureClubId     = `"c15e93f8-c952-11ed-afa1-0242ac120002"`
+	fixtureDivisionId = `"d15e93f8-c952-11ed-afa1-0242ac120002"`
+	fixtureTeamId     = `"f55e93f8-c952-11ed-afa1-0242ac120002"`
+)
+
+// TestSchemas replays a stored fixture of every historical version of
+// every event.
+func TestSchemas(t *testing.T) {
+	testCases := []struct {
+		test     string
+		payload  *eventstore.Payload
+		expected any
+	}{
+		{
+			"ClubCreated version 1",
+			&eventstore.Payload{Type: "ClubCreated", SchemaVersion: 1, Data: []byte(`{"id":` + fixtureClubId + `,"name":"Riverside FC"}`)},
+			clubCreated,
+		},
+		{
+			"TeamAddedToClub version 1",
+			&eventstore.Payload{Type: "TeamAddedToClub", SchemaVersion: 1, Data: []byte(`{"id":` + fixtureClubId + `,"team_id":` + fixtureTeamId + `,"team_name":"Tigers"}`)},
+			&event.TeamAddedToClub{ID: exampleClub.ID, TeamId: exampleTeam.ID, TeamName: exampleTeam.Name},
+		},
+		{
+			"DivisionCreated version 1",
+			&eventstore.Payload{Type: "DivisionCreated", SchemaVersion: 1, Data: []byte(`{"id":` + fixtureDivisionId + `,"name":"U14","club_id":` + fixtureClubId + `}`)},
+			divisionCreated,
+		},
+		{
+			"TeamAddedToDivision version 1",
+			&eventstore.Payload{Type: "TeamAddedToDivision", SchemaVersion: 1, Data: []byte(`{"id":` + fixtureDivisionId + `,"team_id":` + fixtureTeamId + `,"team_name":"Tigers"}`)},
+			teamAdded,
+		},
+		{
+			"TeamRemovedFromDivision version 1",
+			&eventstore.Payload{Type: "TeamRemovedFromDivision", SchemaVersion: 1, Data: []byte(`{"id":` + fixtureDivisionId + `,"team_id":` + fixtureTeamId + `}`)},
+			&event.TeamRemovedFromDivision{ID: exampleDivision.ID, TeamId: exampleTeam.ID},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			c := eventstore.NewCodec(Schemas...)
+
+			e, err := c.Decode(tc.payload)
+
+			is.NoErr(err)
+			is.Equal(e, tc.expected)
+		})
+	}
+}
@@ -9,3 +9,14 @@ type Group struct {
 	ID   uuid.UUID
 	Name string
 }
+
+// Organization is the group of the whole organization. It is above every
+// other group, so roles held within it apply everywhere, and actions not
+// within any one group, such as adding a player, need them.
+var Organization = &Group{ID: uuid.Nil, Name: "Organization"}
+
+// Hierarchy places groups within the groups above them, such as a team
+// within its division and club.
+type Hierarchy interface {
+	GetAncestors(g *Group) ([]*Group, error)
+}
@@ -0,0 +1,17 @@
+package entity
+
+// Permission is an action that can be authorized in all domains.
+type Permission string
+
+const (
+	PermissionManageTeams   Permission = "teams:manage"
+	PermissionManagePlayers Permission = "players:manage"
+	PermissionManageRoster  Permission = "roster:manage"
+	PermissionViewRoster    Permission = "roster:view"
+	// PermissionManageRoles allows granting and revoking the roles held
+	// within a group.
+	PermissionManageRoles Permission = "roles:manage"
+	// PermissionViewSensitivePlayerData reveals sensitive fields of the
+	// profiles of the players in a group.
+	PermissionViewSensitivePlayerData Permission = "players:view_sensitive"
+)
@@ -4,6 +4,9 @@ import (
 	"github.com/google/uuid"
 )
 
+// ErasedName is the name of persons whose personal data was erased.
+const ErasedName = "Erased person"
+
 // Person is an entity that represents a person in all domains.
 type Person struct {
 	ID   uuid.UUID
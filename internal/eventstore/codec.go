@@ -0,0 +1,316 @@
+package eventstore
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"reflect"
+
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrUnknownEventType         = errors.New("eventstore: the event type is not registered")
+	ErrUnsupportedSchemaVersion = errors.New("eventstore: the event schema version is not supported")
+	ErrInvalidSubject           = errors.New("eventstore: personal data needs the ID of its subject")
+	ErrInvalidSealedData        = errors.New("eventstore: sealed personal data is corrupt")
+)
+
+// Payload is an event serialized for storage.
+type Payload struct {
+	Type          string
+	SchemaVersion int
+	Data          []byte
+}
+
+// Upcaster transforms the serialized fields of an event from one schema
+// version to the next. Numbers in the fields are json.Number.
+type Upcaster func(fields map[string]any) error
+
+// Schema describes how an event type is stored. Whenever the fields of the
+// event change, its version is bumped and an upcaster is appended that
+// transforms the previous version to the new one, so Upcasters[0]
+// upgrades version 1 to version 2.
+type Schema struct {
+	Version   int
+	New       func() any
+	Upcasters []Upcaster
+	Personal  []PersonalData
+}
+
+// PersonalData lists the fields of an event holding personal data about
+// the person whose ID is in the subject field. A codec with a key store
+// encrypts them with the key of the subject, so they are unreadable once
+// the key is erased.
+type PersonalData struct {
+	Subject string
+	// Fields read as their zero value once the subject is erased.
+	Fields []string
+	// Names read as entity.ErasedName once the subject is erased.
+	Names []string
+}
+
+// sealed is a field encrypted with the key of its subject. Erased is the
+// value the field reads as once the key is erased.
+type sealed struct {
+	Subject uuid.UUID       `json:"sealed_for"`
+	Data    []byte          `json:"sealed"`
+	Erased  json.RawMessage `json:"erased"`
+}
+
+// Codec serializes events and upcasts older payloads to the current
+// schema when they are read.
+type Codec struct {
+	schemas map[string]Schema
+	keys    KeyStore
+}
+
+// NewCodec initializes a codec for the events of the schemas.
+func NewCodec(schemas ...Schema) *Codec {
+	c := &Codec{schemas: make(map[string]Schema)}
+	for _, s := range schemas {
+		c.schemas[typeName(s.New())] = s
+	}
+	return c
+}
+
+// NewEncryptingCodec initializes a codec for the events of the schemas
+// that encrypts their personal data with the keys in the key store.
+func NewEncryptingCodec(keys KeyStore, schemas ...Schema) *Codec {
+	c := NewCodec(schemas...)
+	c.keys = keys
+	return c
+}
+
+// Encode serializes the event with its current schema version.
+func (c *Codec) Encode(e any) (*Payload, error) {
+	name := typeName(e)
+	s, ok := c.schemas[name]
+	if !ok {
+		return nil, ErrUnknownEventType
+	}
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.keys != nil && len(s.Personal) > 0 {
+		fields, err := decodeFields(data)
+		if err != nil {
+			return nil, err
+		}
+		for _, pd := range s.Personal {
+			if err = c.seal(fields, pd); err != nil {
+				return nil, err
+			}
+		}
+		if data, err = json.Marshal(fields); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Payload{Type: name, SchemaVersion: s.Version, Data: data}, nil
+}
+
+// Decode deserializes the payload into its event, upcasting it first if
+// it was stored with an older schema version.
+func (c *Codec) Decode(p *Payload) (any, error) {
+	s, ok := c.schemas[p.Type]
+	if !ok {
+		return nil, ErrUnknownEventType
+	}
+	if p.SchemaVersion < 1 || p.SchemaVersion > s.Version || len(s.Upcasters) < s.Version-1 {
+		return nil, ErrUnsupportedSchemaVersion
+	}
+
+	data := p.Data
+	if p.SchemaVersion < s.Version || c.keys != nil {
+		fields, err := decodeFields(data)
+		if err != nil {
+			return nil, err
+		}
+		if c.keys != nil {
+			if err = c.unseal(fields); err != nil {
+				return nil, err
+			}
+		}
+		for _, upcast := range s.Upcasters[p.SchemaVersion-1 : s.Version-1] {
+			if err := upcast(fields); err != nil {
+				return nil, err
+			}
+		}
+
+		if data, err = json.Marshal(fields); err != nil {
+			return nil, err
+		}
+	}
+
+	e := s.New()
+	if err := json.Unmarshal(data, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// seal encrypts the personal data in the fields with the key of its
+// subject. The data of erased subjects is replaced by what it reads as
+// once erased.
+func (c *Codec) seal(fields map[string]any, pd PersonalData) error {
+	id, ok := fields[pd.Subject].(string)
+	if !ok {
+		return ErrInvalidSubject
+	}
+	subject, err := uuid.Parse(id)
+	if err != nil {
+		return ErrInvalidSubject
+	}
+
+	key, err := c.keys.Key(subject)
+	if err != nil && err != ErrSubjectErased {
+		return err
+	}
+
+	sealField := func(name string, erased json.RawMessage) error {
+		value, ok := fields[name]
+		if !ok {
+			return nil
+		}
+		if key == nil {
+			fields[name] = erased
+			return nil
+		}
+		plaintext, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		data, err := encrypt(key, subject, plaintext)
+		if err != nil {
+			return err
+		}
+		fields[name] = sealed{Subject: subject, Data: data, Erased: erased}
+		return nil
+	}
+
+	for _, name := range pd.Fields {
+		if err := sealField(name, json.RawMessage("null")); err != nil {
+			return err
+		}
+	}
+	erasedName, _ := json.Marshal(entity.ErasedName)
+	for _, name := range pd.Names {
+		if err := sealField(name, erasedName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unseal decrypts the sealed fields. Fields of erased subjects read as
+// what they were sealed to read as once erased.
+func (c *Codec) unseal(fields map[string]any) error {
+	for name, value := range fields {
+		m, ok := value.(map[string]any)
+		if !ok || m["sealed_for"] == nil || m["sealed"] == nil {
+			continue
+		}
+		raw, err := json.Marshal(m)
+		if err != nil {
+			return err
+		}
+		var v sealed
+		if err = json.Unmarshal(raw, &v); err != nil {
+			return err
+		}
+
+		plaintext := []byte(v.Erased)
+		key, err := c.keys.Lookup(v.Subject)
+		switch {
+		case err == nil:
+			if plaintext, err = decrypt(key, v.Subject, v.Data); err != nil {
+				return err
+			}
+		case err != ErrSubjectErased:
+			return err
+		}
+
+		if fields[name], err = decodeValue(plaintext); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// encrypt seals the plaintext with AES-GCM, binding it to the subject.
+func encrypt(key []byte, subject uuid.UUID, plaintext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, plaintext, subject[:]), nil
+}
+
+// decrypt opens data sealed by encrypt.
+func decrypt(key []byte, subject uuid.UUID, data []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < gcm.NonceSize() {
+		return nil, ErrInvalidSealedData
+	}
+	nonce, ciphertext := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, ciphertext, subject[:])
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// decodeFields deserializes the fields of an event, keeping numbers as
+// they were written.
+func decodeFields(data []byte) (map[string]any, error) {
+	fields := make(map[string]any)
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	if err := d.Decode(&fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// decodeValue deserializes a single field, keeping numbers as they were
+// written.
+func decodeValue(data []byte) (any, error) {
+	var value any
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	if err := d.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// typeName returns the name of the event type, ignoring pointers.
+func typeName(e any) string {
+	t := reflect.TypeOf(e)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil {
+		return ""
+	}
+	return t.Name()
+}
@@ -0,0 +1,186 @@
+package eventstore
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"github.com/google/uuid"
+	"github.com/matryer/is"
+)
+
+var errUpcast = errors.New("upcast failed")
+
+// renamed is at schema version 3: version 2 split name into first and
+// last, version 3 added the number.
+type renamed struct {
+	First  string `json:"first"`
+	Last   string `json:"last"`
+	Number int    `json:"number"`
+}
+
+var renamedSchema = Schema{
+	Version: 3,
+	New:     func() any { return &renamed{} },
+	Upcasters: []Upcaster{
+		func(fields map[string]any) error {
+			name, ok := fields["name"].(string)
+			if !ok {
+				return errUpcast
+			}
+			fields["first"], fields["last"] = name, ""
+			delete(fields, "name")
+			return nil
+		},
+		func(fields map[string]any) error {
+			fields["number"] = 0
+			return nil
+		},
+	},
+}
+
+func TestCodec_Encode(t *testing.T) {
+	testCases := []struct {
+		test        string
+		event       any
+		expectedErr error
+	}{
+		{"Encode with current version", &renamed{First: "Matt"}, nil},
+		{"Unknown event type", "created", ErrUnknownEventType},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			c := NewCodec(renamedSchema)
+
+			p, err := c.Encode(tc.event)
+
+			is.Equal(err, tc.expectedErr)
+			if err == nil {
+				is.Equal(p.Type, "renamed")
+				is.Equal(p.SchemaVersion, 3)
+			}
+		})
+	}
+}
+
+func TestCodec_Decode(t *testing.T) {
+	testCases := []struct {
+		test        string
+		payload     *Payload
+		expected    any
+		expectedErr error
+	}{
+		{"Version 1 is upcast twice", &Payload{"renamed", 1, []byte(`{"name":"Matt"}`)}, &renamed{First: "Matt"}, nil},
+		{"Version 2 is upcast once", &Payload{"renamed", 2, []byte(`{"first":"Matt","last":"Gibbs"}`)}, &renamed{First: "Matt", Last: "Gibbs"}, nil},
+		{"Version 3 is current", &Payload{"renamed", 3, []byte(`{"first":"Matt","last":"Gibbs","number":7}`)}, &renamed{First: "Matt", Last: "Gibbs", Number: 7}, nil},
+		{"Failing upcaster", &Payload{"renamed", 1, []byte(`{"name":7}`)}, nil, errUpcast},
+		{"Version from the future", &Payload{"renamed", 4, []byte(`{}`)}, nil, ErrUnsupportedSchemaVersion},
+		{"Version before the first", &Payload{"renamed", 0, []byte(`{}`)}, nil, ErrUnsupportedSchemaVersion},
+		{"Unknown event type", &Payload{"created", 1, []byte(`{}`)}, nil, ErrUnknownEventType},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			c := NewCodec(renamedSchema)
+
+			e, err := c.Decode(tc.payload)
+
+			is.Equal(err, tc.expectedErr)
+			if err == nil {
+				is.Equal(e, tc.expected)
+			}
+		})
+	}
+}
+
+// profile is at schema version 2, which added the phone number.
+type profile struct {
+	ID    uuid.UUID `json:"id"`
+	Name  string    `json:"name"`
+	Email string    `json:"email"`
+	Phone string    `json:"phone"`
+	Age   int       `json:"age"`
+}
+
+var profileSchema = Schema{
+	Version: 2,
+	New:     func() any { return &profile{} },
+	Upcasters: []Upcaster{
+		func(fields map[string]any) error {
+			fields["phone"] = ""
+			return nil
+		},
+	},
+	Personal: []PersonalData{{Subject: "id", Fields: []string{"email", "phone", "age"}, Names: []string{"name"}}},
+}
+
+func TestCodec_Personal(t *testing.T) {
+	subject := uuid.MustParse("f55e93f8-c952-11ed-afa1-0242ac120002")
+	written := &profile{ID: subject, Name: "Matt", Email: "matt@example.com", Phone: "555-0100", Age: 30}
+
+	t.Run("Personal data is encrypted", func(t *testing.T) {
+		is := is.New(t)
+		c := NewEncryptingCodec(NewMemoryKeyStore(), profileSchema)
+
+		p, err := c.Encode(written)
+
+		is.NoErr(err)
+		is.True(!bytes.Contains(p.Data, []byte("Matt")))
+		is.True(!bytes.Contains(p.Data, []byte("matt@example.com")))
+		e, err := c.Decode(p)
+		is.NoErr(err)
+		is.Equal(e, written)
+	})
+
+	t.Run("Erased subject reads as placeholders", func(t *testing.T) {
+		is := is.New(t)
+		keys := NewMemoryKeyStore()
+		c := NewEncryptingCodec(keys, profileSchema)
+		p, _ := c.Encode(written)
+
+		is.NoErr(keys.Erase(subject))
+
+		e, err := c.Decode(p)
+		is.NoErr(err)
+		is.Equal(e, &profile{ID: subject, Name: entity.ErasedName})
+		p, err = c.Encode(written)
+		is.NoErr(err)
+		e, err = c.Decode(p)
+		is.NoErr(err)
+		is.Equal(e, &profile{ID: subject, Name: entity.ErasedName})
+	})
+
+	t.Run("Sealed fields are upcast", func(t *testing.T) {
+		is := is.New(t)
+		keys := NewMemoryKeyStore()
+		c := NewEncryptingCodec(keys, profileSchema)
+		p, _ := c.Encode(written)
+		fields, _ := decodeFields(p.Data)
+		delete(fields, "phone")
+		p.Data, _ = json.Marshal(fields)
+		p.SchemaVersion = 1
+
+		e, err := c.Decode(p)
+
+		is.NoErr(err)
+		is.Equal(e, &profile{ID: subject, Name: "Matt", Email: "matt@example.com", Age: 30})
+	})
+
+	t.Run("Personal data without subject", func(t *testing.T) {
+		is := is.New(t)
+		c := NewEncryptingCodec(NewMemoryKeyStore(), Schema{
+			Version:  1,
+			New:      func() any { return &renamed{} },
+			Personal: []PersonalData{{Subject: "id", Names: []string{"first"}}},
+		})
+
+		_, err := c.Encode(&renamed{First: "Matt"})
+
+		is.Equal(err, ErrInvalidSubject)
+	})
+}
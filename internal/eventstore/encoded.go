@@ -0,0 +1,75 @@
+package eventstore
+
+// EncodedStore serializes events with a codec before appending them to the
+// store it wraps, and deserializes them when they are read, so the personal
+// data of the events is encrypted whatever store keeps them. Records the
+// codec doesn't know, such as those of other contexts sharing the store,
+// are read as the payloads they are stored as.
+type EncodedStore struct {
+	Store
+	codec *Codec
+}
+
+// NewEncodedStore wraps the store, which should keep events as they are
+// given, with the codec.
+func NewEncodedStore(s Store, c *Codec) *EncodedStore {
+	return &EncodedStore{Store: s, codec: c}
+}
+
+// Append serializes the events and adds them to the end of the stream if
+// the stream is still at the expected version.
+func (s *EncodedStore) Append(stream Stream, expectedVersion int, events ...any) error {
+	return s.AppendStaged(stream, expectedVersion, nil, events...)
+}
+
+// AppendStaged serializes the events and adds them to the end of the
+// stream like Append, running the stage within the append of the store it
+// wraps.
+func (s *EncodedStore) AppendStaged(stream Stream, expectedVersion int, stage Stage, events ...any) error {
+	payloads := make([]any, len(events))
+	for i, e := range events {
+		p, err := s.codec.Encode(e)
+		if err != nil {
+			return err
+		}
+		payloads[i] = p
+	}
+	return s.Store.AppendStaged(stream, expectedVersion, stage, payloads...)
+}
+
+// ReadStream returns every record of the stream in order.
+func (s *EncodedStore) ReadStream(stream Stream) ([]*Record, error) {
+	records, err := s.Store.ReadStream(stream)
+	if err != nil {
+		return nil, err
+	}
+	return s.decode(records)
+}
+
+// ReadAll returns up to limit records after the position in global order.
+func (s *EncodedStore) ReadAll(from uint64, limit int) ([]*Record, error) {
+	records, err := s.Store.ReadAll(from, limit)
+	if err != nil {
+		return nil, err
+	}
+	return s.decode(records)
+}
+
+// decode copies the records, deserializing the payloads the codec knows.
+func (s *EncodedStore) decode(records []*Record) ([]*Record, error) {
+	decoded := make([]*Record, len(records))
+	for i, r := range records {
+		c := *r
+		if p, ok := r.Event.(*Payload); ok {
+			e, err := s.codec.Decode(p)
+			switch {
+			case err == nil:
+				c.Event = e
+			case err != ErrUnknownEventType:
+				return nil, err
+			}
+		}
+		decoded[i] = &c
+	}
+	return decoded, nil
+}
@@ -0,0 +1,60 @@
+package eventstore
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/matryer/is"
+)
+
+func TestEncodedStore_Codec(t *testing.T) {
+	is := is.New(t)
+	inner := NewMemoryStore()
+	s := NewEncodedStore(inner, NewCodec(renamedSchema))
+	errStage := errors.New("stage failed")
+
+	is.Equal(s.Append(exampleStream, 0, "created"), ErrUnknownEventType)
+	is.Equal(s.AppendStaged(exampleStream, 0, func() error { return errStage }, &renamed{First: "Matt"}), errStage)
+	is.NoErr(s.Append(exampleStream, 0, &renamed{First: "Matt", Number: 7}))
+
+	stored, _ := inner.ReadStream(exampleStream)
+	_, ok := stored[0].Event.(*Payload)
+	is.True(ok)
+	records, err := s.ReadStream(exampleStream)
+	is.NoErr(err)
+	is.Equal(records[0].Event, &renamed{First: "Matt", Number: 7})
+	records, err = s.ReadAll(0, 0)
+	is.NoErr(err)
+	is.Equal(records[0].Event, &renamed{First: "Matt", Number: 7})
+}
+
+func TestEncodedStore(t *testing.T) {
+	is := is.New(t)
+	subject := uuid.MustParse("f55e93f8-c952-11ed-afa1-0242ac120002")
+	written := &profile{ID: subject, Name: "Matt", Email: "matt@example.com"}
+	inner := NewMemoryStore()
+	keys := NewMemoryKeyStore()
+	s := NewEncodedStore(inner, NewEncryptingCodec(keys, profileSchema))
+	other := NewEncodedStore(inner, NewCodec(renamedSchema))
+
+	is.Equal(s.Append(exampleStream, 0, "created"), ErrUnknownEventType)
+	is.NoErr(s.Append(exampleStream, 0, written))
+	is.NoErr(other.Append(Stream{Category: "renamed", ID: subject}, 0, &renamed{First: "Matt"}))
+
+	stored, _ := inner.ReadStream(exampleStream)
+	is.True(!bytes.Contains(stored[0].Event.(*Payload).Data, []byte("Matt")))
+	records, err := s.ReadStream(exampleStream)
+	is.NoErr(err)
+	is.Equal(records[0].Event, written)
+	records, err = s.ReadAll(0, 0)
+	is.NoErr(err)
+	is.Equal(records[0].Event, written)
+	_, ok := records[1].Event.(*Payload) // another context's event
+	is.True(ok)
+
+	is.NoErr(keys.Erase(subject))
+	records, _ = s.ReadStream(exampleStream)
+	is.Equal(records[0].Event.(*profile).Email, "")
+}
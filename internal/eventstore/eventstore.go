@@ -0,0 +1,256 @@
+package eventstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"git.sr.ht/~loges/teammate/internal/tenantid"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrStreamNotFound      = errors.New("eventstore: the stream was not found")
+	ErrConcurrencyConflict = errors.New("eventstore: the stream version has changed")
+)
+
+// Stream identifies the events of one aggregate. Streams of different
+// tenants never share events, even when their category and ID are equal.
+type Stream struct {
+	Tenant   tenantid.ID
+	Category string
+	ID       uuid.UUID
+}
+
+func (s Stream) String() string {
+	if s.Tenant != tenantid.None {
+		return fmt.Sprintf("%s/%s-%s", s.Tenant, s.Category, s.ID)
+	}
+	return fmt.Sprintf("%s-%s", s.Category, s.ID)
+}
+
+// Record is an event stored in the event store.
+type Record struct {
+	// Position is the monotonically increasing global sequence number.
+	Position uint64
+	Stream   Stream
+	// Version is the 1-based position of the event within its stream.
+	Version    int
+	Event      any
+	RecordedAt time.Time
+}
+
+// Store defines the interface for the event store.
+type Store interface {
+	Append(s Stream, expectedVersion int, events ...any) error
+	AppendStaged(s Stream, expectedVersion int, stage Stage, events ...any) error
+	Version(s Stream) (int, error)
+	ReadStream(s Stream) ([]*Record, error)
+	Streams(t tenantid.ID, category string) ([]Stream, error)
+	ReadAll(from uint64, limit int) ([]*Record, error)
+	Wait(ctx context.Context, after uint64) error
+}
+
+// Stage records what has to be written together with the events of an
+// append, such as their outbox entries. The store runs it within the
+// append, in the order the appends are stored, and stores nothing if it
+// fails.
+type Stage func() error
+
+// Configuration is a function that modifies the memory store.
+type Configuration func(s *MemoryStore)
+
+// WithClock sets the function the store reads the recording time from.
+func WithClock(now func() time.Time) Configuration {
+	return func(s *MemoryStore) {
+		s.now = now
+	}
+}
+
+// MemoryStore is an in-memory event store.
+type MemoryStore struct {
+	records  []*Record
+	streams  map[Stream][]*Record
+	appended chan struct{}
+	now      func() time.Time
+	sync.RWMutex
+}
+
+// NewMemoryStore initializes an in-memory event store.
+func NewMemoryStore(cfgs ...Configuration) *MemoryStore {
+	s := &MemoryStore{
+		streams:  make(map[Stream][]*Record),
+		appended: make(chan struct{}),
+		now:      time.Now,
+	}
+
+	for _, cfg := range cfgs {
+		cfg(s)
+	}
+
+	return s
+}
+
+// Append adds the events to the end of the stream if the stream is still
+// at the expected version. A new stream is at version 0.
+func (s *MemoryStore) Append(stream Stream, expectedVersion int, events ...any) error {
+	return s.AppendStaged(stream, expectedVersion, nil, events...)
+}
+
+// AppendStaged adds the events to the end of the stream like Append, and
+// runs the stage before they are stored. The events are not stored if the
+// stage fails, and the stage is not run if the stream has moved on.
+func (s *MemoryStore) AppendStaged(stream Stream, expectedVersion int, stage Stage, events ...any) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if len(s.streams[stream]) != expectedVersion {
+		return ErrConcurrencyConflict
+	}
+	if len(events) == 0 {
+		return nil
+	}
+
+	if stage != nil {
+		if err := stage(); err != nil {
+			return err
+		}
+	}
+
+	recordedAt := s.now()
+	for i, e := range events {
+		r := &Record{
+			Position:   uint64(len(s.records) + 1),
+			Stream:     stream,
+			Version:    expectedVersion + i + 1,
+			Event:      e,
+			RecordedAt: recordedAt,
+		}
+		s.records = append(s.records, r)
+		s.streams[stream] = append(s.streams[stream], r)
+	}
+
+	// wake up everyone waiting for new records
+	close(s.appended)
+	s.appended = make(chan struct{})
+
+	return nil
+}
+
+// Version returns the current version of the stream.
+func (s *MemoryStore) Version(stream Stream) (int, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	return len(s.streams[stream]), nil
+}
+
+// ReadStream returns every record of the stream in order.
+func (s *MemoryStore) ReadStream(stream Stream) ([]*Record, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	records, ok := s.streams[stream]
+	if !ok {
+		return nil, ErrStreamNotFound
+	}
+	return copyRecords(records), nil
+}
+
+// Streams returns every stream of the category belonging to the tenant.
+func (s *MemoryStore) Streams(t tenantid.ID, category string) (streams []Stream, err error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	for stream := range s.streams {
+		if stream.Tenant == t && stream.Category == category {
+			streams = append(streams, stream)
+		}
+	}
+	sort.Slice(streams, func(i, j int) bool {
+		return s.streams[streams[i]][0].Position < s.streams[streams[j]][0].Position
+	})
+	return streams, nil
+}
+
+// ReadAll returns up to limit records after the position in global order.
+func (s *MemoryStore) ReadAll(from uint64, limit int) ([]*Record, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	if from >= uint64(len(s.records)) {
+		return nil, nil
+	}
+
+	records := s.records[from:]
+	if limit > 0 && len(records) > limit {
+		records = records[:limit]
+	}
+	return copyRecords(records), nil
+}
+
+// copyRecords copies the records, so readers can't change the stored
+// ones.
+func copyRecords(records []*Record) []*Record {
+	copied := make([]*Record, len(records))
+	for i, r := range records {
+		c := *r
+		copied[i] = &c
+	}
+	return copied
+}
+
+// Wait blocks until a record after the position is stored or ctx is done.
+func (s *MemoryStore) Wait(ctx context.Context, after uint64) error {
+	for {
+		s.RLock()
+		head, appended := uint64(len(s.records)), s.appended
+		s.RUnlock()
+
+		if head > after {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-appended:
+		}
+	}
+}
+
+// Subscribe streams every record after the position, waiting for new
+// records as they are stored, until ctx is done.
+func Subscribe(ctx context.Context, s Store, from uint64) <-chan *Record {
+	records := make(chan *Record)
+
+	go func() {
+		defer close(records)
+
+		position := from
+		for {
+			if err := s.Wait(ctx, position); err != nil {
+				return
+			}
+
+			batch, err := s.ReadAll(position, 100)
+			if err != nil {
+				return
+			}
+
+			for _, r := range batch {
+				select {
+				case <-ctx.Done():
+					return
+				case records <- r:
+					position = r.Position
+				}
+			}
+		}
+	}()
+
+	return records
+}
@@ -0,0 +1,204 @@
+package eventstore
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"git.sr.ht/~loges/teammate/internal/tenantid"
+	"github.com/google/uuid"
+	"github.com/matryer/is"
+)
+
+var (
+	now           = time.Date(2023, time.March, 1, 12, 0, 0, 0, time.UTC)
+	exampleStream = Stream{Category: "team", ID: uuid.MustParse("f55e93f8-c952-11ed-afa1-0242ac120002")}
+	anotherStream = Stream{Category: "player", ID: uuid.MustParse("f47ac10b-58cc-0372-8567-0e02b2c3d479")}
+)
+
+func TestMemoryStore_Append(t *testing.T) {
+	testCases := []struct {
+		test            string
+		expectedVersion int
+		expectedErr     error
+	}{
+		{"Append at current version", 2, nil},
+		{"Append at stale version", 1, ErrConcurrencyConflict},
+		{"Append to new stream that exists", 0, ErrConcurrencyConflict},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			s := NewMemoryStore(WithClock(func() time.Time { return now }))
+			is.NoErr(s.Append(exampleStream, 0, "created", "renamed"))
+
+			err := s.Append(exampleStream, tc.expectedVersion, "deactivated")
+
+			is.Equal(err, tc.expectedErr)
+		})
+	}
+}
+
+func TestMemoryStore_AppendStaged(t *testing.T) {
+	errStage := errors.New("stage failed")
+	testCases := []struct {
+		test            string
+		expectedVersion int
+		stageErr        error
+		expectedStaged  bool
+		storedVersion   int
+		expectedErr     error
+	}{
+		{"Staged with the events", 1, nil, true, 2, nil},
+		{"Failed stage stores nothing", 1, errStage, true, 1, errStage},
+		{"Conflict runs no stage", 0, nil, false, 1, ErrConcurrencyConflict},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			s := NewMemoryStore()
+			is.NoErr(s.Append(exampleStream, 0, "created"))
+			staged := false
+
+			err := s.AppendStaged(exampleStream, tc.expectedVersion, func() error {
+				staged = true
+				return tc.stageErr
+			}, "renamed")
+
+			is.Equal(err, tc.expectedErr)
+			is.Equal(staged, tc.expectedStaged)
+			version, _ := s.Version(exampleStream)
+			is.Equal(version, tc.storedVersion)
+		})
+	}
+}
+
+func TestMemoryStore_ReadStream(t *testing.T) {
+	is := is.New(t)
+	s := NewMemoryStore(WithClock(func() time.Time { return now }))
+	_ = s.Append(exampleStream, 0, "created")
+	_ = s.Append(anotherStream, 0, "registered")
+	_ = s.Append(exampleStream, 1, "renamed")
+
+	records, err := s.ReadStream(exampleStream)
+	is.NoErr(err)
+	is.Equal(len(records), 2)
+	is.Equal(records[1].Event, "renamed")
+	is.Equal(records[1].Version, 2)
+	is.Equal(records[1].Position, uint64(3))
+	is.Equal(records[1].RecordedAt, now)
+
+	version, _ := s.Version(exampleStream)
+	is.Equal(version, 2)
+
+	_, err = s.ReadStream(Stream{Category: "team", ID: uuid.New()})
+	is.Equal(err, ErrStreamNotFound)
+}
+
+func TestMemoryStore_Streams(t *testing.T) {
+	is := is.New(t)
+	s := NewMemoryStore()
+	_ = s.Append(exampleStream, 0, "created")
+	_ = s.Append(anotherStream, 0, "registered")
+
+	streams, err := s.Streams(tenantid.None, "team")
+
+	is.NoErr(err)
+	is.Equal(streams, []Stream{exampleStream})
+}
+
+func TestMemoryStore_Tenants(t *testing.T) {
+	is := is.New(t)
+	s := NewMemoryStore()
+	tenantStream := Stream{Tenant: "riverside", Category: exampleStream.Category, ID: exampleStream.ID}
+	_ = s.Append(exampleStream, 0, "created")
+
+	err := s.Append(tenantStream, 0, "created elsewhere")
+
+	is.NoErr(err)
+	records, _ := s.ReadStream(tenantStream)
+	is.Equal(len(records), 1)
+	is.Equal(records[0].Event, "created elsewhere")
+	is.Equal(records[0].Stream.Tenant, tenantid.ID("riverside"))
+	streams, _ := s.Streams("riverside", "team")
+	is.Equal(streams, []Stream{tenantStream})
+	streams, _ = s.Streams("lakeside", "team")
+	is.Equal(len(streams), 0)
+	is.Equal(tenantStream.String(), "riverside/team-f55e93f8-c952-11ed-afa1-0242ac120002")
+	is.Equal(exampleStream.String(), "team-f55e93f8-c952-11ed-afa1-0242ac120002")
+}
+
+func TestMemoryStore_ReadAll(t *testing.T) {
+	testCases := []struct {
+		test     string
+		from     uint64
+		limit    int
+		expected []any
+	}{
+		{"Read from start", 0, 0, []any{"created", "registered", "renamed"}},
+		{"Read after position", 1, 0, []any{"registered", "renamed"}},
+		{"Read with limit", 0, 2, []any{"created", "registered"}},
+		{"Read past end", 3, 0, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			s := NewMemoryStore()
+			_ = s.Append(exampleStream, 0, "created")
+			_ = s.Append(anotherStream, 0, "registered")
+			_ = s.Append(exampleStream, 1, "renamed")
+
+			records, err := s.ReadAll(tc.from, tc.limit)
+
+			is.NoErr(err)
+			var events []any
+			for _, r := range records {
+				events = append(events, r.Event)
+			}
+			is.Equal(events, tc.expected)
+		})
+	}
+}
+
+func TestMemoryStore_Wait(t *testing.T) {
+	t.Run("Returns once a record is appended", func(t *testing.T) {
+		is := is.New(t)
+		s := NewMemoryStore()
+		done := make(chan error)
+
+		go func() { done <- s.Wait(context.Background(), 0) }()
+		_ = s.Append(exampleStream, 0, "created")
+
+		is.NoErr(<-done)
+	})
+
+	t.Run("Returns when context is done", func(t *testing.T) {
+		is := is.New(t)
+		s := NewMemoryStore()
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		is.Equal(s.Wait(ctx, 0), context.Canceled)
+	})
+}
+
+func TestSubscribe(t *testing.T) {
+	is := is.New(t)
+	s := NewMemoryStore()
+	_ = s.Append(exampleStream, 0, "created")
+	ctx, cancel := context.WithCancel(context.Background())
+
+	records := Subscribe(ctx, s, 0)
+	is.Equal((<-records).Event, "created")
+
+	_ = s.Append(anotherStream, 0, "registered")
+	is.Equal((<-records).Event, "registered")
+
+	cancel()
+	for range records {
+	}
+}
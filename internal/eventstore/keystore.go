@@ -0,0 +1,116 @@
+package eventstore
+
+import (
+	"crypto/rand"
+	"errors"
+	"sync"
+
+	"git.sr.ht/~loges/teammate/internal/tenantid"
+	"github.com/google/uuid"
+)
+
+var ErrSubjectErased = errors.New("eventstore: the personal data of the subject was erased")
+
+// KeyStore holds the keys personal data is encrypted with, one per
+// person. Erasing the key of a person makes the personal data of the
+// person in every stored event unreadable.
+type KeyStore interface {
+	// Key returns the key of the subject, creating it on first use.
+	Key(subject uuid.UUID) ([]byte, error)
+	// Lookup returns the existing key of the subject.
+	Lookup(subject uuid.UUID) ([]byte, error)
+	// Erase destroys the key of the subject for good.
+	Erase(subject uuid.UUID) error
+}
+
+// MemoryKeyStore is an in-memory key store.
+type MemoryKeyStore struct {
+	keys   map[uuid.UUID][]byte
+	erased map[uuid.UUID]bool
+	sync.Mutex
+}
+
+// NewMemoryKeyStore initializes an in-memory key store.
+func NewMemoryKeyStore() *MemoryKeyStore {
+	return &MemoryKeyStore{
+		keys:   make(map[uuid.UUID][]byte),
+		erased: make(map[uuid.UUID]bool),
+	}
+}
+
+// Key returns the key of the subject, creating it on first use. Erased
+// subjects never get a new key.
+func (s *MemoryKeyStore) Key(subject uuid.UUID) ([]byte, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.erased[subject] {
+		return nil, ErrSubjectErased
+	}
+	if key, ok := s.keys[subject]; ok {
+		return key, nil
+	}
+
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	s.keys[subject] = key
+	return key, nil
+}
+
+// Lookup returns the existing key of the subject. Subjects without a key
+// are treated as erased.
+func (s *MemoryKeyStore) Lookup(subject uuid.UUID) ([]byte, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	key, ok := s.keys[subject]
+	if !ok {
+		return nil, ErrSubjectErased
+	}
+	return key, nil
+}
+
+// Erase destroys the key of the subject.
+func (s *MemoryKeyStore) Erase(subject uuid.UUID) error {
+	s.Lock()
+	defer s.Unlock()
+
+	delete(s.keys, subject)
+	s.erased[subject] = true
+	return nil
+}
+
+// ScopeKeys returns the keys ks holds for the tenant, so tenants sharing ks
+// never share a key, even for subjects with the same ID. The keys of
+// tenantid.None are the keys of ks itself.
+func ScopeKeys(ks KeyStore, t tenantid.ID) KeyStore {
+	if ks == nil || t == tenantid.None {
+		return ks
+	}
+	return &scopedKeyStore{keys: ks, namespace: uuid.NewSHA1(uuid.Nil, []byte(t))}
+}
+
+// scopedKeyStore keeps the keys of a tenant under subjects derived from
+// the tenant and the subject.
+type scopedKeyStore struct {
+	keys      KeyStore
+	namespace uuid.UUID
+}
+
+func (s *scopedKeyStore) Key(subject uuid.UUID) ([]byte, error) {
+	return s.keys.Key(s.subject(subject))
+}
+
+func (s *scopedKeyStore) Lookup(subject uuid.UUID) ([]byte, error) {
+	return s.keys.Lookup(s.subject(subject))
+}
+
+func (s *scopedKeyStore) Erase(subject uuid.UUID) error {
+	return s.keys.Erase(s.subject(subject))
+}
+
+func (s *scopedKeyStore) subject(subject uuid.UUID) uuid.UUID {
+	return uuid.NewSHA1(s.namespace, subject[:])
+}
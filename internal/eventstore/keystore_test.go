@@ -0,0 +1,56 @@
+package eventstore
+
+import (
+	"testing"
+
+	"git.sr.ht/~loges/teammate/internal/tenantid"
+	"github.com/google/uuid"
+	"github.com/matryer/is"
+)
+
+func TestMemoryKeyStore(t *testing.T) {
+	is := is.New(t)
+	subject := uuid.MustParse("f55e93f8-c952-11ed-afa1-0242ac120002")
+	s := NewMemoryKeyStore()
+
+	_, err := s.Lookup(subject)
+	is.Equal(err, ErrSubjectErased)
+
+	key, err := s.Key(subject)
+	is.NoErr(err)
+	is.Equal(len(key), 32)
+	again, _ := s.Key(subject)
+	is.Equal(again, key)
+	found, err := s.Lookup(subject)
+	is.NoErr(err)
+	is.Equal(found, key)
+
+	is.NoErr(s.Erase(subject))
+	_, err = s.Lookup(subject)
+	is.Equal(err, ErrSubjectErased)
+	_, err = s.Key(subject)
+	is.Equal(err, ErrSubjectErased)
+}
+
+func TestScopeKeys(t *testing.T) {
+	is := is.New(t)
+	subject := uuid.MustParse("f55e93f8-c952-11ed-afa1-0242ac120002")
+	s := NewMemoryKeyStore()
+	riverside, lakeside := ScopeKeys(s, "riverside"), ScopeKeys(s, "lakeside")
+
+	is.Equal(ScopeKeys(s, tenantid.None), s)
+	key, err := riverside.Key(subject)
+	is.NoErr(err)
+	other, _ := lakeside.Key(subject)
+	is.True(string(key) != string(other))
+	found, _ := ScopeKeys(s, "riverside").Lookup(subject)
+	is.Equal(found, key)
+
+	is.NoErr(riverside.Erase(subject))
+	_, err = riverside.Lookup(subject)
+	is.Equal(err, ErrSubjectErased)
+	_, err = lakeside.Lookup(subject)
+	is.NoErr(err)
+	_, err = s.Lookup(subject)
+	is.Equal(err, ErrSubjectErased)
+}
@@ -0,0 +1,95 @@
+package export_test
+
+import (
+	"bytes"
+	"testing"
+
+	accessservices "git.sr.ht/~loges/teammate/internal/access/application/services"
+	accessmodel "git.sr.ht/~loges/teammate/internal/access/domain/model"
+	accessmemory "git.sr.ht/~loges/teammate/internal/access/infrastructure/memory"
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"git.sr.ht/~loges/teammate/internal/export"
+	teamservices "git.sr.ht/~loges/teammate/internal/team/application/services"
+	teammodel "git.sr.ht/~loges/teammate/internal/team/domain/model"
+	"github.com/google/uuid"
+	"github.com/matryer/is"
+)
+
+// allowAll allows every action.
+type allowAll struct{}
+
+func (allowAll) Authorize(*entity.Person, entity.Permission, *entity.Group) error {
+	return nil
+}
+
+func TestExporter_AcrossContexts(t *testing.T) {
+	is := is.New(t)
+	person := &entity.Person{ID: uuid.New(), Name: "Matt"}
+	group := &entity.Group{ID: uuid.New(), Name: "Tigers"}
+	player := &entity.Person{ID: uuid.New(), Name: "Matt"}
+	child := &entity.Person{ID: uuid.New(), Name: "Sam"}
+	users := accessmemory.NewMemoryUserRepository()
+	u, _ := accessmodel.NewUser(person, "matt@teammate.com")
+	is.NoErr(u.GrantRole(accessmodel.RoleCoach, group))
+	is.NoErr(users.Add(u))
+	rs, _ := teamservices.NewRosterService(teamservices.WithAuthorizer(allowAll{}))
+	is.NoErr(rs.AddTeam(group))
+	is.NoErr(rs.AddPlayer(player))
+	is.NoErr(rs.AddPlayer(child))
+	code, _ := rs.InvitePlayer(player)
+	_, err := rs.ClaimPlayer(code, person)
+	is.NoErr(err)
+	is.NoErr(rs.AssignPlayerToTeam(group, player, 7))
+	is.NoErr(rs.AddStaffMember(group, person, teammodel.StaffRoleManager))
+	is.NoErr(rs.AddGuardian(child, person, "father"))
+	e := export.NewExporter([]export.Source{accessservices.NewExportSource(users), teamservices.NewExportSource(rs)})
+
+	a, err := e.As(person).Export(person)
+
+	is.NoErr(err)
+	access := a.Sections["access"].(*accessservices.UserData)
+	is.Equal(access.Email, "matt@teammate.com")
+	is.Equal(access.Roles, []accessservices.RoleData{{Role: "coach", GroupID: group.ID}})
+	is.Equal(len(access.Events), 2)
+	team := a.Sections["team"].(*teamservices.PersonalData)
+	is.Equal(team.Player.ID, player.ID)
+	is.Equal(len(team.Player.Memberships), 1)
+	is.Equal(team.Guardianships, []teamservices.GuardianshipData{{PlayerID: child.ID, PlayerName: "Sam", Relationship: "father"}})
+	is.Equal(team.Staff, []teamservices.StaffData{{TeamID: group.ID, TeamName: "Tigers", Role: "manager"}})
+	var buf bytes.Buffer
+	is.NoErr(a.WriteJSON(&buf))
+	is.True(bytes.Contains(buf.Bytes(), []byte(`"type": "UserRegistered"`)))
+}
+
+func TestErasure_AcrossContexts(t *testing.T) {
+	is := is.New(t)
+	coach := &entity.Person{ID: uuid.New(), Name: "Matt"}
+	group := &entity.Group{ID: uuid.New(), Name: "Tigers"}
+	player := &entity.Person{ID: uuid.New(), Name: "Sam"}
+	rs, _ := teamservices.NewRosterService(teamservices.WithAuthorizer(allowAll{}))
+	reg, _ := accessservices.NewRegistrationService(accessservices.WithPlayerClaimer(rs), accessservices.WithUserEraser(rs))
+	is.NoErr(teamservices.WithPlayerEraser(reg)(rs))
+	is.NoErr(rs.AddTeam(group))
+	is.NoErr(rs.AddPlayer(player))
+	code, _ := rs.InvitePlayer(player)
+	is.NoErr(reg.RegisterUserWithInvite("Sam", "sam@teammate.com", code))
+	is.NoErr(reg.RegisterUser(coach.Name, "matt@teammate.com"))
+	is.NoErr(reg.ErasePlayer(&entity.Person{ID: uuid.New()})) // nothing held for unknown players
+	e := export.NewExporter([]export.Source{teamservices.NewExportSource(rs)})
+
+	is.NoErr(reg.EraseUser("sam@teammate.com"))
+
+	a, err := e.As(player).Export(player)
+	is.NoErr(err)
+	var buf bytes.Buffer
+	is.NoErr(a.WriteJSON(&buf))
+	is.True(bytes.Contains(buf.Bytes(), []byte(`"type": "UserLinkedToPlayer"`)))
+	is.True(!bytes.Contains(buf.Bytes(), []byte(`"user_name": "Sam"`)))
+
+	is.NoErr(rs.ErasePlayer(player))
+	a, err = e.As(player).Export(player)
+	is.NoErr(err)
+	buf.Reset()
+	is.NoErr(a.WriteJSON(&buf))
+	is.True(!bytes.Contains(buf.Bytes(), []byte(code)))
+}
@@ -0,0 +1,122 @@
+package export
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"time"
+
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrDuplicateSection = errors.New("export: two sources export the same section")
+	ErrNotSubject       = errors.New("export: only the subject can export their data")
+)
+
+// Source gathers the data one part of the application holds about a
+// person.
+type Source interface {
+	// Name identifies the section of the archive the data is put in.
+	Name() string
+	// Export returns the data held about the person. It is encoded as
+	// JSON, so everything meant for the person has to be exported.
+	Export(subject *entity.Person) (any, error)
+}
+
+// Archive is all the data held about a person.
+type Archive struct {
+	Subject    uuid.UUID      `json:"subject"`
+	ExportedAt time.Time      `json:"exported_at"`
+	Sections   map[string]any `json:"sections"`
+}
+
+// WriteJSON writes the archive as indented JSON.
+func (a *Archive) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(a)
+}
+
+// Event is a stored event in an archive.
+type Event struct {
+	Type       string    `json:"type"`
+	Version    int       `json:"version"`
+	RecordedAt time.Time `json:"recorded_at"`
+	Data       any       `json:"data"`
+}
+
+// NewEvent describes the event stored at the version of its stream.
+func NewEvent(version int, recordedAt time.Time, e any) Event {
+	t := reflect.TypeOf(e)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	name := ""
+	if t != nil {
+		name = t.Name()
+	}
+	return Event{Type: name, Version: version, RecordedAt: recordedAt, Data: e}
+}
+
+// Configuration is a function that modifies the exporter.
+type Configuration func(e *Exporter)
+
+// WithClock sets the function the exporter reads the export time from.
+func WithClock(now func() time.Time) Configuration {
+	return func(e *Exporter) {
+		e.now = now
+	}
+}
+
+// Exporter gathers the data every source holds about a person into an
+// archive, answering subject access requests.
+type Exporter struct {
+	sources []Source
+	actor   *entity.Person
+	now     func() time.Time
+}
+
+// NewExporter initializes an exporter gathering data from the sources.
+func NewExporter(sources []Source, cfgs ...Configuration) *Exporter {
+	e := &Exporter{sources: sources, now: time.Now}
+	for _, cfg := range cfgs {
+		cfg(e)
+	}
+	return e
+}
+
+// As returns a copy of the exporter acting on behalf of the actor.
+func (e *Exporter) As(actor *entity.Person) *Exporter {
+	c := *e
+	c.actor = actor
+	return &c
+}
+
+// Export returns the archive of the data every source holds about the
+// person, who has to be the actor. Any failing source fails the export,
+// so an archive is never silently incomplete.
+func (e *Exporter) Export(subject *entity.Person) (*Archive, error) {
+	if e.actor == nil || subject == nil || e.actor.ID != subject.ID {
+		return nil, ErrNotSubject
+	}
+
+	a := &Archive{
+		Subject:    subject.ID,
+		ExportedAt: e.now(),
+		Sections:   make(map[string]any),
+	}
+	for _, s := range e.sources {
+		if _, ok := a.Sections[s.Name()]; ok {
+			return nil, ErrDuplicateSection
+		}
+		data, err := s.Export(subject)
+		if err != nil {
+			return nil, err
+		}
+		a.Sections[s.Name()] = data
+	}
+	return a, nil
+}
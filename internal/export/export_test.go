@@ -0,0 +1,89 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"github.com/google/uuid"
+	"github.com/matryer/is"
+)
+
+var (
+	examplePerson = &entity.Person{ID: uuid.MustParse("f55e93f8-c952-11ed-afa1-0242ac120002"), Name: "Matt"}
+	exportedAt    = time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC)
+	errSource     = errors.New("source failed")
+)
+
+type renamed struct {
+	Name string `json:"name"`
+}
+
+type stubSource struct {
+	name string
+	err  error
+}
+
+func (s stubSource) Name() string {
+	return s.name
+}
+
+func (s stubSource) Export(subject *entity.Person) (any, error) {
+	return map[string]string{"name": subject.Name}, s.err
+}
+
+func TestExporter_Export(t *testing.T) {
+	testCases := []struct {
+		test        string
+		actor       *entity.Person
+		sources     []Source
+		expectedErr error
+	}{
+		{"Export every source", examplePerson, []Source{stubSource{name: "access"}, stubSource{name: "team"}}, nil},
+		{"Failing source", examplePerson, []Source{stubSource{name: "access"}, stubSource{name: "team", err: errSource}}, errSource},
+		{"Duplicate section", examplePerson, []Source{stubSource{name: "team"}, stubSource{name: "team"}}, ErrDuplicateSection},
+		{"Actor is not the subject", &entity.Person{ID: uuid.New()}, []Source{stubSource{name: "team"}}, ErrNotSubject},
+		{"No actor", nil, []Source{stubSource{name: "team"}}, ErrNotSubject},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			e := NewExporter(tc.sources, WithClock(func() time.Time { return exportedAt }))
+
+			a, err := e.As(tc.actor).Export(examplePerson)
+
+			is.Equal(err, tc.expectedErr)
+			if err == nil {
+				is.Equal(a.Subject, examplePerson.ID)
+				is.Equal(a.ExportedAt, exportedAt)
+				is.Equal(len(a.Sections), len(tc.sources))
+			}
+		})
+	}
+}
+
+func TestArchive_WriteJSON(t *testing.T) {
+	is := is.New(t)
+	e := NewExporter([]Source{stubSource{name: "team"}}, WithClock(func() time.Time { return exportedAt }))
+	a, _ := e.As(examplePerson).Export(examplePerson)
+	var buf bytes.Buffer
+
+	is.NoErr(a.WriteJSON(&buf))
+
+	var decoded map[string]any
+	is.NoErr(json.Unmarshal(buf.Bytes(), &decoded))
+	is.Equal(decoded["subject"], examplePerson.ID.String())
+	is.Equal(decoded["sections"], map[string]any{"team": map[string]any{"name": "Matt"}})
+}
+
+func TestNewEvent(t *testing.T) {
+	is := is.New(t)
+
+	e := NewEvent(3, exportedAt, &renamed{Name: "Matt"})
+
+	is.Equal(e, Event{Type: "renamed", Version: 3, RecordedAt: exportedAt, Data: &renamed{Name: "Matt"}})
+}
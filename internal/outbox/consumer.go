@@ -0,0 +1,72 @@
+package outbox
+
+import (
+	"sync"
+
+	"git.sr.ht/~loges/teammate/internal/bus"
+	"git.sr.ht/~loges/teammate/internal/tenantid"
+	"github.com/google/uuid"
+)
+
+// Tracker keeps track of the entries each consumer has processed.
+type Tracker interface {
+	IsProcessed(consumer string, id uuid.UUID) (bool, error)
+	MarkProcessed(consumer string, id uuid.UUID) error
+}
+
+// MemoryTracker is an in-memory tracker.
+type MemoryTracker struct {
+	processed map[string]map[uuid.UUID]bool
+	sync.Mutex
+}
+
+// NewMemoryTracker initializes an in-memory tracker.
+func NewMemoryTracker() *MemoryTracker {
+	return &MemoryTracker{
+		processed: make(map[string]map[uuid.UUID]bool),
+	}
+}
+
+// IsProcessed returns whether the consumer has processed the entry.
+func (t *MemoryTracker) IsProcessed(consumer string, id uuid.UUID) (bool, error) {
+	t.Lock()
+	defer t.Unlock()
+
+	return t.processed[consumer][id], nil
+}
+
+// MarkProcessed records that the consumer has processed the entry.
+func (t *MemoryTracker) MarkProcessed(consumer string, id uuid.UUID) error {
+	t.Lock()
+	defer t.Unlock()
+
+	if _, ok := t.processed[consumer]; !ok {
+		t.processed[consumer] = make(map[uuid.UUID]bool)
+	}
+	t.processed[consumer][id] = true
+
+	return nil
+}
+
+// Handle registers an idempotent consumer for relayed events of type E
+// appended by the tenant. Entries the consumer already processed are
+// skipped, so a redelivered entry is only applied once.
+func Handle[E any](b *bus.Bus, tenant tenantid.ID, consumer string, t Tracker, handler func(E) error) {
+	bus.Subscribe(b, tenant, func(entry *Entry) error {
+		e, ok := entry.Event.(E)
+		if !ok {
+			return nil
+		}
+
+		processed, err := t.IsProcessed(consumer, entry.ID)
+		if err != nil || processed {
+			return err
+		}
+
+		if err = handler(e); err != nil {
+			return err
+		}
+
+		return t.MarkProcessed(consumer, entry.ID)
+	})
+}
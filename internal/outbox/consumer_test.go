@@ -0,0 +1,67 @@
+package outbox
+
+import (
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"git.sr.ht/~loges/teammate/internal/bus"
+	"git.sr.ht/~loges/teammate/internal/tenantid"
+	"github.com/matryer/is"
+)
+
+type registered struct{ Name string }
+
+func TestHandle(t *testing.T) {
+	is := is.New(t)
+	s := NewMemoryStore()
+	_ = s.Append(tenantid.None, &registered{"Jen"}, "ignored")
+	b := bus.New()
+	tracker := NewMemoryTracker()
+
+	applied := 0
+	Handle(b, tenantid.None, "directory", tracker, func(e *registered) error {
+		applied++
+		return nil
+	})
+	failures := 1
+	Handle(b, tenantid.None, "mailer", tracker, func(e *registered) error {
+		if failures > 0 {
+			failures--
+			return errors.New("smtp unavailable")
+		}
+		return nil
+	})
+	r := NewRelay(s, b,
+		WithClock(func() time.Time { return now }),
+		WithBackoff(func(int) time.Duration { return 0 }),
+	)
+
+	// the mailer fails, so the entry is redelivered to both consumers
+	_, err := r.RelayPending()
+	is.Equal(err, bus.ErrDeliveryFailed)
+
+	relayed, err := r.RelayPending()
+	is.NoErr(err)
+	is.Equal(relayed, 2)
+	is.Equal(applied, 1)
+
+	processed, _ := tracker.IsProcessed("mailer", mustPublished(t, s)[0].ID)
+	is.True(processed)
+}
+
+func mustPublished(t *testing.T, s *MemoryStore) (published []*Entry) {
+	t.Helper()
+	s.Lock()
+	defer s.Unlock()
+	for _, e := range s.entries {
+		if e.IsPublished() {
+			published = append(published, e)
+		}
+	}
+	sort.Slice(published, func(i, j int) bool {
+		return published[i].Position < published[j].Position
+	})
+	return published
+}
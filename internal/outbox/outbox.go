@@ -0,0 +1,135 @@
+package outbox
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"git.sr.ht/~loges/teammate/internal/tenantid"
+	"github.com/google/uuid"
+)
+
+var ErrEntryNotFound = errors.New("outbox: the entry was not found")
+
+// Entry is an event waiting in the outbox to be published. Published
+// entries no longer hold their event, so the personal data in it is not
+// kept once it was delivered.
+type Entry struct {
+	ID          uuid.UUID
+	Position    uint64
+	Tenant      tenantid.ID
+	Event       any
+	Attempts    int
+	NextAttempt time.Time
+	PublishedAt time.Time
+}
+
+// IsPublished returns whether the entry has been published.
+func (e *Entry) IsPublished() bool {
+	return !e.PublishedAt.IsZero()
+}
+
+// Writer appends events to the outbox. Repositories call it within the
+// same write that stores the aggregate's events.
+type Writer interface {
+	Append(t tenantid.ID, events ...any) error
+}
+
+// Store defines the interface for the outbox store.
+type Store interface {
+	Writer
+	Pending(now time.Time, limit int) ([]*Entry, error)
+	MarkPublished(id uuid.UUID, at time.Time) error
+	MarkFailed(id uuid.UUID, next time.Time) error
+}
+
+// MemoryStore is an in-memory outbox store.
+type MemoryStore struct {
+	entries  map[uuid.UUID]*Entry
+	position uint64
+	sync.Mutex
+}
+
+// NewMemoryStore initializes an in-memory outbox store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		entries: make(map[uuid.UUID]*Entry),
+	}
+}
+
+// Append adds the events of the tenant to the outbox in order.
+func (s *MemoryStore) Append(t tenantid.ID, events ...any) error {
+	s.Lock()
+	defer s.Unlock()
+
+	for _, e := range events {
+		s.position++
+		id := uuid.New()
+		s.entries[id] = &Entry{ID: id, Position: s.position, Tenant: t, Event: e}
+	}
+
+	return nil
+}
+
+// Pending returns up to limit unpublished entries ordered by position. It
+// stops at the first entry that is not due at now, so an entry waiting to
+// be retried holds back every entry after it.
+func (s *MemoryStore) Pending(now time.Time, limit int) ([]*Entry, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	var unpublished []*Entry
+	for _, e := range s.entries {
+		if !e.IsPublished() {
+			unpublished = append(unpublished, e)
+		}
+	}
+
+	sort.Slice(unpublished, func(i, j int) bool {
+		return unpublished[i].Position < unpublished[j].Position
+	})
+
+	var pending []*Entry
+	for _, e := range unpublished {
+		if e.NextAttempt.After(now) || (limit > 0 && len(pending) == limit) {
+			break
+		}
+		entry := *e
+		pending = append(pending, &entry)
+	}
+
+	return pending, nil
+}
+
+// MarkPublished records that the entry has been published and drops its
+// event.
+func (s *MemoryStore) MarkPublished(id uuid.UUID, at time.Time) error {
+	s.Lock()
+	defer s.Unlock()
+
+	e, ok := s.entries[id]
+	if !ok {
+		return ErrEntryNotFound
+	}
+	e.Attempts++
+	e.PublishedAt = at
+	e.Event = nil
+
+	return nil
+}
+
+// MarkFailed records a failed attempt and when to try again.
+func (s *MemoryStore) MarkFailed(id uuid.UUID, next time.Time) error {
+	s.Lock()
+	defer s.Unlock()
+
+	e, ok := s.entries[id]
+	if !ok {
+		return ErrEntryNotFound
+	}
+	e.Attempts++
+	e.NextAttempt = next
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package outbox
+
+import (
+	"testing"
+	"time"
+
+	"git.sr.ht/~loges/teammate/internal/tenantid"
+	"github.com/google/uuid"
+	"github.com/matryer/is"
+)
+
+var now = time.Date(2023, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+func TestMemoryStore_Pending(t *testing.T) {
+	testCases := []struct {
+		test          string
+		published     int
+		failed        int
+		at            time.Time
+		limit         int
+		expectedCount int
+	}{
+		{"All entries pending", 0, 0, now, 0, 3},
+		{"Limit pending entries", 0, 0, now, 2, 2},
+		{"Published entries are skipped", 1, 0, now, 0, 2},
+		{"Failed entries hold back later entries", 0, 1, now, 0, 0},
+		{"Failed entry after published ones holds back the rest", 1, 1, now, 0, 0},
+		{"Failed entries are retried when due", 0, 1, now.Add(time.Minute), 0, 3},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			s := NewMemoryStore()
+			is.NoErr(s.Append(tenantid.None, "first", "second", "third"))
+			entries, _ := s.Pending(now, 0)
+			for _, e := range entries[:tc.published] {
+				is.NoErr(s.MarkPublished(e.ID, now))
+			}
+			for _, e := range entries[tc.published : tc.published+tc.failed] {
+				is.NoErr(s.MarkFailed(e.ID, now.Add(time.Second)))
+			}
+
+			pending, err := s.Pending(tc.at, tc.limit)
+
+			is.NoErr(err)
+			is.Equal(len(pending), tc.expectedCount)
+			for i := 1; i < len(pending); i++ {
+				is.True(pending[i-1].Position < pending[i].Position)
+			}
+		})
+	}
+}
+
+func TestMemoryStore_Mark(t *testing.T) {
+	is := is.New(t)
+	s := NewMemoryStore()
+	is.NoErr(s.Append(tenantid.None, "first"))
+	entries, _ := s.Pending(now, 0)
+
+	is.NoErr(s.MarkPublished(entries[0].ID, now))
+	is.Equal(s.entries[entries[0].ID].Event, nil)
+
+	is.Equal(s.MarkPublished(uuid.New(), now), ErrEntryNotFound)
+	is.Equal(s.MarkFailed(uuid.New(), now), ErrEntryNotFound)
+}
@@ -0,0 +1,124 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"git.sr.ht/~loges/teammate/internal/bus"
+)
+
+// Backoff returns how long to wait before retrying an entry that failed
+// the given number of attempts.
+type Backoff func(attempts int) time.Duration
+
+// ExponentialBackoff doubles the delay after every attempt, starting at
+// base and capped at limit.
+func ExponentialBackoff(base, limit time.Duration) Backoff {
+	return func(attempts int) time.Duration {
+		d := base
+		for i := 1; i < attempts && d < limit; i++ {
+			d *= 2
+		}
+		if d > limit {
+			return limit
+		}
+		return d
+	}
+}
+
+// RelayConfiguration is a function that modifies the relay.
+type RelayConfiguration func(r *Relay)
+
+// WithBackoff sets the retry backoff of the relay.
+func WithBackoff(b Backoff) RelayConfiguration {
+	return func(r *Relay) {
+		r.backoff = b
+	}
+}
+
+// WithBatchSize sets how many entries the relay publishes per run.
+func WithBatchSize(n int) RelayConfiguration {
+	return func(r *Relay) {
+		r.batchSize = n
+	}
+}
+
+// WithClock sets the function the relay reads the current time from.
+func WithClock(now func() time.Time) RelayConfiguration {
+	return func(r *Relay) {
+		r.now = now
+	}
+}
+
+// Relay publishes pending outbox entries for the tenant that appended
+// them. Entries are marked published
+// only after the publisher succeeded, so delivery is at least once and
+// consumers should be idempotent. The publisher is expected to report
+// handler failures, as a synchronous bus does.
+type Relay struct {
+	store     Store
+	publisher bus.Publisher
+	backoff   Backoff
+	batchSize int
+	now       func() time.Time
+}
+
+// NewRelay initializes a relay from store to publisher.
+func NewRelay(store Store, publisher bus.Publisher, cfgs ...RelayConfiguration) *Relay {
+	r := &Relay{
+		store:     store,
+		publisher: publisher,
+		backoff:   ExponentialBackoff(time.Second, time.Minute),
+		batchSize: 100,
+		now:       time.Now,
+	}
+
+	for _, cfg := range cfgs {
+		cfg(r)
+	}
+
+	return r
+}
+
+// RelayPending publishes due entries in order and returns how many were
+// published. It stops at the first failure, and the failed entry holds
+// back the entries after it until it is retried, so entries are never
+// published out of order.
+func (r *Relay) RelayPending() (int, error) {
+	entries, err := r.store.Pending(r.now(), r.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, e := range entries {
+		if err = r.publisher.Publish(e.Tenant, e); err != nil {
+			next := r.now().Add(r.backoff(e.Attempts + 1))
+			if merr := r.store.MarkFailed(e.ID, next); merr != nil {
+				return i, merr
+			}
+			return i, err
+		}
+
+		if err = r.store.MarkPublished(e.ID, r.now()); err != nil {
+			return i, err
+		}
+	}
+
+	return len(entries), nil
+}
+
+// Run relays pending entries every interval until ctx is done.
+func (r *Relay) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		_, _ = r.RelayPending()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
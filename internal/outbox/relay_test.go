@@ -0,0 +1,121 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"git.sr.ht/~loges/teammate/internal/bus"
+	"git.sr.ht/~loges/teammate/internal/tenantid"
+	"github.com/matryer/is"
+)
+
+var errUnavailable = errors.New("unavailable")
+
+// flakyPublisher fails the first failures publications.
+type flakyPublisher struct {
+	failures  int
+	published []any
+}
+
+func (p *flakyPublisher) Publish(_ tenantid.ID, events ...any) error {
+	if p.failures > 0 {
+		p.failures--
+		return errUnavailable
+	}
+	for _, e := range events {
+		p.published = append(p.published, e.(*Entry).Event)
+	}
+	return nil
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	testCases := []struct {
+		attempts int
+		expected time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{3, 4 * time.Second},
+		{10, 10 * time.Second},
+	}
+
+	for _, tc := range testCases {
+		is := is.New(t)
+		is.Equal(ExponentialBackoff(time.Second, 10*time.Second)(tc.attempts), tc.expected)
+	}
+}
+
+func TestRelay_RelayPending(t *testing.T) {
+	testCases := []struct {
+		test              string
+		failures          int
+		expectedRelayed   int
+		expectedPublished []any
+		expectedErr       error
+	}{
+		{"Publish all entries in order", 0, 3, []any{"first", "second", "third"}, nil},
+		{"Stop at first failure", 1, 0, nil, errUnavailable},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			s := NewMemoryStore()
+			_ = s.Append(tenantid.None, "first", "second", "third")
+			p := &flakyPublisher{failures: tc.failures}
+			r := NewRelay(s, p, WithClock(func() time.Time { return now }))
+
+			relayed, err := r.RelayPending()
+
+			is.Equal(err, tc.expectedErr)
+			is.Equal(relayed, tc.expectedRelayed)
+			is.Equal(p.published, tc.expectedPublished)
+		})
+	}
+}
+
+func TestRelay_Retry(t *testing.T) {
+	is := is.New(t)
+	clock := now
+	s := NewMemoryStore()
+	_ = s.Append(tenantid.None, "first", "second")
+	p := &flakyPublisher{failures: 1}
+	r := NewRelay(s, p,
+		WithClock(func() time.Time { return clock }),
+		WithBackoff(func(int) time.Duration { return time.Minute }),
+		WithBatchSize(10),
+	)
+
+	_, err := r.RelayPending()
+	is.Equal(err, errUnavailable)
+
+	relayed, err := r.RelayPending()
+	is.NoErr(err)
+	is.Equal(relayed, 0) // backing off, and the second waits for the first
+
+	clock = clock.Add(time.Minute)
+	relayed, err = r.RelayPending()
+	is.NoErr(err)
+	is.Equal(relayed, 2)
+	is.Equal(p.published, []any{"first", "second"})
+}
+
+func TestRelay_Run(t *testing.T) {
+	is := is.New(t)
+	s := NewMemoryStore()
+	_ = s.Append("riverside", "first")
+	b := bus.New()
+	delivered := make(chan string, 1)
+	bus.Subscribe(b, "riverside", func(e *Entry) error {
+		delivered <- e.Event.(string)
+		return nil
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go NewRelay(s, b).Run(ctx, time.Millisecond)
+
+	is.Equal(<-delivered, "first")
+}
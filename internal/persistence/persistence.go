@@ -0,0 +1,239 @@
+package persistence
+
+import (
+	"sync"
+	"time"
+
+	"git.sr.ht/~loges/teammate/internal/bus"
+	"git.sr.ht/~loges/teammate/internal/eventstore"
+	"git.sr.ht/~loges/teammate/internal/outbox"
+	"git.sr.ht/~loges/teammate/internal/tenantid"
+	"github.com/google/uuid"
+)
+
+// Configuration is a function that modifies an in-memory repository.
+type Configuration func(c *configuration)
+
+// WithPublisher publishes events to p once they are stored.
+func WithPublisher(p bus.Publisher) Configuration {
+	return func(c *configuration) {
+		c.publisher = p
+	}
+}
+
+// WithOutbox records events in the outbox within the same write that
+// stores them, so a relay can publish them reliably.
+func WithOutbox(o outbox.Writer) Configuration {
+	return func(c *configuration) {
+		c.outbox = o
+	}
+}
+
+// WithEventStore stores events in s, which should keep events as they are
+// given. Repositories sharing an event store share its global order. The
+// personal data of the events is encrypted before it reaches s.
+func WithEventStore(s eventstore.Store) Configuration {
+	return func(c *configuration) {
+		c.store = s
+	}
+}
+
+// WithKeyStore encrypts personal data with the keys in ks, so erasing the
+// key of a person erases the person. Contexts sharing ks erase a person
+// from each other's events too. Repositories of a tenant use the keys ks
+// holds for the tenant, so tenants can share ks.
+func WithKeyStore(ks eventstore.KeyStore) Configuration {
+	return func(c *configuration) {
+		c.keys = ks
+	}
+}
+
+// WithTenant scopes every stream read and written, every key and every
+// published event to the tenant, so the repository never sees the data of
+// other tenants sharing the event store, key store or bus.
+func WithTenant(id tenantid.ID) Configuration {
+	return func(c *configuration) {
+		c.tenant = id
+	}
+}
+
+type configuration struct {
+	tenant    tenantid.ID
+	publisher bus.Publisher
+	outbox    outbox.Writer
+	store     eventstore.Store
+	keys      eventstore.KeyStore
+}
+
+// Events reads and writes the event streams of the aggregates of an
+// in-memory repository. E is the event type of the repository's context.
+type Events[E any] struct {
+	configuration
+}
+
+// New initializes the events of a repository, which are stored with the
+// schemas. Without an event store, the events are stored in memory.
+func New[E any](schemas []eventstore.Schema, cfgs ...Configuration) Events[E] {
+	c := configuration{
+		publisher: bus.Discard,
+		outbox:    discardOutbox{},
+		keys:      eventstore.NewMemoryKeyStore(),
+	}
+	for _, cfg := range cfgs {
+		cfg(&c)
+	}
+	if c.store == nil {
+		c.store = eventstore.NewMemoryStore()
+	}
+	c.keys = eventstore.ScopeKeys(c.keys, c.tenant)
+	c.store = eventstore.NewEncodedStore(c.store, eventstore.NewEncryptingCodec(c.keys, schemas...))
+	return Events[E]{configuration: c}
+}
+
+// KeyStore returns the key store set by the configurations, scoped to
+// their tenant, or nil if none is set and every repository uses its own.
+func KeyStore(cfgs ...Configuration) eventstore.KeyStore {
+	var c configuration
+	for _, cfg := range cfgs {
+		cfg(&c)
+	}
+	return eventstore.ScopeKeys(c.keys, c.tenant)
+}
+
+// Tenant returns the tenant set by the configurations.
+func Tenant(cfgs ...Configuration) tenantid.ID {
+	var c configuration
+	for _, cfg := range cfgs {
+		cfg(&c)
+	}
+	return c.tenant
+}
+
+// Store returns the event store the events are kept in.
+func (e Events[E]) Store() eventstore.Store {
+	return e.store
+}
+
+// Keys returns the key store personal data is encrypted with.
+func (e Events[E]) Keys() eventstore.KeyStore {
+	return e.keys
+}
+
+// Stream returns the stream of the aggregate within the tenant.
+func (e Events[E]) Stream(category string, id uuid.UUID) eventstore.Stream {
+	return eventstore.Stream{Tenant: e.tenant, Category: category, ID: id}
+}
+
+// Streams returns every stream of the category within the tenant.
+func (e Events[E]) Streams(category string) ([]eventstore.Stream, error) {
+	return e.store.Streams(e.tenant, category)
+}
+
+// Load returns the events of the stream.
+func (e Events[E]) Load(s eventstore.Stream) ([]E, error) {
+	return e.loadWhile(s, func(*eventstore.Record) bool {
+		return true
+	})
+}
+
+// LoadCategory returns the events of every stream of the category within
+// the tenant.
+func (e Events[E]) LoadCategory(category string) ([][]E, error) {
+	streams, err := e.Streams(category)
+	if err != nil {
+		return nil, err
+	}
+
+	var all [][]E
+	for _, s := range streams {
+		events, err := e.Load(s)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, events)
+	}
+	return all, nil
+}
+
+// LoadAsOf returns the events of the stream recorded at or before t.
+func (e Events[E]) LoadAsOf(s eventstore.Stream, t time.Time) ([]E, error) {
+	return e.loadWhile(s, func(r *eventstore.Record) bool {
+		return !r.RecordedAt.After(t)
+	})
+}
+
+// LoadAtVersion returns the events of the stream up to the version. The
+// stream has to have reached the version.
+func (e Events[E]) LoadAtVersion(s eventstore.Stream, version int) ([]E, error) {
+	if current, err := e.store.Version(s); err != nil || version < 1 || version > current {
+		return nil, eventstore.ErrStreamNotFound
+	}
+	return e.loadWhile(s, func(r *eventstore.Record) bool {
+		return r.Version <= version
+	})
+}
+
+// Records returns the records of the stream as they were recorded.
+func (e Events[E]) Records(s eventstore.Stream) ([]*eventstore.Record, error) {
+	return e.store.ReadStream(s)
+}
+
+// Exists returns whether the stream has any events.
+func (e Events[E]) Exists(s eventstore.Stream) bool {
+	version, err := e.store.Version(s)
+	return err == nil && version > 0
+}
+
+// Commit appends the events to the stream while holding the lock. The
+// events are recorded in the outbox within the append, so they are stored
+// only if their outbox entries are, and the outbox keeps the order of the
+// event store. A conflicting write of another repository sharing the event
+// store never leaves an entry in the outbox. The events are published once
+// the lock is released. Handler failures are reported by the publisher and
+// never undo a stored change.
+func (e Events[E]) Commit(l sync.Locker, s eventstore.Stream, expectedVersion int, events []E) error {
+	committed := make([]any, len(events))
+	for i, event := range events {
+		committed[i] = event
+	}
+
+	l.Lock()
+	err := e.store.AppendStaged(s, expectedVersion, func() error {
+		return e.outbox.Append(e.tenant, committed...)
+	}, committed...)
+	l.Unlock()
+	if err != nil {
+		return err
+	}
+
+	_ = e.publisher.Publish(e.tenant, committed...)
+
+	return nil
+}
+
+// loadWhile returns the leading events of the stream whose records keep
+// matches. The stream is not found if no event matches.
+func (e Events[E]) loadWhile(s eventstore.Stream, keep func(r *eventstore.Record) bool) ([]E, error) {
+	records, err := e.store.ReadStream(s)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []E
+	for _, r := range records {
+		if !keep(r) {
+			break
+		}
+		events = append(events, r.Event.(E))
+	}
+	if len(events) == 0 {
+		return nil, eventstore.ErrStreamNotFound
+	}
+	return events, nil
+}
+
+type discardOutbox struct{}
+
+func (discardOutbox) Append(tenantid.ID, ...any) error {
+	return nil
+}
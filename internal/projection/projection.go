@@ -0,0 +1,227 @@
+package projection
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"git.sr.ht/~loges/teammate/internal/eventstore"
+	"git.sr.ht/~loges/teammate/internal/tenantid"
+)
+
+var ErrProjectionNotFound = errors.New("projection: the projection was not found")
+
+// Projection maintains a denormalized read model from committed events.
+type Projection interface {
+	// Name identifies the projection and its checkpoint.
+	Name() string
+	// Apply updates the read model with the event. Events the read model
+	// does not care about are ignored.
+	Apply(event any) error
+	// Reset clears the read model so it can be rebuilt.
+	Reset() error
+}
+
+// Log is the globally ordered log of committed events.
+type Log interface {
+	ReadAll(from uint64, limit int) ([]*eventstore.Record, error)
+	Wait(ctx context.Context, after uint64) error
+}
+
+// CheckpointStore persists the position each projection has applied.
+type CheckpointStore interface {
+	Load(name string) (uint64, error)
+	Save(name string, position uint64) error
+}
+
+// MemoryCheckpointStore is an in-memory checkpoint store.
+type MemoryCheckpointStore struct {
+	positions map[string]uint64
+	sync.Mutex
+}
+
+// NewMemoryCheckpointStore initializes an in-memory checkpoint store.
+func NewMemoryCheckpointStore() *MemoryCheckpointStore {
+	return &MemoryCheckpointStore{
+		positions: make(map[string]uint64),
+	}
+}
+
+// Load returns the last position applied by the projection.
+func (s *MemoryCheckpointStore) Load(name string) (uint64, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	return s.positions[name], nil
+}
+
+// Save records the last position applied by the projection.
+func (s *MemoryCheckpointStore) Save(name string, position uint64) error {
+	s.Lock()
+	defer s.Unlock()
+
+	s.positions[name] = position
+	return nil
+}
+
+// Configuration is a function that modifies the runner.
+type Configuration func(r *Runner)
+
+// WithCheckpointStore sets where the runner persists checkpoints.
+func WithCheckpointStore(s CheckpointStore) Configuration {
+	return func(r *Runner) {
+		r.checkpoints = s
+	}
+}
+
+// WithBatchSize sets how many events the runner reads at once.
+func WithBatchSize(n int) Configuration {
+	return func(r *Runner) {
+		r.batchSize = n
+	}
+}
+
+// WithTenant only applies the events of the tenant, so the read models of
+// tenants sharing a log stay apart. Runners without a tenant only apply the
+// events of tenantid.None.
+func WithTenant(id tenantid.ID) Configuration {
+	return func(r *Runner) {
+		r.tenant = id
+	}
+}
+
+// Runner feeds the event log to its projections. Each projection resumes
+// after its checkpoint, so an event is applied to a read model only once.
+type Runner struct {
+	log         Log
+	checkpoints CheckpointStore
+	projections []Projection
+	batchSize   int
+	tenant      tenantid.ID
+	sync.Mutex
+}
+
+// NewRunner initializes a runner feeding log to the projections. Event
+// stores shared by repositories keep their events encoded, so read them
+// through an eventstore.EncodedStore knowing the schemas of every context.
+func NewRunner(log Log, projections []Projection, cfgs ...Configuration) *Runner {
+	r := &Runner{
+		log:         log,
+		checkpoints: NewMemoryCheckpointStore(),
+		projections: projections,
+		batchSize:   100,
+	}
+
+	for _, cfg := range cfgs {
+		cfg(r)
+	}
+
+	return r
+}
+
+// CatchUp applies every event after each projection's checkpoint.
+func (r *Runner) CatchUp() error {
+	r.Lock()
+	defer r.Unlock()
+
+	for _, p := range r.projections {
+		if err := r.catchUp(p); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Rebuild resets the named projection and replays the log from zero.
+func (r *Runner) Rebuild(name string) error {
+	r.Lock()
+	defer r.Unlock()
+
+	for _, p := range r.projections {
+		if p.Name() != name {
+			continue
+		}
+		if err := p.Reset(); err != nil {
+			return err
+		}
+		if err := r.checkpoints.Save(name, 0); err != nil {
+			return err
+		}
+		return r.catchUp(p)
+	}
+
+	return ErrProjectionNotFound
+}
+
+// Run catches up whenever the log grows until ctx is done. A failed
+// catch up is retried after interval.
+func (r *Runner) Run(ctx context.Context, interval time.Duration) {
+	for {
+		if err := r.CatchUp(); err != nil {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(interval):
+			}
+			continue
+		}
+
+		if err := r.log.Wait(ctx, r.position()); err != nil {
+			return
+		}
+	}
+}
+
+// position returns the lowest checkpoint of the projections.
+func (r *Runner) position() uint64 {
+	r.Lock()
+	defer r.Unlock()
+
+	var lowest uint64
+	for i, p := range r.projections {
+		position, err := r.checkpoints.Load(p.Name())
+		if err != nil {
+			return 0
+		}
+		if i == 0 || position < lowest {
+			lowest = position
+		}
+	}
+	return lowest
+}
+
+func (r *Runner) catchUp(p Projection) error {
+	position, err := r.checkpoints.Load(p.Name())
+	if err != nil {
+		return err
+	}
+
+	for {
+		records, err := r.log.ReadAll(position, r.batchSize)
+		if err != nil {
+			return err
+		}
+		if len(records) == 0 {
+			return nil
+		}
+
+		for _, rec := range records {
+			if r.applies(rec) {
+				if err = p.Apply(rec.Event); err != nil {
+					return err
+				}
+			}
+			position = rec.Position
+			if err = r.checkpoints.Save(p.Name(), position); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// applies returns whether the record is fed to the projections.
+func (r *Runner) applies(rec *eventstore.Record) bool {
+	return rec.Stream.Tenant == r.tenant
+}
@@ -0,0 +1,194 @@
+package projection
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	accessprojections "git.sr.ht/~loges/teammate/internal/access/application/projections"
+	accessmodel "git.sr.ht/~loges/teammate/internal/access/domain/model"
+	accessmemory "git.sr.ht/~loges/teammate/internal/access/infrastructure/memory"
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"git.sr.ht/~loges/teammate/internal/eventstore"
+	teamprojections "git.sr.ht/~loges/teammate/internal/team/application/projections"
+	teammodel "git.sr.ht/~loges/teammate/internal/team/domain/model"
+	teammemory "git.sr.ht/~loges/teammate/internal/team/infrastructure/memory"
+	"github.com/google/uuid"
+	"github.com/matryer/is"
+)
+
+var errApply = errors.New("apply failed")
+
+// countingProjection counts the string events it applied.
+type countingProjection struct {
+	name    string
+	applied []any
+	failOn  any
+}
+
+func (p *countingProjection) Name() string {
+	return p.name
+}
+
+func (p *countingProjection) Apply(e any) error {
+	if e == p.failOn {
+		return errApply
+	}
+	p.applied = append(p.applied, e)
+	return nil
+}
+
+func (p *countingProjection) Reset() error {
+	p.applied = nil
+	return nil
+}
+
+// appendEvents appends the events to a single test stream.
+func appendEvents(log *eventstore.MemoryStore, events ...any) {
+	stream := eventstore.Stream{Category: "test", ID: uuid.Nil}
+	version, _ := log.Version(stream)
+	_ = log.Append(stream, version, events...)
+}
+
+func TestRunner_CatchUp(t *testing.T) {
+	t.Run("Projections resume after their checkpoint", func(t *testing.T) {
+		is := is.New(t)
+		log := eventstore.NewMemoryStore()
+		checkpoints := NewMemoryCheckpointStore()
+		p := &countingProjection{name: "counter"}
+		r := NewRunner(log, []Projection{p}, WithCheckpointStore(checkpoints), WithBatchSize(2))
+
+		appendEvents(log, "first", "second", "third")
+		is.NoErr(r.CatchUp())
+		is.Equal(p.applied, []any{"first", "second", "third"})
+
+		appendEvents(log, "fourth")
+		is.NoErr(r.CatchUp())
+		is.Equal(p.applied, []any{"first", "second", "third", "fourth"})
+
+		position, _ := checkpoints.Load("counter")
+		is.Equal(position, uint64(4))
+	})
+
+	t.Run("Failed event is retried on next catch up", func(t *testing.T) {
+		is := is.New(t)
+		log := eventstore.NewMemoryStore()
+		p := &countingProjection{name: "counter", failOn: "second"}
+		r := NewRunner(log, []Projection{p})
+		appendEvents(log, "first", "second")
+
+		is.Equal(r.CatchUp(), errApply)
+		is.Equal(p.applied, []any{"first"})
+
+		p.failOn = nil
+		is.NoErr(r.CatchUp())
+		is.Equal(p.applied, []any{"first", "second"})
+	})
+}
+
+func TestRunner_Tenant(t *testing.T) {
+	is := is.New(t)
+	log := eventstore.NewMemoryStore()
+	riverside := &countingProjection{name: "counter"}
+	lakeside := &countingProjection{name: "counter"}
+	untenanted := &countingProjection{name: "counter"}
+	r := NewRunner(log, []Projection{riverside}, WithTenant("riverside"))
+	l := NewRunner(log, []Projection{lakeside}, WithTenant("lakeside"))
+	u := NewRunner(log, []Projection{untenanted})
+	_ = log.Append(eventstore.Stream{Tenant: "riverside", Category: "test", ID: uuid.Nil}, 0, "riverside")
+	_ = log.Append(eventstore.Stream{Tenant: "lakeside", Category: "test", ID: uuid.Nil}, 0, "lakeside")
+	appendEvents(log, "untenanted")
+
+	is.NoErr(r.CatchUp())
+	is.NoErr(l.CatchUp())
+	is.NoErr(u.CatchUp())
+
+	is.Equal(riverside.applied, []any{"riverside"})
+	is.Equal(lakeside.applied, []any{"lakeside"})
+	is.Equal(untenanted.applied, []any{"untenanted"})
+	is.Equal(r.position(), uint64(3))
+}
+
+func TestRunner_Rebuild(t *testing.T) {
+	testCases := []struct {
+		test        string
+		name        string
+		expectedErr error
+	}{
+		{"Rebuild projection from zero", "counter", nil},
+		{"Unknown projection", "unknown", ErrProjectionNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			log := eventstore.NewMemoryStore()
+			p := &countingProjection{name: "counter"}
+			r := NewRunner(log, []Projection{p})
+			appendEvents(log, "first", "second")
+			_ = r.CatchUp()
+
+			err := r.Rebuild(tc.name)
+
+			is.Equal(err, tc.expectedErr)
+			is.Equal(p.applied, []any{"first", "second"})
+		})
+	}
+}
+
+func TestRunner_AcrossContexts(t *testing.T) {
+	is := is.New(t)
+	log := eventstore.NewMemoryStore()
+	keys := eventstore.NewMemoryKeyStore()
+	teams := teammemory.NewMemoryTeamRepository(teammemory.WithEventStore(log), teammemory.WithKeyStore(keys))
+	users := accessmemory.NewMemoryUserRepository(accessmemory.WithEventStore(log), accessmemory.WithKeyStore(keys))
+	rosters := teamprojections.NewRosterProjection()
+	directory := accessprojections.NewUserDirectoryProjection()
+	schemas := append(append([]eventstore.Schema{}, teammemory.Schemas...), accessmemory.Schemas...)
+	r := NewRunner(eventstore.NewEncodedStore(log, eventstore.NewEncryptingCodec(keys, schemas...)), []Projection{rosters, directory})
+
+	group := &entity.Group{ID: uuid.New(), Name: "Tigers"}
+	team, _ := teammodel.NewTeam(group)
+	is.NoErr(teams.Add(team))
+	u, _ := accessmodel.NewUser(&entity.Person{ID: uuid.New(), Name: "Matt"}, "matt@teammate.com")
+	is.NoErr(users.Add(u))
+
+	is.NoErr(r.CatchUp())
+
+	roster, err := rosters.Get(group)
+	is.NoErr(err)
+	is.Equal(roster.Name, "Tigers")
+	_, err = directory.GetByEmail("matt@teammate.com")
+	is.NoErr(err)
+
+	is.NoErr(r.Rebuild(directory.Name()))
+	is.Equal(len(directory.List()), 1)
+}
+
+func TestRunner_Run(t *testing.T) {
+	is := is.New(t)
+	log := eventstore.NewMemoryStore()
+	p := &countingProjection{name: "counter"}
+	r := NewRunner(log, []Projection{p})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		r.Run(ctx, time.Millisecond)
+		close(done)
+	}()
+	appendEvents(log, "first")
+
+	is.NoErr(log.Wait(context.Background(), 0))
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); {
+		if r.position() == 1 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	<-done
+
+	is.Equal(p.applied, []any{"first"})
+}
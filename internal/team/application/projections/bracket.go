@@ -0,0 +1,119 @@
+package projections
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"git.sr.ht/~loges/teammate/internal/team/domain/bracket"
+	"git.sr.ht/~loges/teammate/internal/team/domain/event"
+	"github.com/google/uuid"
+)
+
+var ErrTournamentNotFound = errors.New("projections: the tournament was not found")
+
+// TournamentBracket is the read model of a tournament and its bracket,
+// for rendering on the team website.
+type TournamentBracket struct {
+	ID      uuid.UUID
+	Name    string
+	Bracket *bracket.Bracket
+}
+
+type tournament struct {
+	id      uuid.UUID
+	name    string
+	teams   []*entity.Group
+	options bracket.Options
+	results []bracket.Result
+}
+
+// BracketProjection maintains the bracket of every tournament.
+type BracketProjection struct {
+	tournaments map[uuid.UUID]*tournament
+	sync.RWMutex
+}
+
+// NewBracketProjection initializes an empty bracket projection.
+func NewBracketProjection() *BracketProjection {
+	return &BracketProjection{
+		tournaments: make(map[uuid.UUID]*tournament),
+	}
+}
+
+// Name identifies the projection.
+func (p *BracketProjection) Name() string {
+	return "team_bracket"
+}
+
+// Apply updates the brackets with tournament events.
+func (p *BracketProjection) Apply(e any) error {
+	p.Lock()
+	defer p.Unlock()
+
+	switch te := e.(type) {
+	case *event.TournamentCreated:
+		t := &tournament{
+			id:   te.ID,
+			name: te.Name,
+			options: bracket.Options{
+				Format:     bracket.Format(te.Format),
+				ThirdPlace: te.ThirdPlace,
+				Groups:     te.Groups,
+				Advance:    te.Advance,
+			},
+		}
+		for _, team := range te.Teams {
+			t.teams = append(t.teams, &entity.Group{ID: team.ID, Name: team.Name})
+		}
+		p.tournaments[te.ID] = t
+
+	case *event.TournamentResultRecorded:
+		if t, ok := p.tournaments[te.ID]; ok {
+			t.results = append(t.results, bracket.Result{MatchID: te.MatchId, HomeScore: te.HomeScore, AwayScore: te.AwayScore})
+		}
+	}
+
+	return nil
+}
+
+// Reset clears every bracket.
+func (p *BracketProjection) Reset() error {
+	p.Lock()
+	defer p.Unlock()
+
+	p.tournaments = make(map[uuid.UUID]*tournament)
+	return nil
+}
+
+// Get returns the bracket of the tournament.
+func (p *BracketProjection) Get(id uuid.UUID) (*TournamentBracket, error) {
+	p.RLock()
+	defer p.RUnlock()
+
+	t, ok := p.tournaments[id]
+	if !ok {
+		return &TournamentBracket{}, ErrTournamentNotFound
+	}
+	return t.view(), nil
+}
+
+// List returns the bracket of every tournament ordered by name.
+func (p *BracketProjection) List() (brackets []*TournamentBracket) {
+	p.RLock()
+	defer p.RUnlock()
+
+	for _, t := range p.tournaments {
+		brackets = append(brackets, t.view())
+	}
+	sort.Slice(brackets, func(i, j int) bool {
+		return brackets[i].Name < brackets[j].Name
+	})
+	return brackets
+}
+
+func (t *tournament) view() *TournamentBracket {
+	b, _ := bracket.Build(t.teams, t.options, t.results)
+	return &TournamentBracket{ID: t.id, Name: t.name, Bracket: b}
+}
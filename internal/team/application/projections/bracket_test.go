@@ -0,0 +1,78 @@
+package projections
+
+import (
+	"testing"
+
+	"git.sr.ht/~loges/teammate/internal/team/domain/bracket"
+	"git.sr.ht/~loges/teammate/internal/team/domain/event"
+	"github.com/google/uuid"
+	"github.com/matryer/is"
+)
+
+var (
+	exampleTournament = uuid.MustParse("b25e93f8-c952-11ed-afa1-0242ac120002")
+	tournamentCreated = &event.TournamentCreated{
+		ID:     exampleTournament,
+		Name:   "Spring Cup",
+		Format: string(bracket.SingleElimination),
+		Teams:  []event.TournamentTeam{{ID: exampleTeam.ID, Name: exampleTeam.Name}, {ID: anotherTeam.ID, Name: anotherTeam.Name}},
+	}
+	anotherTournament = &event.TournamentCreated{
+		ID:     uuid.MustParse("c25e93f8-c952-11ed-afa1-0242ac120002"),
+		Name:   "Fall Cup",
+		Format: string(bracket.SingleElimination),
+		Teams:  tournamentCreated.Teams,
+	}
+	finalPlayed = &event.TournamentResultRecorded{ID: exampleTournament, MatchId: "R1-1", HomeScore: 1, AwayScore: 4}
+)
+
+func TestBracketProjection_Get(t *testing.T) {
+	testCases := []struct {
+		test             string
+		events           []any
+		expectedChampion string
+		expectedErr      error
+	}{
+		{"Tournament not found", []any{anotherTournament}, "", ErrTournamentNotFound},
+		{"Final not played", []any{tournamentCreated}, "", nil},
+		{"Final played", []any{tournamentCreated, finalPlayed}, anotherTeam.Name, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			p := NewBracketProjection()
+			for _, e := range tc.events {
+				is.NoErr(p.Apply(e))
+			}
+
+			tb, err := p.Get(exampleTournament)
+
+			is.Equal(err, tc.expectedErr)
+			if err == nil {
+				is.Equal(tb.Name, tournamentCreated.Name)
+				is.Equal(tb.Bracket.Winners[0].Name, "Final")
+				if tc.expectedChampion != "" {
+					is.Equal(tb.Bracket.Champion.Name, tc.expectedChampion)
+				} else {
+					is.True(tb.Bracket.Champion == nil)
+				}
+			}
+		})
+	}
+}
+
+func TestBracketProjection_List(t *testing.T) {
+	is := is.New(t)
+	p := NewBracketProjection()
+	is.NoErr(p.Apply(tournamentCreated))
+	is.NoErr(p.Apply(anotherTournament))
+
+	brackets := p.List()
+
+	is.Equal(len(brackets), 2)
+	is.Equal(brackets[0].Name, "Fall Cup")
+
+	is.NoErr(p.Reset())
+	is.Equal(len(p.List()), 0)
+}
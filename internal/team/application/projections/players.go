@@ -0,0 +1,151 @@
+package projections
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"git.sr.ht/~loges/teammate/internal/team/domain/event"
+	"github.com/google/uuid"
+)
+
+var ErrPlayerNotFound = errors.New("projections: the player was not found")
+
+// PlayerEntry is the read model of a player in the directory.
+type PlayerEntry struct {
+	ID        uuid.UUID
+	Name      string
+	Activated bool
+	UserID    uuid.UUID
+	Teams     []*entity.Group
+}
+
+type playerEntry struct {
+	person    entity.Person
+	activated bool
+	userId    uuid.UUID
+	teams     map[uuid.UUID]entity.Group
+}
+
+// PlayerDirectoryProjection maintains a directory of every player.
+type PlayerDirectoryProjection struct {
+	players map[uuid.UUID]*playerEntry
+	sync.RWMutex
+}
+
+// NewPlayerDirectoryProjection initializes an empty player directory.
+func NewPlayerDirectoryProjection() *PlayerDirectoryProjection {
+	return &PlayerDirectoryProjection{
+		players: make(map[uuid.UUID]*playerEntry),
+	}
+}
+
+// Name identifies the projection.
+func (p *PlayerDirectoryProjection) Name() string {
+	return "player_directory"
+}
+
+// Apply updates the directory with player events.
+func (p *PlayerDirectoryProjection) Apply(e any) error {
+	p.Lock()
+	defer p.Unlock()
+
+	switch pe := e.(type) {
+	case *event.PlayerCreated:
+		p.players[pe.ID] = &playerEntry{
+			person:    entity.Person{ID: pe.ID, Name: pe.Name},
+			activated: true,
+			teams:     make(map[uuid.UUID]entity.Group),
+		}
+
+	case *event.PlayerActivated:
+		if player, ok := p.players[pe.ID]; ok {
+			player.activated = true
+		}
+
+	case *event.PlayerDeactivated:
+		if player, ok := p.players[pe.ID]; ok {
+			player.activated = false
+		}
+
+	case *event.TeamAssignedToPlayer:
+		if player, ok := p.players[pe.ID]; ok {
+			player.teams[pe.TeamId] = entity.Group{ID: pe.TeamId, Name: pe.TeamName}
+		}
+
+	case *event.TeamUnassignedFromPlayer:
+		if player, ok := p.players[pe.ID]; ok {
+			delete(player.teams, pe.TeamId)
+		}
+
+	case *event.UserLinkedToPlayer:
+		if player, ok := p.players[pe.ID]; ok {
+			player.userId = pe.UserId
+		}
+
+	case *event.UserUnlinkedFromPlayer:
+		if player, ok := p.players[pe.ID]; ok {
+			player.userId = uuid.Nil
+		}
+
+	case *event.PlayerErased:
+		if player, ok := p.players[pe.ID]; ok {
+			player.person.Name = entity.ErasedName
+		}
+	}
+
+	return nil
+}
+
+// Reset clears the directory.
+func (p *PlayerDirectoryProjection) Reset() error {
+	p.Lock()
+	defer p.Unlock()
+
+	p.players = make(map[uuid.UUID]*playerEntry)
+	return nil
+}
+
+// Get returns the directory entry of the player.
+func (p *PlayerDirectoryProjection) Get(person *entity.Person) (*PlayerEntry, error) {
+	p.RLock()
+	defer p.RUnlock()
+
+	player, ok := p.players[person.ID]
+	if !ok {
+		return &PlayerEntry{}, ErrPlayerNotFound
+	}
+	return player.view(), nil
+}
+
+// List returns every player ordered by name.
+func (p *PlayerDirectoryProjection) List() (players []*PlayerEntry) {
+	p.RLock()
+	defer p.RUnlock()
+
+	for _, player := range p.players {
+		players = append(players, player.view())
+	}
+	sort.Slice(players, func(i, j int) bool {
+		return players[i].Name < players[j].Name
+	})
+	return players
+}
+
+func (p *playerEntry) view() *PlayerEntry {
+	entry := &PlayerEntry{
+		ID:        p.person.ID,
+		Name:      p.person.Name,
+		Activated: p.activated,
+		UserID:    p.userId,
+	}
+	for _, team := range p.teams {
+		team := team
+		entry.Teams = append(entry.Teams, &team)
+	}
+	sort.Slice(entry.Teams, func(i, j int) bool {
+		return entry.Teams[i].Name < entry.Teams[j].Name
+	})
+	return entry
+}
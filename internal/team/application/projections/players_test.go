@@ -0,0 +1,80 @@
+package projections
+
+import (
+	"testing"
+
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"git.sr.ht/~loges/teammate/internal/team/domain/event"
+	"github.com/google/uuid"
+	"github.com/matryer/is"
+)
+
+var (
+	exampleUserUUID   = uuid.MustParse("c15e93f8-c952-11ed-afa1-0242ac120002")
+	playerCreated     = &event.PlayerCreated{ID: examplePlayer.ID, Name: examplePlayer.Name}
+	anotherPCreated   = &event.PlayerCreated{ID: anotherPlayer.ID, Name: anotherPlayer.Name}
+	playerDeactivated = &event.PlayerDeactivated{ID: examplePlayer.ID}
+	teamAssigned      = &event.TeamAssignedToPlayer{ID: examplePlayer.ID, TeamId: exampleTeam.ID, TeamName: exampleTeam.Name}
+	teamRemoved       = &event.TeamUnassignedFromPlayer{ID: examplePlayer.ID, TeamId: exampleTeam.ID, TeamName: exampleTeam.Name}
+	userLinked        = &event.UserLinkedToPlayer{ID: examplePlayer.ID, UserId: exampleUserUUID}
+)
+
+func TestPlayerDirectoryProjection_Get(t *testing.T) {
+	testCases := []struct {
+		test              string
+		events            []any
+		expectedActivated bool
+		expectedTeams     int
+		expectedUser      uuid.UUID
+		expectedErr       error
+	}{
+		{"Player not found", []any{anotherPCreated}, false, 0, uuid.Nil, ErrPlayerNotFound},
+		{"Player created", []any{playerCreated}, true, 0, uuid.Nil, nil},
+		{"Player deactivated", []any{playerCreated, playerDeactivated}, false, 0, uuid.Nil, nil},
+		{"Team assigned", []any{playerCreated, teamAssigned}, true, 1, uuid.Nil, nil},
+		{"Team unassigned", []any{playerCreated, teamAssigned, teamRemoved}, true, 0, uuid.Nil, nil},
+		{"User linked", []any{playerCreated, userLinked}, true, 0, exampleUserUUID, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			p := NewPlayerDirectoryProjection()
+			for _, e := range tc.events {
+				is.NoErr(p.Apply(e))
+			}
+
+			player, err := p.Get(examplePlayer)
+
+			is.Equal(err, tc.expectedErr)
+			is.Equal(player.Activated, tc.expectedActivated)
+			is.Equal(len(player.Teams), tc.expectedTeams)
+			is.Equal(player.UserID, tc.expectedUser)
+		})
+	}
+}
+
+func TestPlayerDirectoryProjection_List(t *testing.T) {
+	is := is.New(t)
+	p := NewPlayerDirectoryProjection()
+	_ = p.Apply(playerCreated)
+	_ = p.Apply(anotherPCreated)
+
+	players := p.List()
+	is.Equal(len(players), 2)
+	is.Equal(players[0].Name, anotherPlayer.Name)
+
+	is.NoErr(p.Reset())
+	is.Equal(len(p.List()), 0)
+}
+
+func TestPlayerDirectoryProjection_Erased(t *testing.T) {
+	is := is.New(t)
+	p := NewPlayerDirectoryProjection()
+	_ = p.Apply(playerCreated)
+
+	is.NoErr(p.Apply(&event.PlayerErased{ID: examplePlayer.ID}))
+
+	player, _ := p.Get(examplePlayer)
+	is.Equal(player.Name, entity.ErasedName)
+}
@@ -0,0 +1,219 @@
+package projections
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"git.sr.ht/~loges/teammate/internal/team/domain/event"
+	"github.com/google/uuid"
+)
+
+var ErrTeamNotFound = errors.New("projections: the team was not found")
+
+// TeamRoster is the read model of a team and its players.
+type TeamRoster struct {
+	ID        uuid.UUID
+	Name      string
+	Activated bool
+	Players   []*RosterPlayer
+	Staff     []*RosterStaff
+}
+
+// RosterPlayer is the read model of a player on a roster.
+type RosterPlayer struct {
+	ID           uuid.UUID
+	Name         string
+	JerseyNumber int
+	Designation  string
+}
+
+// RosterStaff is the read model of a staff member of a team.
+type RosterStaff struct {
+	ID   uuid.UUID
+	Name string
+	Role string
+}
+
+type teamRoster struct {
+	group     entity.Group
+	activated bool
+	players   map[uuid.UUID]RosterPlayer
+	staff     map[uuid.UUID]RosterStaff
+}
+
+// RosterProjection maintains the roster of every team.
+type RosterProjection struct {
+	teams map[uuid.UUID]*teamRoster
+	sync.RWMutex
+}
+
+// NewRosterProjection initializes an empty roster projection.
+func NewRosterProjection() *RosterProjection {
+	return &RosterProjection{
+		teams: make(map[uuid.UUID]*teamRoster),
+	}
+}
+
+// Name identifies the projection.
+func (p *RosterProjection) Name() string {
+	return "team_roster"
+}
+
+// Apply updates the rosters with team events.
+func (p *RosterProjection) Apply(e any) error {
+	p.Lock()
+	defer p.Unlock()
+
+	switch te := e.(type) {
+	case *event.TeamCreated:
+		p.teams[te.ID] = &teamRoster{
+			group:     entity.Group{ID: te.ID, Name: te.Name},
+			activated: true,
+			players:   make(map[uuid.UUID]RosterPlayer),
+			staff:     make(map[uuid.UUID]RosterStaff),
+		}
+
+	case *event.TeamActivated:
+		if t, ok := p.teams[te.ID]; ok {
+			t.activated = true
+		}
+
+	case *event.TeamDeactivated:
+		if t, ok := p.teams[te.ID]; ok {
+			t.activated = false
+		}
+
+	case *event.PlayerAssignedToTeam:
+		if t, ok := p.teams[te.ID]; ok {
+			t.players[te.PlayerId] = RosterPlayer{ID: te.PlayerId, Name: te.PlayerName, JerseyNumber: te.JerseyNumber}
+		}
+
+	case *event.JerseyNumberChanged:
+		if t, ok := p.teams[te.ID]; ok {
+			if player, ok := t.players[te.PlayerId]; ok {
+				player.JerseyNumber = te.Number
+				t.players[te.PlayerId] = player
+			}
+		}
+
+	case *event.PlayerDesignated:
+		if t, ok := p.teams[te.ID]; ok {
+			if player, ok := t.players[te.PlayerId]; ok {
+				player.Designation = te.Designation
+				t.players[te.PlayerId] = player
+			}
+		}
+
+	case *event.PlayerDesignationRemoved:
+		if t, ok := p.teams[te.ID]; ok {
+			if player, ok := t.players[te.PlayerId]; ok {
+				player.Designation = ""
+				t.players[te.PlayerId] = player
+			}
+		}
+
+	case *event.StaffMemberAdded:
+		if t, ok := p.teams[te.ID]; ok {
+			t.staff[te.PersonId] = RosterStaff{ID: te.PersonId, Name: te.PersonName, Role: te.Role}
+		}
+
+	case *event.StaffMemberRemoved:
+		if t, ok := p.teams[te.ID]; ok {
+			delete(t.staff, te.PersonId)
+		}
+
+	case *event.StaffMemberErased:
+		if t, ok := p.teams[te.ID]; ok {
+			if staff, ok := t.staff[te.PersonId]; ok {
+				staff.Name = entity.ErasedName
+				t.staff[te.PersonId] = staff
+			}
+		}
+
+	case *event.PlayerUnassignedFromTeam:
+		if t, ok := p.teams[te.ID]; ok {
+			delete(t.players, te.PlayerId)
+		}
+
+	case *event.TeamSeasonStarted:
+		if t, ok := p.teams[te.ID]; ok {
+			for _, id := range te.Released {
+				delete(t.players, id)
+			}
+		}
+
+	case *event.PlayerErased:
+		for _, t := range p.teams {
+			if player, ok := t.players[te.ID]; ok {
+				player.Name = entity.ErasedName
+				t.players[te.ID] = player
+			}
+			if staff, ok := t.staff[te.ID]; ok {
+				staff.Name = entity.ErasedName
+				t.staff[te.ID] = staff
+			}
+		}
+	}
+
+	return nil
+}
+
+// Reset clears every roster.
+func (p *RosterProjection) Reset() error {
+	p.Lock()
+	defer p.Unlock()
+
+	p.teams = make(map[uuid.UUID]*teamRoster)
+	return nil
+}
+
+// Get returns the roster of the team.
+func (p *RosterProjection) Get(g *entity.Group) (*TeamRoster, error) {
+	p.RLock()
+	defer p.RUnlock()
+
+	t, ok := p.teams[g.ID]
+	if !ok {
+		return &TeamRoster{}, ErrTeamNotFound
+	}
+	return t.view(), nil
+}
+
+// List returns the roster of every team ordered by team name.
+func (p *RosterProjection) List() (rosters []*TeamRoster) {
+	p.RLock()
+	defer p.RUnlock()
+
+	for _, t := range p.teams {
+		rosters = append(rosters, t.view())
+	}
+	sort.Slice(rosters, func(i, j int) bool {
+		return rosters[i].Name < rosters[j].Name
+	})
+	return rosters
+}
+
+func (t *teamRoster) view() *TeamRoster {
+	r := &TeamRoster{
+		ID:        t.group.ID,
+		Name:      t.group.Name,
+		Activated: t.activated,
+	}
+	for _, player := range t.players {
+		player := player
+		r.Players = append(r.Players, &player)
+	}
+	sort.Slice(r.Players, func(i, j int) bool {
+		return r.Players[i].Name < r.Players[j].Name
+	})
+	for _, member := range t.staff {
+		member := member
+		r.Staff = append(r.Staff, &member)
+	}
+	sort.Slice(r.Staff, func(i, j int) bool {
+		return r.Staff[i].Name < r.Staff[j].Name
+	})
+	return r
+}
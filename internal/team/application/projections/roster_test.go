@@ -0,0 +1,131 @@
+package projections
+
+import (
+	"testing"
+
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"git.sr.ht/~loges/teammate/internal/team/domain/event"
+	"github.com/google/uuid"
+	"github.com/matryer/is"
+)
+
+var (
+	exampleTeam     = &entity.Group{ID: uuid.MustParse("f55e93f8-c952-11ed-afa1-0242ac120002"), Name: "Tigers"}
+	anotherTeam     = &entity.Group{ID: uuid.MustParse("adbe93f8-c952-11ed-afa1-0242ac120002"), Name: "Bears"}
+	examplePlayer   = &entity.Person{ID: uuid.MustParse("f47ac10b-58cc-0372-8567-0e02b2c3d479"), Name: "Matt"}
+	anotherPlayer   = &entity.Person{ID: uuid.MustParse("d38ad10b-58cc-0372-8567-0e02b2c3d479"), Name: "Jackie"}
+	teamCreated     = &event.TeamCreated{ID: exampleTeam.ID, Name: exampleTeam.Name}
+	anotherCreated  = &event.TeamCreated{ID: anotherTeam.ID, Name: anotherTeam.Name}
+	teamDeactivated = &event.TeamDeactivated{ID: exampleTeam.ID}
+	playerAssigned  = &event.PlayerAssignedToTeam{ID: exampleTeam.ID, PlayerId: examplePlayer.ID, PlayerName: examplePlayer.Name}
+	anotherAssigned = &event.PlayerAssignedToTeam{ID: exampleTeam.ID, PlayerId: anotherPlayer.ID, PlayerName: anotherPlayer.Name}
+	playerRemoved   = &event.PlayerUnassignedFromTeam{ID: exampleTeam.ID, PlayerId: examplePlayer.ID, PlayerName: examplePlayer.Name}
+	seasonStarted   = &event.TeamSeasonStarted{ID: exampleTeam.ID, SeasonId: uuid.MustParse("a15e93f8-c952-11ed-afa1-0242ac120002"), Released: []uuid.UUID{examplePlayer.ID}}
+)
+
+func TestRosterProjection_Get(t *testing.T) {
+	testCases := []struct {
+		test              string
+		events            []any
+		expectedActivated bool
+		expectedPlayers   []string
+		expectedErr       error
+	}{
+		{"Team not found", []any{anotherCreated}, false, nil, ErrTeamNotFound},
+		{"Team without players", []any{teamCreated}, true, nil, nil},
+		{"Players ordered by name", []any{teamCreated, playerAssigned, anotherAssigned}, true, []string{"Jackie", "Matt"}, nil},
+		{"Player unassigned", []any{teamCreated, playerAssigned, anotherAssigned, playerRemoved}, true, []string{"Jackie"}, nil},
+		{"Team deactivated", []any{teamCreated, teamDeactivated}, false, nil, nil},
+		{"Players released at season start", []any{teamCreated, playerAssigned, anotherAssigned, seasonStarted}, true, []string{"Jackie"}, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			p := NewRosterProjection()
+			for _, e := range tc.events {
+				is.NoErr(p.Apply(e))
+			}
+
+			roster, err := p.Get(exampleTeam)
+
+			is.Equal(err, tc.expectedErr)
+			is.Equal(roster.Activated, tc.expectedActivated)
+			var names []string
+			for _, player := range roster.Players {
+				names = append(names, player.Name)
+			}
+			is.Equal(names, tc.expectedPlayers)
+		})
+	}
+}
+
+func TestRosterProjection_List(t *testing.T) {
+	is := is.New(t)
+	p := NewRosterProjection()
+	_ = p.Apply(teamCreated)
+	_ = p.Apply(anotherCreated)
+
+	rosters := p.List()
+	is.Equal(len(rosters), 2)
+	is.Equal(rosters[0].Name, anotherTeam.Name)
+
+	is.NoErr(p.Reset())
+	is.Equal(len(p.List()), 0)
+}
+
+func TestRosterProjection_JerseyNumbers(t *testing.T) {
+	is := is.New(t)
+	p := NewRosterProjection()
+	_ = p.Apply(teamCreated)
+	_ = p.Apply(&event.PlayerAssignedToTeam{ID: exampleTeam.ID, PlayerId: examplePlayer.ID, PlayerName: examplePlayer.Name, JerseyNumber: 7})
+	_ = p.Apply(&event.JerseyNumberChanged{ID: exampleTeam.ID, PlayerId: examplePlayer.ID, Number: 9, Previous: 7})
+
+	roster, err := p.Get(exampleTeam)
+
+	is.NoErr(err)
+	is.Equal(roster.Players[0].JerseyNumber, 9)
+}
+
+func TestRosterProjection_StaffAndDesignations(t *testing.T) {
+	is := is.New(t)
+	p := NewRosterProjection()
+	_ = p.Apply(teamCreated)
+	_ = p.Apply(playerAssigned)
+	_ = p.Apply(&event.PlayerDesignated{ID: exampleTeam.ID, PlayerId: examplePlayer.ID, Designation: "captain"})
+	_ = p.Apply(&event.StaffMemberAdded{ID: exampleTeam.ID, PersonId: anotherPlayer.ID, PersonName: anotherPlayer.Name, Role: "head_coach"})
+
+	roster, err := p.Get(exampleTeam)
+
+	is.NoErr(err)
+	is.Equal(roster.Players[0].Designation, "captain")
+	is.Equal(roster.Staff, []*RosterStaff{{ID: anotherPlayer.ID, Name: anotherPlayer.Name, Role: "head_coach"}})
+
+	_ = p.Apply(&event.PlayerDesignationRemoved{ID: exampleTeam.ID, PlayerId: examplePlayer.ID})
+	_ = p.Apply(&event.StaffMemberRemoved{ID: exampleTeam.ID, PersonId: anotherPlayer.ID})
+
+	roster, _ = p.Get(exampleTeam)
+	is.Equal(roster.Players[0].Designation, "")
+	is.Equal(len(roster.Staff), 0)
+}
+
+func TestRosterProjection_Erased(t *testing.T) {
+	is := is.New(t)
+	p := NewRosterProjection()
+	_ = p.Apply(teamCreated)
+	_ = p.Apply(playerAssigned)
+	_ = p.Apply(&event.StaffMemberAdded{ID: exampleTeam.ID, PersonId: examplePlayer.ID, PersonName: examplePlayer.Name, Role: "manager"})
+
+	is.NoErr(p.Apply(&event.PlayerErased{ID: examplePlayer.ID}))
+
+	roster, _ := p.Get(exampleTeam)
+	is.Equal(roster.Players[0].Name, entity.ErasedName)
+	is.Equal(roster.Staff[0].Name, entity.ErasedName)
+
+	_ = p.Apply(&event.StaffMemberAdded{ID: exampleTeam.ID, PersonId: anotherPlayer.ID, PersonName: anotherPlayer.Name, Role: "head_coach"})
+	is.NoErr(p.Apply(&event.StaffMemberErased{ID: exampleTeam.ID, PersonId: anotherPlayer.ID}))
+
+	roster, _ = p.Get(exampleTeam)
+	is.Equal(roster.Staff[1].Name, entity.ErasedName)
+	is.Equal(roster.Staff[1].Role, "head_coach")
+}
@@ -0,0 +1,161 @@
+package services
+
+import (
+	"sort"
+
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"git.sr.ht/~loges/teammate/internal/team/domain/model"
+	"git.sr.ht/~loges/teammate/internal/team/domain/repository"
+	"github.com/google/uuid"
+)
+
+// MarkPlayerUnavailable records a period the player can't play. The user
+// linked to the player and guardians of the player may do so on the
+// player's behalf, as may those managing the roster of the player's teams.
+func (s *RosterService) MarkPlayerUnavailable(player *entity.Person, u model.Unavailability) error {
+	p, err := s.availabilityPlayer(player)
+	if err != nil {
+		return err
+	}
+	if err = p.MarkUnavailable(u); err != nil {
+		return err
+	}
+
+	return s.players.Update(p)
+}
+
+// ClearPlayerUnavailability removes a period the player can't play, which
+// those who may mark the player unavailable may do.
+func (s *RosterService) ClearPlayerUnavailability(player *entity.Person, id uuid.UUID) error {
+	p, err := s.availabilityPlayer(player)
+	if err != nil {
+		return err
+	}
+	if err = p.ClearUnavailability(id); err != nil {
+		return err
+	}
+
+	return s.players.Update(p)
+}
+
+// RespondToGame records whether the player attends the game. The user
+// linked to the player and guardians of the player may respond on the
+// player's behalf, as may those managing the roster of the player's team.
+func (s *RosterService) RespondToGame(game uuid.UUID, player *entity.Person, r model.RSVP) error {
+	g, err := s.games.Get(game)
+	if err != nil {
+		return err
+	}
+	p, err := s.players.Get(player)
+	if err != nil {
+		return err
+	}
+	team, ok := g.TeamOf(p)
+	if !ok {
+		return model.ErrPlayerNotRostered
+	}
+	if !s.actsFor(p) {
+		if err = s.authorizer.Authorize(s.actor, entity.PermissionManageRoster, team); err != nil {
+			return err
+		}
+	}
+	if err = g.Respond(p, r); err != nil {
+		return err
+	}
+
+	return s.games.Update(g)
+}
+
+// GetGameAvailability returns whether each player on the team can play
+// the game and their responses, ordered by name. Players are unavailable
+// when any of their periods overlaps the game. Reasons only visible to
+// staff are left out unless the actor may view sensitive player data of
+// the team or is a guardian of the player.
+func (s *RosterService) GetGameAvailability(team *entity.Group, game uuid.UUID) ([]model.Availability, error) {
+	if err := s.authorizer.Authorize(s.actor, entity.PermissionViewRoster, team); err != nil {
+		return nil, err
+	}
+
+	g, err := s.games.Get(game)
+	if err != nil {
+		return nil, err
+	}
+	if !g.Involves(team.ID) {
+		return nil, repository.ErrGameNotFound
+	}
+	t, err := s.teams.Get(team)
+	if err != nil {
+		return nil, err
+	}
+
+	staff := s.authorizer.Authorize(s.actor, entity.PermissionViewSensitivePlayerData, team) == nil
+	scheduled := g.GetScheduledGame()
+	availability := []model.Availability{}
+	for _, player := range t.GetPlayers() {
+		p, err := s.players.Get(player)
+		if err != nil {
+			return nil, err
+		}
+		a := model.Availability{Player: player, Available: true}
+		a.RSVP, _ = g.GetRSVP(player.ID)
+		if u, ok := p.UnavailableDuring(scheduled.StartsAt, scheduled.EndsAt); ok {
+			a.Available = false
+			if u.Visibility == model.VisibilityTeam || staff || s.isGuardian(p) {
+				a.Reason = u.Reason
+			}
+		}
+		availability = append(availability, a)
+	}
+
+	sort.Slice(availability, func(i, j int) bool {
+		return availability[i].Player.Name < availability[j].Player.Name
+	})
+	return availability, nil
+}
+
+// availabilityPlayer loads the player if the actor acts for the player or
+// may manage the roster of one of the player's teams.
+func (s *RosterService) availabilityPlayer(player *entity.Person) (*model.Player, error) {
+	p, err := s.players.Get(player)
+	if err != nil || s.actsFor(p) {
+		return p, err
+	}
+
+	err = model.ErrPlayerNotRostered
+	for _, team := range p.GetTeams() {
+		if err = s.authorizer.Authorize(s.actor, entity.PermissionManageRoster, team); err == nil {
+			return p, nil
+		}
+	}
+	return nil, err
+}
+
+// SuggestLineup returns the players picked for the position on the team
+// in depth chart order, leaving out those unavailable for the game.
+func (s *RosterService) SuggestLineup(team *entity.Group, position model.Position, game uuid.UUID) ([]*entity.Person, error) {
+	chart, err := s.GetDepthChart(team, position)
+	if err != nil {
+		return nil, err
+	}
+
+	g, err := s.games.Get(game)
+	if err != nil {
+		return nil, err
+	}
+	if !g.Involves(team.ID) {
+		return nil, repository.ErrGameNotFound
+	}
+
+	scheduled := g.GetScheduledGame()
+	lineup := []*entity.Person{}
+	for _, player := range chart {
+		p, err := s.players.Get(player)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := p.UnavailableDuring(scheduled.StartsAt, scheduled.EndsAt); !ok {
+			lineup = append(lineup, player)
+		}
+	}
+	return lineup, nil
+}
@@ -0,0 +1,222 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"git.sr.ht/~loges/teammate/internal/team/domain/model"
+	"git.sr.ht/~loges/teammate/internal/team/domain/repository"
+	"github.com/google/uuid"
+	"github.com/matryer/is"
+)
+
+var exampleGame = uuid.MustParse("5c2f7e1a-6f1a-11ee-b962-0242ac120002")
+
+func TestRosterService_Availability(t *testing.T) {
+	kickoff := time.Date(2023, time.September, 3, 10, 0, 0, 0, time.UTC)
+	injury := model.Unavailability{ID: uuid.New(), Reason: model.UnavailabilityReasonInjured, From: kickoff.AddDate(0, 0, -7), To: kickoff.AddDate(0, 0, 7)}
+	setup := func(is *is.I, u model.Unavailability) *RosterService {
+		s, _ := NewRosterService(WithAuthorizer(staffAuthorizer{}))
+		startsOn := time.Date(2023, time.August, 1, 0, 0, 0, 0, time.UTC)
+		is.NoErr(s.AddSeason(exampleSeason, "2023/24", startsOn, startsOn.AddDate(1, 0, 0)))
+		is.NoErr(s.AddTeam(exampleGroup))
+		is.NoErr(s.AddTeam(anotherGroup))
+		_ = s.ChangeTeamSport(exampleGroup, model.SportSoccer)
+		for i, p := range []*entity.Person{examplePerson, anotherPerson} {
+			is.NoErr(s.AddPlayer(p))
+			is.NoErr(s.AssignPlayerToTeam(exampleGroup, p, i+1))
+			is.NoErr(s.AssignPlayerPositions(exampleGroup, p, "forward"))
+		}
+		is.NoErr(s.ChangeDepthChart(exampleGroup, "forward", []*entity.Person{examplePerson, anotherPerson}))
+		season, _ := s.seasons.Get(exampleSeason)
+		home, _ := s.teams.Get(exampleGroup)
+		away, _ := s.teams.Get(anotherGroup)
+		g, err := model.NewGame(exampleGame, season, 1, home, away, kickoff, kickoff.Add(90*time.Minute), model.Location{})
+		is.NoErr(err)
+		is.NoErr(s.games.Add(g))
+		is.NoErr(s.MarkPlayerUnavailable(examplePerson, u))
+		return s
+	}
+
+	t.Run("Reason visibility", func(t *testing.T) {
+		testCases := []struct {
+			test       string
+			actor      *entity.Person
+			visibility model.Visibility
+			reason     model.UnavailabilityReason
+		}{
+			{"Staff sees the reason", examplePerson, model.VisibilityStaff, model.UnavailabilityReasonInjured},
+			{"Teammate doesn't see the reason", anotherPerson, model.VisibilityStaff, ""},
+			{"Teammate sees a reason shared with the team", anotherPerson, model.VisibilityTeam, model.UnavailabilityReasonInjured},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.test, func(t *testing.T) {
+				is := is.New(t)
+				u := injury
+				u.Visibility = tc.visibility
+				s := setup(is, u)
+
+				availability, err := s.As(tc.actor).GetGameAvailability(exampleGroup, exampleGame)
+
+				is.NoErr(err)
+				is.Equal(availability, []model.Availability{
+					{Player: anotherPerson, Available: true},
+					{Player: examplePerson, Available: false, Reason: tc.reason},
+				})
+			})
+		}
+	})
+
+	t.Run("Lineup leaves out unavailable players", func(t *testing.T) {
+		is := is.New(t)
+		s := setup(is, injury)
+
+		lineup, err := s.SuggestLineup(exampleGroup, "forward", exampleGame)
+
+		is.NoErr(err)
+		is.Equal(lineup, []*entity.Person{anotherPerson})
+		chart, _ := s.GetDepthChart(exampleGroup, "forward")
+		is.Equal(len(chart), 2) // the depth chart stays as picked
+
+		is.NoErr(s.ClearPlayerUnavailability(examplePerson, injury.ID))
+		lineup, err = s.SuggestLineup(exampleGroup, "forward", exampleGame)
+		is.NoErr(err)
+		is.Equal(lineup, []*entity.Person{examplePerson, anotherPerson})
+	})
+
+	t.Run("Availability can't be changed", func(t *testing.T) {
+		testCases := []struct {
+			test        string
+			change      func(*RosterService) error
+			expectedErr error
+		}{
+			{"Invalid period", func(s *RosterService) error {
+				return s.MarkPlayerUnavailable(anotherPerson, model.Unavailability{ID: uuid.New(), Reason: model.UnavailabilityReasonIll, From: kickoff, To: kickoff})
+			}, model.ErrInvalidUnavailability},
+			{"Period not found", func(s *RosterService) error { return s.ClearPlayerUnavailability(examplePerson, uuid.New()) }, model.ErrUnavailabilityNotFound},
+			{"Unavailable player attends", func(s *RosterService) error {
+				return s.RespondToGame(exampleGame, examplePerson, model.RSVPAttending)
+			}, model.ErrPlayerUnavailable},
+			{"Game not found", func(s *RosterService) error {
+				_, err := s.GetGameAvailability(exampleGroup, uuid.New())
+				return err
+			}, repository.ErrGameNotFound},
+			{"Team doesn't play the game", func(s *RosterService) error {
+				team := &entity.Group{ID: uuid.New(), Name: "Hawks"}
+				_ = s.AddTeam(team)
+				_, err := s.GetGameAvailability(team, exampleGame)
+				return err
+			}, repository.ErrGameNotFound},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.test, func(t *testing.T) {
+				is := is.New(t)
+				s := setup(is, injury)
+
+				err := tc.change(s)
+
+				is.Equal(err, tc.expectedErr)
+			})
+		}
+	})
+
+	t.Run("Marking is authorized", func(t *testing.T) {
+		user := &entity.Person{ID: uuid.MustParse("c15e93f8-c952-11ed-afa1-0242ac120002"), Name: "Ann"}
+		holiday := model.Unavailability{ID: uuid.New(), Reason: model.UnavailabilityReasonHoliday, From: kickoff, To: kickoff.AddDate(0, 0, 1)}
+		testCases := []struct {
+			test        string
+			actor       *entity.Person
+			team        *entity.Group
+			expectedErr error
+		}{
+			{"Coach of the player's team", examplePerson, exampleGroup, nil},
+			{"Coach of another team", examplePerson, anotherGroup, errDenied},
+			{"Linked user", user, anotherGroup, nil},
+			{"Other user", anotherPerson, exampleGroup, errDenied},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.test, func(t *testing.T) {
+				is := is.New(t)
+				s := setup(is, injury)
+				player := &entity.Person{ID: uuid.New(), Name: "Sam"}
+				is.NoErr(s.AddPlayer(player))
+				is.NoErr(s.AssignPlayerToTeam(tc.team, player, 9))
+				code, _ := s.InvitePlayer(player)
+				_, err := s.ClaimPlayer(code, user)
+				is.NoErr(err)
+				s.authorizer = coachAuthorizer{}
+
+				err = s.As(tc.actor).MarkPlayerUnavailable(player, holiday)
+
+				is.Equal(err, tc.expectedErr)
+				is.Equal(s.As(tc.actor).ClearPlayerUnavailability(player, holiday.ID) == nil, err == nil)
+			})
+		}
+	})
+}
+
+func TestRosterService_RespondToGame(t *testing.T) {
+	kickoff := time.Date(2023, time.September, 3, 10, 0, 0, 0, time.UTC)
+	guardian := &entity.Person{ID: uuid.MustParse("c15e93f8-c952-11ed-afa1-0242ac120002"), Name: "Ann"}
+	setup := func(is *is.I) *RosterService {
+		s, _ := NewRosterService(WithAuthorizer(allowAll{}))
+		startsOn := time.Date(2023, time.August, 1, 0, 0, 0, 0, time.UTC)
+		is.NoErr(s.AddSeason(exampleSeason, "2023/24", startsOn, startsOn.AddDate(1, 0, 0)))
+		is.NoErr(s.AddTeam(exampleGroup))
+		is.NoErr(s.AddTeam(anotherGroup))
+		is.NoErr(s.AddPlayer(examplePerson))
+		is.NoErr(s.AddPlayer(anotherPerson))
+		is.NoErr(s.AssignPlayerToTeam(anotherGroup, examplePerson, 7))
+		is.NoErr(s.AddGuardian(examplePerson, guardian, "mother"))
+		season, _ := s.seasons.Get(exampleSeason)
+		home, _ := s.teams.Get(exampleGroup)
+		away, _ := s.teams.Get(anotherGroup)
+		g, err := model.NewGame(exampleGame, season, 1, home, away, kickoff, kickoff.Add(90*time.Minute), model.Location{})
+		is.NoErr(err)
+		is.NoErr(s.games.Add(g))
+		s.authorizer = coachAuthorizer{}
+		return s
+	}
+
+	testCases := []struct {
+		test        string
+		actor       *entity.Person
+		player      *entity.Person
+		expectedErr error
+	}{
+		{"Guardian responds for the child", guardian, examplePerson, nil},
+		{"Other user is denied", anotherPerson, examplePerson, errDenied},
+		{"Player not on a team playing", guardian, anotherPerson, model.ErrPlayerNotRostered},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			s := setup(is)
+
+			err := s.As(tc.actor).RespondToGame(exampleGame, tc.player, model.RSVPAttending)
+
+			is.Equal(err, tc.expectedErr)
+			g, _ := s.games.Get(exampleGame)
+			_, responded := g.GetRSVP(examplePerson.ID)
+			is.Equal(responded, err == nil)
+		})
+	}
+
+	t.Run("Guardian views the child's schedule", func(t *testing.T) {
+		is := is.New(t)
+		s := setup(is)
+
+		schedule, err := s.As(guardian).GetPlayerSchedule(examplePerson)
+
+		is.NoErr(err)
+		is.Equal(len(schedule), 1)
+		is.Equal(schedule[0].ID, exampleGame)
+		_, err = s.As(anotherPerson).GetPlayerSchedule(examplePerson)
+		is.Equal(err, errDenied)
+	})
+}
@@ -0,0 +1,275 @@
+package services
+
+import (
+	"time"
+
+	"git.sr.ht/~loges/teammate/internal/bus"
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"git.sr.ht/~loges/teammate/internal/team/domain/event"
+	"git.sr.ht/~loges/teammate/internal/team/domain/model"
+	"git.sr.ht/~loges/teammate/internal/team/domain/repository"
+	"github.com/google/uuid"
+)
+
+// Schedule cancels and restores the scheduled sessions of a team.
+type Schedule interface {
+	CancelFutureSessions(team *entity.Group) ([]uuid.UUID, error)
+	RestoreSessions(team *entity.Group, sessions []uuid.UUID) error
+}
+
+// gameSchedule is the schedule of the games a team plays.
+type gameSchedule struct {
+	games repository.GameRepository
+	now   func() time.Time
+}
+
+// CancelFutureSessions cancels the games the team plays after now and
+// returns them. Games already cancelled are returned too, as only the
+// cascade cancels games, so a failed cascade is finished when it is run
+// again.
+func (s gameSchedule) CancelFutureSessions(team *entity.Group) ([]uuid.UUID, error) {
+	games, err := s.games.GetByTeam(team)
+	if err != nil {
+		return nil, err
+	}
+
+	var cancelled []uuid.UUID
+	for _, g := range games {
+		if !g.GetStartsAt().After(s.now()) {
+			continue
+		}
+		if !g.IsCancelled() {
+			if err = g.Cancel(); err != nil {
+				return nil, err
+			}
+			if err = s.games.Update(g); err != nil {
+				return nil, err
+			}
+		}
+		cancelled = append(cancelled, g.GetID())
+	}
+	return cancelled, nil
+}
+
+// RestoreSessions restores the cancelled games of the team. Games already
+// restored are skipped.
+func (s gameSchedule) RestoreSessions(team *entity.Group, sessions []uuid.UUID) error {
+	for _, id := range sessions {
+		g, err := s.games.Get(id)
+		if err != nil {
+			return err
+		}
+		if !g.Involves(team.ID) || !g.IsCancelled() {
+			continue
+		}
+		if err = g.Restore(); err != nil {
+			return err
+		}
+		if err = s.games.Update(g); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CascadeConfiguration is a function that modifies the cascade.
+type CascadeConfiguration func(c *DeactivationCascade)
+
+// WithPlayerUnassignment unassigns every player from a deactivated team.
+func WithPlayerUnassignment() CascadeConfiguration {
+	return func(c *DeactivationCascade) {
+		c.unassignPlayers = true
+	}
+}
+
+// WithSchedule cancels the future sessions of a deactivated team on s,
+// instead of the games of the team.
+func WithSchedule(s Schedule) CascadeConfiguration {
+	return func(c *DeactivationCascade) {
+		c.schedule = s
+	}
+}
+
+// DeactivationCascade is a process manager that cascades a team's
+// deactivation to its roster and schedule. What it changed is recorded on
+// the team and reverted when the team is activated again.
+type DeactivationCascade struct {
+	roster          *RosterService
+	schedule        Schedule
+	unassignPlayers bool
+}
+
+// NewDeactivationCascade initializes a cascade working on the roster
+// service's repositories, which cancels the future games of a deactivated
+// team.
+func NewDeactivationCascade(s *RosterService, cfgs ...CascadeConfiguration) *DeactivationCascade {
+	c := &DeactivationCascade{roster: s, schedule: s.schedule}
+
+	for _, cfg := range cfgs {
+		cfg(c)
+	}
+
+	return c
+}
+
+// Subscribe registers the cascade's handlers with the bus for the events
+// of the roster service's tenant.
+func (c *DeactivationCascade) Subscribe(b *bus.Bus) {
+	bus.Subscribe(b, c.roster.tenant, c.HandleTeamDeactivated)
+	bus.Subscribe(b, c.roster.tenant, c.HandleTeamActivated)
+}
+
+// HandleTeamDeactivated unassigns the team's players and cancels its
+// future sessions, as configured. The team records the cascade before the
+// players are updated, and players already unassigned are skipped, so a
+// failed cascade is finished when the event is handled again.
+func (c *DeactivationCascade) HandleTeamDeactivated(e *event.TeamDeactivated) error {
+	group := &entity.Group{ID: e.ID}
+
+	t, err := c.roster.teams.Get(group)
+	if err != nil {
+		return err
+	}
+	if t.IsActivated() {
+		return nil
+	}
+
+	compensation, ok := t.GetCompensation()
+	if !ok {
+		if compensation, err = c.cascade(t); err != nil {
+			return err
+		}
+		if len(compensation.PlayerIds) == 0 && len(compensation.SessionIds) == 0 {
+			return nil
+		}
+		if err = t.RecordDeactivationCascade(compensation); err != nil {
+			return err
+		}
+		if err = c.roster.teams.Update(t); err != nil {
+			return err
+		}
+	}
+
+	rostered := make(map[uuid.UUID]bool)
+	for _, p := range t.GetPlayers() {
+		rostered[p.ID] = true
+	}
+	for _, id := range compensation.PlayerIds {
+		p, err := c.roster.players.Get(&entity.Person{ID: id})
+		if err != nil {
+			return err
+		}
+		if !onTeam(p, group) || rostered[id] {
+			continue // unassigned by an earlier attempt, or assigned again
+		}
+		if err = p.UnassignTeam(t, model.LeaveReasonTeamDeactivated, c.roster.now()); err != nil {
+			return err
+		}
+		if err = c.roster.players.Update(p); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// cascade unassigns the players from the team and cancels its future
+// sessions, as configured, and returns what to revert on activation.
+func (c *DeactivationCascade) cascade(t *model.Team) (*model.Compensation, error) {
+	compensation := &model.Compensation{JerseyNumbers: make(map[uuid.UUID]int)}
+
+	if c.unassignPlayers {
+		for _, person := range t.GetPlayers() {
+			p, err := c.roster.players.Get(person)
+			if err != nil {
+				return nil, err
+			}
+			number := t.GetJerseyNumber(person.ID)
+			if err = t.UnassignPlayer(p); err != nil {
+				return nil, err
+			}
+			compensation.PlayerIds = append(compensation.PlayerIds, person.ID)
+			if number != model.NoJerseyNumber {
+				compensation.JerseyNumbers[person.ID] = number
+			}
+		}
+	}
+
+	if c.schedule != nil {
+		sessions, err := c.schedule.CancelFutureSessions(&entity.Group{ID: t.GetID()})
+		if err != nil {
+			return nil, err
+		}
+		compensation.SessionIds = sessions
+	}
+
+	return compensation, nil
+}
+
+// HandleTeamActivated reverts the pending compensation of the team by
+// reassigning its players and restoring its sessions.
+func (c *DeactivationCascade) HandleTeamActivated(e *event.TeamActivated) error {
+	group := &entity.Group{ID: e.ID}
+
+	t, err := c.roster.teams.Get(group)
+	if err != nil {
+		return err
+	}
+	if !t.IsActivated() {
+		return nil
+	}
+	compensation, ok := t.GetCompensation()
+	if !ok {
+		return nil
+	}
+
+	for _, id := range compensation.PlayerIds {
+		p, err := c.roster.players.Get(&entity.Person{ID: id})
+		if err != nil {
+			continue // the player no longer exists
+		}
+		number, ok := compensation.JerseyNumbers[id]
+		if !ok {
+			number = model.NoJerseyNumber
+		}
+		if reassign(t, p, number) != nil || p.AssignTeam(t, c.roster.now()) != nil {
+			continue // the player was assigned again in the meantime
+		}
+		if err = c.roster.players.Update(p); err != nil {
+			return err
+		}
+	}
+
+	if c.schedule != nil && len(compensation.SessionIds) > 0 {
+		if err = c.schedule.RestoreSessions(group, compensation.SessionIds); err != nil {
+			return err
+		}
+	}
+
+	if err = t.RevertDeactivationCascade(); err != nil {
+		return err
+	}
+
+	return c.roster.teams.Update(t)
+}
+
+// onTeam returns whether the player is on the team by the player's own
+// record.
+func onTeam(p *model.Player, team *entity.Group) bool {
+	for _, t := range p.GetTeams() {
+		if t.ID == team.ID {
+			return true
+		}
+	}
+	return false
+}
+
+// reassign puts the player back on the team wearing the jersey number, or
+// without a number if it is no longer available.
+func reassign(t *model.Team, p *model.Player, number int) error {
+	err := t.AssignPlayer(p, number)
+	if err == model.ErrJerseyNumberTaken || err == model.ErrInvalidJerseyNumber {
+		err = t.AssignPlayer(p, model.NoJerseyNumber)
+	}
+	return err
+}
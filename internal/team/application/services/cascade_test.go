@@ -0,0 +1,193 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"git.sr.ht/~loges/teammate/internal/bus"
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"git.sr.ht/~loges/teammate/internal/team/domain/event"
+	"git.sr.ht/~loges/teammate/internal/team/domain/model"
+	"git.sr.ht/~loges/teammate/internal/team/domain/repository"
+	"git.sr.ht/~loges/teammate/internal/team/infrastructure/memory"
+	"github.com/google/uuid"
+	"github.com/matryer/is"
+)
+
+var exampleSession = uuid.MustParse("e15e93f8-c952-11ed-afa1-0242ac120002")
+
+// stubSchedule has one future session per team.
+type stubSchedule struct {
+	cancelled map[uuid.UUID][]uuid.UUID
+}
+
+func (s *stubSchedule) CancelFutureSessions(team *entity.Group) ([]uuid.UUID, error) {
+	s.cancelled[team.ID] = []uuid.UUID{exampleSession}
+	return s.cancelled[team.ID], nil
+}
+
+func (s *stubSchedule) RestoreSessions(team *entity.Group, sessions []uuid.UUID) error {
+	delete(s.cancelled, team.ID)
+	return nil
+}
+
+var errUnavailable = errors.New("unavailable")
+
+// failingPlayers fails the one player update after the first updates.
+type failingPlayers struct {
+	repository.PlayerRepository
+	updates int
+}
+
+func (r *failingPlayers) Update(p *model.Player) error {
+	r.updates--
+	if r.updates == -1 {
+		return errUnavailable
+	}
+	return r.PlayerRepository.Update(p)
+}
+
+func newCascadingRosterService(cfgs ...CascadeConfiguration) *RosterService {
+	b := bus.New()
+	s, _ := NewRosterService(WithAuthorizer(allowAll{}), WithMemoryRepositories(memory.WithPublisher(b)))
+	NewDeactivationCascade(s, cfgs...).Subscribe(b)
+
+	_ = s.AddTeam(exampleGroup)
+	_ = s.AddPlayer(examplePerson)
+	_ = s.AssignPlayerToTeam(exampleGroup, examplePerson, 7)
+	return s
+}
+
+func TestDeactivationCascade(t *testing.T) {
+	testCases := []struct {
+		test              string
+		unassign          bool
+		expectedPlayers   int
+		expectedCancelled int
+	}{
+		{"Nothing is cascaded by default", false, 1, 1},
+		{"Players are unassigned", true, 0, 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			schedule := &stubSchedule{cancelled: make(map[uuid.UUID][]uuid.UUID)}
+			cfgs := []CascadeConfiguration{WithSchedule(schedule)}
+			if tc.unassign {
+				cfgs = append(cfgs, WithPlayerUnassignment())
+			}
+			s := newCascadingRosterService(cfgs...)
+
+			is.NoErr(s.DeactivateTeam(exampleGroup))
+
+			players, _ := s.teams.GetPlayers(exampleGroup)
+			is.Equal(len(players), tc.expectedPlayers)
+			teams, _ := s.players.GetTeams(examplePerson)
+			is.Equal(len(teams), tc.expectedPlayers)
+			is.Equal(len(schedule.cancelled[exampleGroup.ID]), tc.expectedCancelled)
+
+			is.NoErr(s.ActivateTeam(exampleGroup))
+
+			players, _ = s.teams.GetPlayers(exampleGroup)
+			is.Equal(len(players), 1)
+			teams, _ = s.players.GetTeams(examplePerson)
+			is.Equal(len(teams), 1)
+			is.Equal(len(schedule.cancelled), 0)
+
+			team, _ := s.teams.Get(exampleGroup)
+			_, pending := team.GetCompensation()
+			is.Equal(pending, false)
+			is.Equal(team.GetJerseyNumber(examplePerson.ID), 7)
+		})
+	}
+
+	t.Run("Player assigned again before activation", func(t *testing.T) {
+		is := is.New(t)
+		s := newCascadingRosterService(WithPlayerUnassignment())
+
+		is.NoErr(s.DeactivateTeam(exampleGroup))
+		is.NoErr(s.AssignPlayerToTeam(exampleGroup, examplePerson, 7))
+		is.NoErr(s.ActivateTeam(exampleGroup))
+
+		players, _ := s.teams.GetPlayers(exampleGroup)
+		is.Equal(len(players), 1)
+	})
+
+	t.Run("Team without players or schedule", func(t *testing.T) {
+		is := is.New(t)
+		b := bus.New()
+		s, _ := NewRosterService(WithAuthorizer(allowAll{}), WithMemoryRepositories(memory.WithPublisher(b)))
+		NewDeactivationCascade(s, WithPlayerUnassignment()).Subscribe(b)
+		_ = s.AddTeam(exampleGroup)
+
+		is.NoErr(s.DeactivateTeam(exampleGroup))
+
+		team, _ := s.teams.Get(exampleGroup)
+		_, pending := team.GetCompensation()
+		is.Equal(pending, false)
+	})
+	t.Run("Future games are cancelled by default", func(t *testing.T) {
+		is := is.New(t)
+		kickoff := time.Date(2023, time.September, 3, 10, 0, 0, 0, time.UTC)
+		b := bus.New()
+		s, _ := NewRosterService(WithAuthorizer(allowAll{}), WithMemoryRepositories(memory.WithPublisher(b)), WithClock(func() time.Time { return kickoff.AddDate(0, 0, 1) }))
+		NewDeactivationCascade(s).Subscribe(b)
+		is.NoErr(s.AddSeason(exampleSeason, "2023/24", kickoff.AddDate(0, -1, 0), kickoff.AddDate(1, 0, 0)))
+		is.NoErr(s.AddTeam(exampleGroup))
+		is.NoErr(s.AddTeam(anotherGroup))
+		season, _ := s.seasons.Get(exampleSeason)
+		home, _ := s.teams.Get(exampleGroup)
+		away, _ := s.teams.Get(anotherGroup)
+		played, future := uuid.New(), uuid.New()
+		for id, startsAt := range map[uuid.UUID]time.Time{played: kickoff, future: kickoff.AddDate(0, 0, 7)} {
+			g, err := model.NewGame(id, season, 1, home, away, startsAt, startsAt.Add(90*time.Minute), model.Location{})
+			is.NoErr(err)
+			is.NoErr(s.games.Add(g))
+		}
+
+		is.NoErr(s.DeactivateTeam(exampleGroup))
+
+		g, _ := s.games.Get(played)
+		is.True(!g.IsCancelled())
+		g, _ = s.games.Get(future)
+		is.True(g.IsCancelled())
+		team, _ := s.teams.Get(exampleGroup)
+		compensation, _ := team.GetCompensation()
+		is.Equal(compensation.SessionIds, []uuid.UUID{future})
+
+		is.NoErr(s.ActivateTeam(exampleGroup))
+
+		g, _ = s.games.Get(future)
+		is.True(!g.IsCancelled())
+	})
+
+	t.Run("Failed cascade is finished when handled again", func(t *testing.T) {
+		is := is.New(t)
+		s, _ := NewRosterService(WithAuthorizer(allowAll{}))
+		c := NewDeactivationCascade(s, WithPlayerUnassignment())
+		_ = s.AddTeam(exampleGroup)
+		for i, p := range []*entity.Person{examplePerson, anotherPerson} {
+			_ = s.AddPlayer(p)
+			_ = s.AssignPlayerToTeam(exampleGroup, p, i+1)
+		}
+		is.NoErr(s.DeactivateTeam(exampleGroup))
+		players := s.players
+		s.players = &failingPlayers{PlayerRepository: players, updates: 1}
+		deactivated := &event.TeamDeactivated{ID: exampleGroup.ID}
+
+		is.Equal(c.HandleTeamDeactivated(deactivated), errUnavailable)
+		is.NoErr(c.HandleTeamDeactivated(deactivated))
+
+		rostered, _ := s.teams.GetPlayers(exampleGroup)
+		is.Equal(len(rostered), 0)
+		for _, p := range []*entity.Person{examplePerson, anotherPerson} {
+			teams, _ := players.GetTeams(p)
+			is.Equal(len(teams), 0)
+		}
+		team, _ := s.teams.Get(exampleGroup)
+		compensation, _ := team.GetCompensation()
+		is.Equal(len(compensation.PlayerIds), 2)
+	})
+}
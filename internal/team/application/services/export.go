@@ -0,0 +1,297 @@
+package services
+
+import (
+	"sort"
+	"time"
+
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"git.sr.ht/~loges/teammate/internal/export"
+	"git.sr.ht/~loges/teammate/internal/team/domain/event"
+	"git.sr.ht/~loges/teammate/internal/team/domain/model"
+	"git.sr.ht/~loges/teammate/internal/team/domain/repository"
+	"github.com/google/uuid"
+)
+
+// PersonalData is the data the team context holds about a person.
+type PersonalData struct {
+	// Player is the player profile of the person, or of the player linked
+	// to the person as a user.
+	Player        *PlayerData        `json:"player"`
+	Guardianships []GuardianshipData `json:"guardianships"`
+	Staff         []StaffData        `json:"staff"`
+}
+
+// PlayerData is the profile and history of a player.
+type PlayerData struct {
+	ID                uuid.UUID                `json:"id"`
+	Name              string                   `json:"name"`
+	Activated         bool                     `json:"activated"`
+	DateOfBirth       time.Time                `json:"date_of_birth"`
+	Gender            string                   `json:"gender"`
+	Phone             string                   `json:"phone"`
+	Street            string                   `json:"street"`
+	City              string                   `json:"city"`
+	PostalCode        string                   `json:"postal_code"`
+	Country           string                   `json:"country"`
+	EmergencyContacts []event.EmergencyContact `json:"emergency_contacts"`
+	MedicalNotes      string                   `json:"medical_notes"`
+	HouseholdID       uuid.UUID                `json:"household_id"`
+	Guardians         []GuardianData           `json:"guardians"`
+	Memberships       []MembershipData         `json:"memberships"`
+	Attendance        []AttendanceData         `json:"attendance"`
+	Events            []export.Event           `json:"events"`
+}
+
+// GuardianData is a user acting on behalf of the player. The name is only
+// exported to the guardian.
+type GuardianData struct {
+	UserID       uuid.UUID `json:"user_id"`
+	Name         string    `json:"name"`
+	Relationship string    `json:"relationship"`
+}
+
+// MembershipData is a team the player has been on and the jersey number
+// the player wore, the last one for a past membership.
+type MembershipData struct {
+	TeamID       uuid.UUID `json:"team_id"`
+	TeamName     string    `json:"team_name"`
+	JerseyNumber int       `json:"jersey_number"`
+	JoinedAt     time.Time `json:"joined_at"`
+	LeftAt       time.Time `json:"left_at"`
+	Reason       string    `json:"reason"`
+	Current      bool      `json:"current"`
+}
+
+// AttendanceData is the response of the player to a game.
+type AttendanceData struct {
+	GameID   uuid.UUID `json:"game_id"`
+	StartsAt time.Time `json:"starts_at"`
+	Response string    `json:"response"`
+}
+
+// GuardianshipData is a player the person is a guardian of.
+type GuardianshipData struct {
+	PlayerID     uuid.UUID `json:"player_id"`
+	PlayerName   string    `json:"player_name"`
+	Relationship string    `json:"relationship"`
+}
+
+// StaffData is a team the person is on the staff of.
+type StaffData struct {
+	TeamID   uuid.UUID `json:"team_id"`
+	TeamName string    `json:"team_name"`
+	Role     string    `json:"role"`
+}
+
+// ExportSource gathers the data the team context holds about a person
+// for subject access requests.
+type ExportSource struct {
+	roster *RosterService
+}
+
+// NewExportSource returns an export source reading from the repositories
+// of the roster service.
+func NewExportSource(rs *RosterService) *ExportSource {
+	return &ExportSource{roster: rs}
+}
+
+// Name identifies the team section of the archive.
+func (s *ExportSource) Name() string {
+	return "team"
+}
+
+// Export returns the player profile, memberships and stored events of the
+// person, along with the players the person is a guardian of and the
+// teams the person is on the staff of.
+func (s *ExportSource) Export(subject *entity.Person) (any, error) {
+	data := &PersonalData{
+		Guardianships: []GuardianshipData{},
+		Staff:         []StaffData{},
+	}
+
+	p, err := s.roster.players.Get(subject)
+	if err == repository.ErrPlayerNotFound {
+		p, err = s.roster.players.GetByUser(subject)
+	}
+	switch {
+	case err == nil:
+		if data.Player, err = s.player(p, subject); err != nil {
+			return nil, err
+		}
+	case err != repository.ErrPlayerNotFound:
+		return nil, err
+	}
+
+	children, err := s.roster.players.GetByGuardian(subject)
+	if err != nil {
+		return nil, err
+	}
+	for _, child := range children {
+		for _, g := range child.GetGuardians() {
+			if g.User.ID == subject.ID {
+				data.Guardianships = append(data.Guardianships, GuardianshipData{
+					PlayerID:     child.GetID(),
+					PlayerName:   child.GetName(),
+					Relationship: g.Relationship,
+				})
+			}
+		}
+	}
+
+	teams, err := s.roster.teams.GetByStaffMember(subject)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range teams {
+		for _, member := range t.GetStaff() {
+			if member.Person.ID == subject.ID {
+				data.Staff = append(data.Staff, StaffData{
+					TeamID:   t.GetID(),
+					TeamName: t.GetName(),
+					Role:     string(member.Role),
+				})
+			}
+		}
+	}
+
+	return data, nil
+}
+
+// player returns the full profile, attendance and history of the player.
+// The names and phone numbers of other people, such as guardians and
+// emergency contacts, are left out for anyone but them.
+func (s *ExportSource) player(p *model.Player, subject *entity.Person) (*PlayerData, error) {
+	changes, err := s.roster.players.GetHistory(&entity.Person{ID: p.GetID()})
+	if err != nil {
+		return nil, err
+	}
+
+	profile := p.GetProfile()
+	data := &PlayerData{
+		ID:                p.GetID(),
+		Name:              p.GetName(),
+		Activated:         p.IsActivated(),
+		DateOfBirth:       profile.DateOfBirth,
+		Gender:            string(profile.Gender),
+		Phone:             profile.Phone,
+		Street:            profile.Address.Street,
+		City:              profile.Address.City,
+		PostalCode:        profile.Address.PostalCode,
+		Country:           profile.Address.Country,
+		EmergencyContacts: []event.EmergencyContact{},
+		MedicalNotes:      profile.MedicalNotes,
+		HouseholdID:       p.GetHouseholdID(),
+		Guardians:         []GuardianData{},
+		Memberships:       []MembershipData{},
+		Attendance:        []AttendanceData{},
+		Events:            make([]export.Event, len(changes)),
+	}
+	for _, c := range profile.EmergencyContacts {
+		data.EmergencyContacts = append(data.EmergencyContacts, event.EmergencyContact{
+			Relationship: c.Relationship,
+		})
+	}
+	for _, g := range p.GetGuardians() {
+		guardian := GuardianData{UserID: g.User.ID, Relationship: g.Relationship}
+		if g.User.ID == subject.ID {
+			guardian.Name = g.User.Name
+		}
+		data.Guardians = append(data.Guardians, guardian)
+	}
+	for _, m := range p.GetMembershipHistory() {
+		number, err := s.jerseyNumber(p, m)
+		if err != nil {
+			return nil, err
+		}
+		data.Memberships = append(data.Memberships, MembershipData{
+			TeamID:       m.Team.ID,
+			TeamName:     m.Team.Name,
+			JerseyNumber: number,
+			JoinedAt:     m.JoinedAt,
+			LeftAt:       m.LeftAt,
+			Reason:       string(m.Reason),
+			Current:      m.Current,
+		})
+	}
+	if data.Attendance, err = s.attendance(p); err != nil {
+		return nil, err
+	}
+	for i, c := range changes {
+		data.Events[i] = export.NewEvent(c.Version, c.RecordedAt, withoutOthers(c.Event, subject))
+	}
+	return data, nil
+}
+
+// jerseyNumber returns the jersey number the player wears on the team of
+// the membership, or wore when leaving it. The number is unknown if the
+// team was not recorded by the time the player left.
+func (s *ExportSource) jerseyNumber(p *model.Player, m model.Membership) (int, error) {
+	var t *model.Team
+	var err error
+	if m.Current {
+		t, err = s.roster.teams.Get(m.Team)
+	} else {
+		t, err = s.roster.teams.GetAsOf(m.Team, m.LeftAt)
+	}
+	if err == repository.ErrTeamNotFound && !m.Current {
+		return model.NoJerseyNumber, nil
+	}
+	if err != nil {
+		return model.NoJerseyNumber, err
+	}
+	return t.GetJerseyNumber(p.GetID()), nil
+}
+
+// attendance returns the responses of the player to the games of every
+// team the player has been on, in order of when the games start.
+func (s *ExportSource) attendance(p *model.Player) ([]AttendanceData, error) {
+	seen := make(map[uuid.UUID]bool)
+	var games []*model.Game
+	for _, m := range p.GetMembershipHistory() {
+		played, err := s.roster.games.GetByTeam(m.Team)
+		if err != nil {
+			return nil, err
+		}
+		for _, g := range played {
+			if _, ok := g.GetRSVP(p.GetID()); ok && !seen[g.GetID()] {
+				seen[g.GetID()] = true
+				games = append(games, g)
+			}
+		}
+	}
+	sort.Slice(games, func(i, j int) bool {
+		return games[i].GetStartsAt().Before(games[j].GetStartsAt())
+	})
+
+	attendance := []AttendanceData{}
+	for _, g := range games {
+		r, _ := g.GetRSVP(p.GetID())
+		attendance = append(attendance, AttendanceData{
+			GameID:   g.GetID(),
+			StartsAt: g.GetStartsAt(),
+			Response: string(r),
+		})
+	}
+	return attendance, nil
+}
+
+// withoutOthers returns a copy of the event without the names and phone
+// numbers of people other than the subject.
+func withoutOthers(e event.Event, subject *entity.Person) event.Event {
+	switch pe := e.(type) {
+	case *event.GuardianAddedToPlayer:
+		if pe.UserId != subject.ID {
+			c := *pe
+			c.UserName = ""
+			return &c
+		}
+	case *event.PlayerEmergencyContactsChanged:
+		c := *pe
+		c.Contacts = make([]event.EmergencyContact, len(pe.Contacts))
+		for i, contact := range pe.Contacts {
+			c.Contacts[i] = event.EmergencyContact{Relationship: contact.Relationship}
+		}
+		return &c
+	}
+	return e
+}
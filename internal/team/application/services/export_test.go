@@ -0,0 +1,96 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"git.sr.ht/~loges/teammate/internal/team/domain/event"
+	"git.sr.ht/~loges/teammate/internal/team/domain/model"
+	"github.com/google/uuid"
+	"github.com/matryer/is"
+)
+
+func TestExportSource_Export(t *testing.T) {
+	kickoff := time.Date(2023, time.September, 3, 10, 0, 0, 0, time.UTC)
+	guardian := &entity.Person{ID: uuid.MustParse("c15e93f8-c952-11ed-afa1-0242ac120002"), Name: "Ann"}
+	setup := func(is *is.I) *ExportSource {
+		s, _ := NewRosterService(WithAuthorizer(allowAll{}))
+		startsOn := time.Date(2023, time.August, 1, 0, 0, 0, 0, time.UTC)
+		is.NoErr(s.AddSeason(exampleSeason, "2023/24", startsOn, startsOn.AddDate(1, 0, 0)))
+		is.NoErr(s.AddTeam(exampleGroup))
+		is.NoErr(s.AddTeam(anotherGroup))
+		is.NoErr(s.AddPlayer(examplePerson))
+		is.NoErr(s.AssignPlayerToTeam(exampleGroup, examplePerson, 7))
+		is.NoErr(s.AddGuardian(examplePerson, guardian, "mother"))
+		is.NoErr(s.ChangePlayerEmergencyContacts(examplePerson, []model.EmergencyContact{{Name: "Bob", Relationship: "uncle", Phone: "555-0100"}}))
+		season, _ := s.seasons.Get(exampleSeason)
+		home, _ := s.teams.Get(exampleGroup)
+		away, _ := s.teams.Get(anotherGroup)
+		g, err := model.NewGame(exampleGame, season, 1, home, away, kickoff, kickoff.Add(90*time.Minute), model.Location{})
+		is.NoErr(err)
+		is.NoErr(s.games.Add(g))
+		is.NoErr(s.RespondToGame(exampleGame, examplePerson, model.RSVPAttending))
+		return NewExportSource(s)
+	}
+
+	t.Run("Attendance is exported", func(t *testing.T) {
+		is := is.New(t)
+		s := setup(is)
+
+		data, err := s.Export(examplePerson)
+
+		is.NoErr(err)
+		is.Equal(data.(*PersonalData).Player.Attendance, []AttendanceData{{GameID: exampleGame, StartsAt: kickoff, Response: "attending"}})
+	})
+
+	t.Run("Memberships are exported with jersey numbers", func(t *testing.T) {
+		is := is.New(t)
+		s := setup(is)
+		is.NoErr(s.roster.AssignPlayerToTeam(anotherGroup, examplePerson, 10))
+		is.NoErr(s.roster.ChangeJerseyNumber(anotherGroup, examplePerson, 11))
+		is.NoErr(s.roster.UnassignPlayerFromTeam(anotherGroup, examplePerson, model.LeaveReasonTransfer))
+
+		data, err := s.Export(examplePerson)
+
+		is.NoErr(err)
+		memberships := data.(*PersonalData).Player.Memberships
+		is.Equal(len(memberships), 2)
+		is.Equal(memberships[0].TeamID, exampleGroup.ID)
+		is.Equal(memberships[0].JerseyNumber, 7)
+		is.Equal(memberships[1].TeamID, anotherGroup.ID)
+		is.Equal(memberships[1].JerseyNumber, 11) // the number worn when leaving
+		is.True(!memberships[1].Current)
+	})
+
+	t.Run("Other people are left out", func(t *testing.T) {
+		is := is.New(t)
+		s := setup(is)
+
+		data, err := s.Export(examplePerson)
+
+		is.NoErr(err)
+		player := data.(*PersonalData).Player
+		is.Equal(player.Guardians, []GuardianData{{UserID: guardian.ID, Relationship: "mother"}})
+		is.Equal(player.EmergencyContacts, []event.EmergencyContact{{Relationship: "uncle"}})
+		for _, e := range player.Events {
+			switch pe := e.Data.(type) {
+			case *event.GuardianAddedToPlayer:
+				is.Equal(pe.UserName, "")
+			case *event.PlayerEmergencyContactsChanged:
+				is.Equal(pe.Contacts, []event.EmergencyContact{{Relationship: "uncle"}})
+			}
+		}
+	})
+
+	t.Run("Guardians see their own name", func(t *testing.T) {
+		is := is.New(t)
+		s := setup(is)
+		p, _ := s.roster.players.Get(examplePerson)
+
+		data, err := s.player(p, guardian)
+
+		is.NoErr(err)
+		is.Equal(data.Guardians, []GuardianData{{UserID: guardian.ID, Name: "Ann", Relationship: "mother"}})
+	})
+}
@@ -0,0 +1,307 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"git.sr.ht/~loges/teammate/internal/team/domain/bracket"
+	"git.sr.ht/~loges/teammate/internal/team/domain/fixtures"
+	"git.sr.ht/~loges/teammate/internal/team/domain/model"
+	"git.sr.ht/~loges/teammate/internal/team/domain/repository"
+	"github.com/google/uuid"
+)
+
+// PreviewFixtures generates a round-robin of the teams in the season
+// without scheduling it, so it can be reviewed first. The teams have to
+// be active, the slots have to be within the open season and the games
+// must not double-book a field. The actor has to manage every team.
+func (s *RosterService) PreviewFixtures(id uuid.UUID, plan fixtures.Plan) (*fixtures.Schedule, error) {
+	if err := s.authorizeTeams(plan.Teams...); err != nil {
+		return nil, err
+	}
+
+	season, err := s.seasons.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if season.IsClosed() {
+		return nil, model.ErrSeasonClosed
+	}
+	for _, slot := range plan.Slots {
+		if !season.Contains(slot.StartsAt) {
+			return nil, model.ErrOutsideOfSeason
+		}
+	}
+
+	teams := make([]*entity.Group, len(plan.Teams))
+	for i, team := range plan.Teams {
+		t, err := s.teams.Get(team)
+		if err != nil {
+			return nil, err
+		}
+		if !t.IsActivated() {
+			return nil, model.ErrTeamNotActive
+		}
+		teams[i] = &entity.Group{ID: t.GetID(), Name: t.GetName()}
+	}
+	plan.Teams = teams
+
+	schedule, err := fixtures.Generate(plan)
+	if err != nil {
+		return nil, err
+	}
+
+	bookings := make([]model.Booking, len(schedule.Fixtures))
+	for i, f := range schedule.Fixtures {
+		bookings[i] = model.Booking{VenueID: f.Slot.Location.VenueID, Field: f.Slot.Location.Field, StartsAt: f.Slot.StartsAt, EndsAt: f.Slot.EndsAt}
+	}
+	venues, err := s.checkBookings(bookings)
+	if err != nil {
+		return nil, err
+	}
+	for i, f := range schedule.Fixtures {
+		if v, ok := venues[f.Slot.Location.VenueID]; ok {
+			schedule.Fixtures[i].Slot.Location = v.GetLocation(f.Slot.Location.Field)
+		}
+	}
+
+	return schedule, nil
+}
+
+// ScheduleFixtures schedules the games of a previewed round-robin in the
+// season. Every game is checked before any is stored, so a game that
+// can't be scheduled or double-books a field schedules none. Games of the
+// schedule already stored are skipped, so a schedule that failed while
+// its games were stored is finished by scheduling it again. The actor has
+// to manage every team playing.
+func (s *RosterService) ScheduleFixtures(id uuid.UUID, schedule *fixtures.Schedule) error {
+	for _, f := range schedule.Fixtures {
+		if err := s.authorizeTeams(f.Home, f.Away); err != nil {
+			return err
+		}
+	}
+
+	season, err := s.seasons.Get(id)
+	if err != nil {
+		return err
+	}
+
+	s.booking.Lock()
+	defer s.booking.Unlock()
+
+	teams := map[uuid.UUID]*model.Team{}
+	var games []*model.Game
+	for _, f := range schedule.Fixtures {
+		game := fixtureGameID(id, f)
+		_, err = s.games.Get(game)
+		if err == nil {
+			continue
+		}
+		if err != repository.ErrGameNotFound {
+			return err
+		}
+
+		for _, team := range []*entity.Group{f.Home, f.Away} {
+			if _, ok := teams[team.ID]; ok {
+				continue
+			}
+			if teams[team.ID], err = s.teams.Get(team); err != nil {
+				return err
+			}
+		}
+
+		g, err := model.NewGame(game, season, f.Round, teams[f.Home.ID], teams[f.Away.ID], f.Slot.StartsAt, f.Slot.EndsAt, f.Slot.Location)
+		if err != nil {
+			return err
+		}
+		games = append(games, g)
+	}
+
+	bookings := make([]model.Booking, len(games))
+	for i, g := range games {
+		bookings[i] = g.GetBooking()
+	}
+	if _, err = s.checkBookings(bookings); err != nil {
+		return err
+	}
+
+	for _, g := range games {
+		if err = s.games.Add(g); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// authorizeTeams checks that the actor manages each of the teams.
+func (s *RosterService) authorizeTeams(teams ...*entity.Group) error {
+	for _, team := range teams {
+		if err := s.authorizer.Authorize(s.actor, entity.PermissionManageTeams, team); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fixtureGameID returns the ID of the game played for the fixture in the
+// season, which is the same every time the fixture is scheduled.
+func fixtureGameID(season uuid.UUID, f fixtures.Fixture) uuid.UUID {
+	name := fmt.Sprintf("%d/%s/%s/%d", f.Round, f.Home.ID, f.Away.ID, f.Slot.StartsAt.Unix())
+	return uuid.NewSHA1(season, []byte(name))
+}
+
+// GetSchedule returns the games the team plays in order of when they
+// start.
+func (s *RosterService) GetSchedule(team *entity.Group) ([]model.ScheduledGame, error) {
+	if err := s.authorizer.Authorize(s.actor, entity.PermissionViewRoster, team); err != nil {
+		return nil, err
+	}
+
+	games, err := s.games.GetByTeam(team)
+	if err != nil {
+		return nil, err
+	}
+
+	return scheduleOf(games), nil
+}
+
+// GetSeasonSchedule returns the games the team plays in the season in
+// order of when they start.
+func (s *RosterService) GetSeasonSchedule(team *entity.Group, id uuid.UUID) ([]model.ScheduledGame, error) {
+	if err := s.authorizer.Authorize(s.actor, entity.PermissionViewRoster, team); err != nil {
+		return nil, err
+	}
+
+	games, err := s.seasonGames(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var played []*model.Game
+	for _, g := range games {
+		if g.Involves(team.ID) {
+			played = append(played, g)
+		}
+	}
+	return scheduleOf(played), nil
+}
+
+// RecordGameResult records the score of a game, which counts towards the
+// standings of its season. The actor has to manage both teams playing.
+func (s *RosterService) RecordGameResult(game uuid.UUID, homeScore, awayScore int) error {
+	g, err := s.games.Get(game)
+	if err != nil {
+		return err
+	}
+	played := g.GetScheduledGame()
+	if err = s.authorizeTeams(played.Home, played.Away); err != nil {
+		return err
+	}
+	if err = g.RecordResult(homeScore, awayScore); err != nil {
+		return err
+	}
+
+	return s.games.Update(g)
+}
+
+// GetStandings returns the standings of the teams playing in the season
+// after the recorded results.
+func (s *RosterService) GetStandings(id uuid.UUID) ([]bracket.Standing, error) {
+	if err := s.authorizer.Authorize(s.actor, entity.PermissionViewRoster, nil); err != nil {
+		return nil, err
+	}
+
+	games, err := s.seasonGames(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.Standings(games), nil
+}
+
+// GetTeamStats returns the record of the team in the season: the games
+// it played, won, drew and lost, and the goals it scored and conceded.
+func (s *RosterService) GetTeamStats(team *entity.Group, id uuid.UUID) (bracket.Standing, error) {
+	if err := s.authorizer.Authorize(s.actor, entity.PermissionViewRoster, team); err != nil {
+		return bracket.Standing{}, err
+	}
+
+	t, err := s.teams.Get(team)
+	if err != nil {
+		return bracket.Standing{}, err
+	}
+	games, err := s.seasonGames(id)
+	if err != nil {
+		return bracket.Standing{}, err
+	}
+
+	for _, standing := range model.Standings(games) {
+		if standing.Team.ID == t.GetID() {
+			return standing, nil
+		}
+	}
+	return bracket.Standing{Team: &entity.Group{ID: t.GetID(), Name: t.GetName()}}, nil
+}
+
+// seasonGames returns the games of the season in order of when they
+// start.
+func (s *RosterService) seasonGames(id uuid.UUID) ([]*model.Game, error) {
+	if _, err := s.seasons.Get(id); err != nil {
+		return nil, err
+	}
+
+	games, err := s.games.GetBySeason(id)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(games, func(i, j int) bool {
+		return games[i].GetStartsAt().Before(games[j].GetStartsAt())
+	})
+	return games, nil
+}
+
+// GetPlayerSchedule returns the games of every team the player is on in
+// order of when they start. The user linked to the player and guardians
+// of the player may view it, as may those allowed to manage players.
+func (s *RosterService) GetPlayerSchedule(player *entity.Person) ([]model.ScheduledGame, error) {
+	authErr := s.authorizer.Authorize(s.actor, entity.PermissionManagePlayers, nil)
+	p, err := s.players.Get(player)
+	if authErr != nil && (err != nil || !s.actsFor(p)) {
+		return nil, authErr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	var games []*model.Game
+	for _, team := range p.GetTeams() {
+		played, err := s.games.GetByTeam(team)
+		if err != nil {
+			return nil, err
+		}
+		for _, g := range played {
+			if !seen[g.GetID()] {
+				seen[g.GetID()] = true
+				games = append(games, g)
+			}
+		}
+	}
+
+	return scheduleOf(games), nil
+}
+
+// scheduleOf returns the games as they are on the schedule, in order of
+// when they start.
+func scheduleOf(games []*model.Game) []model.ScheduledGame {
+	schedule := make([]model.ScheduledGame, len(games))
+	for i, g := range games {
+		schedule[i] = g.GetScheduledGame()
+	}
+	sort.Slice(schedule, func(i, j int) bool {
+		return schedule[i].StartsAt.Before(schedule[j].StartsAt)
+	})
+	return schedule
+}
@@ -0,0 +1,297 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"git.sr.ht/~loges/teammate/internal/team/domain/fixtures"
+	"git.sr.ht/~loges/teammate/internal/team/domain/model"
+	"git.sr.ht/~loges/teammate/internal/team/domain/repository"
+	"github.com/google/uuid"
+	"github.com/matryer/is"
+)
+
+func TestRosterService_Fixtures(t *testing.T) {
+	startsOn := time.Date(2023, time.August, 1, 0, 0, 0, 0, time.UTC)
+	thirdGroup := &entity.Group{ID: uuid.MustParse("bcbe93f8-c952-11ed-afa1-0242ac120002"), Name: "Lions"}
+	venue := uuid.MustParse("3e1c8a4e-6f1a-11ee-b962-0242ac120002")
+	location := model.Location{VenueID: venue, Field: "North Field"}
+	var slots []fixtures.Slot
+	for i := 0; i < 3; i++ {
+		startsAt := startsOn.AddDate(0, 1, 7*i).Add(10 * time.Hour)
+		slots = append(slots, fixtures.Slot{StartsAt: startsAt, EndsAt: startsAt.Add(90 * time.Minute), Location: location})
+	}
+	plan := fixtures.Plan{Teams: []*entity.Group{{ID: exampleGroup.ID}, {ID: anotherGroup.ID}, {ID: thirdGroup.ID}}, Slots: slots}
+	setup := func(is *is.I) *RosterService {
+		s, _ := NewRosterService(WithAuthorizer(allowAll{}))
+		is.NoErr(s.AddSeason(exampleSeason, "2023/24", startsOn, startsOn.AddDate(1, 0, 0)))
+		is.NoErr(s.AddTeam(exampleGroup))
+		is.NoErr(s.AddTeam(anotherGroup))
+		is.NoErr(s.AddTeam(thirdGroup))
+		is.NoErr(s.AddVenue(venue, "Riverside Park", model.Address{}))
+		is.NoErr(s.AddVenueField(venue, model.Field{Name: "North Field", Surface: "grass"}))
+		return s
+	}
+
+	t.Run("Preview then schedule the games", func(t *testing.T) {
+		is := is.New(t)
+		s := setup(is)
+
+		preview, err := s.PreviewFixtures(exampleSeason, plan)
+		is.NoErr(err)
+		is.Equal(len(preview.Fixtures), 3)
+		is.Equal(len(preview.Byes), 3)
+		schedule, _ := s.GetSchedule(exampleGroup)
+		is.Equal(len(schedule), 0) // previewing schedules nothing
+
+		is.NoErr(s.ScheduleFixtures(exampleSeason, preview))
+
+		schedule, err = s.GetSchedule(exampleGroup)
+		is.NoErr(err)
+		is.Equal(len(schedule), 2)
+		is.True(schedule[0].StartsAt.Before(schedule[1].StartsAt))
+		is.Equal(schedule[0].SeasonID, exampleSeason)
+		is.Equal(schedule[0].Location, model.Location{VenueID: venue, VenueName: "Riverside Park", Field: "North Field"})
+		for _, g := range schedule {
+			is.True(g.Home.Name != "" && g.Away.Name != "") // names come from the repository
+		}
+	})
+
+	t.Run("Plan can't be previewed", func(t *testing.T) {
+		testCases := []struct {
+			test        string
+			season      uuid.UUID
+			plan        fixtures.Plan
+			prepare     func(*RosterService) error
+			expectedErr error
+		}{
+			{"Season not found", uuid.New(), plan, nil, repository.ErrSeasonNotFound},
+			{"Season is closed", exampleSeason, plan, func(s *RosterService) error { return s.CloseSeason(exampleSeason) }, model.ErrSeasonClosed},
+			{"Slot is outside of the season", exampleSeason, fixtures.Plan{Teams: plan.Teams, Slots: []fixtures.Slot{{StartsAt: startsOn.AddDate(-1, 0, 0)}}}, nil, model.ErrOutsideOfSeason},
+			{"Team not found", exampleSeason, fixtures.Plan{Teams: []*entity.Group{exampleGroup, {ID: uuid.New()}}, Slots: slots}, nil, repository.ErrTeamNotFound},
+			{"Team is not active", exampleSeason, plan, func(s *RosterService) error { return s.DeactivateTeam(thirdGroup) }, model.ErrTeamNotActive},
+			{"Not enough slots", exampleSeason, fixtures.Plan{Teams: plan.Teams, Slots: slots[:1]}, nil, fixtures.ErrNotEnoughSlots},
+			{"Venue not found", exampleSeason, fixtures.Plan{Teams: plan.Teams, Slots: []fixtures.Slot{withLocation(slots[0], model.Location{VenueID: uuid.New()}), slots[1], slots[2]}}, nil, repository.ErrVenueNotFound},
+			{"Field not found", exampleSeason, fixtures.Plan{Teams: plan.Teams, Slots: []fixtures.Slot{withLocation(slots[0], model.Location{VenueID: venue, Field: "South Field"}), slots[1], slots[2]}}, nil, model.ErrFieldNotFound},
+			{"Venue is closed", exampleSeason, plan, func(s *RosterService) error {
+				return s.SetVenueOpeningHours(venue, time.Saturday, model.OpeningHours{Opens: 9 * time.Hour, Closes: 17 * time.Hour})
+			}, model.ErrVenueClosed},
+			{"Field is already booked", exampleSeason, plan, func(s *RosterService) error {
+				preview, _ := s.PreviewFixtures(exampleSeason, plan)
+				return s.ScheduleFixtures(exampleSeason, preview)
+			}, model.ErrDoubleBooked},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.test, func(t *testing.T) {
+				is := is.New(t)
+				s := setup(is)
+				if tc.prepare != nil {
+					is.NoErr(tc.prepare(s))
+				}
+
+				_, err := s.PreviewFixtures(tc.season, tc.plan)
+
+				is.Equal(err, tc.expectedErr)
+			})
+		}
+	})
+
+	t.Run("Nothing is scheduled if a game can't be", func(t *testing.T) {
+		is := is.New(t)
+		s := setup(is)
+		preview, err := s.PreviewFixtures(exampleSeason, plan)
+		is.NoErr(err)
+		is.NoErr(s.DeactivateTeam(thirdGroup))
+
+		err = s.ScheduleFixtures(exampleSeason, preview)
+
+		is.Equal(err, model.ErrTeamNotActive)
+		schedule, _ := s.GetSchedule(exampleGroup)
+		is.Equal(len(schedule), 0)
+	})
+
+	t.Run("Nothing is scheduled if a field is double-booked", func(t *testing.T) {
+		is := is.New(t)
+		s := setup(is)
+		preview, err := s.PreviewFixtures(exampleSeason, plan)
+		is.NoErr(err)
+		reversed, err := s.PreviewFixtures(exampleSeason, fixtures.Plan{Teams: []*entity.Group{plan.Teams[2], plan.Teams[1], plan.Teams[0]}, Slots: slots})
+		is.NoErr(err)
+		is.NoErr(s.ScheduleFixtures(exampleSeason, preview))
+
+		err = s.ScheduleFixtures(exampleSeason, reversed)
+
+		is.Equal(err, model.ErrDoubleBooked)
+		games, _ := s.games.GetBySeason(exampleSeason)
+		is.Equal(len(games), 3)
+	})
+
+	t.Run("Scheduling again finishes a failed schedule", func(t *testing.T) {
+		is := is.New(t)
+		s := setup(is)
+		preview, err := s.PreviewFixtures(exampleSeason, plan)
+		is.NoErr(err)
+		games := s.games
+		s.games = &failingGames{GameRepository: games, adds: 1}
+
+		is.Equal(s.ScheduleFixtures(exampleSeason, preview), errUnavailable)
+		scheduled, _ := games.GetBySeason(exampleSeason)
+		is.Equal(len(scheduled), 1)
+
+		s.games = games
+		is.NoErr(s.ScheduleFixtures(exampleSeason, preview))
+		scheduled, _ = games.GetBySeason(exampleSeason)
+		is.Equal(len(scheduled), 3)
+		is.NoErr(s.ScheduleFixtures(exampleSeason, preview))
+		scheduled, _ = games.GetBySeason(exampleSeason)
+		is.Equal(len(scheduled), 3)
+	})
+
+	t.Run("Concurrent schedules don't double-book a field", func(t *testing.T) {
+		is := is.New(t)
+		s := setup(is)
+		preview, err := s.PreviewFixtures(exampleSeason, plan)
+		is.NoErr(err)
+		reversed, err := s.PreviewFixtures(exampleSeason, fixtures.Plan{Teams: []*entity.Group{plan.Teams[2], plan.Teams[1], plan.Teams[0]}, Slots: slots})
+		is.NoErr(err)
+		s.games = &slowGames{GameRepository: s.games}
+
+		errs := make(chan error, 2)
+		for _, schedule := range []*fixtures.Schedule{preview, reversed} {
+			go func(schedule *fixtures.Schedule) {
+				errs <- s.As(examplePerson).ScheduleFixtures(exampleSeason, schedule)
+			}(schedule)
+		}
+
+		failed := 0
+		for i := 0; i < 2; i++ {
+			if err := <-errs; err != nil {
+				is.Equal(err, model.ErrDoubleBooked)
+				failed++
+			}
+		}
+		is.Equal(failed, 1)
+		games, _ := s.games.GetBySeason(exampleSeason)
+		is.Equal(len(games), 3)
+	})
+
+	t.Run("Schedule and standings are kept per season", func(t *testing.T) {
+		is := is.New(t)
+		s := setup(is)
+		nextSeason := uuid.New()
+		is.NoErr(s.AddSeason(nextSeason, "2024/25", startsOn.AddDate(1, 0, 0), startsOn.AddDate(2, 0, 0)))
+		preview, err := s.PreviewFixtures(exampleSeason, plan)
+		is.NoErr(err)
+		is.NoErr(s.ScheduleFixtures(exampleSeason, preview))
+		schedule, _ := s.GetSeasonSchedule(exampleGroup, exampleSeason)
+		game := schedule[0]
+
+		is.NoErr(s.RecordGameResult(game.ID, 3, 1))
+
+		standings, err := s.GetStandings(exampleSeason)
+		is.NoErr(err)
+		is.Equal(len(standings), 3)
+		is.Equal(standings[0].Team.ID, game.Home.ID)
+		stats, err := s.GetTeamStats(game.Away, exampleSeason)
+		is.NoErr(err)
+		is.Equal(stats.Lost, 1)
+		is.Equal(stats.Against, 3)
+
+		schedule, err = s.GetSeasonSchedule(exampleGroup, nextSeason)
+		is.NoErr(err)
+		is.Equal(len(schedule), 0)
+		standings, _ = s.GetStandings(nextSeason)
+		is.Equal(len(standings), 0)
+		stats, err = s.GetTeamStats(game.Away, nextSeason)
+		is.NoErr(err)
+		is.Equal(stats.Played, 0)
+		_, err = s.GetStandings(uuid.New())
+		is.Equal(err, repository.ErrSeasonNotFound)
+		is.Equal(s.RecordGameResult(game.ID, -1, 0), model.ErrInvalidResult)
+	})
+
+	t.Run("Scheduling is authorized", func(t *testing.T) {
+		is := is.New(t)
+		s := setup(is)
+		preview, _ := s.PreviewFixtures(exampleSeason, plan)
+		s.authorizer = coachAuthorizer{}
+
+		_, err := s.As(examplePerson).PreviewFixtures(exampleSeason, plan)
+		is.Equal(err, errDenied)
+		is.Equal(s.As(examplePerson).ScheduleFixtures(exampleSeason, preview), errDenied)
+	})
+
+	t.Run("Only the managers of every team schedule and record", func(t *testing.T) {
+		is := is.New(t)
+		s := setup(is)
+		preview, _ := s.PreviewFixtures(exampleSeason, plan)
+		manager := s.As(examplePerson)
+		manager.authorizer = managerAuthorizer{}
+		pair := fixtures.Plan{Teams: plan.Teams[:2], Slots: slots}
+
+		_, err := manager.PreviewFixtures(exampleSeason, plan)
+		is.Equal(err, errDenied) // the third team is managed by someone else
+		is.Equal(manager.ScheduleFixtures(exampleSeason, preview), errDenied)
+		games, _ := s.games.GetBySeason(exampleSeason)
+		is.Equal(len(games), 0)
+
+		_, err = manager.PreviewFixtures(exampleSeason, pair)
+		is.NoErr(err)
+
+		is.NoErr(s.ScheduleFixtures(exampleSeason, preview))
+		games, _ = s.games.GetBySeason(exampleSeason)
+		for _, g := range games {
+			played := g.GetScheduledGame()
+			err = manager.RecordGameResult(g.GetID(), 1, 0)
+			if played.Home.ID == thirdGroup.ID || played.Away.ID == thirdGroup.ID {
+				is.Equal(err, errDenied)
+			} else {
+				is.NoErr(err)
+			}
+		}
+	})
+}
+
+// managerAuthorizer only allows the example person to manage the example
+// and another team.
+type managerAuthorizer struct{}
+
+func (managerAuthorizer) Authorize(actor *entity.Person, p entity.Permission, g *entity.Group) error {
+	if actor != examplePerson || p != entity.PermissionManageTeams || g == nil || (g.ID != exampleGroup.ID && g.ID != anotherGroup.ID) {
+		return errDenied
+	}
+	return nil
+}
+
+// failingGames fails to add the games after the first adds.
+type failingGames struct {
+	repository.GameRepository
+	adds int
+}
+
+func (r *failingGames) Add(g *model.Game) error {
+	if r.adds == 0 {
+		return errUnavailable
+	}
+	r.adds--
+	return r.GameRepository.Add(g)
+}
+
+// slowGames takes a while to look up the games at a venue, so a schedule
+// checking the bookings of a field leaves time for another to book it.
+type slowGames struct {
+	repository.GameRepository
+}
+
+func (r *slowGames) GetByVenue(id uuid.UUID) ([]*model.Game, error) {
+	games, err := r.GameRepository.GetByVenue(id)
+	time.Sleep(10 * time.Millisecond)
+	return games, err
+}
+
+func withLocation(s fixtures.Slot, l model.Location) fixtures.Slot {
+	s.Location = l
+	return s
+}
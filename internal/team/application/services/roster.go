@@ -2,14 +2,42 @@ package services
 
 import (
 	"errors"
+	"sync"
+	"time"
 
 	"git.sr.ht/~loges/teammate/internal/entity"
+	"git.sr.ht/~loges/teammate/internal/eventstore"
 	"git.sr.ht/~loges/teammate/internal/team/domain/model"
 	"git.sr.ht/~loges/teammate/internal/team/domain/repository"
+	"git.sr.ht/~loges/teammate/internal/team/domain/rules"
 	"git.sr.ht/~loges/teammate/internal/team/infrastructure/memory"
+	"git.sr.ht/~loges/teammate/internal/tenantid"
+	"github.com/google/uuid"
 )
 
-var ErrInvalidRosterConfig = errors.New("services: invalid roster configuration")
+var (
+	ErrInvalidRosterConfig = errors.New("services: invalid roster configuration")
+	ErrNoAuthorizer        = errors.New("services: no authorizer is configured")
+)
+
+// Authorizer decides whether an actor is allowed to act within a group.
+type Authorizer interface {
+	Authorize(actor *entity.Person, p entity.Permission, g *entity.Group) error
+}
+
+// denyAll is the authorizer used when none is configured, so no action is
+// allowed until one is.
+type denyAll struct{}
+
+func (denyAll) Authorize(*entity.Person, entity.Permission, *entity.Group) error {
+	return ErrNoAuthorizer
+}
+
+// PlayerEraser erases the personal data of a player held by another
+// context.
+type PlayerEraser interface {
+	ErasePlayer(player *entity.Person) error
+}
 
 // RosterConfigs defines the configurations to intialize the service with.
 var RosterConfigs = []RosterConfiguration{
@@ -19,36 +47,110 @@ var RosterConfigs = []RosterConfiguration{
 // RosterConfiguration is a function that modifies the service.
 type RosterConfiguration func(s *RosterService) error
 
-// WithMemoryRepositories attaches in memory repostories to service.
-func WithMemoryRepositories() RosterConfiguration {
+// WithMemoryRepositories attaches in memory repostories to service. The
+// repositories share a key store, so erasing a player erases the player
+// from team events too.
+func WithMemoryRepositories(cfgs ...memory.Configuration) RosterConfiguration {
 	return func(s *RosterService) error {
-		s.players = memory.NewMemoryPlayerRepository()
-		s.teams = memory.NewMemoryTeamRepository()
+		cfgs := append([]memory.Configuration{memory.WithKeyStore(eventstore.NewMemoryKeyStore())}, cfgs...)
+		s.keys = memory.KeyStore(cfgs...)
+		s.tenant = memory.Tenant(cfgs...)
+		s.players = memory.NewMemoryPlayerRepository(cfgs...)
+		s.teams = memory.NewMemoryTeamRepository(cfgs...)
+		s.seasons = memory.NewMemorySeasonRepository(cfgs...)
+		s.games = memory.NewMemoryGameRepository(cfgs...)
+		s.tournaments = memory.NewMemoryTournamentRepository(cfgs...)
+		s.venues = memory.NewMemoryVenueRepository(cfgs...)
+		return nil
+	}
+}
+
+// WithPlayerEraser erases the personal data of players held by another
+// context whenever a player is erased.
+func WithPlayerEraser(e PlayerEraser) RosterConfiguration {
+	return func(s *RosterService) error {
+		s.eraser = e
+		return nil
+	}
+}
+
+// WithAuthorizer consults the authorizer before every roster operation.
+func WithAuthorizer(a Authorizer) RosterConfiguration {
+	return func(s *RosterService) error {
+		s.authorizer = a
+		return nil
+	}
+}
+
+// WithRules checks the rules before a player is assigned to a team,
+// instead of the default rules.
+func WithRules(r ...rules.Rule) RosterConfiguration {
+	return func(s *RosterService) error {
+		s.rules = r
+		return nil
+	}
+}
+
+// WithClock sets the function the service reads the current time from.
+func WithClock(now func() time.Time) RosterConfiguration {
+	return func(s *RosterService) error {
+		s.now = now
 		return nil
 	}
 }
 
 // RosterService is a implementation of the RosterService.
 type RosterService struct {
-	players repository.PlayerRepository
-	teams   repository.TeamRepository
+	players     repository.PlayerRepository
+	teams       repository.TeamRepository
+	seasons     repository.SeasonRepository
+	games       repository.GameRepository
+	tournaments repository.TournamentRepository
+	venues      repository.VenueRepository
+	schedule    Schedule
+	keys        eventstore.KeyStore
+	tenant      tenantid.ID
+	eraser      PlayerEraser
+	authorizer  Authorizer
+	actor       *entity.Person
+	rules       []rules.Rule
+	now         func() time.Time
+	// booking is held while games are checked against the fields booked
+	// and stored, so no two schedules book the same field. Copies of the
+	// service share it.
+	booking *sync.Mutex
 }
 
-// NewRosterService accepts configs and returns a new service.
-func NewRosterService() (*RosterService, error) {
-	s := &RosterService{}
+// NewRosterService accepts configs and returns a new service. The given
+// configs are applied after the default RosterConfigs. The schedule of
+// the teams is made of the games they play.
+func NewRosterService(cfgs ...RosterConfiguration) (*RosterService, error) {
+	s := &RosterService{authorizer: denyAll{}, rules: rules.Defaults, now: time.Now, booking: &sync.Mutex{}}
 
-	for _, cfg := range RosterConfigs {
+	configs := append([]RosterConfiguration{}, RosterConfigs...)
+	for _, cfg := range append(configs, cfgs...) {
 		err := cfg(s)
 		if err != nil {
 			return nil, err
 		}
 	}
+	s.schedule = gameSchedule{games: s.games, now: s.now}
 	return s, nil
 }
 
+// As returns a copy of the service acting on behalf of the actor.
+func (s *RosterService) As(actor *entity.Person) *RosterService {
+	c := *s
+	c.actor = actor
+	return &c
+}
+
 // AddPlayer initializes a new player to the repository if valid.
 func (s *RosterService) AddPlayer(player *entity.Person) error {
+	if err := s.authorizer.Authorize(s.actor, entity.PermissionManagePlayers, nil); err != nil {
+		return err
+	}
+
 	p, err := model.NewPlayer(player)
 	if err != nil {
 		return err
@@ -59,6 +161,10 @@ func (s *RosterService) AddPlayer(player *entity.Person) error {
 
 // AddTeam initializes a new team to the repository if valid.
 func (s *RosterService) AddTeam(team *entity.Group) error {
+	if err := s.authorizer.Authorize(s.actor, entity.PermissionManageTeams, team); err != nil {
+		return err
+	}
+
 	t, err := model.NewTeam(team)
 	if err != nil {
 		return err
@@ -67,52 +173,883 @@ func (s *RosterService) AddTeam(team *entity.Group) error {
 	return s.teams.Add(t)
 }
 
-// AssignPlayerToTeam assigns player to team's roster.
-func (s *RosterService) AssignPlayerToTeam(team *entity.Group, player *entity.Person) error {
+// AssignPlayerToTeam assigns player to team's roster wearing the jersey
+// number, which can be model.NoJerseyNumber.
+func (s *RosterService) AssignPlayerToTeam(team *entity.Group, player *entity.Person, number int) error {
+	if err := s.authorizer.Authorize(s.actor, entity.PermissionManageRoster, team); err != nil {
+		return err
+	}
+
+	t, err := s.openTeam(team)
+	if err != nil {
+		return err
+	}
+	p, err := s.players.Get(player)
+	if err != nil {
+		return err
+	}
+	c, err := s.candidate(t, p)
+	if err != nil {
+		return err
+	}
+	if err = rules.Evaluate(s.rules, c); err != nil {
+		return err
+	}
+	err = t.AssignPlayer(p, number)
+	if err != nil {
+		return err
+	}
+	err = p.AssignTeam(t, s.now())
+	if err != nil {
+		return err
+	}
+
+	if err = s.teams.Update(t); err != nil {
+		return err
+	}
+
+	return s.players.Update(p)
+}
+
+// candidate loads the team's season and the player's teams, which the
+// rules judge the player by.
+func (s *RosterService) candidate(t *model.Team, p *model.Player) (rules.Candidate, error) {
+	c := rules.Candidate{Team: t, Player: p, At: s.now()}
+
+	if id := t.GetSeason(); id != model.NoSeason {
+		season, err := s.seasons.Get(id)
+		if err != nil {
+			return c, err
+		}
+		c.Season = season
+	}
+	for _, team := range p.GetTeams() {
+		other, err := s.teams.Get(team)
+		if err != nil {
+			return c, err
+		}
+		c.Teams = append(c.Teams, other)
+	}
+
+	return c, nil
+}
+
+// UnassignPlayerToTeam unassigns player from team's roster for the reason.
+func (s *RosterService) UnassignPlayerFromTeam(team *entity.Group, player *entity.Person, reason model.LeaveReason) error {
+	if err := s.authorizer.Authorize(s.actor, entity.PermissionManageRoster, team); err != nil {
+		return err
+	}
+
+	t, err := s.openTeam(team)
+	if err != nil {
+		return err
+	}
+	p, err := s.players.Get(player)
+	if err != nil {
+		return err
+	}
+	err = t.UnassignPlayer(p)
+	if err != nil {
+		return err
+	}
+	err = p.UnassignTeam(t, reason, s.now())
+	if err != nil {
+		return err
+	}
+
+	if err = s.teams.Update(t); err != nil {
+		return err
+	}
+
+	return s.players.Update(p)
+}
+
+// ChangeJerseyNumber changes the jersey number of a player on the team's
+// roster.
+func (s *RosterService) ChangeJerseyNumber(team *entity.Group, player *entity.Person, number int) error {
+	if err := s.authorizer.Authorize(s.actor, entity.PermissionManageRoster, team); err != nil {
+		return err
+	}
+
+	t, err := s.openTeam(team)
+	if err != nil {
+		return err
+	}
+	p, err := s.players.Get(player)
+	if err != nil {
+		return err
+	}
+	if err = t.ChangeJerseyNumber(p, number); err != nil {
+		return err
+	}
+
+	return s.teams.Update(t)
+}
+
+// AssignPlayerPositions records the primary and secondary positions of a
+// player on the team's roster.
+func (s *RosterService) AssignPlayerPositions(team *entity.Group, player *entity.Person, primary model.Position, secondary ...model.Position) error {
+	if err := s.authorizer.Authorize(s.actor, entity.PermissionManageRoster, team); err != nil {
+		return err
+	}
+
+	t, err := s.openTeam(team)
+	if err != nil {
+		return err
+	}
+	p, err := s.players.Get(player)
+	if err != nil {
+		return err
+	}
+	if err = t.AssignPositions(p, primary, secondary...); err != nil {
+		return err
+	}
+
+	return s.teams.Update(t)
+}
+
+// ChangeDepthChart orders the players picked for the position on the team.
+func (s *RosterService) ChangeDepthChart(team *entity.Group, position model.Position, players []*entity.Person) error {
+	if err := s.authorizer.Authorize(s.actor, entity.PermissionManageRoster, team); err != nil {
+		return err
+	}
+
+	t, err := s.openTeam(team)
+	if err != nil {
+		return err
+	}
+
+	ids := make([]uuid.UUID, len(players))
+	for i, p := range players {
+		ids[i] = p.ID
+	}
+	if err = t.ChangeDepthChart(position, ids); err != nil {
+		return err
+	}
+
+	return s.teams.Update(t)
+}
+
+// GetDepthChart returns the players picked for the position on the team,
+// in order.
+func (s *RosterService) GetDepthChart(team *entity.Group, position model.Position) ([]*entity.Person, error) {
+	if err := s.authorizer.Authorize(s.actor, entity.PermissionViewRoster, team); err != nil {
+		return nil, err
+	}
+
 	t, err := s.teams.Get(team)
 	if err != nil {
+		return nil, err
+	}
+
+	players := make(map[uuid.UUID]*entity.Person)
+	for _, p := range t.GetPlayers() {
+		players[p.ID] = p
+	}
+
+	var chart []*entity.Person
+	for _, id := range t.GetDepthChart(position).PlayerIds {
+		chart = append(chart, players[id])
+	}
+	return chart, nil
+}
+
+// AddStaffMember adds a person to the team staff in the role.
+func (s *RosterService) AddStaffMember(team *entity.Group, person *entity.Person, role model.StaffRole) error {
+	if err := s.authorizer.Authorize(s.actor, entity.PermissionManageTeams, team); err != nil {
+		return err
+	}
+
+	t, err := s.openTeam(team)
+	if err != nil {
+		return err
+	}
+	if err = t.AddStaffMember(person, role); err != nil {
+		return err
+	}
+
+	return s.teams.Update(t)
+}
+
+// RemoveStaffMember removes a person from the team staff.
+func (s *RosterService) RemoveStaffMember(team *entity.Group, person *entity.Person) error {
+	if err := s.authorizer.Authorize(s.actor, entity.PermissionManageTeams, team); err != nil {
+		return err
+	}
+
+	t, err := s.openTeam(team)
+	if err != nil {
+		return err
+	}
+	if err = t.RemoveStaffMember(person); err != nil {
+		return err
+	}
+
+	return s.teams.Update(t)
+}
+
+// GetStaff returns the staff of the team.
+func (s *RosterService) GetStaff(team *entity.Group) ([]model.StaffMember, error) {
+	if err := s.authorizer.Authorize(s.actor, entity.PermissionViewRoster, team); err != nil {
+		return nil, err
+	}
+
+	t, err := s.teams.Get(team)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.GetStaff(), nil
+}
+
+// DesignatePlayer gives a player on the team's roster the designation.
+func (s *RosterService) DesignatePlayer(team *entity.Group, player *entity.Person, d model.Designation) error {
+	if err := s.authorizer.Authorize(s.actor, entity.PermissionManageRoster, team); err != nil {
+		return err
+	}
+
+	t, err := s.openTeam(team)
+	if err != nil {
+		return err
+	}
+	p, err := s.players.Get(player)
+	if err != nil {
+		return err
+	}
+	if err = t.Designate(p, d); err != nil {
+		return err
+	}
+
+	return s.teams.Update(t)
+}
+
+// RemovePlayerDesignation removes the designation of a player on the
+// team's roster.
+func (s *RosterService) RemovePlayerDesignation(team *entity.Group, player *entity.Person) error {
+	if err := s.authorizer.Authorize(s.actor, entity.PermissionManageRoster, team); err != nil {
+		return err
+	}
+
+	t, err := s.openTeam(team)
+	if err != nil {
+		return err
+	}
+	p, err := s.players.Get(player)
+	if err != nil {
+		return err
+	}
+	if err = t.RemoveDesignation(p); err != nil {
+		return err
+	}
+
+	return s.teams.Update(t)
+}
+
+// ChangeTeamRules changes who is eligible for the team's roster.
+func (s *RosterService) ChangeTeamRules(team *entity.Group, r model.RosterRules) error {
+	if err := s.authorizer.Authorize(s.actor, entity.PermissionManageTeams, team); err != nil {
+		return err
+	}
+
+	t, err := s.openTeam(team)
+	if err != nil {
+		return err
+	}
+	if err = t.ChangeRules(r); err != nil {
+		return err
+	}
+
+	return s.teams.Update(t)
+}
+
+// ChangePlayerDetails records the date of birth and gender of the player.
+// Guardians of the player may change them on the player's behalf.
+func (s *RosterService) ChangePlayerDetails(player *entity.Person, birth time.Time, g model.Gender) error {
+	p, err := s.managedPlayer(player)
+	if err != nil {
+		return err
+	}
+	if err = p.ChangeDetails(birth, g); err != nil {
+		return err
+	}
+
+	return s.players.Update(p)
+}
+
+// ChangePlayerContactDetails records the phone number and address of the
+// player. Guardians of the player may change them on the player's behalf.
+func (s *RosterService) ChangePlayerContactDetails(player *entity.Person, phone string, a model.Address) error {
+	p, err := s.managedPlayer(player)
+	if err != nil {
+		return err
+	}
+	if err = p.ChangeContactDetails(phone, a); err != nil {
+		return err
+	}
+
+	return s.players.Update(p)
+}
+
+// ChangePlayerEmergencyContacts replaces the emergency contacts of the
+// player, which guardians of the player may do on the player's behalf.
+func (s *RosterService) ChangePlayerEmergencyContacts(player *entity.Person, contacts []model.EmergencyContact) error {
+	p, err := s.managedPlayer(player)
+	if err != nil {
+		return err
+	}
+	if err = p.ChangeEmergencyContacts(contacts); err != nil {
+		return err
+	}
+
+	return s.players.Update(p)
+}
+
+// ChangePlayerMedicalNotes replaces the medical notes of the player, which
+// guardians of the player may do on the player's behalf.
+func (s *RosterService) ChangePlayerMedicalNotes(player *entity.Person, notes string) error {
+	p, err := s.managedPlayer(player)
+	if err != nil {
+		return err
+	}
+	if err = p.ChangeMedicalNotes(notes); err != nil {
 		return err
 	}
+
+	return s.players.Update(p)
+}
+
+// GetPlayerProfile returns the profile of a player on the team. Sensitive
+// fields are left out unless the actor may view sensitive player data of
+// the team or is a guardian of the player.
+func (s *RosterService) GetPlayerProfile(team *entity.Group, player *entity.Person) (model.Profile, error) {
+	if err := s.authorizer.Authorize(s.actor, entity.PermissionViewRoster, team); err != nil {
+		if p, gErr := s.players.Get(player); gErr == nil && s.isGuardian(p) {
+			return p.GetProfile(), nil
+		}
+		return model.Profile{}, err
+	}
+
+	t, err := s.teams.Get(team)
+	if err != nil {
+		return model.Profile{}, err
+	}
+	rostered := false
+	for _, p := range t.GetPlayers() {
+		rostered = rostered || p.ID == player.ID
+	}
+	if !rostered {
+		return model.Profile{}, model.ErrPlayerNotRostered
+	}
+
 	p, err := s.players.Get(player)
 	if err != nil {
+		return model.Profile{}, err
+	}
+
+	profile := p.GetProfile()
+	if !s.isGuardian(p) && s.authorizer.Authorize(s.actor, entity.PermissionViewSensitivePlayerData, team) != nil {
+		profile = profile.Redact()
+	}
+	return profile, nil
+}
+
+// AddGuardian lets the user act on behalf of the player.
+func (s *RosterService) AddGuardian(player *entity.Person, user *entity.Person, relationship string) error {
+	if err := s.authorizer.Authorize(s.actor, entity.PermissionManagePlayers, nil); err != nil {
 		return err
 	}
-	err = t.AssignPlayer(p)
+
+	p, err := s.players.Get(player)
 	if err != nil {
 		return err
 	}
-	err = p.AssignTeam(t)
+	if err = p.AddGuardian(user, relationship); err != nil {
+		return err
+	}
+
+	return s.players.Update(p)
+}
+
+// RemoveGuardian stops the user from acting on behalf of the player.
+func (s *RosterService) RemoveGuardian(player *entity.Person, user *entity.Person) error {
+	if err := s.authorizer.Authorize(s.actor, entity.PermissionManagePlayers, nil); err != nil {
+		return err
+	}
+
+	p, err := s.players.Get(player)
 	if err != nil {
 		return err
 	}
+	if err = p.RemoveGuardian(user); err != nil {
+		return err
+	}
 
-	s.teams.Update(t)
-	s.players.Update(p)
+	return s.players.Update(p)
+}
+
+// GroupSiblings puts the players in one household, so they appear under
+// one family account. They join the household of the sibling, else the
+// household of the player, else a new one. Guardians of both players may
+// group them, as may those allowed to manage players.
+func (s *RosterService) GroupSiblings(player, sibling *entity.Person) error {
+	authErr := s.authorizer.Authorize(s.actor, entity.PermissionManagePlayers, nil)
+	p, err := s.players.Get(player)
+	if err != nil && authErr == nil {
+		return err
+	}
+	other, oErr := s.players.Get(sibling)
+	if oErr != nil && authErr == nil {
+		return oErr
+	}
+	if authErr != nil && (err != nil || oErr != nil || !s.isGuardian(p) || !s.isGuardian(other)) {
+		return authErr
+	}
+	if p.GetID() == other.GetID() {
+		return model.ErrPlayerUpdateFailed
+	}
+
+	household := other.GetHouseholdID()
+	if household == model.NoHousehold {
+		household = p.GetHouseholdID()
+	}
+	if household == model.NoHousehold {
+		household = uuid.New()
+	}
+
+	var moved []*model.Player
+	for _, member := range []*model.Player{p, other} {
+		if member.GetHouseholdID() == household {
+			continue
+		}
+		if err = member.ChangeHousehold(household); err != nil {
+			return err
+		}
+		moved = append(moved, member)
+	}
+	if len(moved) == 0 {
+		return model.ErrPlayerUpdateFailed
+	}
 
+	for _, member := range moved {
+		if err = s.players.Update(member); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-// UnassignPlayerToTeam unassigns player from team's roster.
-func (s *RosterService) UnassignPlayerFromTeam(team *entity.Group, player *entity.Person) error {
+// GetFamily returns the players the user is a guardian of, together with
+// their siblings in the same households, and the teams they are on.
+func (s *RosterService) GetFamily(user *entity.Person) ([]model.FamilyMember, error) {
+	children, err := s.players.GetByGuardian(user)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	family := []model.FamilyMember{}
+	add := func(p *model.Player) {
+		if seen[p.GetID()] {
+			return
+		}
+		seen[p.GetID()] = true
+		family = append(family, model.FamilyMember{
+			Player: &entity.Person{ID: p.GetID(), Name: p.GetName()},
+			Teams:  p.GetTeams(),
+		})
+	}
+
+	for _, child := range children {
+		add(child)
+		siblings, err := s.players.GetByHousehold(child.GetHouseholdID())
+		if err != nil {
+			return nil, err
+		}
+		for _, sibling := range siblings {
+			add(sibling)
+		}
+	}
+	return family, nil
+}
+
+// ErasePlayer erases the personal data of the player. The player keeps the
+// roster spots and history, but reads as an erased person. The copies
+// held by the player eraser are erased first, so a failed erasure can be
+// retried.
+func (s *RosterService) ErasePlayer(player *entity.Person) error {
+	if err := s.authorizer.Authorize(s.actor, entity.PermissionManagePlayers, nil); err != nil {
+		return err
+	}
+
+	p, err := s.players.Get(player)
+	if err != nil {
+		return err
+	}
+	if s.eraser != nil {
+		if err = s.eraser.ErasePlayer(player); err != nil {
+			return err
+		}
+	}
+	if err = p.Erase(); err != nil {
+		return err
+	}
+
+	return s.players.Erase(p)
+}
+
+// EraseUser erases the personal data of the user copied into team events,
+// such as the user's name on claimed players, guardianships and staff.
+// The access context calls it when the user is erased. The teams record
+// that their staff member is erased before the user's key is, and teams
+// already recording it are skipped, so a failed erasure can be retried.
+func (s *RosterService) EraseUser(user *entity.Person) error {
+	if err := s.authorizer.Authorize(s.actor, entity.PermissionManagePlayers, nil); err != nil {
+		return err
+	}
+
+	teams, err := s.teams.GetByStaffMember(user)
+	if err != nil {
+		return err
+	}
+	for _, t := range teams {
+		if t.EraseStaffMember(user) != nil {
+			continue // erased by an earlier attempt
+		}
+		if err = s.teams.Update(t); err != nil {
+			return err
+		}
+	}
+
+	if s.keys == nil {
+		return nil
+	}
+	return s.keys.Erase(user.ID)
+}
+
+// ChangeTeamSport changes the sport the team plays.
+func (s *RosterService) ChangeTeamSport(team *entity.Group, sport model.Sport) error {
+	if err := s.authorizer.Authorize(s.actor, entity.PermissionManageTeams, team); err != nil {
+		return err
+	}
+
 	t, err := s.teams.Get(team)
 	if err != nil {
 		return err
 	}
+	if err = t.ChangeSport(sport); err != nil {
+		return err
+	}
+
+	return s.teams.Update(t)
+}
+
+// GetRoster returns the players on the team's roster and their jersey
+// numbers.
+func (s *RosterService) GetRoster(team *entity.Group) ([]model.RosterSpot, error) {
+	if err := s.authorizer.Authorize(s.actor, entity.PermissionViewRoster, team); err != nil {
+		return nil, err
+	}
+
+	t, err := s.teams.Get(team)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.GetRoster(), nil
+}
+
+// InvitePlayer issues an invite code a user can claim the player profile with.
+func (s *RosterService) InvitePlayer(player *entity.Person) (string, error) {
+	if err := s.authorizer.Authorize(s.actor, entity.PermissionManagePlayers, nil); err != nil {
+		return "", err
+	}
+
 	p, err := s.players.Get(player)
 	if err != nil {
+		return "", err
+	}
+
+	code := uuid.NewString()
+	if err = p.Invite(code); err != nil {
+		return "", err
+	}
+
+	if err = s.players.Update(p); err != nil {
+		return "", err
+	}
+
+	return code, nil
+}
+
+// ClaimPlayer links the user to the player profile the invite code was
+// issued for. Claiming again with the same code and user returns the same
+// player, so a claim can be retried.
+func (s *RosterService) ClaimPlayer(code string, user *entity.Person) (*entity.Person, error) {
+	p, err := s.players.GetByInvite(code)
+	if err != nil {
+		if claimed, gErr := s.players.GetByUser(user); gErr == nil && claimed.HasClaimed(code) {
+			return &entity.Person{ID: claimed.GetID(), Name: claimed.GetName()}, nil
+		}
+		return nil, err
+	}
+
+	if err = p.LinkUser(user, code); err != nil {
+		return nil, err
+	}
+
+	if err = s.players.Update(p); err != nil {
+		return nil, err
+	}
+
+	return &entity.Person{ID: p.GetID(), Name: p.GetName()}, nil
+}
+
+// GetUserTeams returns the teams of the player linked to the user.
+func (s *RosterService) GetUserTeams(user *entity.Person) ([]*entity.Group, error) {
+	p, err := s.players.GetByUser(user)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.GetTeams(), nil
+}
+
+// GetMembershipHistory returns every team the player has been on.
+func (s *RosterService) GetMembershipHistory(player *entity.Person) ([]model.Membership, error) {
+	p, err := s.players.Get(player)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.GetMembershipHistory(), nil
+}
+
+// GetPlayersAsOf returns the players that were on the team's roster at
+// the given time.
+func (s *RosterService) GetPlayersAsOf(team *entity.Group, at time.Time) ([]*entity.Person, error) {
+	if err := s.authorizer.Authorize(s.actor, entity.PermissionViewRoster, team); err != nil {
+		return nil, err
+	}
+
+	t, err := s.teams.GetAsOf(team, at)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.GetPlayers(), nil
+}
+
+// GetPlayersAtVersion returns the players that were on the team's roster
+// at the given version of the team.
+func (s *RosterService) GetPlayersAtVersion(team *entity.Group, version int) ([]*entity.Person, error) {
+	if err := s.authorizer.Authorize(s.actor, entity.PermissionViewRoster, team); err != nil {
+		return nil, err
+	}
+
+	t, err := s.teams.GetAtVersion(team, version)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.GetPlayers(), nil
+}
+
+// AddSeason initializes a new season to the repository if valid.
+func (s *RosterService) AddSeason(id uuid.UUID, name string, startsOn, endsOn time.Time) error {
+	if err := s.authorizer.Authorize(s.actor, entity.PermissionManageTeams, nil); err != nil {
 		return err
 	}
-	err = t.UnassignPlayer(p)
+
+	season, err := model.NewSeason(id, name, startsOn, endsOn)
 	if err != nil {
 		return err
 	}
-	err = p.UnassignTeam(t)
+
+	return s.seasons.Add(season)
+}
+
+// CloseSeason closes the season, after which the rosters of the teams
+// playing it can no longer change.
+func (s *RosterService) CloseSeason(id uuid.UUID) error {
+	if err := s.authorizer.Authorize(s.actor, entity.PermissionManageTeams, nil); err != nil {
+		return err
+	}
+
+	season, err := s.seasons.Get(id)
+	if err != nil {
+		return err
+	}
+	if err = season.Close(); err != nil {
+		return err
+	}
+
+	return s.seasons.Update(season)
+}
+
+// StartTeamSeason rolls the team over into the season, keeping the given
+// players on the roster. The other players leave the team because the
+// season ended. The season has to start after the one the team is
+// playing. The team records the roll over before the players are updated,
+// and players already released are skipped, so starting the season again
+// finishes a failed roll over.
+func (s *RosterService) StartTeamSeason(team *entity.Group, id uuid.UUID, keep []*entity.Person) error {
+	if err := s.authorizer.Authorize(s.actor, entity.PermissionManageTeams, team); err != nil {
+		return err
+	}
+
+	t, err := s.teams.Get(team)
+	if err != nil {
+		return err
+	}
+	season, err := s.seasons.Get(id)
 	if err != nil {
 		return err
 	}
 
-	s.teams.Update(t)
-	s.players.Update(p)
+	if t.GetSeason() != id || season.IsClosed() {
+		if err = s.startSeason(t, season, keep); err != nil {
+			return err
+		}
+	}
+
+	rostered := make(map[uuid.UUID]bool)
+	for _, p := range t.GetPlayers() {
+		rostered[p.ID] = true
+	}
+	for _, released := range t.GetReleased() {
+		p, err := s.players.Get(&entity.Person{ID: released})
+		if err != nil {
+			return err
+		}
+		if !onTeam(p, team) || rostered[released] {
+			continue // released by an earlier attempt, or assigned again
+		}
+		if err = p.UnassignTeam(t, model.LeaveReasonSeasonEnded, s.now()); err != nil {
+			return err
+		}
+		if err = s.players.Update(p); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
+
+// startSeason moves the team on to the season if it starts after the
+// season the team is playing, and stores the team.
+func (s *RosterService) startSeason(t *model.Team, season *model.Season, keep []*entity.Person) error {
+	if current := t.GetSeason(); current != model.NoSeason && current != season.GetID() {
+		playing, err := s.seasons.Get(current)
+		if err != nil {
+			return err
+		}
+		if !season.GetStartsOn().After(playing.GetStartsOn()) {
+			return model.ErrSeasonNotNext
+		}
+	}
+
+	ids := make([]uuid.UUID, len(keep))
+	for i, p := range keep {
+		ids[i] = p.ID
+	}
+	if _, err := t.StartSeason(season, ids); err != nil {
+		return err
+	}
+	return s.teams.Update(t)
+}
+
+// GetSeasonRoster returns the players on the team's roster in the season
+// and their jersey numbers.
+func (s *RosterService) GetSeasonRoster(team *entity.Group, id uuid.UUID) ([]model.RosterSpot, error) {
+	if err := s.authorizer.Authorize(s.actor, entity.PermissionViewRoster, team); err != nil {
+		return nil, err
+	}
+
+	t, err := s.teams.Get(team)
+	if err != nil {
+		return nil, err
+	}
+
+	roster, ok := t.GetSeasonRoster(id)
+	if !ok {
+		return nil, repository.ErrSeasonNotFound
+	}
+	return roster, nil
+}
+
+// ActivateTeam activates a deactivated team.
+func (s *RosterService) ActivateTeam(team *entity.Group) error {
+	if err := s.authorizer.Authorize(s.actor, entity.PermissionManageTeams, team); err != nil {
+		return err
+	}
+
+	t, err := s.teams.Get(team)
+	if err != nil {
+		return err
+	}
+	if err = t.Activate(); err != nil {
+		return err
+	}
+
+	return s.teams.Update(t)
+}
+
+// DeactivateTeam deactivates an active team.
+func (s *RosterService) DeactivateTeam(team *entity.Group) error {
+	if err := s.authorizer.Authorize(s.actor, entity.PermissionManageTeams, team); err != nil {
+		return err
+	}
+
+	t, err := s.teams.Get(team)
+	if err != nil {
+		return err
+	}
+	if err = t.Deactivate(); err != nil {
+		return err
+	}
+
+	return s.teams.Update(t)
+}
+
+// openTeam returns the team if the season it is playing is still open,
+// since the rosters of closed seasons are read-only.
+func (s *RosterService) openTeam(team *entity.Group) (*model.Team, error) {
+	t, err := s.teams.Get(team)
+	if err != nil {
+		return nil, err
+	}
+	if t.GetSeason() == model.NoSeason {
+		return t, nil
+	}
+
+	season, err := s.seasons.Get(t.GetSeason())
+	if err != nil {
+		return nil, err
+	}
+	if season.IsClosed() {
+		return nil, model.ErrSeasonClosed
+	}
+	return t, nil
+}
+
+// managedPlayer loads the player if the actor may manage players or is a
+// guardian of the player.
+func (s *RosterService) managedPlayer(player *entity.Person) (*model.Player, error) {
+	authErr := s.authorizer.Authorize(s.actor, entity.PermissionManagePlayers, nil)
+	p, err := s.players.Get(player)
+	if authErr == nil || (err == nil && s.isGuardian(p)) {
+		return p, err
+	}
+	return nil, authErr
+}
+
+// actsFor returns whether the actor is the user linked to the player or a
+// guardian of the player.
+func (s *RosterService) actsFor(p *model.Player) bool {
+	return s.isGuardian(p) || (s.actor != nil && p.IsLinked() && p.GetUserID() == s.actor.ID)
+}
+
+// isGuardian returns whether the actor is a guardian of the player.
+func (s *RosterService) isGuardian(p *model.Player) bool {
+	return s.actor != nil && p.IsGuardian(s.actor.ID)
+}
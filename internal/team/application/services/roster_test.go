@@ -1,11 +1,16 @@
 package services
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	"git.sr.ht/~loges/teammate/internal/entity"
+	"git.sr.ht/~loges/teammate/internal/eventstore"
 	"git.sr.ht/~loges/teammate/internal/team/domain/model"
 	"git.sr.ht/~loges/teammate/internal/team/domain/repository"
+	"git.sr.ht/~loges/teammate/internal/team/domain/rules"
+	"git.sr.ht/~loges/teammate/internal/team/infrastructure/memory"
 	"github.com/google/uuid"
 	"github.com/matryer/is"
 )
@@ -15,15 +20,42 @@ var (
 	examplePerson = &entity.Person{ID: uuid.MustParse("f47ac10b-58cc-0372-8567-0e02b2c3d479"), Name: "Matt"}
 	anotherGroup  = &entity.Group{ID: uuid.MustParse("adbe93f8-c952-11ed-afa1-0242ac120002"), Name: "Bears"}
 	anotherPerson = &entity.Person{ID: uuid.MustParse("d38ad10b-58cc-0372-8567-0e02b2c3d479"), Name: "Jackie"}
+	exampleSeason = uuid.MustParse("a15e93f8-c952-11ed-afa1-0242ac120002")
+	errDenied     = errors.New("denied")
 )
 
+// allowAll allows every action.
+type allowAll struct{}
+
+func (allowAll) Authorize(*entity.Person, entity.Permission, *entity.Group) error {
+	return nil
+}
+
+// coachAuthorizer only allows the example person to manage the example group's roster.
+type coachAuthorizer struct{}
+
+func (coachAuthorizer) Authorize(actor *entity.Person, p entity.Permission, g *entity.Group) error {
+	if actor != examplePerson || p != entity.PermissionManageRoster || g.ID != exampleGroup.ID {
+		return errDenied
+	}
+	return nil
+}
+
 func TestNewRosterService(t *testing.T) {
 	t.Run("Create service with defaults", func(t *testing.T) {
 		is := is.New(t)
-		_, err := NewRosterService()
+		_, err := NewRosterService(WithAuthorizer(allowAll{}))
 		is.NoErr(err)
 	})
 
+	t.Run("Deny every action without an authorizer", func(t *testing.T) {
+		is := is.New(t)
+		s, err := NewRosterService()
+		is.NoErr(err)
+
+		is.Equal(s.As(examplePerson).AddTeam(exampleGroup), ErrNoAuthorizer)
+	})
+
 	t.Run("Create service with bad config", func(t *testing.T) {
 		is := is.New(t)
 		withInvalidConfig := func() RosterConfiguration {
@@ -34,7 +66,7 @@ func TestNewRosterService(t *testing.T) {
 		originalConfigs := RosterConfigs
 		RosterConfigs = []RosterConfiguration{withInvalidConfig()}
 
-		_, err := NewRosterService()
+		_, err := NewRosterService(WithAuthorizer(allowAll{}))
 
 		is.Equal(err, ErrInvalidRosterConfig)
 		// clean up configs
@@ -56,7 +88,7 @@ func TestRosterService_AddPlayer(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.test, func(t *testing.T) {
 			is := is.New(t)
-			s, _ := NewRosterService()
+			s, _ := NewRosterService(WithAuthorizer(allowAll{}))
 			_ = s.AddPlayer(examplePerson)
 
 			err := s.AddPlayer(tc.person)
@@ -80,7 +112,7 @@ func TestRosterService_AddTeam(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.test, func(t *testing.T) {
 			is := is.New(t)
-			s, _ := NewRosterService()
+			s, _ := NewRosterService(WithAuthorizer(allowAll{}))
 			_ = s.AddTeam(exampleGroup)
 
 			err := s.AddTeam(tc.group)
@@ -97,13 +129,15 @@ func TestRosterService_AssignPlayerToTeam(t *testing.T) {
 		person              *entity.Person
 		alreadyAssignPlayer bool
 		alreadyAssignTeam   bool
+		failUpdate          bool
 		expectedErr         error
 	}{
-		{"Team not found", anotherGroup, examplePerson, false, false, repository.ErrTeamNotFound},
-		{"Player not found", exampleGroup, anotherPerson, false, false, repository.ErrPlayerNotFound},
-		{"Team already assigned to player", exampleGroup, examplePerson, false, true, model.ErrPlayerUpdateFailed},
-		{"Player already assigned to Team", exampleGroup, examplePerson, true, false, model.ErrTeamUpdateFailed},
-		{"Player assigned to team", exampleGroup, examplePerson, false, false, nil},
+		{"Team not found", anotherGroup, examplePerson, false, false, false, repository.ErrTeamNotFound},
+		{"Player not found", exampleGroup, anotherPerson, false, false, false, repository.ErrPlayerNotFound},
+		{"Team already assigned to player", exampleGroup, examplePerson, false, true, false, model.ErrPlayerUpdateFailed},
+		{"Player already assigned to Team", exampleGroup, examplePerson, true, false, false, model.ErrTeamUpdateFailed},
+		{"Player update fails", exampleGroup, examplePerson, false, false, true, errUnavailable},
+		{"Player assigned to team", exampleGroup, examplePerson, false, false, false, nil},
 	}
 
 	for _, tc := range testCases {
@@ -111,7 +145,7 @@ func TestRosterService_AssignPlayerToTeam(t *testing.T) {
 			is := is.New(t)
 
 			// initialize roster service
-			s, err := NewRosterService()
+			s, err := NewRosterService(WithAuthorizer(allowAll{}))
 
 			// instantiate player and team aggregate
 			player, _ := model.NewPlayer(examplePerson)
@@ -119,17 +153,20 @@ func TestRosterService_AssignPlayerToTeam(t *testing.T) {
 
 			// already assign to team or player aggregate
 			if tc.alreadyAssignTeam {
-				player.AssignTeam(team)
+				player.AssignTeam(team, time.Now())
 			}
 			if tc.alreadyAssignPlayer {
-				team.AssignPlayer(player)
+				team.AssignPlayer(player, model.NoJerseyNumber)
 			}
 
 			// store aggregates in repository
 			s.players.Add(player)
 			s.teams.Add(team)
+			if tc.failUpdate {
+				s.players = &failingPlayers{PlayerRepository: s.players}
+			}
 
-			err = s.AssignPlayerToTeam(tc.group, tc.person)
+			err = s.AssignPlayerToTeam(tc.group, tc.person, 7)
 
 			is.Equal(err, tc.expectedErr)
 		})
@@ -143,13 +180,15 @@ func TestRosterService_UnassignPlayerToTeam(t *testing.T) {
 		person       *entity.Person
 		assignPlayer bool
 		assignTeam   bool
+		failUpdate   bool
 		expectedErr  error
 	}{
-		{"Team not found", anotherGroup, examplePerson, false, false, repository.ErrTeamNotFound},
-		{"Player not found", exampleGroup, anotherPerson, false, false, repository.ErrPlayerNotFound},
-		{"Team not assigned to player", exampleGroup, examplePerson, true, false, model.ErrPlayerUpdateFailed},
-		{"Player not assigned to team", exampleGroup, examplePerson, false, true, model.ErrTeamUpdateFailed},
-		{"Player unassigned from team", exampleGroup, examplePerson, true, true, nil},
+		{"Team not found", anotherGroup, examplePerson, false, false, false, repository.ErrTeamNotFound},
+		{"Player not found", exampleGroup, anotherPerson, false, false, false, repository.ErrPlayerNotFound},
+		{"Team not assigned to player", exampleGroup, examplePerson, true, false, false, model.ErrPlayerUpdateFailed},
+		{"Player not assigned to team", exampleGroup, examplePerson, false, true, false, model.ErrTeamUpdateFailed},
+		{"Player update fails", exampleGroup, examplePerson, true, true, true, errUnavailable},
+		{"Player unassigned from team", exampleGroup, examplePerson, true, true, false, nil},
 	}
 
 	for _, tc := range testCases {
@@ -157,7 +196,7 @@ func TestRosterService_UnassignPlayerToTeam(t *testing.T) {
 			is := is.New(t)
 
 			// initialize roster service
-			s, err := NewRosterService()
+			s, err := NewRosterService(WithAuthorizer(allowAll{}))
 
 			// instantiate player and team aggregate
 			player, _ := model.NewPlayer(examplePerson)
@@ -165,19 +204,899 @@ func TestRosterService_UnassignPlayerToTeam(t *testing.T) {
 
 			// already assign to team or player aggregate
 			if tc.assignTeam {
-				player.AssignTeam(team)
+				player.AssignTeam(team, time.Now())
 			}
 			if tc.assignPlayer {
-				team.AssignPlayer(player)
+				team.AssignPlayer(player, model.NoJerseyNumber)
 			}
 
 			// store aggregates in repository
 			s.players.Add(player)
 			s.teams.Add(team)
+			if tc.failUpdate {
+				s.players = &failingPlayers{PlayerRepository: s.players}
+			}
+
+			err = s.UnassignPlayerFromTeam(tc.group, tc.person, model.LeaveReasonReleased)
+
+			is.Equal(err, tc.expectedErr)
+		})
+	}
+}
+
+func TestRosterService_Authorization(t *testing.T) {
+	testCases := []struct {
+		test        string
+		actor       *entity.Person
+		action      func(s *RosterService) error
+		expectedErr error
+	}{
+		{
+			"Actor may not add players",
+			examplePerson,
+			func(s *RosterService) error { return s.AddPlayer(anotherPerson) },
+			errDenied,
+		},
+		{
+			"Actor may not add teams",
+			examplePerson,
+			func(s *RosterService) error { return s.AddTeam(anotherGroup) },
+			errDenied,
+		},
+		{
+			"Actor may not manage roster of other team",
+			examplePerson,
+			func(s *RosterService) error { return s.AssignPlayerToTeam(anotherGroup, examplePerson, 7) },
+			errDenied,
+		},
+		{
+			"Other actor may not manage roster",
+			anotherPerson,
+			func(s *RosterService) error { return s.AssignPlayerToTeam(exampleGroup, examplePerson, 7) },
+			errDenied,
+		},
+		{
+			"Actor assigns player to team",
+			examplePerson,
+			func(s *RosterService) error { return s.AssignPlayerToTeam(exampleGroup, examplePerson, 7) },
+			nil,
+		},
+		{
+			"Actor unassigns player from team",
+			examplePerson,
+			func(s *RosterService) error {
+				_ = s.AssignPlayerToTeam(exampleGroup, examplePerson, 7)
+				return s.UnassignPlayerFromTeam(exampleGroup, examplePerson, model.LeaveReasonReleased)
+			},
+			nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			s, err := NewRosterService(WithAuthorizer(allowAll{}))
+			is.NoErr(err)
+			_ = s.AddPlayer(examplePerson)
+			_ = s.AddTeam(exampleGroup)
+
+			restricted, err := NewRosterService(WithAuthorizer(coachAuthorizer{}))
+			is.NoErr(err)
+			restricted.players, restricted.teams = s.players, s.teams
+
+			err = tc.action(restricted.As(tc.actor))
+
+			is.Equal(err, tc.expectedErr)
+		})
+	}
+}
+
+func TestRosterService_InvitePlayer(t *testing.T) {
+	testCases := []struct {
+		test        string
+		person      *entity.Person
+		expectedErr error
+	}{
+		{"Player not found", anotherPerson, repository.ErrPlayerNotFound},
+		{"Player invited", examplePerson, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			s, _ := NewRosterService(WithAuthorizer(allowAll{}))
+			_ = s.AddPlayer(examplePerson)
+
+			code, err := s.InvitePlayer(tc.person)
+
+			is.Equal(err, tc.expectedErr)
+			is.Equal(code != "", tc.expectedErr == nil)
+		})
+	}
+}
+
+func TestRosterService_ClaimPlayer(t *testing.T) {
+	user := &entity.Person{ID: uuid.MustParse("c15e93f8-c952-11ed-afa1-0242ac120002"), Name: "Matt"}
+
+	t.Run("Unknown invite code", func(t *testing.T) {
+		is := is.New(t)
+		s, _ := NewRosterService(WithAuthorizer(allowAll{}))
+		_ = s.AddPlayer(examplePerson)
+
+		_, err := s.ClaimPlayer("a1b2c3", user)
+
+		is.Equal(err, repository.ErrPlayerNotFound)
+	})
+
+	t.Run("Claim player and list user teams", func(t *testing.T) {
+		is := is.New(t)
+		s, _ := NewRosterService(WithAuthorizer(allowAll{}))
+		_ = s.AddPlayer(examplePerson)
+		_ = s.AddTeam(exampleGroup)
+		_ = s.AssignPlayerToTeam(exampleGroup, examplePerson, 7)
+		code, _ := s.InvitePlayer(examplePerson)
+
+		player, err := s.ClaimPlayer(code, user)
+		is.NoErr(err)
+		is.Equal(player.ID, examplePerson.ID)
+
+		teams, err := s.GetUserTeams(user)
+		is.NoErr(err)
+		is.Equal(len(teams), 1)
+		is.Equal(teams[0].ID, exampleGroup.ID)
+
+		player, err = s.ClaimPlayer(code, user)
+		is.NoErr(err) // retrying the claim
+		is.Equal(player.ID, examplePerson.ID)
+		_, err = s.ClaimPlayer(code, anotherPerson)
+		is.Equal(err, repository.ErrPlayerNotFound)
+	})
+
+	t.Run("User without player has no teams", func(t *testing.T) {
+		is := is.New(t)
+		s, _ := NewRosterService(WithAuthorizer(allowAll{}))
+
+		_, err := s.GetUserTeams(user)
+
+		is.Equal(err, repository.ErrPlayerNotFound)
+	})
+}
+
+func TestRosterService_ActivateTeam(t *testing.T) {
+	testCases := []struct {
+		test        string
+		group       *entity.Group
+		deactivate  bool
+		expectedErr error
+	}{
+		{"Team not found", anotherGroup, false, repository.ErrTeamNotFound},
+		{"Team already active", exampleGroup, false, model.ErrTeamUpdateFailed},
+		{"Team activated", exampleGroup, true, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			s, _ := NewRosterService(WithAuthorizer(allowAll{}))
+			_ = s.AddTeam(exampleGroup)
+			if tc.deactivate {
+				_ = s.DeactivateTeam(exampleGroup)
+			}
+
+			err := s.ActivateTeam(tc.group)
+
+			is.Equal(err, tc.expectedErr)
+		})
+	}
+}
+
+func TestRosterService_DeactivateTeam(t *testing.T) {
+	testCases := []struct {
+		test        string
+		group       *entity.Group
+		deactivate  bool
+		expectedErr error
+	}{
+		{"Team not found", anotherGroup, false, repository.ErrTeamNotFound},
+		{"Team already deactivated", exampleGroup, true, model.ErrTeamUpdateFailed},
+		{"Team deactivated", exampleGroup, false, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			s, _ := NewRosterService(WithAuthorizer(allowAll{}))
+			_ = s.AddTeam(exampleGroup)
+			if tc.deactivate {
+				_ = s.DeactivateTeam(exampleGroup)
+			}
+
+			err := s.DeactivateTeam(tc.group)
+
+			is.Equal(err, tc.expectedErr)
+		})
+	}
+}
+
+func TestRosterService_GetPlayersAsOf(t *testing.T) {
+	march := time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		test        string
+		group       *entity.Group
+		at          time.Time
+		playerCount int
+		expectedErr error
+	}{
+		{"Team not found", anotherGroup, march, 0, repository.ErrTeamNotFound},
+		{"Team not created yet", exampleGroup, march.AddDate(0, 0, -1), 0, repository.ErrTeamNotFound},
+		{"Roster before assignment", exampleGroup, march, 0, nil},
+		{"Roster after assignment", exampleGroup, march.AddDate(0, 0, 1), 1, nil},
+		{"Roster after unassignment", exampleGroup, march.AddDate(0, 0, 2), 0, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			now := march
+			store := eventstore.NewMemoryStore(eventstore.WithClock(func() time.Time { return now }))
+			s, _ := NewRosterService(WithAuthorizer(allowAll{}), WithMemoryRepositories(memory.WithEventStore(store)))
+			_ = s.AddTeam(exampleGroup)
+			_ = s.AddPlayer(examplePerson)
+			now = now.AddDate(0, 0, 1)
+			_ = s.AssignPlayerToTeam(exampleGroup, examplePerson, 7)
+			now = now.AddDate(0, 0, 1)
+			_ = s.UnassignPlayerFromTeam(exampleGroup, examplePerson, model.LeaveReasonReleased)
+
+			players, err := s.GetPlayersAsOf(tc.group, tc.at)
+
+			is.Equal(err, tc.expectedErr)
+			is.Equal(len(players), tc.playerCount)
+		})
+	}
+}
+
+func TestRosterService_GetPlayersAtVersion(t *testing.T) {
+	testCases := []struct {
+		test        string
+		group       *entity.Group
+		version     int
+		playerCount int
+		expectedErr error
+	}{
+		{"Team not found", anotherGroup, 1, 0, repository.ErrTeamNotFound},
+		{"Version not reached", exampleGroup, 4, 0, repository.ErrTeamNotFound},
+		{"Roster when created", exampleGroup, 1, 0, nil},
+		{"Roster after assignment", exampleGroup, 2, 1, nil},
+		{"Roster after unassignment", exampleGroup, 3, 0, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			s, _ := NewRosterService(WithAuthorizer(allowAll{}))
+			_ = s.AddTeam(exampleGroup)
+			_ = s.AddPlayer(examplePerson)
+			_ = s.AssignPlayerToTeam(exampleGroup, examplePerson, 7)
+			_ = s.UnassignPlayerFromTeam(exampleGroup, examplePerson, model.LeaveReasonReleased)
+
+			players, err := s.GetPlayersAtVersion(tc.group, tc.version)
+
+			is.Equal(err, tc.expectedErr)
+			is.Equal(len(players), tc.playerCount)
+		})
+	}
+}
+
+func TestRosterService_GetMembershipHistory(t *testing.T) {
+	joined := time.Date(2022, time.September, 1, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		test         string
+		person       *entity.Person
+		historyCount int
+		expectedErr  error
+	}{
+		{"Player not found", anotherPerson, 0, repository.ErrPlayerNotFound},
+		{"Player left the team", examplePerson, 1, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			s, _ := NewRosterService(WithAuthorizer(allowAll{}), WithClock(func() time.Time { return joined }))
+			_ = s.AddTeam(exampleGroup)
+			_ = s.AddPlayer(examplePerson)
+			_ = s.AssignPlayerToTeam(exampleGroup, examplePerson, 7)
+			_ = s.UnassignPlayerFromTeam(exampleGroup, examplePerson, model.LeaveReasonGraduation)
+
+			history, err := s.GetMembershipHistory(tc.person)
+
+			is.Equal(err, tc.expectedErr)
+			is.Equal(len(history), tc.historyCount)
+			if err == nil {
+				is.Equal(history[0].JoinedAt, joined)
+				is.Equal(history[0].Reason, model.LeaveReasonGraduation)
+			}
+		})
+	}
+}
+
+func TestRosterService_ChangeJerseyNumber(t *testing.T) {
+	testCases := []struct {
+		test        string
+		group       *entity.Group
+		number      int
+		expectedErr error
+	}{
+		{"Team not found", anotherGroup, 9, repository.ErrTeamNotFound},
+		{"Number is not allowed", exampleGroup, 100, model.ErrInvalidJerseyNumber},
+		{"Number changed", exampleGroup, 9, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			s, _ := NewRosterService(WithAuthorizer(allowAll{}))
+			_ = s.AddTeam(exampleGroup)
+			_ = s.AddPlayer(examplePerson)
+			_ = s.AssignPlayerToTeam(exampleGroup, examplePerson, 7)
+
+			err := s.ChangeJerseyNumber(tc.group, examplePerson, tc.number)
+
+			is.Equal(err, tc.expectedErr)
+		})
+	}
+}
+
+func TestRosterService_ChangeTeamSport(t *testing.T) {
+	testCases := []struct {
+		test        string
+		group       *entity.Group
+		sport       model.Sport
+		expectedErr error
+	}{
+		{"Team not found", anotherGroup, model.SportSoccer, repository.ErrTeamNotFound},
+		{"Unknown sport", exampleGroup, model.Sport("quidditch"), model.ErrInvalidSport},
+		{"Roster number not allowed", exampleGroup, model.SportSoccer, model.ErrInvalidJerseyNumber},
+		{"Sport changed", exampleGroup, model.SportBasketball, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			s, _ := NewRosterService(WithAuthorizer(allowAll{}))
+			_ = s.AddTeam(exampleGroup)
+			_ = s.AddPlayer(examplePerson)
+			_ = s.AssignPlayerToTeam(exampleGroup, examplePerson, 0)
+
+			err := s.ChangeTeamSport(tc.group, tc.sport)
+
+			is.Equal(err, tc.expectedErr)
+		})
+	}
+}
+
+func TestRosterService_GetRoster(t *testing.T) {
+	is := is.New(t)
+	s, _ := NewRosterService(WithAuthorizer(allowAll{}))
+	_ = s.AddTeam(exampleGroup)
+	_ = s.AddPlayer(examplePerson)
+	_ = s.AddPlayer(anotherPerson)
+	_ = s.AssignPlayerToTeam(exampleGroup, examplePerson, 7)
+
+	is.Equal(s.AssignPlayerToTeam(exampleGroup, anotherPerson, 7), model.ErrJerseyNumberTaken)
+	is.NoErr(s.AssignPlayerToTeam(exampleGroup, anotherPerson, 8))
+
+	roster, err := s.GetRoster(exampleGroup)
+	is.NoErr(err)
+	is.Equal(len(roster), 2)
+	is.Equal(roster[0].Player.Name, "Jackie")
+	is.Equal(roster[0].JerseyNumber, 8)
+	is.Equal(roster[1].JerseyNumber, 7)
+}
+
+func TestRosterService_DepthChart(t *testing.T) {
+	testCases := []struct {
+		test        string
+		players     []*entity.Person
+		expectedErr error
+	}{
+		{"Player not on the roster", []*entity.Person{anotherPerson}, model.ErrPlayerNotRostered},
+		{"Depth chart changed", []*entity.Person{examplePerson}, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			s, _ := NewRosterService(WithAuthorizer(allowAll{}))
+			_ = s.AddTeam(exampleGroup)
+			_ = s.ChangeTeamSport(exampleGroup, model.SportSoccer)
+			_ = s.AddPlayer(examplePerson)
+			_ = s.AddPlayer(anotherPerson)
+			_ = s.AssignPlayerToTeam(exampleGroup, examplePerson, 7)
+			is.NoErr(s.AssignPlayerPositions(exampleGroup, examplePerson, "forward", "midfielder"))
+
+			err := s.ChangeDepthChart(exampleGroup, "forward", tc.players)
+
+			is.Equal(err, tc.expectedErr)
+			chart, _ := s.GetDepthChart(exampleGroup, "forward")
+			if err == nil {
+				is.Equal(chart, tc.players)
+			} else {
+				is.Equal(len(chart), 0)
+			}
+		})
+	}
+}
+
+func TestRosterService_Staff(t *testing.T) {
+	is := is.New(t)
+	s, _ := NewRosterService(WithAuthorizer(allowAll{}))
+	_ = s.AddTeam(exampleGroup)
+
+	is.NoErr(s.AddStaffMember(exampleGroup, anotherPerson, model.StaffRoleHeadCoach))
+	is.Equal(s.AddStaffMember(exampleGroup, examplePerson, model.StaffRoleHeadCoach), model.ErrLimitReached)
+	is.NoErr(s.AddStaffMember(exampleGroup, examplePerson, model.StaffRoleManager))
+	is.NoErr(s.RemoveStaffMember(exampleGroup, examplePerson))
+
+	staff, err := s.GetStaff(exampleGroup)
+	is.NoErr(err)
+	is.Equal(staff, []model.StaffMember{{Person: anotherPerson, Role: model.StaffRoleHeadCoach}})
+}
+
+func TestRosterService_DesignatePlayer(t *testing.T) {
+	testCases := []struct {
+		test        string
+		person      *entity.Person
+		expectedErr error
+	}{
+		{"Player not found", &entity.Person{ID: uuid.New()}, repository.ErrPlayerNotFound},
+		{"Player not on the roster", anotherPerson, model.ErrPlayerNotRostered},
+		{"Player designated", examplePerson, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			s, _ := NewRosterService(WithAuthorizer(allowAll{}))
+			_ = s.AddTeam(exampleGroup)
+			_ = s.AddPlayer(examplePerson)
+			_ = s.AddPlayer(anotherPerson)
+			_ = s.AssignPlayerToTeam(exampleGroup, examplePerson, 7)
+
+			err := s.DesignatePlayer(exampleGroup, tc.person, model.DesignationCaptain)
+
+			is.Equal(err, tc.expectedErr)
+			roster, _ := s.GetRoster(exampleGroup)
+			is.Equal(roster[0].Designation == model.DesignationCaptain, err == nil)
+			is.Equal(s.RemovePlayerDesignation(exampleGroup, examplePerson) == nil, err == nil)
+		})
+	}
+}
+
+func TestRosterService_AssignPlayerToTeamRules(t *testing.T) {
+	joined := time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		test        string
+		birth       time.Time
+		cfgs        []RosterConfiguration
+		expectedErr bool
+	}{
+		{"Eligible player", time.Date(2012, time.May, 1, 0, 0, 0, 0, time.UTC), nil, false},
+		{"Player too old for U12", time.Date(2010, time.May, 1, 0, 0, 0, 0, time.UTC), nil, true},
+		{"Rules replaced", time.Date(2010, time.May, 1, 0, 0, 0, 0, time.UTC), []RosterConfiguration{WithRules()}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			s, _ := NewRosterService(append(tc.cfgs, WithAuthorizer(allowAll{}), WithClock(func() time.Time { return joined }))...)
+			_ = s.AddTeam(exampleGroup)
+			_ = s.AddPlayer(examplePerson)
+			is.NoErr(s.ChangeTeamRules(exampleGroup, model.RosterRules{MaxAge: 12}))
+			is.NoErr(s.ChangePlayerDetails(examplePerson, tc.birth, model.GenderMale))
+
+			err := s.AssignPlayerToTeam(exampleGroup, examplePerson, 7)
+
+			var violations *rules.ViolationError
+			is.Equal(errors.As(err, &violations), tc.expectedErr)
+			players, _ := s.teams.GetPlayers(exampleGroup)
+			is.Equal(len(players) == 0, tc.expectedErr)
+		})
+	}
+}
+
+func TestRosterService_AssignPlayerToTeamSeasonRules(t *testing.T) {
+	startsOn := time.Date(2023, time.August, 1, 0, 0, 0, 0, time.UTC)
+	setup := func(is *is.I) *RosterService {
+		s, _ := NewRosterService(WithAuthorizer(allowAll{}), WithClock(func() time.Time { return startsOn.AddDate(0, 3, 0) }))
+		is.NoErr(s.AddSeason(exampleSeason, "2023/24", startsOn, startsOn.AddDate(1, 0, 0)))
+		is.NoErr(s.AddTeam(exampleGroup))
+		is.NoErr(s.AddTeam(anotherGroup))
+		is.NoErr(s.AddPlayer(examplePerson))
+		is.NoErr(s.StartTeamSeason(exampleGroup, exampleSeason, nil))
+		return s
+	}
+
+	t.Run("Age is counted when the season starts", func(t *testing.T) {
+		is := is.New(t)
+		s := setup(is)
+		is.NoErr(s.ChangeTeamRules(exampleGroup, model.RosterRules{MaxAge: 12}))
+		is.NoErr(s.ChangePlayerDetails(examplePerson, startsOn.AddDate(-12, 1, 0), model.GenderMale))
+
+		is.NoErr(s.AssignPlayerToTeam(exampleGroup, examplePerson, 7))
+	})
+
+	t.Run("Teams of other seasons are not counted", func(t *testing.T) {
+		is := is.New(t)
+		s := setup(is)
+		is.NoErr(s.AssignPlayerToTeam(anotherGroup, examplePerson, 7))
+		is.NoErr(s.ChangeTeamRules(exampleGroup, model.RosterRules{MaxTeamsPerPlayer: 1}))
+
+		is.NoErr(s.AssignPlayerToTeam(exampleGroup, examplePerson, 7))
+	})
+}
+
+// staffAuthorizer lets everyone view the roster but only the example person
+// view sensitive player data.
+type staffAuthorizer struct{}
+
+func (staffAuthorizer) Authorize(actor *entity.Person, p entity.Permission, g *entity.Group) error {
+	if p == entity.PermissionViewSensitivePlayerData && actor != examplePerson {
+		return errDenied
+	}
+	return nil
+}
+
+func TestRosterService_GetPlayerProfile(t *testing.T) {
+	birth := time.Date(2012, time.May, 1, 0, 0, 0, 0, time.UTC)
+	address := model.Address{Street: "1 Main St", City: "Syracuse", PostalCode: "13202", Country: "US"}
+	contacts := []model.EmergencyContact{{Name: "Ann", Relationship: "mother", Phone: "555-0101"}}
+	full := model.Profile{
+		Name:              examplePerson.Name,
+		DateOfBirth:       birth,
+		Gender:            model.GenderFemale,
+		Phone:             "555-0100",
+		Address:           address,
+		EmergencyContacts: contacts,
+		MedicalNotes:      "asthma",
+	}
+	testCases := []struct {
+		test        string
+		actor       *entity.Person
+		player      *entity.Person
+		expected    model.Profile
+		expectedErr error
+	}{
+		{"Coach sees sensitive fields", examplePerson, examplePerson, full, nil},
+		{"Teammate sees redacted profile", anotherPerson, examplePerson, full.Redact(), nil},
+		{"Player not on the roster", examplePerson, anotherPerson, model.Profile{}, model.ErrPlayerNotRostered},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			s, _ := NewRosterService(WithAuthorizer(staffAuthorizer{}))
+			_ = s.AddTeam(exampleGroup)
+			_ = s.AddPlayer(examplePerson)
+			_ = s.AddPlayer(anotherPerson)
+			_ = s.AssignPlayerToTeam(exampleGroup, examplePerson, 7)
+			is.NoErr(s.ChangePlayerDetails(examplePerson, birth, model.GenderFemale))
+			is.NoErr(s.ChangePlayerContactDetails(examplePerson, "555-0100", address))
+			is.NoErr(s.ChangePlayerEmergencyContacts(examplePerson, contacts))
+			is.NoErr(s.ChangePlayerMedicalNotes(examplePerson, "asthma"))
+
+			profile, err := s.As(tc.actor).GetPlayerProfile(exampleGroup, tc.player)
+
+			is.Equal(err, tc.expectedErr)
+			is.Equal(profile, tc.expected)
+		})
+	}
+}
+
+func TestRosterService_GuardianActsOnBehalf(t *testing.T) {
+	guardian := &entity.Person{ID: uuid.MustParse("c15e93f8-c952-11ed-afa1-0242ac120002"), Name: "Ann"}
+	testCases := []struct {
+		test        string
+		actor       *entity.Person
+		expectedErr error
+	}{
+		{"Guardian changes the profile", guardian, nil},
+		{"Other user is denied", anotherPerson, errDenied},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			s, _ := NewRosterService(WithAuthorizer(allowAll{}))
+			_ = s.AddTeam(exampleGroup)
+			_ = s.AddPlayer(examplePerson)
+			_ = s.AssignPlayerToTeam(exampleGroup, examplePerson, 7)
+			is.NoErr(s.AddGuardian(examplePerson, guardian, "mother"))
+			s.authorizer = coachAuthorizer{}
+
+			err := s.As(tc.actor).ChangePlayerMedicalNotes(examplePerson, "asthma")
+
+			is.Equal(err, tc.expectedErr)
+			profile, err := s.As(tc.actor).GetPlayerProfile(exampleGroup, examplePerson)
+			is.Equal(err, tc.expectedErr)
+			is.Equal(profile.MedicalNotes != "", tc.expectedErr == nil)
+		})
+	}
+}
+
+func TestRosterService_GetFamily(t *testing.T) {
+	guardian := &entity.Person{ID: uuid.MustParse("c15e93f8-c952-11ed-afa1-0242ac120002"), Name: "Ann"}
+	setup := func(is *is.I) *RosterService {
+		s, _ := NewRosterService(WithAuthorizer(allowAll{}))
+		_ = s.AddTeam(exampleGroup)
+		_ = s.AddTeam(anotherGroup)
+		_ = s.AddPlayer(examplePerson)
+		_ = s.AddPlayer(anotherPerson)
+		_ = s.AssignPlayerToTeam(exampleGroup, examplePerson, 7)
+		_ = s.AssignPlayerToTeam(anotherGroup, anotherPerson, 7)
+		is.NoErr(s.AddGuardian(examplePerson, guardian, "mother"))
+		s.authorizer = coachAuthorizer{}
+		return s
+	}
+
+	t.Run("Guardian groups siblings", func(t *testing.T) {
+		is := is.New(t)
+		s := setup(is)
+		s.authorizer = allowAll{}
+		is.NoErr(s.AddGuardian(anotherPerson, guardian, "mother"))
+		s.authorizer = coachAuthorizer{}
+
+		is.NoErr(s.As(guardian).GroupSiblings(examplePerson, anotherPerson))
+		is.Equal(s.As(guardian).GroupSiblings(examplePerson, anotherPerson), model.ErrPlayerUpdateFailed)
+
+		family, err := s.GetFamily(guardian)
+		is.NoErr(err)
+		is.Equal(family, []model.FamilyMember{
+			{Player: examplePerson, Teams: []*entity.Group{exampleGroup}},
+			{Player: anotherPerson, Teams: []*entity.Group{anotherGroup}},
+		})
+
+		s.authorizer = allowAll{}
+		is.NoErr(s.RemoveGuardian(examplePerson, guardian))
+		is.NoErr(s.RemoveGuardian(anotherPerson, guardian))
+		family, _ = s.GetFamily(guardian)
+		is.Equal(family, []model.FamilyMember{})
+	})
+
+	t.Run("Guardian can't join another family", func(t *testing.T) {
+		is := is.New(t)
+		s := setup(is)
+
+		is.Equal(s.As(guardian).GroupSiblings(examplePerson, anotherPerson), errDenied)
+
+		family, _ := s.GetFamily(guardian)
+		is.Equal(len(family), 1)
+	})
 
-			err = s.UnassignPlayerFromTeam(tc.group, tc.person)
+	t.Run("Remove a missing guardian", func(t *testing.T) {
+		is := is.New(t)
+		s := setup(is)
+		s.authorizer = allowAll{}
+
+		is.Equal(s.RemoveGuardian(examplePerson, nil), model.ErrInvalidGuardian)
+	})
+}
+
+func TestRosterService_ErasePlayer(t *testing.T) {
+	testCases := []struct {
+		test        string
+		person      *entity.Person
+		expectedErr error
+	}{
+		{"Player not found", &entity.Person{ID: uuid.New()}, repository.ErrPlayerNotFound},
+		{"Player erased", examplePerson, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			s, _ := NewRosterService(WithAuthorizer(allowAll{}))
+			_ = s.AddTeam(exampleGroup)
+			_ = s.AddPlayer(examplePerson)
+			_ = s.AssignPlayerToTeam(exampleGroup, examplePerson, 7)
+
+			err := s.ErasePlayer(tc.person)
 
 			is.Equal(err, tc.expectedErr)
+			roster, _ := s.GetRoster(exampleGroup)
+			is.Equal(roster[0].Player.Name == entity.ErasedName, err == nil)
 		})
 	}
+
+	t.Run("Copies held by other contexts are erased first", func(t *testing.T) {
+		is := is.New(t)
+		eraser := &failingEraser{}
+		s, _ := NewRosterService(WithAuthorizer(allowAll{}), WithPlayerEraser(eraser))
+		_ = s.AddTeam(exampleGroup)
+		_ = s.AddPlayer(examplePerson)
+		_ = s.AssignPlayerToTeam(exampleGroup, examplePerson, 7)
+
+		is.Equal(s.ErasePlayer(examplePerson), errEraserUnavailable)
+		roster, _ := s.GetRoster(exampleGroup)
+		is.Equal(roster[0].Player.Name, examplePerson.Name)
+
+		eraser.recovered = true
+		is.NoErr(s.ErasePlayer(examplePerson))
+		is.Equal(len(eraser.erased), 1)
+	})
+}
+
+func TestRosterService_EraseUser(t *testing.T) {
+	testCases := []struct {
+		test        string
+		authorizer  Authorizer
+		expectedErr error
+	}{
+		{"Actor may not manage players", coachAuthorizer{}, errDenied},
+		{"User erased", allowAll{}, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			s, _ := NewRosterService(WithAuthorizer(allowAll{}))
+			is.NoErr(s.AddTeam(exampleGroup))
+			is.NoErr(s.AddStaffMember(exampleGroup, anotherPerson, model.StaffRoleManager))
+			s.authorizer = tc.authorizer
+
+			err := s.As(examplePerson).EraseUser(anotherPerson)
+
+			is.Equal(err, tc.expectedErr)
+			team, _ := s.teams.Get(exampleGroup)
+			is.Equal(team.GetStaff()[0].Person.Name == entity.ErasedName, err == nil)
+		})
+	}
+
+	t.Run("Erasing again is a no-op", func(t *testing.T) {
+		is := is.New(t)
+		s, _ := NewRosterService(WithAuthorizer(allowAll{}))
+		is.NoErr(s.AddTeam(exampleGroup))
+		is.NoErr(s.AddStaffMember(exampleGroup, anotherPerson, model.StaffRoleManager))
+		is.NoErr(s.EraseUser(anotherPerson))
+		before, _ := s.teams.Get(exampleGroup)
+
+		is.NoErr(s.EraseUser(anotherPerson))
+
+		after, _ := s.teams.Get(exampleGroup)
+		is.Equal(after.Version(), before.Version())
+	})
+}
+
+var errEraserUnavailable = errors.New("eraser unavailable")
+
+// failingEraser fails until it has recovered.
+type failingEraser struct {
+	recovered bool
+	erased    []*entity.Person
+}
+
+func (e *failingEraser) ErasePlayer(player *entity.Person) error {
+	if !e.recovered {
+		return errEraserUnavailable
+	}
+	e.erased = append(e.erased, player)
+	return nil
+}
+
+func TestRosterService_AddSeason(t *testing.T) {
+	startsOn := time.Date(2023, time.August, 1, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		test        string
+		id          uuid.UUID
+		name        string
+		endsOn      time.Time
+		expectedErr error
+	}{
+		{"Season added", uuid.New(), "2023/24", startsOn.AddDate(1, 0, 0), nil},
+		{"Season ends before it starts", uuid.New(), "2023/24", startsOn.AddDate(-1, 0, 0), model.ErrInvalidSeason},
+		{"Season already exists", exampleSeason, "2023/24", startsOn.AddDate(1, 0, 0), repository.ErrSeasonAlreadyExists},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			s, _ := NewRosterService(WithAuthorizer(allowAll{}))
+			_ = s.AddSeason(exampleSeason, "2022/23", startsOn, startsOn.AddDate(1, 0, 0))
+
+			err := s.AddSeason(tc.id, tc.name, startsOn, tc.endsOn)
+
+			is.Equal(err, tc.expectedErr)
+		})
+	}
+}
+
+func TestRosterService_StartTeamSeason(t *testing.T) {
+	startsOn := time.Date(2023, time.August, 1, 0, 0, 0, 0, time.UTC)
+	nextSeason := uuid.MustParse("b15e93f8-c952-11ed-afa1-0242ac120002")
+	setup := func(is *is.I) *RosterService {
+		s, _ := NewRosterService(WithAuthorizer(allowAll{}))
+		is.NoErr(s.AddSeason(exampleSeason, "2023/24", startsOn, startsOn.AddDate(1, 0, 0)))
+		is.NoErr(s.AddSeason(nextSeason, "2024/25", startsOn.AddDate(1, 0, 0), startsOn.AddDate(2, 0, 0)))
+		is.NoErr(s.AddTeam(exampleGroup))
+		is.NoErr(s.AddPlayer(examplePerson))
+		is.NoErr(s.AddPlayer(anotherPerson))
+		is.NoErr(s.AssignPlayerToTeam(exampleGroup, examplePerson, 7))
+		is.NoErr(s.AssignPlayerToTeam(exampleGroup, anotherPerson, 9))
+		is.NoErr(s.StartTeamSeason(exampleGroup, exampleSeason, []*entity.Person{examplePerson, anotherPerson}))
+		return s
+	}
+
+	t.Run("Roll over copies the selected players", func(t *testing.T) {
+		is := is.New(t)
+		s := setup(is)
+
+		err := s.StartTeamSeason(exampleGroup, nextSeason, []*entity.Person{examplePerson})
+
+		is.NoErr(err)
+		roster, _ := s.GetRoster(exampleGroup)
+		is.Equal(len(roster), 1)
+		is.Equal(roster[0].JerseyNumber, 7)
+		past, err := s.GetSeasonRoster(exampleGroup, exampleSeason)
+		is.NoErr(err)
+		is.Equal(len(past), 2)
+		history, _ := s.GetMembershipHistory(anotherPerson)
+		is.Equal(history[0].Reason, model.LeaveReasonSeasonEnded)
+	})
+
+	t.Run("Season not found", func(t *testing.T) {
+		is := is.New(t)
+		s := setup(is)
+
+		err := s.StartTeamSeason(exampleGroup, uuid.New(), nil)
+
+		is.Equal(err, repository.ErrSeasonNotFound)
+		_, err = s.GetSeasonRoster(exampleGroup, uuid.New())
+		is.Equal(err, repository.ErrSeasonNotFound)
+	})
+
+	t.Run("Closed season roster is read-only", func(t *testing.T) {
+		is := is.New(t)
+		s := setup(is)
+		is.NoErr(s.UnassignPlayerFromTeam(exampleGroup, anotherPerson, model.LeaveReasonReleased))
+
+		is.NoErr(s.CloseSeason(exampleSeason))
+
+		is.Equal(s.CloseSeason(exampleSeason), model.ErrSeasonClosed)
+		is.Equal(s.AssignPlayerToTeam(exampleGroup, anotherPerson, 9), model.ErrSeasonClosed)
+		is.Equal(s.ChangeJerseyNumber(exampleGroup, examplePerson, 8), model.ErrSeasonClosed)
+		is.Equal(s.StartTeamSeason(exampleGroup, exampleSeason, nil), model.ErrSeasonClosed)
+		is.NoErr(s.StartTeamSeason(exampleGroup, nextSeason, []*entity.Person{examplePerson}))
+		is.NoErr(s.ChangeJerseyNumber(exampleGroup, examplePerson, 8))
+	})
+
+	t.Run("Earlier season is rejected", func(t *testing.T) {
+		is := is.New(t)
+		s := setup(is)
+		earlier := uuid.New()
+		is.NoErr(s.AddSeason(earlier, "2022/23", startsOn.AddDate(-1, 0, 0), startsOn))
+		is.NoErr(s.StartTeamSeason(exampleGroup, nextSeason, []*entity.Person{examplePerson}))
+
+		is.Equal(s.StartTeamSeason(exampleGroup, earlier, nil), model.ErrSeasonNotNext)
+		is.Equal(s.StartTeamSeason(exampleGroup, exampleSeason, nil), model.ErrSeasonNotNext)
+		past, _ := s.GetSeasonRoster(exampleGroup, exampleSeason)
+		is.Equal(len(past), 2)
+		roster, _ := s.GetSeasonRoster(exampleGroup, nextSeason)
+		is.Equal(len(roster), 1) // the rejected season left the team alone
+	})
+
+	t.Run("Failed release is finished when started again", func(t *testing.T) {
+		is := is.New(t)
+		s := setup(is)
+		s.players = &failingPlayers{PlayerRepository: s.players}
+
+		is.Equal(s.StartTeamSeason(exampleGroup, nextSeason, []*entity.Person{examplePerson}), errUnavailable)
+		history, _ := s.GetMembershipHistory(anotherPerson)
+		is.True(history[0].Current)
+
+		is.NoErr(s.StartTeamSeason(exampleGroup, nextSeason, nil))
+		history, _ = s.GetMembershipHistory(anotherPerson)
+		is.Equal(history[0].Reason, model.LeaveReasonSeasonEnded)
+		roster, _ := s.GetRoster(exampleGroup)
+		is.Equal(len(roster), 1)
+	})
 }
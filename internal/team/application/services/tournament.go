@@ -0,0 +1,85 @@
+package services
+
+import (
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"git.sr.ht/~loges/teammate/internal/team/domain/bracket"
+	"git.sr.ht/~loges/teammate/internal/team/domain/model"
+	"github.com/google/uuid"
+)
+
+// CreateTournament draws the bracket of a tournament between the teams,
+// given in seeding order. Tournaments seeded by the standings of a season
+// are created with CreateSeasonTournament.
+func (s *RosterService) CreateTournament(id uuid.UUID, name string, teams []*entity.Group, o bracket.Options) error {
+	if err := s.authorizer.Authorize(s.actor, entity.PermissionManageTeams, nil); err != nil {
+		return err
+	}
+
+	return s.createTournament(id, name, teams, o)
+}
+
+// CreateSeasonTournament draws the bracket of a tournament between the
+// teams playing in the season, seeded by their standings after the
+// recorded results.
+func (s *RosterService) CreateSeasonTournament(id uuid.UUID, name string, season uuid.UUID, o bracket.Options) error {
+	if err := s.authorizer.Authorize(s.actor, entity.PermissionManageTeams, nil); err != nil {
+		return err
+	}
+
+	games, err := s.seasonGames(season)
+	if err != nil {
+		return err
+	}
+
+	return s.createTournament(id, name, bracket.Seeds(model.Standings(games)), o)
+}
+
+func (s *RosterService) createTournament(id uuid.UUID, name string, teams []*entity.Group, o bracket.Options) error {
+	seeded := make([]*model.Team, len(teams))
+	for i, team := range teams {
+		t, err := s.teams.Get(team)
+		if err != nil {
+			return err
+		}
+		seeded[i] = t
+	}
+
+	t, err := model.NewTournament(id, name, seeded, o)
+	if err != nil {
+		return err
+	}
+
+	return s.tournaments.Add(t)
+}
+
+// RecordTournamentResult records the score of a match of the tournament,
+// after which the winner advances through the bracket.
+func (s *RosterService) RecordTournamentResult(id uuid.UUID, matchId string, homeScore, awayScore int) error {
+	if err := s.authorizer.Authorize(s.actor, entity.PermissionManageTeams, nil); err != nil {
+		return err
+	}
+
+	t, err := s.tournaments.Get(id)
+	if err != nil {
+		return err
+	}
+	if err = t.RecordResult(matchId, homeScore, awayScore); err != nil {
+		return err
+	}
+
+	return s.tournaments.Update(t)
+}
+
+// GetBracket returns the bracket of the tournament.
+func (s *RosterService) GetBracket(id uuid.UUID) (*bracket.Bracket, error) {
+	if err := s.authorizer.Authorize(s.actor, entity.PermissionViewRoster, nil); err != nil {
+		return nil, err
+	}
+
+	t, err := s.tournaments.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.GetBracket()
+}
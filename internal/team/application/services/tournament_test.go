@@ -0,0 +1,129 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"git.sr.ht/~loges/teammate/internal/team/domain/bracket"
+	"git.sr.ht/~loges/teammate/internal/team/domain/model"
+	"git.sr.ht/~loges/teammate/internal/team/domain/repository"
+	"github.com/google/uuid"
+	"github.com/matryer/is"
+)
+
+func TestRosterService_Tournament(t *testing.T) {
+	tournament := uuid.MustParse("b25e93f8-c952-11ed-afa1-0242ac120002")
+	thirdGroup := &entity.Group{ID: uuid.MustParse("bcbe93f8-c952-11ed-afa1-0242ac120002"), Name: "Lions"}
+	knockout := bracket.Options{Format: bracket.SingleElimination}
+	setup := func(is *is.I) *RosterService {
+		s, _ := NewRosterService(WithAuthorizer(allowAll{}))
+		is.NoErr(s.AddTeam(exampleGroup))
+		is.NoErr(s.AddTeam(anotherGroup))
+		is.NoErr(s.AddTeam(thirdGroup))
+		return s
+	}
+
+	t.Run("Winners advance as results are recorded", func(t *testing.T) {
+		is := is.New(t)
+		s := setup(is)
+		is.NoErr(s.CreateTournament(tournament, "Spring Cup", []*entity.Group{{ID: exampleGroup.ID}, {ID: anotherGroup.ID}, {ID: thirdGroup.ID}}, knockout))
+
+		is.NoErr(s.RecordTournamentResult(tournament, "R1-2", 1, 2))
+
+		b, err := s.GetBracket(tournament)
+		is.NoErr(err)
+		final := b.Winners[1].Matches[0]
+		is.Equal(final.Home.Name, exampleGroup.Name) // top seed had a bye
+		is.Equal(final.Away.Name, thirdGroup.Name)
+		is.NoErr(s.RecordTournamentResult(tournament, "R2-1", 0, 1))
+		b, _ = s.GetBracket(tournament)
+		is.Equal(b.Champion.ID, thirdGroup.ID)
+	})
+
+	t.Run("Season standings seed the tournament", func(t *testing.T) {
+		is := is.New(t)
+		s := setup(is)
+		startsOn := time.Date(2023, time.August, 1, 0, 0, 0, 0, time.UTC)
+		is.NoErr(s.AddSeason(exampleSeason, "2023/24", startsOn, startsOn.AddDate(1, 0, 0)))
+		season, _ := s.seasons.Get(exampleSeason)
+		for i, r := range []struct {
+			home, away           *entity.Group
+			homeScore, awayScore int
+		}{
+			{exampleGroup, thirdGroup, 0, 2},
+			{exampleGroup, anotherGroup, 0, 1},
+			{thirdGroup, anotherGroup, 1, 1},
+		} {
+			home, _ := s.teams.Get(r.home)
+			away, _ := s.teams.Get(r.away)
+			kickoff := startsOn.AddDate(0, 1, 7*i)
+			g, err := model.NewGame(uuid.New(), season, i+1, home, away, kickoff, kickoff.Add(90*time.Minute), model.Location{})
+			is.NoErr(err)
+			is.NoErr(s.games.Add(g))
+			is.NoErr(s.RecordGameResult(g.GetID(), r.homeScore, r.awayScore))
+		}
+
+		is.NoErr(s.CreateSeasonTournament(tournament, "Spring Cup", exampleSeason, knockout))
+
+		b, err := s.GetBracket(tournament)
+		is.NoErr(err)
+		is.Equal(b.Winners[0].Matches[0].Home.ID, thirdGroup.ID) // top of the standings has a bye
+		is.True(b.Winners[0].Matches[0].Bye)
+		is.Equal(b.Winners[0].Matches[1].Home.ID, anotherGroup.ID)
+		is.Equal(b.Winners[0].Matches[1].Away.ID, exampleGroup.ID)
+		is.Equal(s.CreateSeasonTournament(uuid.New(), "Fall Cup", uuid.New(), knockout), repository.ErrSeasonNotFound)
+	})
+
+	t.Run("Tournament can't be created", func(t *testing.T) {
+		testCases := []struct {
+			test        string
+			teams       []*entity.Group
+			prepare     func(*RosterService) error
+			expectedErr error
+		}{
+			{"Team not found", []*entity.Group{exampleGroup, {ID: uuid.New()}}, nil, repository.ErrTeamNotFound},
+			{"Team is not active", []*entity.Group{exampleGroup, anotherGroup}, func(s *RosterService) error { return s.DeactivateTeam(anotherGroup) }, model.ErrTeamNotActive},
+			{"Tournament already exists", []*entity.Group{exampleGroup, anotherGroup}, func(s *RosterService) error {
+				return s.CreateTournament(tournament, "Fall Cup", []*entity.Group{exampleGroup, thirdGroup}, knockout)
+			}, repository.ErrTournamentAlreadyExists},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.test, func(t *testing.T) {
+				is := is.New(t)
+				s := setup(is)
+				if tc.prepare != nil {
+					is.NoErr(tc.prepare(s))
+				}
+
+				err := s.CreateTournament(tournament, "Spring Cup", tc.teams, knockout)
+
+				is.Equal(err, tc.expectedErr)
+			})
+		}
+	})
+
+	t.Run("Result can't be recorded", func(t *testing.T) {
+		is := is.New(t)
+		s := setup(is)
+		is.NoErr(s.CreateTournament(tournament, "Spring Cup", []*entity.Group{exampleGroup, anotherGroup}, knockout))
+
+		is.Equal(s.RecordTournamentResult(uuid.New(), "R1-1", 1, 0), repository.ErrTournamentNotFound)
+		is.Equal(s.RecordTournamentResult(tournament, "R1-1", 1, 1), bracket.ErrDrawNotAllowed)
+		_, err := s.GetBracket(uuid.New())
+		is.Equal(err, repository.ErrTournamentNotFound)
+	})
+
+	t.Run("Tournaments are authorized", func(t *testing.T) {
+		is := is.New(t)
+		s := setup(is)
+		s.authorizer = coachAuthorizer{}
+
+		is.Equal(s.As(examplePerson).CreateTournament(tournament, "Spring Cup", []*entity.Group{exampleGroup, anotherGroup}, knockout), errDenied)
+		is.Equal(s.As(examplePerson).CreateSeasonTournament(tournament, "Spring Cup", exampleSeason, knockout), errDenied)
+		is.Equal(s.As(examplePerson).RecordTournamentResult(tournament, "R1-1", 1, 0), errDenied)
+		_, err := s.As(examplePerson).GetBracket(tournament)
+		is.Equal(err, errDenied)
+	})
+}
@@ -0,0 +1,147 @@
+package services
+
+import (
+	"sort"
+	"time"
+
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"git.sr.ht/~loges/teammate/internal/team/domain/model"
+	"github.com/google/uuid"
+)
+
+// AddVenue initializes a new venue to the repository if valid.
+func (s *RosterService) AddVenue(id uuid.UUID, name string, a model.Address) error {
+	if err := s.authorizer.Authorize(s.actor, entity.PermissionManageTeams, nil); err != nil {
+		return err
+	}
+
+	v, err := model.NewVenue(id, name, a)
+	if err != nil {
+		return err
+	}
+
+	return s.venues.Add(v)
+}
+
+// AddVenueField adds a field or court to the venue.
+func (s *RosterService) AddVenueField(id uuid.UUID, f model.Field) error {
+	if err := s.authorizer.Authorize(s.actor, entity.PermissionManageTeams, nil); err != nil {
+		return err
+	}
+
+	v, err := s.venues.Get(id)
+	if err != nil {
+		return err
+	}
+	if err = v.AddField(f); err != nil {
+		return err
+	}
+
+	return s.venues.Update(v)
+}
+
+// RemoveVenueField removes a field or court from the venue. A field with
+// games booked that haven't ended yet can't be removed.
+func (s *RosterService) RemoveVenueField(id uuid.UUID, name string) error {
+	if err := s.authorizer.Authorize(s.actor, entity.PermissionManageTeams, nil); err != nil {
+		return err
+	}
+
+	v, err := s.venues.Get(id)
+	if err != nil {
+		return err
+	}
+	games, err := s.games.GetByVenue(id)
+	if err != nil {
+		return err
+	}
+	for _, g := range games {
+		if b := g.GetBooking(); b.Field == name && b.EndsAt.After(s.now()) {
+			return model.ErrFieldBooked
+		}
+	}
+	if err = v.RemoveField(name); err != nil {
+		return err
+	}
+
+	return s.venues.Update(v)
+}
+
+// SetVenueOpeningHours sets when the venue opens and closes on the day.
+func (s *RosterService) SetVenueOpeningHours(id uuid.UUID, day time.Weekday, h model.OpeningHours) error {
+	if err := s.authorizer.Authorize(s.actor, entity.PermissionManageTeams, nil); err != nil {
+		return err
+	}
+
+	v, err := s.venues.Get(id)
+	if err != nil {
+		return err
+	}
+	if err = v.SetOpeningHours(day, h); err != nil {
+		return err
+	}
+
+	return s.venues.Update(v)
+}
+
+// GetBookingConflicts returns the games of the calendar that are booked
+// on the same field at the same time.
+func (s *RosterService) GetBookingConflicts() ([]model.BookingConflict, error) {
+	if err := s.authorizer.Authorize(s.actor, entity.PermissionManageTeams, nil); err != nil {
+		return nil, err
+	}
+
+	venues, err := s.venues.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	conflicts := []model.BookingConflict{}
+	for _, v := range venues {
+		games, err := s.games.GetByVenue(v.GetID())
+		if err != nil {
+			return nil, err
+		}
+		conflicts = append(conflicts, model.FindConflicts(games)...)
+	}
+	sort.Slice(conflicts, func(i, j int) bool {
+		return conflicts[i].First.StartsAt.Before(conflicts[j].First.StartsAt)
+	})
+
+	return conflicts, nil
+}
+
+// checkBookings returns whether the fields can be booked on top of the
+// games already booked at the venues, and the venues that were booked.
+// Bookings without a venue are not checked.
+func (s *RosterService) checkBookings(bookings []model.Booking) (map[uuid.UUID]*model.Venue, error) {
+	venues := map[uuid.UUID]*model.Venue{}
+	booked := map[uuid.UUID][]model.Booking{}
+	for _, b := range bookings {
+		if b.VenueID == uuid.Nil {
+			continue
+		}
+
+		v, ok := venues[b.VenueID]
+		if !ok {
+			var err error
+			if v, err = s.venues.Get(b.VenueID); err != nil {
+				return nil, err
+			}
+			games, err := s.games.GetByVenue(b.VenueID)
+			if err != nil {
+				return nil, err
+			}
+			for _, g := range games {
+				booked[b.VenueID] = append(booked[b.VenueID], g.GetBooking())
+			}
+			venues[b.VenueID] = v
+		}
+
+		if err := v.CheckBooking(b, booked[b.VenueID]); err != nil {
+			return nil, err
+		}
+		booked[b.VenueID] = append(booked[b.VenueID], b)
+	}
+	return venues, nil
+}
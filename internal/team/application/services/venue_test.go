@@ -0,0 +1,116 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"git.sr.ht/~loges/teammate/internal/team/domain/model"
+	"git.sr.ht/~loges/teammate/internal/team/domain/repository"
+	"github.com/google/uuid"
+	"github.com/matryer/is"
+)
+
+var exampleVenue = uuid.MustParse("3e1c8a4e-6f1a-11ee-b962-0242ac120002")
+
+func TestRosterService_Venue(t *testing.T) {
+	setup := func(is *is.I) *RosterService {
+		s, _ := NewRosterService(WithAuthorizer(allowAll{}))
+		is.NoErr(s.AddVenue(exampleVenue, "Riverside Park", model.Address{Street: "1 River Rd", City: "Syracuse"}))
+		is.NoErr(s.AddVenueField(exampleVenue, model.Field{Name: "North Field", Surface: "grass"}))
+		return s
+	}
+
+	t.Run("Venue can't be changed", func(t *testing.T) {
+		testCases := []struct {
+			test        string
+			change      func(*RosterService) error
+			expectedErr error
+		}{
+			{"Venue already exists", func(s *RosterService) error { return s.AddVenue(exampleVenue, "Riverside Park", model.Address{}) }, repository.ErrVenueAlreadyExists},
+			{"Venue without a name", func(s *RosterService) error { return s.AddVenue(uuid.New(), "", model.Address{}) }, model.ErrInvalidVenue},
+			{"Venue not found", func(s *RosterService) error { return s.AddVenueField(uuid.New(), model.Field{Name: "Court 1"}) }, repository.ErrVenueNotFound},
+			{"Field already exists", func(s *RosterService) error { return s.AddVenueField(exampleVenue, model.Field{Name: "North Field"}) }, model.ErrFieldAlreadyExists},
+			{"Field not found", func(s *RosterService) error { return s.RemoveVenueField(exampleVenue, "South Field") }, model.ErrFieldNotFound},
+			{"Invalid opening hours", func(s *RosterService) error {
+				return s.SetVenueOpeningHours(exampleVenue, time.Sunday, model.OpeningHours{Opens: 18 * time.Hour, Closes: 9 * time.Hour})
+			}, model.ErrInvalidOpeningHours},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.test, func(t *testing.T) {
+				is := is.New(t)
+				s := setup(is)
+
+				err := tc.change(s)
+
+				is.Equal(err, tc.expectedErr)
+			})
+		}
+	})
+
+	t.Run("Conflicts across the calendar", func(t *testing.T) {
+		is := is.New(t)
+		s := setup(is)
+		is.NoErr(s.AddVenueField(exampleVenue, model.Field{Name: "South Field", Surface: "turf"}))
+		is.NoErr(s.RemoveVenueField(exampleVenue, "South Field"))
+		is.NoErr(s.SetVenueOpeningHours(exampleVenue, time.Sunday, model.OpeningHours{Opens: 9 * time.Hour, Closes: 18 * time.Hour}))
+		startsOn := time.Date(2023, time.August, 1, 0, 0, 0, 0, time.UTC)
+		is.NoErr(s.AddSeason(exampleSeason, "2023/24", startsOn, startsOn.AddDate(1, 0, 0)))
+		is.NoErr(s.AddTeam(exampleGroup))
+		is.NoErr(s.AddTeam(anotherGroup))
+		season, _ := s.seasons.Get(exampleSeason)
+		home, _ := s.teams.Get(exampleGroup)
+		away, _ := s.teams.Get(anotherGroup)
+		location := model.Location{VenueID: exampleVenue, VenueName: "Riverside Park", Field: "North Field"}
+		sunday := time.Date(2023, time.September, 3, 10, 0, 0, 0, time.UTC)
+		// games booked before the booking checks, or imported, may overlap
+		for _, startsAt := range []time.Time{sunday, sunday.Add(time.Hour), sunday.Add(3 * time.Hour)} {
+			g, err := model.NewGame(uuid.New(), season, 1, home, away, startsAt, startsAt.Add(90*time.Minute), location)
+			is.NoErr(err)
+			is.NoErr(s.games.Add(g))
+		}
+
+		conflicts, err := s.GetBookingConflicts()
+
+		is.NoErr(err)
+		is.Equal(len(conflicts), 1)
+		is.Equal(conflicts[0].Location, location)
+		is.Equal(conflicts[0].First.StartsAt, sunday)
+		is.Equal(conflicts[0].Second.StartsAt, sunday.Add(time.Hour))
+	})
+
+	t.Run("Booked field can't be removed", func(t *testing.T) {
+		is := is.New(t)
+		s := setup(is)
+		startsOn := time.Date(2023, time.August, 1, 0, 0, 0, 0, time.UTC)
+		kickoff := time.Date(2023, time.September, 3, 10, 0, 0, 0, time.UTC)
+		is.NoErr(s.AddSeason(exampleSeason, "2023/24", startsOn, startsOn.AddDate(1, 0, 0)))
+		is.NoErr(s.AddTeam(exampleGroup))
+		is.NoErr(s.AddTeam(anotherGroup))
+		season, _ := s.seasons.Get(exampleSeason)
+		home, _ := s.teams.Get(exampleGroup)
+		away, _ := s.teams.Get(anotherGroup)
+		g, err := model.NewGame(uuid.New(), season, 1, home, away, kickoff, kickoff.Add(90*time.Minute), model.Location{VenueID: exampleVenue, Field: "North Field"})
+		is.NoErr(err)
+		is.NoErr(s.games.Add(g))
+		s.now = func() time.Time { return kickoff }
+
+		is.Equal(s.RemoveVenueField(exampleVenue, "North Field"), model.ErrFieldBooked)
+
+		s.now = func() time.Time { return kickoff.Add(2 * time.Hour) }
+		is.NoErr(s.RemoveVenueField(exampleVenue, "North Field"))
+	})
+
+	t.Run("Venues are authorized", func(t *testing.T) {
+		is := is.New(t)
+		s := setup(is)
+		s.authorizer = coachAuthorizer{}
+
+		is.Equal(s.As(examplePerson).AddVenue(uuid.New(), "Lakeside Courts", model.Address{}), errDenied)
+		is.Equal(s.As(examplePerson).AddVenueField(exampleVenue, model.Field{Name: "Court 1"}), errDenied)
+		is.Equal(s.As(examplePerson).RemoveVenueField(exampleVenue, "North Field"), errDenied)
+		is.Equal(s.As(examplePerson).SetVenueOpeningHours(exampleVenue, time.Sunday, model.OpeningHours{}), errDenied)
+		_, err := s.As(examplePerson).GetBookingConflicts()
+		is.Equal(err, errDenied)
+	})
+}
@@ -7,19 +7,35 @@ import (
 // TeamApplication holds all services related to team management.
 type TeamApplication struct {
 	rosterService *services.RosterService
+	exportSource  *services.ExportSource
 }
 
-// NewTeamApplication intitializes the team application.
-func NewTeamApplication() (*TeamApplication, error) {
-	rs, err := services.NewRosterService()
+// NewTeamApplication intitializes the team application. Every roster
+// operation is authorized by the authorizer, and the given configs are
+// passed on to the roster service.
+func NewTeamApplication(a services.Authorizer, cfgs ...services.RosterConfiguration) (*TeamApplication, error) {
+	if a == nil {
+		return &TeamApplication{}, services.ErrInvalidRosterConfig
+	}
+
+	rs, err := services.NewRosterService(append([]services.RosterConfiguration{services.WithAuthorizer(a)}, cfgs...)...)
 	if err != nil {
 		return &TeamApplication{}, services.ErrInvalidRosterConfig
 	}
 
-	return &TeamApplication{rosterService: rs}, nil
+	return &TeamApplication{
+		rosterService: rs,
+		exportSource:  services.NewExportSource(rs),
+	}, nil
 }
 
 // GetRosterService returns the roster service from the app.
 func (a *TeamApplication) GetRosterService() *services.RosterService {
 	return a.rosterService
 }
+
+// GetExportSource returns the source of team data for subject access
+// requests.
+func (a *TeamApplication) GetExportSource() *services.ExportSource {
+	return a.exportSource
+}
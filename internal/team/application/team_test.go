@@ -5,6 +5,7 @@ import (
 
 	"git.sr.ht/~loges/teammate/internal/entity"
 	"git.sr.ht/~loges/teammate/internal/team/application/services"
+	"git.sr.ht/~loges/teammate/internal/team/domain/model"
 	"github.com/google/uuid"
 	"github.com/matryer/is"
 )
@@ -14,6 +15,13 @@ var (
 	examplePerson = &entity.Person{ID: uuid.MustParse("d17ac10b-58cc-0372-8567-0e02b2c3d479"), Name: "Jen"}
 )
 
+// allowAll allows every action.
+type allowAll struct{}
+
+func (allowAll) Authorize(*entity.Person, entity.Permission, *entity.Group) error {
+	return nil
+}
+
 func withInvalidConfig() services.RosterConfiguration {
 	return func(s *services.RosterService) error {
 		return services.ErrInvalidRosterConfig
@@ -23,16 +31,22 @@ func withInvalidConfig() services.RosterConfiguration {
 func TestTeamApplication(t *testing.T) {
 	t.Run("Init team app", func(t *testing.T) {
 		is := is.New(t)
-		_, err := NewTeamApplication()
+		_, err := NewTeamApplication(allowAll{})
 		is.NoErr(err)
 	})
 
+	t.Run("Init failure without an authorizer", func(t *testing.T) {
+		is := is.New(t)
+		_, err := NewTeamApplication(nil)
+		is.Equal(err, services.ErrInvalidRosterConfig)
+	})
+
 	t.Run("Init failure due to bad roster service config", func(t *testing.T) {
 		is := is.New(t)
 		originalConfigs := services.RosterConfigs
 		services.RosterConfigs = []services.RosterConfiguration{withInvalidConfig()}
 
-		_, err := NewTeamApplication()
+		_, err := NewTeamApplication(allowAll{})
 
 		is.Equal(err, services.ErrInvalidRosterConfig)
 		// clean up configs
@@ -41,7 +55,7 @@ func TestTeamApplication(t *testing.T) {
 
 	t.Run("Roster service workflow", func(t *testing.T) {
 		is := is.New(t)
-		ta, err := NewTeamApplication()
+		ta, err := NewTeamApplication(allowAll{})
 		rs := ta.GetRosterService()
 
 		err = rs.AddTeam(exampleGroup)
@@ -50,10 +64,10 @@ func TestTeamApplication(t *testing.T) {
 		err = rs.AddPlayer(examplePerson)
 		is.NoErr(err)
 
-		err = rs.AssignPlayerToTeam(exampleGroup, examplePerson)
+		err = rs.AssignPlayerToTeam(exampleGroup, examplePerson, 7)
 		is.NoErr(err)
 
-		err = rs.UnassignPlayerFromTeam(exampleGroup, examplePerson)
+		err = rs.UnassignPlayerFromTeam(exampleGroup, examplePerson, model.LeaveReasonTransfer)
 		is.NoErr(err)
 	})
 }
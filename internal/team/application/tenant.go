@@ -0,0 +1,42 @@
+package application
+
+import (
+	"strings"
+
+	"git.sr.ht/~loges/teammate/internal/team/application/services"
+	"git.sr.ht/~loges/teammate/internal/team/domain/rules"
+	"git.sr.ht/~loges/teammate/internal/team/infrastructure/memory"
+	"git.sr.ht/~loges/teammate/internal/tenant"
+)
+
+// RulesSetting is the tenant setting listing the comma separated names of
+// the eligibility rules the tenant enforces. Tenants without it enforce
+// rules.Defaults.
+const RulesSetting = "rules"
+
+// TenantConfigs returns the roster configurations of the tenant. Its
+// memory repositories are scoped to the tenant and the rules it enforces
+// are read from its settings.
+func TenantConfigs(t *tenant.Tenant, cfgs ...memory.Configuration) ([]services.RosterConfiguration, error) {
+	defaults := make([]string, len(rules.Defaults))
+	for i, r := range rules.Defaults {
+		defaults[i] = r.Name()
+	}
+
+	var names []string
+	for _, name := range strings.Split(t.Setting(RulesSetting, strings.Join(defaults, ",")), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	enforced, err := rules.Named(names...)
+	if err != nil {
+		return nil, err
+	}
+
+	repositories := append(append([]memory.Configuration{}, cfgs...), memory.WithTenant(t.ID))
+	return []services.RosterConfiguration{
+		services.WithMemoryRepositories(repositories...),
+		services.WithRules(enforced...),
+	}, nil
+}
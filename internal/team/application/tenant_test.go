@@ -0,0 +1,52 @@
+package application
+
+import (
+	"errors"
+	"testing"
+
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"git.sr.ht/~loges/teammate/internal/team/application/services"
+	"git.sr.ht/~loges/teammate/internal/team/domain/model"
+	"git.sr.ht/~loges/teammate/internal/team/domain/rules"
+	"git.sr.ht/~loges/teammate/internal/tenant"
+	"github.com/google/uuid"
+	"github.com/matryer/is"
+)
+
+func TestTenantConfigs(t *testing.T) {
+	anotherPerson := &entity.Person{ID: uuid.MustParse("e27ac10b-58cc-0372-8567-0e02b2c3d479"), Name: "Jackie"}
+	testCases := []struct {
+		test        string
+		settings    map[string]string
+		expectedErr error
+		rosterFull  bool
+	}{
+		{"Default rules", nil, nil, true},
+		{"Rules from settings", map[string]string{RulesSetting: "age_band, gender_division"}, nil, false},
+		{"No rules", map[string]string{RulesSetting: ""}, nil, false},
+		{"Unknown rule", map[string]string{RulesSetting: "salary_cap"}, rules.ErrUnknownRule, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			cfgs, err := TenantConfigs(&tenant.Tenant{ID: "riverside", Name: "Riverside FC", Settings: tc.settings})
+			is.Equal(err, tc.expectedErr)
+			if err != nil {
+				return
+			}
+			rs, err := services.NewRosterService(append(cfgs, services.WithAuthorizer(allowAll{}))...)
+			is.NoErr(err)
+			is.NoErr(rs.AddTeam(exampleGroup))
+			is.NoErr(rs.ChangeTeamRules(exampleGroup, model.RosterRules{MaxRosterSize: 1}))
+			is.NoErr(rs.AddPlayer(examplePerson))
+			is.NoErr(rs.AddPlayer(anotherPerson))
+			is.NoErr(rs.AssignPlayerToTeam(exampleGroup, examplePerson, 7))
+
+			err = rs.AssignPlayerToTeam(exampleGroup, anotherPerson, 8)
+
+			var violation *rules.ViolationError
+			is.Equal(errors.As(err, &violation), tc.rosterFull)
+		})
+	}
+}
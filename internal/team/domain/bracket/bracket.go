@@ -0,0 +1,527 @@
+package bracket
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrNotEnoughTeams = errors.New("bracket: a tournament needs at least two teams")
+	ErrDuplicateTeam  = errors.New("bracket: team is listed more than once")
+	ErrInvalidOptions = errors.New("bracket: invalid tournament options")
+	ErrMatchNotFound  = errors.New("bracket: the match was not found")
+	ErrMatchNotReady  = errors.New("bracket: the teams of the match are not known yet")
+	ErrMatchPlayed    = errors.New("bracket: the match was already played")
+	ErrDrawNotAllowed = errors.New("bracket: a knockout match needs a winner")
+	ErrInvalidScore   = errors.New("bracket: scores can't be negative")
+)
+
+// Format is how the teams of a tournament play each other.
+type Format string
+
+const (
+	// SingleElimination knocks a team out after its first loss.
+	SingleElimination Format = "single_elimination"
+	// DoubleElimination knocks a team out after its second loss. Teams
+	// that lose once drop into the losers bracket, whose winner plays the
+	// winner of the winners bracket in the grand final.
+	DoubleElimination Format = "double_elimination"
+	// GroupsToKnockout plays a round-robin in every group, after which the
+	// best teams of each group play a single elimination knockout.
+	GroupsToKnockout Format = "groups_to_knockout"
+)
+
+// Options configure the format of a tournament.
+type Options struct {
+	Format Format
+	// ThirdPlace adds a playoff between the losing semi-finalists of a
+	// knockout. Double elimination decides third place in the losers
+	// bracket instead.
+	ThirdPlace bool
+	// Groups is the number of groups of a group stage.
+	Groups int
+	// Advance is the number of teams of each group that go through to the
+	// knockout.
+	Advance int
+}
+
+// Result is the score of a played match.
+type Result struct {
+	MatchID   string
+	HomeScore int
+	AwayScore int
+}
+
+// Standing is the record of a team in a league or group.
+type Standing struct {
+	Team    *entity.Group
+	Played  int
+	Won     int
+	Drawn   int
+	Lost    int
+	For     int
+	Against int
+	Points  int
+}
+
+// Match is a match of the bracket. The teams are nil until the matches
+// deciding them are played.
+type Match struct {
+	ID        string
+	Home      *entity.Group
+	Away      *entity.Group
+	HomeScore int
+	AwayScore int
+	Played    bool
+	// Bye is set when a team advances without an opponent.
+	Bye    bool
+	Winner *entity.Group
+	Loser  *entity.Group
+
+	draws bool
+}
+
+// Round is a round of matches of a knockout.
+type Round struct {
+	Name    string
+	Matches []*Match
+}
+
+// Group is a group of a group stage and its standings.
+type Group struct {
+	Name      string
+	Matches   []*Match
+	Standings []Standing
+}
+
+// Bracket is the state of a tournament after the recorded results.
+type Bracket struct {
+	Format Format
+	Groups []*Group
+	// Winners are the rounds of the knockout, ending with the final. In
+	// double elimination they are the rounds of the winners bracket.
+	Winners []*Round
+	// Losers are the rounds of the losers bracket of double elimination.
+	Losers []*Round
+	// GrandFinal is played by the winners of both brackets of double
+	// elimination. It is replayed when the winner of the losers bracket
+	// wins the first game, since neither team has lost twice.
+	GrandFinal []*Match
+	ThirdPlace *Match
+	// Champion is nil until the tournament is decided.
+	Champion *entity.Group
+
+	matches map[string]*Match
+}
+
+// Check returns whether the result can be recorded.
+func (b *Bracket) Check(r Result) error {
+	m, ok := b.matches[r.MatchID]
+	if !ok {
+		return ErrMatchNotFound
+	}
+	if m.Played {
+		return ErrMatchPlayed
+	}
+	if m.Bye || m.Home == nil || m.Away == nil {
+		return ErrMatchNotReady
+	}
+	if r.HomeScore < 0 || r.AwayScore < 0 {
+		return ErrInvalidScore
+	}
+	if !m.draws && r.HomeScore == r.AwayScore {
+		return ErrDrawNotAllowed
+	}
+	return nil
+}
+
+// Build draws the bracket of the teams, given in seeding order, and
+// advances the teams through it with the results.
+func Build(teams []*entity.Group, o Options, results []Result) (*Bracket, error) {
+	if len(teams) < 2 {
+		return nil, ErrNotEnoughTeams
+	}
+	seen := make(map[uuid.UUID]bool, len(teams))
+	for _, t := range teams {
+		if seen[t.ID] {
+			return nil, ErrDuplicateTeam
+		}
+		seen[t.ID] = true
+	}
+
+	b := &builder{
+		bracket: &Bracket{Format: o.Format, matches: make(map[string]*Match)},
+		results: make(map[string]Result, len(results)),
+	}
+	for _, r := range results {
+		b.results[r.MatchID] = r
+	}
+
+	seeds := make([]slot, len(teams))
+	for i, t := range teams {
+		seeds[i] = slot{team: t, known: true}
+	}
+
+	switch o.Format {
+	case SingleElimination:
+		b.bracket.Champion = b.knockout(draw(seeds), o.ThirdPlace).team
+	case DoubleElimination:
+		if o.ThirdPlace {
+			return nil, ErrInvalidOptions
+		}
+		b.bracket.Champion = b.doubleElimination(seeds).team
+	case GroupsToKnockout:
+		if o.Groups < 1 || o.Advance < 1 || o.Groups*o.Advance < 2 || len(teams) < o.Groups*2 || len(teams)/o.Groups < o.Advance {
+			return nil, ErrInvalidOptions
+		}
+		qualifiers, groups := b.groupStage(seeds, o.Groups, o.Advance)
+		b.bracket.Champion = b.knockout(drawApart(qualifiers, groups), o.ThirdPlace).team
+	default:
+		return nil, ErrInvalidOptions
+	}
+
+	return b.bracket, nil
+}
+
+// Seeds returns the teams of the standings in seeding order, ranked by
+// points, goal difference and goals scored. Tied teams keep their order.
+func Seeds(standings []Standing) []*entity.Group {
+	ranked := Rank(standings)
+	teams := make([]*entity.Group, len(ranked))
+	for i, s := range ranked {
+		teams[i] = s.Team
+	}
+	return teams
+}
+
+// Rank returns the standings ranked by points, goal difference and goals
+// scored. Tied teams keep their order.
+func Rank(standings []Standing) []Standing {
+	ranked := append([]Standing{}, standings...)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		if a.Points != b.Points {
+			return a.Points > b.Points
+		}
+		if a.For-a.Against != b.For-b.Against {
+			return a.For-a.Against > b.For-b.Against
+		}
+		return a.For > b.For
+	})
+	return ranked
+}
+
+// slot is a place in a match. A known slot without a team is a bye.
+type slot struct {
+	team  *entity.Group
+	known bool
+}
+
+type builder struct {
+	bracket *Bracket
+	results map[string]Result
+}
+
+// match adds the match between the slots and returns the winner and the
+// loser once they are known.
+func (b *builder) match(id string, home, away slot, draws bool) (*Match, slot, slot) {
+	m := &Match{ID: id, Home: home.team, Away: away.team, draws: draws}
+	b.bracket.matches[id] = m
+
+	if !home.known || !away.known {
+		return m, slot{}, slot{}
+	}
+	if home.team == nil || away.team == nil {
+		m.Bye = true
+		m.Winner = home.team
+		if m.Winner == nil {
+			m.Winner = away.team
+		}
+		return m, slot{team: m.Winner, known: true}, slot{known: true}
+	}
+
+	r, ok := b.results[id]
+	if !ok {
+		return m, slot{}, slot{}
+	}
+	m.Played = true
+	m.HomeScore, m.AwayScore = r.HomeScore, r.AwayScore
+	switch {
+	case r.HomeScore > r.AwayScore:
+		m.Winner, m.Loser = home.team, away.team
+	case r.AwayScore > r.HomeScore:
+		m.Winner, m.Loser = away.team, home.team
+	}
+	return m, slot{team: m.Winner, known: true}, slot{team: m.Loser, known: true}
+}
+
+// draw pads the seeds with byes to a power of two and orders them so the
+// best seeds meet as late as possible and get the byes.
+func draw(seeds []slot) []slot {
+	return place(seeds, seedOrder(len(seeds)))
+}
+
+// drawApart draws the seeds like draw, but swaps seeds of close rank so
+// that teams from the same group don't meet in the first round, unless no
+// team from another group can take their place. groups holds the group
+// of every seed.
+func drawApart(seeds []slot, groups []int) []slot {
+	order := seedOrder(len(seeds))
+	apart := func(a, b int) bool {
+		return a > len(seeds) || b > len(seeds) || groups[a-1] != groups[b-1]
+	}
+	distance := func(a, b int) int {
+		if a > b {
+			return a - b
+		}
+		return b - a
+	}
+
+	for i := 0; i < len(order); i += 2 {
+		if apart(order[i], order[i+1]) {
+			continue
+		}
+		swap := -1
+		for k, s := range order {
+			if k/2 == i/2 || s > len(seeds) || !apart(order[i], s) || !apart(order[k^1], order[i+1]) {
+				continue
+			}
+			if swap == -1 || distance(s, order[i+1]) < distance(order[swap], order[i+1]) {
+				swap = k
+			}
+		}
+		if swap != -1 {
+			order[i+1], order[swap] = order[swap], order[i+1]
+		}
+	}
+	return place(seeds, order)
+}
+
+// seedOrder returns the seed numbers, counting from 1, in the order they
+// are drawn for n seeds padded to a power of two. Numbers above n are
+// byes.
+func seedOrder(n int) []int {
+	order := []int{1}
+	for len(order) < n {
+		size := len(order) * 2
+		next := make([]int, 0, size)
+		for _, s := range order {
+			next = append(next, s, size+1-s)
+		}
+		order = next
+	}
+	return order
+}
+
+// place puts the seeds in the order of their seed numbers, with byes for
+// the numbers without a seed.
+func place(seeds []slot, order []int) []slot {
+	drawn := make([]slot, len(order))
+	for i, s := range order {
+		if s <= len(seeds) {
+			drawn[i] = seeds[s-1]
+		} else {
+			drawn[i] = slot{known: true}
+		}
+	}
+	return drawn
+}
+
+// knockout adds the rounds of a single elimination of the drawn slots and
+// returns the champion.
+func (b *builder) knockout(alive []slot, thirdPlace bool) slot {
+	var semiLosers []slot
+	for r := 1; len(alive) > 1; r++ {
+		round := &Round{Name: roundName(len(alive) / 2)}
+		var winners []slot
+		for i := 0; i < len(alive); i += 2 {
+			m, winner, loser := b.match(fmt.Sprintf("R%d-%d", r, i/2+1), alive[i], alive[i+1], false)
+			round.Matches = append(round.Matches, m)
+			winners = append(winners, winner)
+			if len(alive) == 4 {
+				semiLosers = append(semiLosers, loser)
+			}
+		}
+		b.bracket.Winners = append(b.bracket.Winners, round)
+		alive = winners
+	}
+
+	if thirdPlace && len(semiLosers) == 2 {
+		b.bracket.ThirdPlace, _, _ = b.match("3P", semiLosers[0], semiLosers[1], false)
+	}
+	return alive[0]
+}
+
+// doubleElimination adds the winners bracket, the losers bracket and the
+// grand final of the seeds and returns the champion.
+func (b *builder) doubleElimination(seeds []slot) slot {
+	alive := draw(seeds)
+	var losers [][]slot
+	for r := 1; len(alive) > 1; r++ {
+		name := fmt.Sprintf("Winners round %d", r)
+		if len(alive) == 2 {
+			name = "Winners final"
+		}
+		round := &Round{Name: name}
+		var winners, dropped []slot
+		for i := 0; i < len(alive); i += 2 {
+			m, winner, loser := b.match(fmt.Sprintf("W%d-%d", r, i/2+1), alive[i], alive[i+1], false)
+			round.Matches = append(round.Matches, m)
+			winners = append(winners, winner)
+			dropped = append(dropped, loser)
+		}
+		b.bracket.Winners = append(b.bracket.Winners, round)
+		losers = append(losers, dropped)
+		alive = winners
+	}
+
+	survivors := losers[0]
+	r := 1
+	play := func(pairs [][2]slot) {
+		round := &Round{Name: fmt.Sprintf("Losers round %d", r)}
+		var winners []slot
+		for i, pair := range pairs {
+			m, winner, _ := b.match(fmt.Sprintf("L%d-%d", r, i+1), pair[0], pair[1], false)
+			round.Matches = append(round.Matches, m)
+			winners = append(winners, winner)
+		}
+		b.bracket.Losers = append(b.bracket.Losers, round)
+		survivors = winners
+		r++
+	}
+	for w := 0; w < len(losers); w++ {
+		if w > 0 {
+			// Teams dropping from the winners bracket play the survivors of
+			// the losers bracket, in reverse every other round so that
+			// teams don't meet again straight away.
+			dropped := losers[w]
+			var pairs [][2]slot
+			for i := range survivors {
+				j := i
+				if w%2 == 1 {
+					j = len(dropped) - 1 - i
+				}
+				pairs = append(pairs, [2]slot{survivors[i], dropped[j]})
+			}
+			play(pairs)
+		}
+		if len(survivors) > 1 {
+			var pairs [][2]slot
+			for i := 0; i < len(survivors); i += 2 {
+				pairs = append(pairs, [2]slot{survivors[i], survivors[i+1]})
+			}
+			play(pairs)
+		}
+	}
+	if n := len(b.bracket.Losers); n > 0 {
+		b.bracket.Losers[n-1].Name = "Losers final"
+	}
+
+	first, winner, _ := b.match("GF1", alive[0], survivors[0], false)
+	b.bracket.GrandFinal = append(b.bracket.GrandFinal, first)
+	if first.Played && first.Winner.ID == survivors[0].team.ID {
+		var reset *Match
+		reset, winner, _ = b.match("GF2", alive[0], survivors[0], false)
+		b.bracket.GrandFinal = append(b.bracket.GrandFinal, reset)
+	}
+	return winner
+}
+
+// groupStage adds a round-robin group for every group and returns the
+// teams going through to the knockout in seeding order, the group
+// winners first, and the group each of them comes from. The seeds are
+// spread over the groups in a snake so that the groups are of equal
+// strength.
+func (b *builder) groupStage(seeds []slot, groups, advance int) ([]slot, []int) {
+	members := make([][]*entity.Group, groups)
+	for i, s := range seeds {
+		g := i % groups
+		if (i/groups)%2 == 1 {
+			g = groups - 1 - g
+		}
+		members[g] = append(members[g], s.team)
+	}
+
+	places := make([][]slot, advance)
+	for g, teams := range members {
+		name := string(rune('A' + g))
+		group := &Group{Name: name}
+		standings := make(map[uuid.UUID]*Standing, len(teams))
+		for _, t := range teams {
+			standings[t.ID] = &Standing{Team: t}
+		}
+
+		complete := true
+		for i := 0; i < len(teams); i++ {
+			for j := i + 1; j < len(teams); j++ {
+				home, away := slot{team: teams[i], known: true}, slot{team: teams[j], known: true}
+				m, _, _ := b.match(fmt.Sprintf("%s-%d", name, len(group.Matches)+1), home, away, true)
+				group.Matches = append(group.Matches, m)
+				if !m.Played {
+					complete = false
+					continue
+				}
+				standings[m.Home.ID].Record(m.HomeScore, m.AwayScore)
+				standings[m.Away.ID].Record(m.AwayScore, m.HomeScore)
+			}
+		}
+
+		for _, t := range teams {
+			group.Standings = append(group.Standings, *standings[t.ID])
+		}
+		group.Standings = Rank(group.Standings)
+		b.bracket.Groups = append(b.bracket.Groups, group)
+
+		for p := 0; p < advance; p++ {
+			if complete {
+				places[p] = append(places[p], slot{team: group.Standings[p].Team, known: true})
+			} else {
+				places[p] = append(places[p], slot{})
+			}
+		}
+	}
+
+	var qualifiers []slot
+	var origins []int
+	for _, place := range places {
+		qualifiers = append(qualifiers, place...)
+		for g := range place {
+			origins = append(origins, g)
+		}
+	}
+	return qualifiers, origins
+}
+
+// Record adds a played match to the standing.
+func (s *Standing) Record(scored, conceded int) {
+	s.Played++
+	s.For += scored
+	s.Against += conceded
+	switch {
+	case scored > conceded:
+		s.Won++
+		s.Points += 3
+	case scored == conceded:
+		s.Drawn++
+		s.Points++
+	default:
+		s.Lost++
+	}
+}
+
+func roundName(matches int) string {
+	switch matches {
+	case 1:
+		return "Final"
+	case 2:
+		return "Semi-finals"
+	case 4:
+		return "Quarter-finals"
+	default:
+		return fmt.Sprintf("Round of %d", matches*2)
+	}
+}
@@ -0,0 +1,303 @@
+package bracket
+
+import (
+	"fmt"
+	"testing"
+
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"github.com/google/uuid"
+	"github.com/matryer/is"
+)
+
+func teams(n int) []*entity.Group {
+	var teams []*entity.Group
+	for i := 0; i < n; i++ {
+		teams = append(teams, &entity.Group{ID: uuid.New(), Name: fmt.Sprintf("Seed %d", i+1)})
+	}
+	return teams
+}
+
+// play records a win for the better seed of every match that is ready,
+// unless upset names the match, until no match is left.
+func play(is *is.I, teams []*entity.Group, o Options, upset map[string]bool) (*Bracket, []Result) {
+	seed := map[uuid.UUID]int{}
+	for i, t := range teams {
+		seed[t.ID] = i
+	}
+	var results []Result
+	for {
+		b, err := Build(teams, o, results)
+		is.NoErr(err)
+		var ready []Result
+		for id, m := range b.matches {
+			if m.Played || m.Bye || m.Home == nil || m.Away == nil {
+				continue
+			}
+			r := Result{MatchID: id, HomeScore: 1}
+			if seed[m.Away.ID] < seed[m.Home.ID] != upset[id] {
+				r = Result{MatchID: id, AwayScore: 1}
+			}
+			is.NoErr(b.Check(r))
+			ready = append(ready, r)
+		}
+		if len(ready) == 0 {
+			return b, results
+		}
+		results = append(results, ready...)
+	}
+}
+
+func names(rounds []*Round) []string {
+	var names []string
+	for _, r := range rounds {
+		names = append(names, r.Name)
+	}
+	return names
+}
+
+func TestBuild_SingleElimination(t *testing.T) {
+	testCases := []struct {
+		test       string
+		teams      int
+		thirdPlace bool
+		rounds     []string
+		byes       int
+	}{
+		{"Two teams", 2, false, []string{"Final"}, 0},
+		{"Four teams with third place", 4, true, []string{"Semi-finals", "Final"}, 0},
+		{"Six teams get byes", 6, false, []string{"Quarter-finals", "Semi-finals", "Final"}, 2},
+		{"Sixteen teams", 16, true, []string{"Round of 16", "Quarter-finals", "Semi-finals", "Final"}, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			teams := teams(tc.teams)
+
+			b, results := play(is, teams, Options{Format: SingleElimination, ThirdPlace: tc.thirdPlace}, nil)
+
+			is.Equal(names(b.Winners), tc.rounds)
+			byes := 0
+			for _, m := range b.Winners[0].Matches {
+				if m.Bye {
+					byes++
+					is.True(m.Winner == teams[0] || m.Winner == teams[1]) // top seeds get the byes
+				}
+			}
+			is.Equal(byes, tc.byes)
+			is.Equal(b.Champion, teams[0])
+			if tc.thirdPlace {
+				is.Equal(len(results), tc.teams) // the playoff is played too
+				is.True(b.ThirdPlace.Played)
+				is.Equal(b.ThirdPlace.Winner, teams[2])
+			} else {
+				is.Equal(len(results), tc.teams-1) // every other team is knocked out once
+				is.True(b.ThirdPlace == nil)
+			}
+		})
+	}
+}
+
+func TestBuild_Seeding(t *testing.T) {
+	is := is.New(t)
+	teams := teams(8)
+
+	b, err := Build(teams, Options{Format: SingleElimination}, nil)
+
+	is.NoErr(err)
+	var pairs [][2]string
+	for _, m := range b.Winners[0].Matches {
+		pairs = append(pairs, [2]string{m.Home.Name, m.Away.Name})
+	}
+	is.Equal(pairs, [][2]string{{"Seed 1", "Seed 8"}, {"Seed 4", "Seed 5"}, {"Seed 2", "Seed 7"}, {"Seed 3", "Seed 6"}})
+	is.True(b.Winners[1].Matches[0].Home == nil) // decided by the first round
+	is.True(b.Champion == nil)
+}
+
+func TestBuild_Advancement(t *testing.T) {
+	is := is.New(t)
+	teams := teams(4)
+	o := Options{Format: SingleElimination}
+
+	b, _ := Build(teams, o, []Result{{MatchID: "R1-1", HomeScore: 0, AwayScore: 2}})
+
+	is.Equal(b.Winners[0].Matches[0].Loser, teams[0])
+	is.Equal(b.Winners[1].Matches[0].Home, teams[3]) // the winner advances
+	is.True(b.Winners[1].Matches[0].Away == nil)
+	is.Equal(b.Check(Result{MatchID: "R2-1", HomeScore: 1}), ErrMatchNotReady)
+	is.Equal(b.Check(Result{MatchID: "R1-1", HomeScore: 1}), ErrMatchPlayed)
+	is.Equal(b.Check(Result{MatchID: "R1-2", HomeScore: 1, AwayScore: 1}), ErrDrawNotAllowed)
+	is.Equal(b.Check(Result{MatchID: "R1-2", HomeScore: -1}), ErrInvalidScore)
+	is.Equal(b.Check(Result{MatchID: "R9-9", HomeScore: 1}), ErrMatchNotFound)
+	is.NoErr(b.Check(Result{MatchID: "R1-2", AwayScore: 3}))
+}
+
+func TestBuild_DoubleElimination(t *testing.T) {
+	testCases := []struct {
+		test       string
+		teams      int
+		upset      map[string]bool
+		losers     []string
+		grandFinal int
+		champion   int
+	}{
+		{"Two teams", 2, nil, nil, 1, 0},
+		{"Three teams", 3, nil, []string{"Losers round 1", "Losers final"}, 1, 0},
+		{"Eight teams", 8, nil, []string{"Losers round 1", "Losers round 2", "Losers round 3", "Losers final"}, 1, 0},
+		{"Winners bracket winner takes the title", 4, map[string]bool{"W1-1": true, "GF1": true}, []string{"Losers round 1", "Losers final"}, 1, 1},
+		{"Losers bracket winner forces a reset", 4, map[string]bool{"W1-1": true}, []string{"Losers round 1", "Losers final"}, 2, 0},
+		{"Winners bracket winner takes the reset", 4, map[string]bool{"W1-1": true, "GF2": true}, []string{"Losers round 1", "Losers final"}, 2, 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			teams := teams(tc.teams)
+
+			b, _ := play(is, teams, Options{Format: DoubleElimination}, tc.upset)
+
+			is.Equal(names(b.Losers), tc.losers)
+			is.Equal(len(b.GrandFinal), tc.grandFinal)
+			is.Equal(b.Champion, teams[tc.champion])
+
+			losses := map[uuid.UUID]int{}
+			for _, rounds := range [][]*Round{b.Winners, b.Losers} {
+				for _, r := range rounds {
+					for _, m := range r.Matches {
+						if m.Loser != nil {
+							losses[m.Loser.ID]++
+						}
+					}
+				}
+			}
+			for _, m := range b.GrandFinal {
+				losses[m.Loser.ID]++
+			}
+			for _, team := range teams {
+				if team == b.Champion {
+					is.True(losses[team.ID] < 2)
+				} else {
+					is.Equal(losses[team.ID], 2) // knocked out after two losses
+				}
+			}
+		})
+	}
+}
+
+func TestBuild_GroupsToKnockout(t *testing.T) {
+	is := is.New(t)
+	teams := teams(8)
+	o := Options{Format: GroupsToKnockout, Groups: 2, Advance: 2, ThirdPlace: true}
+
+	b, err := Build(teams, o, nil)
+	is.NoErr(err)
+	is.Equal(len(b.Groups), 2)
+	var seeds []string
+	for _, s := range b.Groups[0].Standings {
+		seeds = append(seeds, s.Team.Name)
+	}
+	is.Equal(seeds, []string{"Seed 1", "Seed 4", "Seed 5", "Seed 8"}) // snake draw
+	is.Equal(len(b.Groups[0].Matches), 6)
+	is.True(b.Winners[0].Matches[0].Home == nil) // waits for the group stage
+	is.NoErr(b.Check(Result{MatchID: "A-1", HomeScore: 2, AwayScore: 2}))
+
+	b, _ = play(is, teams, o, map[string]bool{"B-1": true})
+
+	is.Equal(b.Groups[0].Standings[0].Points, 9)
+	is.Equal(b.Groups[1].Standings[0].Team, teams[2]) // beat the top seed of the group
+	is.Equal(b.Winners[0].Matches[0].Home, teams[0])  // group winner plays a runner-up
+	is.Equal(b.Winners[0].Matches[0].Away, teams[1])
+	is.Equal(b.Champion, teams[0])
+	is.True(b.ThirdPlace.Played)
+}
+
+func TestBuild_GroupsApartInFirstRound(t *testing.T) {
+	testCases := []struct {
+		test  string
+		teams int
+		o     Options
+	}{
+		{"Two groups", 8, Options{Format: GroupsToKnockout, Groups: 2, Advance: 2}},
+		{"Three groups", 9, Options{Format: GroupsToKnockout, Groups: 3, Advance: 2}},
+		{"Four groups", 16, Options{Format: GroupsToKnockout, Groups: 4, Advance: 2}},
+		{"Five groups", 15, Options{Format: GroupsToKnockout, Groups: 5, Advance: 2}},
+		{"Three groups, three advance", 12, Options{Format: GroupsToKnockout, Groups: 3, Advance: 3}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			teams := teams(tc.teams)
+			var results []Result
+			for g := 0; g < tc.o.Groups; g++ {
+				for m := 1; m <= 10; m++ {
+					results = append(results, Result{MatchID: fmt.Sprintf("%c-%d", 'A'+g, m), HomeScore: 1})
+				}
+			}
+
+			b, err := Build(teams, tc.o, results)
+
+			is.NoErr(err)
+			group := map[uuid.UUID]string{}
+			for _, g := range b.Groups {
+				for _, s := range g.Standings {
+					group[s.Team.ID] = g.Name
+				}
+			}
+			qualified := 0
+			for _, m := range b.Winners[0].Matches {
+				if m.Bye {
+					qualified++
+					continue
+				}
+				qualified += 2
+				is.True(group[m.Home.ID] != group[m.Away.ID]) // same group met in the first round
+			}
+			is.Equal(qualified, tc.o.Groups*tc.o.Advance)
+		})
+	}
+}
+
+func TestBuild_Errors(t *testing.T) {
+	team := &entity.Group{ID: uuid.New(), Name: "Tigers"}
+	testCases := []struct {
+		test  string
+		teams []*entity.Group
+		o     Options
+		err   error
+	}{
+		{"Too few teams", []*entity.Group{team}, Options{Format: SingleElimination}, ErrNotEnoughTeams},
+		{"Team is listed twice", []*entity.Group{team, team}, Options{Format: SingleElimination}, ErrDuplicateTeam},
+		{"Unknown format", teams(4), Options{Format: "swiss"}, ErrInvalidOptions},
+		{"Third place in double elimination", teams(4), Options{Format: DoubleElimination, ThirdPlace: true}, ErrInvalidOptions},
+		{"No groups", teams(4), Options{Format: GroupsToKnockout, Advance: 1}, ErrInvalidOptions},
+		{"Group of one", teams(3), Options{Format: GroupsToKnockout, Groups: 2, Advance: 1}, ErrInvalidOptions},
+		{"Too many advance", teams(6), Options{Format: GroupsToKnockout, Groups: 2, Advance: 4}, ErrInvalidOptions},
+		{"Only one advances", teams(4), Options{Format: GroupsToKnockout, Groups: 1, Advance: 1}, ErrInvalidOptions},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+
+			_, err := Build(tc.teams, tc.o, nil)
+
+			is.Equal(err, tc.err)
+		})
+	}
+}
+
+func TestSeeds(t *testing.T) {
+	is := is.New(t)
+	teams := teams(4)
+	standings := []Standing{
+		{Team: teams[0], Points: 4, For: 4, Against: 2},
+		{Team: teams[1], Points: 6, For: 1, Against: 4},
+		{Team: teams[2], Points: 4, For: 5, Against: 3},
+		{Team: teams[3], Points: 4, For: 5, Against: 2},
+	}
+
+	seeds := Seeds(standings)
+
+	is.Equal(seeds, []*entity.Group{teams[1], teams[3], teams[2], teams[0]})
+}
@@ -0,0 +1,68 @@
+package event
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GameScheduled event.
+type GameScheduled struct {
+	ID           uuid.UUID `json:"id"`
+	SeasonId     uuid.UUID `json:"season_id"`
+	Round        int       `json:"round"`
+	HomeTeamId   uuid.UUID `json:"home_team_id"`
+	HomeTeamName string    `json:"home_team_name"`
+	AwayTeamId   uuid.UUID `json:"away_team_id"`
+	AwayTeamName string    `json:"away_team_name"`
+	StartsAt     time.Time `json:"starts_at"`
+	EndsAt       time.Time `json:"ends_at"`
+	VenueId      uuid.UUID `json:"venue_id"`
+	VenueName    string    `json:"venue_name"`
+	Field        string    `json:"field"`
+}
+
+func (e GameScheduled) eventName() string {
+	return reflect.TypeOf(e).Name()
+}
+
+// PlayerRespondedToGame event.
+type PlayerRespondedToGame struct {
+	ID       uuid.UUID `json:"id"`
+	PlayerId uuid.UUID `json:"player_id"`
+	Response string    `json:"response"`
+}
+
+func (e PlayerRespondedToGame) eventName() string {
+	return reflect.TypeOf(e).Name()
+}
+
+// GameResultRecorded event.
+type GameResultRecorded struct {
+	ID        uuid.UUID `json:"id"`
+	HomeScore int       `json:"home_score"`
+	AwayScore int       `json:"away_score"`
+}
+
+func (e GameResultRecorded) eventName() string {
+	return reflect.TypeOf(e).Name()
+}
+
+// GameCancelled event.
+type GameCancelled struct {
+	ID uuid.UUID `json:"id"`
+}
+
+func (e GameCancelled) eventName() string {
+	return reflect.TypeOf(e).Name()
+}
+
+// GameRestored event.
+type GameRestored struct {
+	ID uuid.UUID `json:"id"`
+}
+
+func (e GameRestored) eventName() string {
+	return reflect.TypeOf(e).Name()
+}
@@ -0,0 +1,28 @@
+package event
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestGameEvent(t *testing.T) {
+	testCases := []struct {
+		test     string
+		event    Event
+		expected string
+	}{
+		{"GameScheduled event name", &GameScheduled{}, "GameScheduled"},
+		{"PlayerRespondedToGame event name", &PlayerRespondedToGame{}, "PlayerRespondedToGame"},
+		{"GameResultRecorded event name", &GameResultRecorded{}, "GameResultRecorded"},
+		{"GameCancelled event name", &GameCancelled{}, "GameCancelled"},
+		{"GameRestored event name", &GameRestored{}, "GameRestored"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			is.Equal(tc.event.eventName(), tc.expected)
+		})
+	}
+}
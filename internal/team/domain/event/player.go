@@ -2,6 +2,7 @@ package event
 
 import (
 	"reflect"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -39,6 +40,7 @@ type TeamAssignedToPlayer struct {
 	ID       uuid.UUID `json:"id"`
 	TeamId   uuid.UUID `json:"team_id"`
 	TeamName string    `json:"team_name"`
+	JoinedAt time.Time `json:"joined_at"`
 }
 
 func (e TeamAssignedToPlayer) eventName() string {
@@ -50,8 +52,158 @@ type TeamUnassignedFromPlayer struct {
 	ID       uuid.UUID `json:"id"`
 	TeamId   uuid.UUID `json:"team_id"`
 	TeamName string    `json:"team_name"`
+	LeftAt   time.Time `json:"left_at"`
+	Reason   string    `json:"reason"`
 }
 
 func (e TeamUnassignedFromPlayer) eventName() string {
 	return reflect.TypeOf(e).Name()
 }
+
+// PlayerInvited event.
+type PlayerInvited struct {
+	ID   uuid.UUID `json:"id"`
+	Code string    `json:"code"`
+}
+
+func (e PlayerInvited) eventName() string {
+	return reflect.TypeOf(e).Name()
+}
+
+// UserLinkedToPlayer event.
+type UserLinkedToPlayer struct {
+	ID       uuid.UUID `json:"id"`
+	UserId   uuid.UUID `json:"user_id"`
+	UserName string    `json:"user_name"`
+}
+
+func (e UserLinkedToPlayer) eventName() string {
+	return reflect.TypeOf(e).Name()
+}
+
+// UserUnlinkedFromPlayer event.
+type UserUnlinkedFromPlayer struct {
+	ID     uuid.UUID `json:"id"`
+	UserId uuid.UUID `json:"user_id"`
+}
+
+func (e UserUnlinkedFromPlayer) eventName() string {
+	return reflect.TypeOf(e).Name()
+}
+
+// PlayerDetailsChanged event.
+type PlayerDetailsChanged struct {
+	ID          uuid.UUID `json:"id"`
+	DateOfBirth time.Time `json:"date_of_birth"`
+	Gender      string    `json:"gender"`
+}
+
+func (e PlayerDetailsChanged) eventName() string {
+	return reflect.TypeOf(e).Name()
+}
+
+// PlayerContactDetailsChanged event.
+type PlayerContactDetailsChanged struct {
+	ID         uuid.UUID `json:"id"`
+	Phone      string    `json:"phone"`
+	Street     string    `json:"street"`
+	City       string    `json:"city"`
+	PostalCode string    `json:"postal_code"`
+	Country    string    `json:"country"`
+}
+
+func (e PlayerContactDetailsChanged) eventName() string {
+	return reflect.TypeOf(e).Name()
+}
+
+// EmergencyContact is an emergency contact within player events.
+type EmergencyContact struct {
+	Name         string `json:"name"`
+	Relationship string `json:"relationship"`
+	Phone        string `json:"phone"`
+}
+
+// PlayerEmergencyContactsChanged event.
+type PlayerEmergencyContactsChanged struct {
+	ID       uuid.UUID          `json:"id"`
+	Contacts []EmergencyContact `json:"contacts"`
+}
+
+func (e PlayerEmergencyContactsChanged) eventName() string {
+	return reflect.TypeOf(e).Name()
+}
+
+// PlayerMedicalNotesChanged event.
+type PlayerMedicalNotesChanged struct {
+	ID    uuid.UUID `json:"id"`
+	Notes string    `json:"notes"`
+}
+
+func (e PlayerMedicalNotesChanged) eventName() string {
+	return reflect.TypeOf(e).Name()
+}
+
+// GuardianAddedToPlayer event.
+type GuardianAddedToPlayer struct {
+	ID           uuid.UUID `json:"id"`
+	UserId       uuid.UUID `json:"user_id"`
+	UserName     string    `json:"user_name"`
+	Relationship string    `json:"relationship"`
+}
+
+func (e GuardianAddedToPlayer) eventName() string {
+	return reflect.TypeOf(e).Name()
+}
+
+// GuardianRemovedFromPlayer event.
+type GuardianRemovedFromPlayer struct {
+	ID     uuid.UUID `json:"id"`
+	UserId uuid.UUID `json:"user_id"`
+}
+
+func (e GuardianRemovedFromPlayer) eventName() string {
+	return reflect.TypeOf(e).Name()
+}
+
+// PlayerHouseholdChanged event.
+type PlayerHouseholdChanged struct {
+	ID          uuid.UUID `json:"id"`
+	HouseholdId uuid.UUID `json:"household_id"`
+}
+
+func (e PlayerHouseholdChanged) eventName() string {
+	return reflect.TypeOf(e).Name()
+}
+
+// PlayerMarkedUnavailable event.
+type PlayerMarkedUnavailable struct {
+	ID         uuid.UUID `json:"id"`
+	PeriodId   uuid.UUID `json:"period_id"`
+	Reason     string    `json:"reason"`
+	From       time.Time `json:"from"`
+	To         time.Time `json:"to"`
+	Visibility string    `json:"visibility"`
+}
+
+func (e PlayerMarkedUnavailable) eventName() string {
+	return reflect.TypeOf(e).Name()
+}
+
+// PlayerUnavailabilityCleared event.
+type PlayerUnavailabilityCleared struct {
+	ID       uuid.UUID `json:"id"`
+	PeriodId uuid.UUID `json:"period_id"`
+}
+
+func (e PlayerUnavailabilityCleared) eventName() string {
+	return reflect.TypeOf(e).Name()
+}
+
+// PlayerErased event.
+type PlayerErased struct {
+	ID uuid.UUID `json:"id"`
+}
+
+func (e PlayerErased) eventName() string {
+	return reflect.TypeOf(e).Name()
+}
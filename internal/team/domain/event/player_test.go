@@ -17,6 +17,19 @@ func TestPlayerEvent(t *testing.T) {
 		{"PlayerDeactivated event name", &PlayerDeactivated{}, "PlayerDeactivated"},
 		{"TeamAssignedToPlayer event name", &TeamAssignedToPlayer{}, "TeamAssignedToPlayer"},
 		{"TeamUnassignedFromPlayer event name", &TeamUnassignedFromPlayer{}, "TeamUnassignedFromPlayer"},
+		{"PlayerInvited event name", &PlayerInvited{}, "PlayerInvited"},
+		{"UserLinkedToPlayer event name", &UserLinkedToPlayer{}, "UserLinkedToPlayer"},
+		{"UserUnlinkedFromPlayer event name", &UserUnlinkedFromPlayer{}, "UserUnlinkedFromPlayer"},
+		{"PlayerDetailsChanged event name", &PlayerDetailsChanged{}, "PlayerDetailsChanged"},
+		{"PlayerContactDetailsChanged event name", &PlayerContactDetailsChanged{}, "PlayerContactDetailsChanged"},
+		{"PlayerEmergencyContactsChanged event name", &PlayerEmergencyContactsChanged{}, "PlayerEmergencyContactsChanged"},
+		{"PlayerMedicalNotesChanged event name", &PlayerMedicalNotesChanged{}, "PlayerMedicalNotesChanged"},
+		{"GuardianAddedToPlayer event name", &GuardianAddedToPlayer{}, "GuardianAddedToPlayer"},
+		{"GuardianRemovedFromPlayer event name", &GuardianRemovedFromPlayer{}, "GuardianRemovedFromPlayer"},
+		{"PlayerHouseholdChanged event name", &PlayerHouseholdChanged{}, "PlayerHouseholdChanged"},
+		{"PlayerMarkedUnavailable event name", &PlayerMarkedUnavailable{}, "PlayerMarkedUnavailable"},
+		{"PlayerUnavailabilityCleared event name", &PlayerUnavailabilityCleared{}, "PlayerUnavailabilityCleared"},
+		{"PlayerErased event name", &PlayerErased{}, "PlayerErased"},
 	}
 
 	for _, tc := range testCases {
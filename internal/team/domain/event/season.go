@@ -0,0 +1,29 @@
+package event
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SeasonCreated event.
+type SeasonCreated struct {
+	ID       uuid.UUID `json:"id"`
+	Name     string    `json:"name"`
+	StartsOn time.Time `json:"starts_on"`
+	EndsOn   time.Time `json:"ends_on"`
+}
+
+func (e SeasonCreated) eventName() string {
+	return reflect.TypeOf(e).Name()
+}
+
+// SeasonClosed event.
+type SeasonClosed struct {
+	ID uuid.UUID `json:"id"`
+}
+
+func (e SeasonClosed) eventName() string {
+	return reflect.TypeOf(e).Name()
+}
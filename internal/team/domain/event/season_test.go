@@ -0,0 +1,25 @@
+package event
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestSeasonEvent(t *testing.T) {
+	testCases := []struct {
+		test     string
+		event    Event
+		expected string
+	}{
+		{"SeasonCreated event name", &SeasonCreated{}, "SeasonCreated"},
+		{"SeasonClosed event name", &SeasonClosed{}, "SeasonClosed"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			is.Equal(tc.event.eventName(), tc.expected)
+		})
+	}
+}
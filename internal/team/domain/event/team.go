@@ -36,9 +36,10 @@ func (e TeamDeactivated) eventName() string {
 
 // PlayerAssignedToTeam event.
 type PlayerAssignedToTeam struct {
-	ID         uuid.UUID `json:"id"`
-	PlayerId   uuid.UUID `json:"player_id"`
-	PlayerName string    `json:"player_name"`
+	ID           uuid.UUID `json:"id"`
+	PlayerId     uuid.UUID `json:"player_id"`
+	PlayerName   string    `json:"player_name"`
+	JerseyNumber int       `json:"jersey_number"`
 }
 
 func (e PlayerAssignedToTeam) eventName() string {
@@ -55,3 +56,147 @@ type PlayerUnassignedFromTeam struct {
 func (e PlayerUnassignedFromTeam) eventName() string {
 	return reflect.TypeOf(e).Name()
 }
+
+// TeamDeactivationCascaded event.
+type TeamDeactivationCascaded struct {
+	ID            uuid.UUID         `json:"id"`
+	PlayerIds     []uuid.UUID       `json:"player_ids"`
+	SessionIds    []uuid.UUID       `json:"session_ids"`
+	JerseyNumbers map[uuid.UUID]int `json:"jersey_numbers"`
+}
+
+func (e TeamDeactivationCascaded) eventName() string {
+	return reflect.TypeOf(e).Name()
+}
+
+// TeamDeactivationReverted event.
+type TeamDeactivationReverted struct {
+	ID uuid.UUID `json:"id"`
+}
+
+func (e TeamDeactivationReverted) eventName() string {
+	return reflect.TypeOf(e).Name()
+}
+
+// TeamSportChanged event.
+type TeamSportChanged struct {
+	ID    uuid.UUID `json:"id"`
+	Sport string    `json:"sport"`
+}
+
+func (e TeamSportChanged) eventName() string {
+	return reflect.TypeOf(e).Name()
+}
+
+// JerseyNumberChanged event.
+type JerseyNumberChanged struct {
+	ID       uuid.UUID `json:"id"`
+	PlayerId uuid.UUID `json:"player_id"`
+	Number   int       `json:"number"`
+	Previous int       `json:"previous"`
+}
+
+func (e JerseyNumberChanged) eventName() string {
+	return reflect.TypeOf(e).Name()
+}
+
+// PlayerPositionsAssigned event.
+type PlayerPositionsAssigned struct {
+	ID        uuid.UUID `json:"id"`
+	PlayerId  uuid.UUID `json:"player_id"`
+	Primary   string    `json:"primary"`
+	Secondary []string  `json:"secondary"`
+}
+
+func (e PlayerPositionsAssigned) eventName() string {
+	return reflect.TypeOf(e).Name()
+}
+
+// DepthChartChanged event.
+type DepthChartChanged struct {
+	ID        uuid.UUID   `json:"id"`
+	Position  string      `json:"position"`
+	PlayerIds []uuid.UUID `json:"player_ids"`
+}
+
+func (e DepthChartChanged) eventName() string {
+	return reflect.TypeOf(e).Name()
+}
+
+// StaffMemberAdded event.
+type StaffMemberAdded struct {
+	ID         uuid.UUID `json:"id"`
+	PersonId   uuid.UUID `json:"person_id"`
+	PersonName string    `json:"person_name"`
+	Role       string    `json:"role"`
+}
+
+func (e StaffMemberAdded) eventName() string {
+	return reflect.TypeOf(e).Name()
+}
+
+// StaffMemberRemoved event.
+type StaffMemberRemoved struct {
+	ID       uuid.UUID `json:"id"`
+	PersonId uuid.UUID `json:"person_id"`
+}
+
+func (e StaffMemberRemoved) eventName() string {
+	return reflect.TypeOf(e).Name()
+}
+
+// StaffMemberErased event.
+type StaffMemberErased struct {
+	ID       uuid.UUID `json:"id"`
+	PersonId uuid.UUID `json:"person_id"`
+}
+
+func (e StaffMemberErased) eventName() string {
+	return reflect.TypeOf(e).Name()
+}
+
+// PlayerDesignated event.
+type PlayerDesignated struct {
+	ID          uuid.UUID `json:"id"`
+	PlayerId    uuid.UUID `json:"player_id"`
+	Designation string    `json:"designation"`
+}
+
+func (e PlayerDesignated) eventName() string {
+	return reflect.TypeOf(e).Name()
+}
+
+// PlayerDesignationRemoved event.
+type PlayerDesignationRemoved struct {
+	ID       uuid.UUID `json:"id"`
+	PlayerId uuid.UUID `json:"player_id"`
+}
+
+func (e PlayerDesignationRemoved) eventName() string {
+	return reflect.TypeOf(e).Name()
+}
+
+// TeamRulesChanged event.
+type TeamRulesChanged struct {
+	ID                uuid.UUID `json:"id"`
+	MaxRosterSize     int       `json:"max_roster_size"`
+	MinAge            int       `json:"min_age"`
+	MaxAge            int       `json:"max_age"`
+	Division          string    `json:"division"`
+	MaxTeamsPerPlayer int       `json:"max_teams_per_player"`
+}
+
+func (e TeamRulesChanged) eventName() string {
+	return reflect.TypeOf(e).Name()
+}
+
+// TeamSeasonStarted event.
+type TeamSeasonStarted struct {
+	ID       uuid.UUID   `json:"id"`
+	SeasonId uuid.UUID   `json:"season_id"`
+	Released []uuid.UUID `json:"released"`
+}
+
+func (e TeamSeasonStarted) eventName() string {
+	return reflect.TypeOf(e).Name()
+}
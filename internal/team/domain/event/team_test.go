@@ -17,6 +17,19 @@ func TestTeamEvent(t *testing.T) {
 		{"TeamDeactivated event name", &TeamDeactivated{}, "TeamDeactivated"},
 		{"PlayerAssignedToTeam event name", &PlayerAssignedToTeam{}, "PlayerAssignedToTeam"},
 		{"PlayerUnassignedFromTeam event name", &PlayerUnassignedFromTeam{}, "PlayerUnassignedFromTeam"},
+		{"TeamDeactivationCascaded event name", &TeamDeactivationCascaded{}, "TeamDeactivationCascaded"},
+		{"TeamDeactivationReverted event name", &TeamDeactivationReverted{}, "TeamDeactivationReverted"},
+		{"TeamSportChanged event name", &TeamSportChanged{}, "TeamSportChanged"},
+		{"JerseyNumberChanged event name", &JerseyNumberChanged{}, "JerseyNumberChanged"},
+		{"PlayerPositionsAssigned event name", &PlayerPositionsAssigned{}, "PlayerPositionsAssigned"},
+		{"DepthChartChanged event name", &DepthChartChanged{}, "DepthChartChanged"},
+		{"StaffMemberAdded event name", &StaffMemberAdded{}, "StaffMemberAdded"},
+		{"StaffMemberRemoved event name", &StaffMemberRemoved{}, "StaffMemberRemoved"},
+		{"StaffMemberErased event name", &StaffMemberErased{}, "StaffMemberErased"},
+		{"PlayerDesignated event name", &PlayerDesignated{}, "PlayerDesignated"},
+		{"PlayerDesignationRemoved event name", &PlayerDesignationRemoved{}, "PlayerDesignationRemoved"},
+		{"TeamRulesChanged event name", &TeamRulesChanged{}, "TeamRulesChanged"},
+		{"TeamSeasonStarted event name", &TeamSeasonStarted{}, "TeamSeasonStarted"},
 	}
 
 	for _, tc := range testCases {
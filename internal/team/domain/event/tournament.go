@@ -0,0 +1,40 @@
+package event
+
+import (
+	"reflect"
+
+	"github.com/google/uuid"
+)
+
+// TournamentTeam is a team within tournament events.
+type TournamentTeam struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+}
+
+// TournamentCreated event. The teams are in seeding order.
+type TournamentCreated struct {
+	ID         uuid.UUID        `json:"id"`
+	Name       string           `json:"name"`
+	Format     string           `json:"format"`
+	ThirdPlace bool             `json:"third_place"`
+	Groups     int              `json:"groups"`
+	Advance    int              `json:"advance"`
+	Teams      []TournamentTeam `json:"teams"`
+}
+
+func (e TournamentCreated) eventName() string {
+	return reflect.TypeOf(e).Name()
+}
+
+// TournamentResultRecorded event.
+type TournamentResultRecorded struct {
+	ID        uuid.UUID `json:"id"`
+	MatchId   string    `json:"match_id"`
+	HomeScore int       `json:"home_score"`
+	AwayScore int       `json:"away_score"`
+}
+
+func (e TournamentResultRecorded) eventName() string {
+	return reflect.TypeOf(e).Name()
+}
@@ -0,0 +1,25 @@
+package event
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestTournamentEvent(t *testing.T) {
+	testCases := []struct {
+		test     string
+		event    Event
+		expected string
+	}{
+		{"TournamentCreated event name", &TournamentCreated{}, "TournamentCreated"},
+		{"TournamentResultRecorded event name", &TournamentResultRecorded{}, "TournamentResultRecorded"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			is.Equal(tc.event.eventName(), tc.expected)
+		})
+	}
+}
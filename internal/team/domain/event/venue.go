@@ -0,0 +1,55 @@
+package event
+
+import (
+	"reflect"
+
+	"github.com/google/uuid"
+)
+
+// VenueCreated event.
+type VenueCreated struct {
+	ID         uuid.UUID `json:"id"`
+	Name       string    `json:"name"`
+	Street     string    `json:"street"`
+	City       string    `json:"city"`
+	PostalCode string    `json:"postal_code"`
+	Country    string    `json:"country"`
+}
+
+func (e VenueCreated) eventName() string {
+	return reflect.TypeOf(e).Name()
+}
+
+// VenueFieldAdded event.
+type VenueFieldAdded struct {
+	ID      uuid.UUID `json:"id"`
+	Field   string    `json:"field"`
+	Surface string    `json:"surface"`
+}
+
+func (e VenueFieldAdded) eventName() string {
+	return reflect.TypeOf(e).Name()
+}
+
+// VenueFieldRemoved event.
+type VenueFieldRemoved struct {
+	ID    uuid.UUID `json:"id"`
+	Field string    `json:"field"`
+}
+
+func (e VenueFieldRemoved) eventName() string {
+	return reflect.TypeOf(e).Name()
+}
+
+// VenueOpeningHoursChanged event. The venue opens and closes the given
+// number of minutes after midnight, and is closed when both are zero.
+type VenueOpeningHoursChanged struct {
+	ID       uuid.UUID `json:"id"`
+	Weekday  int       `json:"weekday"`
+	OpensAt  int       `json:"opens_at"`
+	ClosesAt int       `json:"closes_at"`
+}
+
+func (e VenueOpeningHoursChanged) eventName() string {
+	return reflect.TypeOf(e).Name()
+}
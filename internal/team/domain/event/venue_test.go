@@ -0,0 +1,27 @@
+package event
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestVenueEvent(t *testing.T) {
+	testCases := []struct {
+		test     string
+		event    Event
+		expected string
+	}{
+		{"VenueCreated event name", &VenueCreated{}, "VenueCreated"},
+		{"VenueFieldAdded event name", &VenueFieldAdded{}, "VenueFieldAdded"},
+		{"VenueFieldRemoved event name", &VenueFieldRemoved{}, "VenueFieldRemoved"},
+		{"VenueOpeningHoursChanged event name", &VenueOpeningHoursChanged{}, "VenueOpeningHoursChanged"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			is.Equal(tc.event.eventName(), tc.expected)
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package fixtures
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"git.sr.ht/~loges/teammate/internal/team/domain/model"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrNotEnoughTeams = errors.New("fixtures: a round-robin needs at least two teams")
+	ErrDuplicateTeam  = errors.New("fixtures: team is listed more than once")
+	ErrNotEnoughSlots = errors.New("fixtures: not enough dates with enough slots for every round")
+)
+
+// Slot is a time a game can be played on a field of a venue.
+type Slot struct {
+	StartsAt time.Time
+	EndsAt   time.Time
+	Location model.Location
+}
+
+// Plan describes the round-robin to generate.
+type Plan struct {
+	Teams []*entity.Group
+	// Slots are the times and venues available. The slots on one date
+	// make up a matchday, which hosts a whole round.
+	Slots []Slot
+	// Double plays every pairing twice, once at the home of each team.
+	Double bool
+	// Blackouts are dates no game is played on.
+	Blackouts []time.Time
+}
+
+// Fixture is a game of the generated schedule.
+type Fixture struct {
+	Round int
+	Home  *entity.Group
+	Away  *entity.Group
+	Slot  Slot
+}
+
+// Bye is a team sitting out a round, since an odd number of teams leaves
+// one team without an opponent every round.
+type Bye struct {
+	Round int
+	Team  *entity.Group
+}
+
+// Schedule is a generated round-robin, ordered by round.
+type Schedule struct {
+	Fixtures []Fixture
+	Byes     []Bye
+}
+
+// Generate pairs every team with every other team once, or twice for a
+// double round-robin, using the circle method. Home games are balanced so
+// no team hosts more than one game more than it visits, and a double
+// round-robin mirrors the first half with home and away swapped. Each
+// round is played on the next date that is not blacked out and has a
+// slot for every game of the round.
+func Generate(p Plan) (*Schedule, error) {
+	if len(p.Teams) < 2 {
+		return nil, ErrNotEnoughTeams
+	}
+	seen := make(map[uuid.UUID]bool, len(p.Teams))
+	for _, t := range p.Teams {
+		if seen[t.ID] {
+			return nil, ErrDuplicateTeam
+		}
+		seen[t.ID] = true
+	}
+
+	pairings, byes := pair(p.Teams)
+	if p.Double {
+		rounds := len(pairings)
+		for r := 0; r < rounds; r++ {
+			var mirrored [][2]*entity.Group
+			for _, game := range pairings[r] {
+				mirrored = append(mirrored, [2]*entity.Group{game[1], game[0]})
+			}
+			pairings = append(pairings, mirrored)
+			byes = append(byes, byes[r])
+		}
+	}
+
+	days := matchdays(p.Slots, p.Blackouts, len(pairings[0]))
+	if len(days) < len(pairings) {
+		return nil, ErrNotEnoughSlots
+	}
+
+	s := &Schedule{}
+	for r, games := range pairings {
+		for i, game := range games {
+			s.Fixtures = append(s.Fixtures, Fixture{Round: r + 1, Home: game[0], Away: game[1], Slot: days[r][i]})
+		}
+		if byes[r] != nil {
+			s.Byes = append(s.Byes, Bye{Round: r + 1, Team: byes[r]})
+		}
+	}
+	return s, nil
+}
+
+// pair returns the home and away team of every game of every round, and
+// the team with a bye in every round. An odd number of teams is padded
+// with a bye, which is kept in the fixed position of the circle so the
+// home games of the teams stay balanced.
+func pair(teams []*entity.Group) ([][][2]*entity.Group, []*entity.Group) {
+	circle := append([]*entity.Group{}, teams...)
+	if len(circle)%2 == 1 {
+		circle = append([]*entity.Group{nil}, circle...)
+	}
+	n := len(circle)
+
+	rounds := make([][][2]*entity.Group, n-1)
+	byes := make([]*entity.Group, n-1)
+	for r := range rounds {
+		for i := 0; i < n/2; i++ {
+			a, b := circle[i], circle[n-1-i]
+			if a == nil {
+				byes[r] = b
+				continue
+			}
+			if (i == 0 && r%2 == 1) || (i > 0 && i%2 == 1) {
+				a, b = b, a
+			}
+			rounds[r] = append(rounds[r], [2]*entity.Group{a, b})
+		}
+		last := circle[n-1]
+		copy(circle[2:], circle[1:n-1])
+		circle[1] = last
+	}
+	return rounds, byes
+}
+
+// matchdays groups the slots by date in chronological order, leaving out
+// blacked out dates and dates with fewer than games slots. The slots of a
+// matchday are ordered by time, venue and field.
+func matchdays(slots []Slot, blackouts []time.Time, games int) [][]Slot {
+	sorted := append([]Slot{}, slots...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if !sorted[i].StartsAt.Equal(sorted[j].StartsAt) {
+			return sorted[i].StartsAt.Before(sorted[j].StartsAt)
+		}
+		a, b := sorted[i].Location, sorted[j].Location
+		if a.VenueName != b.VenueName {
+			return a.VenueName < b.VenueName
+		}
+		return a.Field < b.Field
+	})
+
+	var days [][]Slot
+	var day []Slot
+	for i, slot := range sorted {
+		day = append(day, slot)
+		if i+1 < len(sorted) && sameDate(slot.StartsAt, sorted[i+1].StartsAt) {
+			continue
+		}
+		if len(day) >= games && !blackedOut(slot.StartsAt, blackouts) {
+			days = append(days, day)
+		}
+		day = nil
+	}
+	return days
+}
+
+// blackedOut returns whether t falls on one of the blackout dates.
+func blackedOut(t time.Time, blackouts []time.Time) bool {
+	for _, b := range blackouts {
+		if sameDate(t, b.In(t.Location())) {
+			return true
+		}
+	}
+	return false
+}
+
+func sameDate(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.In(a.Location()).Date()
+	return ay == by && am == bm && ad == bd
+}
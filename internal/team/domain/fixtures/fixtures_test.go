@@ -0,0 +1,152 @@
+package fixtures
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"git.sr.ht/~loges/teammate/internal/team/domain/model"
+	"github.com/google/uuid"
+	"github.com/matryer/is"
+)
+
+var (
+	northField    = model.Location{VenueID: uuid.MustParse("3e1c8a4e-6f1a-11ee-b962-0242ac120002"), VenueName: "Riverside Park", Field: "North Field"}
+	southField    = model.Location{VenueID: uuid.MustParse("3e1c8a4e-6f1a-11ee-b962-0242ac120002"), VenueName: "Riverside Park", Field: "South Field"}
+	exampleSunday = time.Date(2023, time.September, 3, 10, 0, 0, 0, time.UTC)
+)
+
+func teams(n int) []*entity.Group {
+	var teams []*entity.Group
+	for i := 0; i < n; i++ {
+		teams = append(teams, &entity.Group{ID: uuid.New(), Name: fmt.Sprintf("Team %d", i+1)})
+	}
+	return teams
+}
+
+// sundays returns two slots on each field on each of the next n Sundays.
+func sundays(n int) []Slot {
+	var slots []Slot
+	for i := 0; i < n; i++ {
+		day := exampleSunday.AddDate(0, 0, 7*i)
+		for _, l := range []model.Location{northField, southField} {
+			slots = append(slots, Slot{StartsAt: day, EndsAt: day.Add(90 * time.Minute), Location: l}, Slot{StartsAt: day.Add(2 * time.Hour), EndsAt: day.Add(210 * time.Minute), Location: l})
+		}
+	}
+	return slots
+}
+
+func TestGenerate(t *testing.T) {
+	testCases := []struct {
+		test   string
+		teams  int
+		double bool
+		rounds int
+		games  int
+		byes   int
+	}{
+		{"Two teams", 2, false, 1, 1, 0},
+		{"Even number of teams", 6, false, 5, 15, 0},
+		{"Odd number of teams", 5, false, 5, 10, 5},
+		{"Double round-robin", 4, true, 6, 12, 0},
+		{"Double round-robin with byes", 7, true, 14, 42, 14},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			teams := teams(tc.teams)
+
+			s, err := Generate(Plan{Teams: teams, Slots: sundays(tc.rounds), Double: tc.double})
+
+			is.NoErr(err)
+			is.Equal(len(s.Fixtures), tc.games)
+			is.Equal(len(s.Byes), tc.byes)
+			is.Equal(s.Fixtures[len(s.Fixtures)-1].Round, tc.rounds)
+
+			pairings := map[[2]uuid.UUID]int{}
+			home := map[uuid.UUID]int{}
+			away := map[uuid.UUID]int{}
+			played := map[int]map[uuid.UUID]bool{}
+			for _, f := range s.Fixtures {
+				is.True(f.Home.ID != f.Away.ID)
+				if played[f.Round] == nil {
+					played[f.Round] = map[uuid.UUID]bool{}
+				}
+				is.True(!played[f.Round][f.Home.ID]) // plays once a round
+				is.True(!played[f.Round][f.Away.ID]) // plays once a round
+				played[f.Round][f.Home.ID] = true
+				played[f.Round][f.Away.ID] = true
+				pairings[[2]uuid.UUID{f.Home.ID, f.Away.ID}]++
+				home[f.Home.ID]++
+				away[f.Away.ID]++
+			}
+			for _, b := range s.Byes {
+				is.True(!played[b.Round][b.Team.ID]) // sits out the round
+			}
+			for _, a := range teams {
+				is.True(home[a.ID]-away[a.ID] <= 1) // home games are balanced
+				is.True(away[a.ID]-home[a.ID] <= 1) // away games are balanced
+				for _, b := range teams {
+					if a == b {
+						continue
+					}
+					games := pairings[[2]uuid.UUID{a.ID, b.ID}] + pairings[[2]uuid.UUID{b.ID, a.ID}]
+					if tc.double {
+						is.Equal(pairings[[2]uuid.UUID{a.ID, b.ID}], 1) // hosts each team once
+					} else {
+						is.Equal(games, 1)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateSlots(t *testing.T) {
+	is := is.New(t)
+	teams := teams(4)
+	slots := sundays(4)
+	// A single slot on a Saturday can't fit a round of two games.
+	slots = append(slots, Slot{StartsAt: exampleSunday.AddDate(0, 0, -1), Location: northField})
+
+	s, err := Generate(Plan{Teams: teams, Slots: slots, Blackouts: []time.Time{exampleSunday.AddDate(0, 0, 7)}})
+
+	is.NoErr(err)
+	days := map[int]time.Time{}
+	for _, f := range s.Fixtures {
+		is.True(!f.Slot.StartsAt.IsZero())
+		days[f.Round] = f.Slot.StartsAt.Truncate(24 * time.Hour)
+	}
+	is.Equal(days[1], exampleSunday.Truncate(24*time.Hour))
+	is.Equal(days[2], exampleSunday.AddDate(0, 0, 14).Truncate(24*time.Hour)) // skips the blackout
+	is.Equal(days[3], exampleSunday.AddDate(0, 0, 21).Truncate(24*time.Hour))
+	is.Equal(s.Fixtures[0].Slot.Location, northField)
+	is.Equal(s.Fixtures[1].Slot.Location, southField)
+	is.Equal(s.Fixtures[1].Slot.StartsAt, exampleSunday)
+}
+
+func TestGenerateErrors(t *testing.T) {
+	team := &entity.Group{ID: uuid.New(), Name: "Tigers"}
+	testCases := []struct {
+		test string
+		plan Plan
+		err  error
+	}{
+		{"Too few teams", Plan{Teams: []*entity.Group{team}, Slots: sundays(1)}, ErrNotEnoughTeams},
+		{"Team is listed twice", Plan{Teams: []*entity.Group{team, team}, Slots: sundays(1)}, ErrDuplicateTeam},
+		{"Too few dates", Plan{Teams: teams(4), Slots: sundays(2)}, ErrNotEnoughSlots},
+		{"Dates are blacked out", Plan{Teams: teams(2), Slots: sundays(1), Blackouts: []time.Time{exampleSunday}}, ErrNotEnoughSlots},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+
+			_, err := Generate(tc.plan)
+
+			is.Equal(err, tc.err)
+		})
+	}
+}
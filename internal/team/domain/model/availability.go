@@ -0,0 +1,71 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidUnavailability  = errors.New("model: unavailability needs a known reason and has to end after it starts")
+	ErrUnavailabilityNotFound = errors.New("model: player has no unavailability with this ID")
+)
+
+// UnavailabilityReason is why a player can't play.
+type UnavailabilityReason string
+
+const (
+	UnavailabilityReasonInjured   UnavailabilityReason = "injured"
+	UnavailabilityReasonIll       UnavailabilityReason = "ill"
+	UnavailabilityReasonSuspended UnavailabilityReason = "suspended"
+	UnavailabilityReasonHoliday   UnavailabilityReason = "holiday"
+	UnavailabilityReasonOther     UnavailabilityReason = "other"
+)
+
+// IsValid returns whether the reason is a known reason.
+func (r UnavailabilityReason) IsValid() bool {
+	switch r {
+	case UnavailabilityReasonInjured, UnavailabilityReasonIll, UnavailabilityReasonSuspended,
+		UnavailabilityReasonHoliday, UnavailabilityReasonOther:
+		return true
+	}
+	return false
+}
+
+// Visibility is who may see why a player is unavailable. Everyone who
+// can view the roster sees that the player is unavailable.
+type Visibility string
+
+const (
+	// VisibilityStaff shows the reason only to those allowed to view
+	// sensitive player data, and to the guardians of the player.
+	VisibilityStaff Visibility = "staff"
+	// VisibilityTeam shows the reason to everyone who can view the roster.
+	VisibilityTeam Visibility = "team"
+)
+
+// Unavailability is a period a player can't play.
+type Unavailability struct {
+	ID         uuid.UUID
+	Reason     UnavailabilityReason
+	From       time.Time
+	To         time.Time
+	Visibility Visibility
+}
+
+// Overlaps returns whether the player is unavailable for any of the time.
+func (u Unavailability) Overlaps(from, to time.Time) bool {
+	return u.From.Before(to) && from.Before(u.To)
+}
+
+// Availability is whether a player can play a game and whether the player
+// attends it. The reason is left out when the player is available or the
+// reason is not visible, and the RSVP when the player has not responded.
+type Availability struct {
+	Player    *entity.Person
+	Available bool
+	Reason    UnavailabilityReason
+	RSVP      RSVP
+}
@@ -0,0 +1,95 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidGender      = errors.New("model: gender is not valid")
+	ErrInvalidDivision    = errors.New("model: division is not valid")
+	ErrInvalidRosterRules = errors.New("model: roster rules are not valid")
+)
+
+// Gender is the gender a player competes as.
+type Gender string
+
+const (
+	GenderUnspecified Gender = ""
+	GenderFemale      Gender = "female"
+	GenderMale        Gender = "male"
+)
+
+// IsValid returns whether the gender is a known gender.
+func (g Gender) IsValid() bool {
+	switch g {
+	case GenderUnspecified, GenderFemale, GenderMale:
+		return true
+	}
+	return false
+}
+
+// Division is the gender division a team competes in.
+type Division string
+
+const (
+	DivisionOpen   Division = ""
+	DivisionFemale Division = "female"
+	DivisionMale   Division = "male"
+)
+
+// IsValid returns whether the division is a known division.
+func (d Division) IsValid() bool {
+	switch d {
+	case DivisionOpen, DivisionFemale, DivisionMale:
+		return true
+	}
+	return false
+}
+
+// Admits returns whether a player of the gender can compete in the
+// division.
+func (d Division) Admits(g Gender) bool {
+	switch d {
+	case DivisionFemale:
+		return g == GenderFemale
+	case DivisionMale:
+		return g == GenderMale
+	}
+	return true
+}
+
+// RosterRules configure who is eligible for a team's roster. Zero values
+// are not enforced.
+type RosterRules struct {
+	MaxRosterSize int
+	// MinAge is the age a player has to have reached when the season
+	// starts.
+	MinAge int
+	// MaxAge is the age a player has to be under when the season starts,
+	// so 12 is an U12 team.
+	MaxAge   int
+	Division Division
+	// MaxTeamsPerPlayer is how many teams a player can be on in a season.
+	MaxTeamsPerPlayer int
+}
+
+// IsValid returns whether the rules are consistent.
+func (r RosterRules) IsValid() bool {
+	if r.MaxRosterSize < 0 || r.MinAge < 0 || r.MaxAge < 0 || r.MaxTeamsPerPlayer < 0 {
+		return false
+	}
+	if r.MaxAge > 0 && r.MinAge >= r.MaxAge {
+		return false
+	}
+	return r.Division.IsValid()
+}
+
+// ageOn returns the age in whole years of someone born at birth on the day.
+func ageOn(birth, day time.Time) int {
+	age := day.Year() - birth.Year()
+	if day.Month() < birth.Month() || (day.Month() == birth.Month() && day.Day() < birth.Day()) {
+		age--
+	}
+	return age
+}
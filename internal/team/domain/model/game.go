@@ -0,0 +1,346 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"git.sr.ht/~loges/teammate/internal/team/domain/bracket"
+	"git.sr.ht/~loges/teammate/internal/team/domain/event"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidGame       = errors.New("model: game has to be between two teams at a time")
+	ErrTeamNotActive     = errors.New("model: team is not active")
+	ErrOutsideOfSeason   = errors.New("model: game is outside of the season")
+	ErrInvalidRSVP       = errors.New("model: rsvp is not valid")
+	ErrGameUpdateFailed  = errors.New("model: game update failed")
+	ErrInvalidResult     = errors.New("model: scores can't be negative")
+	ErrGameCancelled     = errors.New("model: game is cancelled")
+	ErrPlayerUnavailable = errors.New("model: player is unavailable for the game")
+)
+
+// RSVP is whether a player attends a game.
+type RSVP string
+
+const (
+	RSVPAttending    RSVP = "attending"
+	RSVPNotAttending RSVP = "not_attending"
+	RSVPMaybe        RSVP = "maybe"
+)
+
+// IsValid returns whether the RSVP is known.
+func (r RSVP) IsValid() bool {
+	return r == RSVPAttending || r == RSVPNotAttending || r == RSVPMaybe
+}
+
+// Location is the field of a venue a game is played on.
+type Location struct {
+	VenueID   uuid.UUID
+	VenueName string
+	Field     string
+}
+
+// ScheduledGame is a game of the schedule, the teams playing it and when
+// and where it is played.
+type ScheduledGame struct {
+	ID       uuid.UUID
+	SeasonID uuid.UUID
+	Round    int
+	Home     *entity.Group
+	Away     *entity.Group
+	StartsAt time.Time
+	EndsAt   time.Time
+	Location Location
+}
+
+// Result is the score of a played game.
+type Result struct {
+	HomeScore int
+	AwayScore int
+}
+
+// Game is a aggregate that represents a game between two teams.
+type Game struct {
+	id        uuid.UUID
+	seasonId  uuid.UUID
+	round     int
+	home      *entity.Group
+	away      *entity.Group
+	startsAt  time.Time
+	endsAt    time.Time
+	location  Location
+	rsvps     map[uuid.UUID]RSVP
+	result    *Result
+	cancelled bool
+
+	changes []event.Event
+	version int
+}
+
+// NewGame is a factory to create a new Game aggregate in the round of the
+// season. Both teams have to be active and the game has to start within
+// the open season.
+func NewGame(id uuid.UUID, s *Season, round int, home, away *Team, startsAt, endsAt time.Time, l Location) (*Game, error) {
+	g := &Game{}
+
+	if home.GetID() == away.GetID() || startsAt.IsZero() || !endsAt.After(startsAt) || round < 1 {
+		return g, ErrInvalidGame
+	}
+	if !home.IsActivated() || !away.IsActivated() {
+		return g, ErrTeamNotActive
+	}
+	if s.IsClosed() {
+		return g, ErrSeasonClosed
+	}
+	if !s.Contains(startsAt) {
+		return g, ErrOutsideOfSeason
+	}
+
+	g.register(&event.GameScheduled{
+		ID:           id,
+		SeasonId:     s.GetID(),
+		Round:        round,
+		HomeTeamId:   home.GetID(),
+		HomeTeamName: home.GetName(),
+		AwayTeamId:   away.GetID(),
+		AwayTeamName: away.GetName(),
+		StartsAt:     startsAt,
+		EndsAt:       endsAt,
+		VenueId:      l.VenueID,
+		VenueName:    l.VenueName,
+		Field:        l.Field,
+	})
+
+	return g, nil
+}
+
+// NewGameFromEvents is a helper method that creates a new game from a
+// series of events.
+func NewGameFromEvents(events []event.Event) *Game {
+	g := &Game{}
+
+	for _, event := range events {
+		g.Apply(event, false)
+	}
+
+	return g
+}
+
+// GetID returns the game root entity ID.
+func (g *Game) GetID() uuid.UUID {
+	return g.id
+}
+
+// GetSeasonID returns the ID of the season the game is played in.
+func (g *Game) GetSeasonID() uuid.UUID {
+	return g.seasonId
+}
+
+// GetStartsAt returns when the game starts.
+func (g *Game) GetStartsAt() time.Time {
+	return g.startsAt
+}
+
+// GetBooking returns the use of the field the game is played on.
+func (g *Game) GetBooking() Booking {
+	return Booking{VenueID: g.location.VenueID, Field: g.location.Field, StartsAt: g.startsAt, EndsAt: g.endsAt}
+}
+
+// Involves returns whether the team plays the game.
+func (g *Game) Involves(teamId uuid.UUID) bool {
+	return g.home.ID == teamId || g.away.ID == teamId
+}
+
+// TeamOf returns the team the player plays the game for, by the player's
+// own record.
+func (g *Game) TeamOf(p *Player) (*entity.Group, bool) {
+	for _, t := range p.GetTeams() {
+		if g.Involves(t.ID) {
+			return t, true
+		}
+	}
+	return nil, false
+}
+
+// Cancel calls the game off. The game keeps its field booked, so it can
+// be restored.
+func (g *Game) Cancel() error {
+	if g.cancelled {
+		return ErrGameUpdateFailed
+	}
+
+	g.register(&event.GameCancelled{ID: g.id})
+
+	return nil
+}
+
+// Restore puts a cancelled game back on the schedule.
+func (g *Game) Restore() error {
+	if !g.cancelled {
+		return ErrGameUpdateFailed
+	}
+
+	g.register(&event.GameRestored{ID: g.id})
+
+	return nil
+}
+
+// IsCancelled returns whether the game is called off.
+func (g *Game) IsCancelled() bool {
+	return g.cancelled
+}
+
+// Respond records whether the player attends the game. The player has to
+// be on a team playing the game, which must not be cancelled, and can't
+// attend while unavailable.
+func (g *Game) Respond(p *Player, r RSVP) error {
+	if !r.IsValid() {
+		return ErrInvalidRSVP
+	}
+	if g.cancelled {
+		return ErrGameCancelled
+	}
+	if _, ok := g.TeamOf(p); !ok {
+		return ErrPlayerNotRostered
+	}
+	if _, ok := p.UnavailableDuring(g.startsAt, g.endsAt); ok && r == RSVPAttending {
+		return ErrPlayerUnavailable
+	}
+	if current, ok := g.rsvps[p.GetID()]; ok && current == r {
+		return ErrGameUpdateFailed
+	}
+
+	g.register(&event.PlayerRespondedToGame{
+		ID:       g.id,
+		PlayerId: p.GetID(),
+		Response: string(r),
+	})
+
+	return nil
+}
+
+// GetRSVP returns whether the player attends the game, if the player has
+// responded.
+func (g *Game) GetRSVP(playerId uuid.UUID) (RSVP, bool) {
+	r, ok := g.rsvps[playerId]
+	return r, ok
+}
+
+// RecordResult records the score of the game. A recorded result can be
+// corrected by recording another. Cancelled games have no result.
+func (g *Game) RecordResult(homeScore, awayScore int) error {
+	if homeScore < 0 || awayScore < 0 {
+		return ErrInvalidResult
+	}
+	if g.cancelled {
+		return ErrGameCancelled
+	}
+	if g.result != nil && *g.result == (Result{HomeScore: homeScore, AwayScore: awayScore}) {
+		return ErrGameUpdateFailed
+	}
+
+	g.register(&event.GameResultRecorded{
+		ID:        g.id,
+		HomeScore: homeScore,
+		AwayScore: awayScore,
+	})
+
+	return nil
+}
+
+// GetResult returns the score of the game, if it has been played.
+func (g *Game) GetResult() (Result, bool) {
+	if g.result == nil {
+		return Result{}, false
+	}
+	return *g.result, true
+}
+
+// Standings returns the standings of the teams playing the games after
+// the recorded results, ranked by points, goal difference and goals
+// scored. Teams are listed from their first game on, played or not.
+func Standings(games []*Game) []bracket.Standing {
+	var teams []uuid.UUID
+	standings := make(map[uuid.UUID]*bracket.Standing)
+	for _, g := range games {
+		for _, team := range []*entity.Group{g.home, g.away} {
+			if _, ok := standings[team.ID]; !ok {
+				teams = append(teams, team.ID)
+				standings[team.ID] = &bracket.Standing{Team: &entity.Group{ID: team.ID, Name: team.Name}}
+			}
+		}
+		if r, ok := g.GetResult(); ok {
+			standings[g.home.ID].Record(r.HomeScore, r.AwayScore)
+			standings[g.away.ID].Record(r.AwayScore, r.HomeScore)
+		}
+	}
+
+	table := make([]bracket.Standing, len(teams))
+	for i, id := range teams {
+		table[i] = *standings[id]
+	}
+	return bracket.Rank(table)
+}
+
+// GetScheduledGame returns the game as it is on the schedule.
+func (g *Game) GetScheduledGame() ScheduledGame {
+	return ScheduledGame{
+		ID:       g.id,
+		SeasonID: g.seasonId,
+		Round:    g.round,
+		Home:     &entity.Group{ID: g.home.ID, Name: g.home.Name},
+		Away:     &entity.Group{ID: g.away.ID, Name: g.away.Name},
+		StartsAt: g.startsAt,
+		EndsAt:   g.endsAt,
+		Location: g.location,
+	}
+}
+
+// Apply applies game events to the game aggregate.
+func (g *Game) Apply(e event.Event, new bool) {
+	switch ge := e.(type) {
+	case *event.GameScheduled:
+		g.id = ge.ID
+		g.seasonId = ge.SeasonId
+		g.round = ge.Round
+		g.home = &entity.Group{ID: ge.HomeTeamId, Name: ge.HomeTeamName}
+		g.away = &entity.Group{ID: ge.AwayTeamId, Name: ge.AwayTeamName}
+		g.startsAt = ge.StartsAt
+		g.endsAt = ge.EndsAt
+		g.location = Location{VenueID: ge.VenueId, VenueName: ge.VenueName, Field: ge.Field}
+		g.rsvps = make(map[uuid.UUID]RSVP)
+
+	case *event.PlayerRespondedToGame:
+		g.rsvps[ge.PlayerId] = RSVP(ge.Response)
+
+	case *event.GameResultRecorded:
+		g.result = &Result{HomeScore: ge.HomeScore, AwayScore: ge.AwayScore}
+
+	case *event.GameCancelled:
+		g.cancelled = true
+
+	case *event.GameRestored:
+		g.cancelled = false
+	}
+
+	if !new {
+		g.version++
+	}
+}
+
+// Events returns the uncommitted events from the game aggregate.
+func (g Game) Events() []event.Event {
+	return g.changes
+}
+
+// Version returns the last version of the aggregate before changes.
+func (g Game) Version() int {
+	return g.version
+}
+
+func (g *Game) register(event event.Event) {
+	g.changes = append(g.changes, event)
+	g.Apply(event, true)
+}
@@ -0,0 +1,211 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"git.sr.ht/~loges/teammate/internal/team/domain/event"
+	"github.com/google/uuid"
+	"github.com/matryer/is"
+)
+
+var (
+	exampleGameUUID = uuid.MustParse("c15e93f8-c952-11ed-afa1-0242ac120002")
+	awayTeamUUID    = uuid.MustParse("e35e93f8-c952-11ed-afa1-0242ac120002")
+	exampleLocation = Location{VenueID: uuid.MustParse("f15e93f8-c952-11ed-afa1-0242ac120002"), VenueName: "Riverside Park", Field: "North Field"}
+	gameStartsAt    = time.Date(2023, time.September, 3, 10, 0, 0, 0, time.UTC)
+	gameEndsAt      = gameStartsAt.Add(90 * time.Minute)
+)
+
+func TestGame_NewGame(t *testing.T) {
+	active := []event.Event{teamCreated}
+	away := []event.Event{&event.TeamCreated{ID: awayTeamUUID, Name: "Bears"}}
+	testCases := []struct {
+		test        string
+		season      []event.Event
+		round       int
+		home        []event.Event
+		away        []event.Event
+		startsAt    time.Time
+		endsAt      time.Time
+		expectedErr error
+	}{
+		{"Valid game", []event.Event{seasonCreated}, 1, active, away, gameStartsAt, gameEndsAt, nil},
+		{"Team plays itself", []event.Event{seasonCreated}, 1, active, active, gameStartsAt, gameEndsAt, ErrInvalidGame},
+		{"Missing start", []event.Event{seasonCreated}, 1, active, away, time.Time{}, gameEndsAt, ErrInvalidGame},
+		{"Ends before it starts", []event.Event{seasonCreated}, 1, active, away, gameStartsAt, gameStartsAt, ErrInvalidGame},
+		{"Missing round", []event.Event{seasonCreated}, 0, active, away, gameStartsAt, gameEndsAt, ErrInvalidGame},
+		{"Team is not active", []event.Event{seasonCreated}, 1, []event.Event{teamCreated, teamDeactivated}, away, gameStartsAt, gameEndsAt, ErrTeamNotActive},
+		{"Season is closed", []event.Event{seasonCreated, seasonClosed}, 1, active, away, gameStartsAt, gameEndsAt, ErrSeasonClosed},
+		{"Game after the season", []event.Event{seasonCreated}, 1, active, away, seasonEndsOn, seasonEndsOn.Add(time.Hour), ErrOutsideOfSeason},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			s := NewSeasonFromEvents(tc.season)
+			home := NewTeamFromEvents(tc.home)
+			away := NewTeamFromEvents(tc.away)
+
+			g, err := NewGame(exampleGameUUID, s, tc.round, home, away, tc.startsAt, tc.endsAt, exampleLocation)
+
+			is.Equal(err, tc.expectedErr)
+			if err == nil {
+				sg := g.GetScheduledGame()
+				is.Equal(sg.SeasonID, exampleSeasonUUID)
+				is.Equal(sg.Home.ID, exampleTeamUUID)
+				is.Equal(sg.Away.Name, "Bears")
+				is.Equal(sg.Location, exampleLocation)
+				is.Equal(g.GetBooking(), Booking{VenueID: exampleLocation.VenueID, Field: "North Field", StartsAt: gameStartsAt, EndsAt: gameEndsAt})
+				is.True(g.Involves(awayTeamUUID))
+				is.True(!g.Involves(uuid.New()))
+				is.Equal(len(g.Events()), 1)
+				is.Equal(g.Version(), 0)
+			}
+		})
+	}
+}
+
+func TestGame_Respond(t *testing.T) {
+	scheduled := &event.GameScheduled{ID: exampleGameUUID, SeasonId: exampleSeasonUUID, Round: 1, HomeTeamId: exampleTeamUUID, AwayTeamId: awayTeamUUID, StartsAt: gameStartsAt, EndsAt: gameEndsAt}
+	injured := &event.PlayerMarkedUnavailable{ID: examplePlayerUUID, PeriodId: uuid.New(), Reason: "injured", From: gameStartsAt.AddDate(0, 0, -1), To: gameEndsAt.AddDate(0, 0, 1), Visibility: "staff"}
+	testCases := []struct {
+		test        string
+		player      []event.Event
+		rsvp        RSVP
+		expectedErr error
+	}{
+		{"Player attends", []event.Event{playerCreated, teamAssigned}, RSVPAttending, nil},
+		{"Unknown response", []event.Event{playerCreated, teamAssigned}, "later", ErrInvalidRSVP},
+		{"Player not on a team playing", []event.Event{playerCreated}, RSVPAttending, ErrPlayerNotRostered},
+		{"Unavailable player attends", []event.Event{playerCreated, teamAssigned, injured}, RSVPAttending, ErrPlayerUnavailable},
+		{"Unavailable player doesn't attend", []event.Event{playerCreated, teamAssigned, injured}, RSVPNotAttending, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			g := NewGameFromEvents([]event.Event{scheduled})
+			p := NewPlayerFromEvents(tc.player)
+
+			err := g.Respond(p, tc.rsvp)
+
+			is.Equal(err, tc.expectedErr)
+			_, responded := g.GetRSVP(examplePlayerUUID)
+			is.Equal(responded, err == nil)
+		})
+	}
+
+	t.Run("Replay responses", func(t *testing.T) {
+		is := is.New(t)
+		g := NewGameFromEvents([]event.Event{scheduled})
+		p := NewPlayerFromEvents([]event.Event{playerCreated, teamAssigned})
+		is.NoErr(g.Respond(p, RSVPMaybe))
+		is.Equal(g.Respond(p, RSVPMaybe), ErrGameUpdateFailed)
+		is.NoErr(g.Respond(p, RSVPNotAttending))
+
+		replayed := NewGameFromEvents(append([]event.Event{scheduled}, g.Events()...))
+
+		rsvp, _ := replayed.GetRSVP(examplePlayerUUID)
+		is.Equal(rsvp, RSVPNotAttending)
+		is.Equal(replayed.Version(), 3)
+	})
+}
+
+func TestGame_RecordResult(t *testing.T) {
+	scheduled := &event.GameScheduled{ID: exampleGameUUID, SeasonId: exampleSeasonUUID, Round: 1, HomeTeamId: exampleTeamUUID, AwayTeamId: awayTeamUUID, StartsAt: gameStartsAt, EndsAt: gameEndsAt}
+	recorded := &event.GameResultRecorded{ID: exampleGameUUID, HomeScore: 2, AwayScore: 1}
+	testCases := []struct {
+		test        string
+		events      []event.Event
+		home, away  int
+		expectedErr error
+	}{
+		{"Result recorded", []event.Event{scheduled}, 2, 1, nil},
+		{"Result corrected", []event.Event{scheduled, recorded}, 1, 1, nil},
+		{"Same result", []event.Event{scheduled, recorded}, 2, 1, ErrGameUpdateFailed},
+		{"Negative score", []event.Event{scheduled}, -1, 0, ErrInvalidResult},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			g := NewGameFromEvents(tc.events)
+
+			err := g.RecordResult(tc.home, tc.away)
+
+			is.Equal(err, tc.expectedErr)
+			if err == nil {
+				r, ok := g.GetResult()
+				is.True(ok)
+				is.Equal(r, Result{HomeScore: tc.home, AwayScore: tc.away})
+			}
+		})
+	}
+}
+
+func TestGame_Cancel(t *testing.T) {
+	scheduled := &event.GameScheduled{ID: exampleGameUUID, SeasonId: exampleSeasonUUID, Round: 1, HomeTeamId: exampleTeamUUID, AwayTeamId: awayTeamUUID, StartsAt: gameStartsAt, EndsAt: gameEndsAt}
+	cancelled := &event.GameCancelled{ID: exampleGameUUID}
+	testCases := []struct {
+		test              string
+		events            []event.Event
+		cancel            bool
+		expectedErr       error
+		expectedCancelled bool
+	}{
+		{"Game cancelled", []event.Event{scheduled}, true, nil, true},
+		{"Game already cancelled", []event.Event{scheduled, cancelled}, true, ErrGameUpdateFailed, true},
+		{"Game restored", []event.Event{scheduled, cancelled}, false, nil, false},
+		{"Game not cancelled", []event.Event{scheduled}, false, ErrGameUpdateFailed, false},
+		{"Game restored again", []event.Event{scheduled, cancelled, &event.GameRestored{ID: exampleGameUUID}}, false, ErrGameUpdateFailed, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			g := NewGameFromEvents(tc.events)
+
+			var err error
+			if tc.cancel {
+				err = g.Cancel()
+			} else {
+				err = g.Restore()
+			}
+
+			is.Equal(err, tc.expectedErr)
+			is.Equal(g.IsCancelled(), tc.expectedCancelled)
+		})
+	}
+
+	t.Run("Cancelled game takes no responses or results", func(t *testing.T) {
+		is := is.New(t)
+		g := NewGameFromEvents([]event.Event{scheduled, cancelled})
+		p := NewPlayerFromEvents([]event.Event{playerCreated, teamAssigned})
+
+		is.Equal(g.Respond(p, RSVPAttending), ErrGameCancelled)
+		is.Equal(g.RecordResult(2, 1), ErrGameCancelled)
+	})
+}
+
+func TestStandings(t *testing.T) {
+	is := is.New(t)
+	thirdTeamUUID := uuid.New()
+	games := []*Game{
+		NewGameFromEvents([]event.Event{
+			&event.GameScheduled{ID: uuid.New(), HomeTeamId: exampleTeamUUID, HomeTeamName: "Syracuse", AwayTeamId: awayTeamUUID, AwayTeamName: "Cornell"},
+			&event.GameResultRecorded{HomeScore: 0, AwayScore: 2},
+		}),
+		NewGameFromEvents([]event.Event{
+			&event.GameScheduled{ID: uuid.New(), HomeTeamId: awayTeamUUID, HomeTeamName: "Cornell", AwayTeamId: thirdTeamUUID, AwayTeamName: "Colgate"},
+		}),
+	}
+
+	standings := Standings(games)
+
+	is.Equal(len(standings), 3)
+	is.Equal(standings[0].Team.ID, awayTeamUUID)
+	is.Equal(standings[0].Points, 3)
+	is.Equal(standings[1].Team.ID, thirdTeamUUID) // unplayed, but no goals conceded
+	is.Equal(standings[2].Lost, 1)
+}
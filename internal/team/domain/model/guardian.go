@@ -0,0 +1,30 @@
+package model
+
+import (
+	"errors"
+
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidGuardian  = errors.New("model: guardian has to be a valid user")
+	ErrGuardianNotFound = errors.New("model: user is not a guardian of the player")
+)
+
+// Guardian is a user acting on behalf of a player, typically a parent of
+// a youth player.
+type Guardian struct {
+	User         *entity.Person
+	Relationship string
+}
+
+// FamilyMember is a player in the family of a guardian, with the teams
+// the player is on.
+type FamilyMember struct {
+	Player *entity.Person
+	Teams  []*entity.Group
+}
+
+// NoHousehold is the household of players not grouped with their siblings.
+var NoHousehold = uuid.Nil
@@ -0,0 +1,61 @@
+package model
+
+import "errors"
+
+var (
+	ErrInvalidSport        = errors.New("model: sport is not valid")
+	ErrInvalidJerseyNumber = errors.New("model: jersey number is not allowed")
+	ErrJerseyNumberTaken   = errors.New("model: jersey number is already taken")
+)
+
+// NoJerseyNumber is the jersey number of a player who has none.
+const NoJerseyNumber = -1
+
+// Sport is the sport a team plays, which decides the jersey numbers its
+// players are allowed to wear.
+type Sport string
+
+const (
+	SportUnspecified Sport = ""
+	SportSoccer      Sport = "soccer"
+	SportBasketball  Sport = "basketball"
+	SportHockey      Sport = "hockey"
+	SportLacrosse    Sport = "lacrosse"
+)
+
+// NumberRange is an inclusive range of jersey numbers.
+type NumberRange struct {
+	Min int
+	Max int
+}
+
+// Contains returns whether the number is within the range.
+func (r NumberRange) Contains(number int) bool {
+	return number >= r.Min && number <= r.Max
+}
+
+// NumberRanges are the jersey numbers allowed per sport. Sports without a
+// range allow any number from 0 to 99.
+var NumberRanges = map[Sport]NumberRange{
+	SportSoccer:     {Min: 1, Max: 99},
+	SportBasketball: {Min: 0, Max: 99},
+	SportHockey:     {Min: 1, Max: 98},
+	SportLacrosse:   {Min: 0, Max: 99},
+}
+
+// IsValid returns whether the sport is a known sport.
+func (s Sport) IsValid() bool {
+	if s == SportUnspecified {
+		return true
+	}
+	_, ok := NumberRanges[s]
+	return ok
+}
+
+// NumberRange returns the jersey numbers allowed in the sport.
+func (s Sport) NumberRange() NumberRange {
+	if r, ok := NumberRanges[s]; ok {
+		return r
+	}
+	return NumberRange{Min: 0, Max: 99}
+}
@@ -0,0 +1,44 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"git.sr.ht/~loges/teammate/internal/entity"
+)
+
+var ErrInvalidLeaveReason = errors.New("model: leave reason is not valid")
+
+// LeaveReason is why a player left a team.
+type LeaveReason string
+
+const (
+	LeaveReasonUnspecified     LeaveReason = ""
+	LeaveReasonTransfer        LeaveReason = "transfer"
+	LeaveReasonInjury          LeaveReason = "injury"
+	LeaveReasonGraduation      LeaveReason = "graduation"
+	LeaveReasonReleased        LeaveReason = "released"
+	LeaveReasonTeamDeactivated LeaveReason = "team_deactivated"
+	LeaveReasonSeasonEnded     LeaveReason = "season_ended"
+)
+
+// IsValid returns whether the reason is a known reason.
+func (r LeaveReason) IsValid() bool {
+	switch r {
+	case LeaveReasonUnspecified, LeaveReasonTransfer, LeaveReasonInjury,
+		LeaveReasonGraduation, LeaveReasonReleased, LeaveReasonTeamDeactivated,
+		LeaveReasonSeasonEnded:
+		return true
+	}
+	return false
+}
+
+// Membership is a period a player spent on a team. Memberships recorded
+// before dates were tracked have zero dates.
+type Membership struct {
+	Team     *entity.Group
+	JoinedAt time.Time
+	LeftAt   time.Time
+	Reason   LeaveReason
+	Current  bool
+}
@@ -2,6 +2,7 @@ package model
 
 import (
 	"errors"
+	"time"
 
 	"git.sr.ht/~loges/teammate/internal/entity"
 	"git.sr.ht/~loges/teammate/internal/team/domain/event"
@@ -11,6 +12,7 @@ import (
 var (
 	ErrInvalidPerson      = errors.New("model: player has to be a valid person")
 	ErrPlayerUpdateFailed = errors.New("model: player update failed")
+	ErrInvalidInvite      = errors.New("model: player invite is not valid")
 )
 
 // Player is a aggregate that combines all entities needed to represent a player.
@@ -18,6 +20,20 @@ type Player struct {
 	person    *entity.Person
 	activated bool
 	teams     map[uuid.UUID]*entity.Group
+	history   []*Membership
+	birth     time.Time
+	gender    Gender
+	phone     string
+	address   Address
+	contacts  []EmergencyContact
+	medical   string
+	invite    string
+	claimed   string
+	userId    uuid.UUID
+	guardians []*Guardian
+	household uuid.UUID
+	absences  []Unavailability
+	erased    bool
 
 	changes []event.Event
 	version int
@@ -68,6 +84,100 @@ func (p *Player) GetTeams() (teams []*entity.Group) {
 	return teams
 }
 
+// GetMembershipHistory returns every team the player has been on, in the
+// order the player joined them.
+func (p *Player) GetMembershipHistory() []Membership {
+	history := make([]Membership, len(p.history))
+	for i, m := range p.history {
+		history[i] = *m
+	}
+	return history
+}
+
+// GetDateOfBirth returns the date of birth of the player, which is zero if
+// it is unknown.
+func (p *Player) GetDateOfBirth() time.Time {
+	return p.birth
+}
+
+// AgeOn returns the age of the player on the day, if the date of birth is
+// known.
+func (p *Player) AgeOn(day time.Time) (int, bool) {
+	if p.birth.IsZero() {
+		return 0, false
+	}
+	return ageOn(p.birth, day), true
+}
+
+// GetGender returns the gender the player competes as.
+func (p *Player) GetGender() Gender {
+	return p.gender
+}
+
+// GetProfile returns the personal information held about the player.
+func (p *Player) GetProfile() Profile {
+	return Profile{
+		Name:              p.person.Name,
+		DateOfBirth:       p.birth,
+		Gender:            p.gender,
+		Phone:             p.phone,
+		Address:           p.address,
+		EmergencyContacts: append([]EmergencyContact{}, p.contacts...),
+		MedicalNotes:      p.medical,
+	}
+}
+
+// GetUserID returns the ID of the user linked to the player, if any.
+func (p *Player) GetUserID() uuid.UUID {
+	return p.userId
+}
+
+// IsLinked returns whether the player is linked to a user.
+func (p *Player) IsLinked() bool {
+	return p.userId != uuid.Nil
+}
+
+// GetGuardians returns the users acting on behalf of the player.
+func (p *Player) GetGuardians() []Guardian {
+	guardians := make([]Guardian, len(p.guardians))
+	for i, g := range p.guardians {
+		guardians[i] = *g
+	}
+	return guardians
+}
+
+// IsGuardian returns whether the user is a guardian of the player.
+func (p *Player) IsGuardian(userId uuid.UUID) bool {
+	for _, g := range p.guardians {
+		if g.User.ID == userId {
+			return true
+		}
+	}
+	return false
+}
+
+// GetHouseholdID returns the household grouping the player with siblings,
+// which is NoHousehold if the player is not grouped.
+func (p *Player) GetHouseholdID() uuid.UUID {
+	return p.household
+}
+
+// IsErased returns whether the personal data of the player was erased.
+func (p *Player) IsErased() bool {
+	return p.erased
+}
+
+// HasInvite returns whether the code is the player's pending invite.
+func (p *Player) HasInvite(code string) bool {
+	return p.invite != "" && p.invite == code
+}
+
+// HasClaimed returns whether the linked user claimed the player with the
+// invite code.
+func (p *Player) HasClaimed(code string) bool {
+	return p.IsLinked() && p.claimed != "" && p.claimed == code
+}
+
 // IsActivated returns whether the player is activated.
 func (p *Player) IsActivated() bool {
 	return p.activated
@@ -99,8 +209,8 @@ func (p *Player) Deactivate() error {
 	return nil
 }
 
-// AssignTeam assigns team to player.
-func (p *Player) AssignTeam(t *Team) error {
+// AssignTeam assigns team to player, who joined it at the given time.
+func (p *Player) AssignTeam(t *Team, at time.Time) error {
 	if _, ok := p.teams[t.group.ID]; ok {
 		return ErrPlayerUpdateFailed
 	}
@@ -109,13 +219,18 @@ func (p *Player) AssignTeam(t *Team) error {
 		ID:       p.person.ID,
 		TeamId:   t.group.ID,
 		TeamName: t.group.Name,
+		JoinedAt: at,
 	})
 
 	return nil
 }
 
-// UnassignTeam unassigns team from player.
-func (p *Player) UnassignTeam(t *Team) error {
+// UnassignTeam unassigns team from player, who left it at the given time
+// for the reason.
+func (p *Player) UnassignTeam(t *Team, reason LeaveReason, at time.Time) error {
+	if !reason.IsValid() {
+		return ErrInvalidLeaveReason
+	}
 	if _, ok := p.teams[t.group.ID]; !ok {
 		return ErrPlayerUpdateFailed
 	}
@@ -124,6 +239,271 @@ func (p *Player) UnassignTeam(t *Team) error {
 		ID:       p.person.ID,
 		TeamId:   t.group.ID,
 		TeamName: t.group.Name,
+		LeftAt:   at,
+		Reason:   string(reason),
+	})
+
+	return nil
+}
+
+// ChangeDetails records the date of birth and gender of the player, which
+// decide the teams the player is eligible for.
+func (p *Player) ChangeDetails(birth time.Time, g Gender) error {
+	if !g.IsValid() {
+		return ErrInvalidGender
+	}
+	if birth.Equal(p.birth) && g == p.gender {
+		return ErrPlayerUpdateFailed
+	}
+
+	p.register(&event.PlayerDetailsChanged{
+		ID:          p.person.ID,
+		DateOfBirth: birth,
+		Gender:      string(g),
+	})
+
+	return nil
+}
+
+// ChangeContactDetails records how to reach the player.
+func (p *Player) ChangeContactDetails(phone string, a Address) error {
+	if phone == p.phone && a == p.address {
+		return ErrPlayerUpdateFailed
+	}
+
+	p.register(&event.PlayerContactDetailsChanged{
+		ID:         p.person.ID,
+		Phone:      phone,
+		Street:     a.Street,
+		City:       a.City,
+		PostalCode: a.PostalCode,
+		Country:    a.Country,
+	})
+
+	return nil
+}
+
+// ChangeEmergencyContacts replaces the emergency contacts of the player.
+func (p *Player) ChangeEmergencyContacts(contacts []EmergencyContact) error {
+	e := &event.PlayerEmergencyContactsChanged{ID: p.person.ID}
+	for _, c := range contacts {
+		if c.Name == "" || c.Phone == "" {
+			return ErrInvalidEmergencyContact
+		}
+		e.Contacts = append(e.Contacts, event.EmergencyContact{
+			Name:         c.Name,
+			Relationship: c.Relationship,
+			Phone:        c.Phone,
+		})
+	}
+
+	if equalContacts(contacts, p.contacts) {
+		return ErrPlayerUpdateFailed
+	}
+
+	p.register(e)
+
+	return nil
+}
+
+// equalContacts returns whether both lists hold the same contacts in the
+// same order.
+func equalContacts(a, b []EmergencyContact) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// ChangeMedicalNotes replaces the medical notes of the player.
+func (p *Player) ChangeMedicalNotes(notes string) error {
+	if notes == p.medical {
+		return ErrPlayerUpdateFailed
+	}
+
+	p.register(&event.PlayerMedicalNotesChanged{
+		ID:    p.person.ID,
+		Notes: notes,
+	})
+
+	return nil
+}
+
+// Invite issues an invite code a user can claim the player profile with.
+// Issuing a new invite replaces the previous one.
+func (p *Player) Invite(code string) error {
+	if code == "" {
+		return ErrInvalidInvite
+	}
+	if p.IsLinked() {
+		return ErrPlayerUpdateFailed
+	}
+
+	p.register(&event.PlayerInvited{
+		ID:   p.person.ID,
+		Code: code,
+	})
+
+	return nil
+}
+
+// LinkUser links the user claiming the player profile with an invite code.
+func (p *Player) LinkUser(u *entity.Person, code string) error {
+	if p.IsLinked() {
+		return ErrPlayerUpdateFailed
+	}
+	if !p.HasInvite(code) {
+		return ErrInvalidInvite
+	}
+
+	p.register(&event.UserLinkedToPlayer{
+		ID:       p.person.ID,
+		UserId:   u.ID,
+		UserName: u.Name,
+	})
+
+	return nil
+}
+
+// UnlinkUser removes the link between the player and its user.
+func (p *Player) UnlinkUser() error {
+	if !p.IsLinked() {
+		return ErrPlayerUpdateFailed
+	}
+
+	p.register(&event.UserUnlinkedFromPlayer{
+		ID:     p.person.ID,
+		UserId: p.userId,
+	})
+
+	return nil
+}
+
+// AddGuardian lets the user act on behalf of the player.
+func (p *Player) AddGuardian(u *entity.Person, relationship string) error {
+	if u == nil || u.ID == uuid.Nil {
+		return ErrInvalidGuardian
+	}
+	if p.IsGuardian(u.ID) {
+		return ErrPlayerUpdateFailed
+	}
+
+	p.register(&event.GuardianAddedToPlayer{
+		ID:           p.person.ID,
+		UserId:       u.ID,
+		UserName:     u.Name,
+		Relationship: relationship,
+	})
+
+	return nil
+}
+
+// RemoveGuardian stops the user from acting on behalf of the player.
+func (p *Player) RemoveGuardian(u *entity.Person) error {
+	if u == nil {
+		return ErrInvalidGuardian
+	}
+	if !p.IsGuardian(u.ID) {
+		return ErrGuardianNotFound
+	}
+
+	p.register(&event.GuardianRemovedFromPlayer{
+		ID:     p.person.ID,
+		UserId: u.ID,
+	})
+
+	return nil
+}
+
+// ChangeHousehold groups the player with the siblings in the household.
+func (p *Player) ChangeHousehold(household uuid.UUID) error {
+	if household == p.household {
+		return ErrPlayerUpdateFailed
+	}
+
+	p.register(&event.PlayerHouseholdChanged{
+		ID:          p.person.ID,
+		HouseholdId: household,
+	})
+
+	return nil
+}
+
+// GetUnavailability returns the periods the player can't play, in the
+// order they start.
+func (p *Player) GetUnavailability() []Unavailability {
+	return append([]Unavailability{}, p.absences...)
+}
+
+// UnavailableDuring returns the first period the player can't play within
+// the time, if any.
+func (p *Player) UnavailableDuring(from, to time.Time) (Unavailability, bool) {
+	for _, u := range p.absences {
+		if u.Overlaps(from, to) {
+			return u, true
+		}
+	}
+	return Unavailability{}, false
+}
+
+// MarkUnavailable records a period the player can't play. The reason is
+// only visible to staff unless the visibility says otherwise.
+func (p *Player) MarkUnavailable(u Unavailability) error {
+	if u.Visibility == "" {
+		u.Visibility = VisibilityStaff
+	}
+	if u.ID == uuid.Nil || !u.Reason.IsValid() || !u.To.After(u.From) ||
+		(u.Visibility != VisibilityStaff && u.Visibility != VisibilityTeam) {
+		return ErrInvalidUnavailability
+	}
+	for _, absence := range p.absences {
+		if absence.ID == u.ID {
+			return ErrPlayerUpdateFailed
+		}
+	}
+
+	p.register(&event.PlayerMarkedUnavailable{
+		ID:         p.person.ID,
+		PeriodId:   u.ID,
+		Reason:     string(u.Reason),
+		From:       u.From,
+		To:         u.To,
+		Visibility: string(u.Visibility),
+	})
+
+	return nil
+}
+
+// ClearUnavailability removes a period the player can't play, such as
+// when an injured player recovers early.
+func (p *Player) ClearUnavailability(id uuid.UUID) error {
+	for _, absence := range p.absences {
+		if absence.ID == id {
+			p.register(&event.PlayerUnavailabilityCleared{
+				ID:       p.person.ID,
+				PeriodId: id,
+			})
+			return nil
+		}
+	}
+	return ErrUnavailabilityNotFound
+}
+
+// Erase erases the personal data of the player. The personal data in
+// stored events becomes unreadable once the key of the player is
+// destroyed, and the player reads as an erased person.
+func (p *Player) Erase() error {
+	if p.erased {
+		return ErrPlayerUpdateFailed
+	}
+
+	p.register(&event.PlayerErased{
+		ID: p.person.ID,
 	})
 
 	return nil
@@ -148,9 +528,113 @@ func (p *Player) Apply(e event.Event, new bool) {
 
 	case *event.TeamAssignedToPlayer:
 		p.teams[pe.TeamId] = &entity.Group{ID: pe.TeamId, Name: pe.TeamName}
+		p.history = append(p.history, &Membership{
+			Team:     p.teams[pe.TeamId],
+			JoinedAt: pe.JoinedAt,
+			Current:  true,
+		})
 
 	case *event.TeamUnassignedFromPlayer:
 		delete(p.teams, pe.TeamId)
+		for _, m := range p.history {
+			if m.Current && m.Team.ID == pe.TeamId {
+				m.LeftAt = pe.LeftAt
+				m.Reason = LeaveReason(pe.Reason)
+				m.Current = false
+			}
+		}
+
+	case *event.PlayerInvited:
+		p.invite = pe.Code
+
+	case *event.UserLinkedToPlayer:
+		p.userId = pe.UserId
+		p.claimed = p.invite
+		p.invite = ""
+
+	case *event.UserUnlinkedFromPlayer:
+		p.userId = uuid.Nil
+		p.claimed = ""
+
+	case *event.PlayerDetailsChanged:
+		p.birth = pe.DateOfBirth
+		p.gender = Gender(pe.Gender)
+
+	case *event.PlayerContactDetailsChanged:
+		p.phone = pe.Phone
+		p.address = Address{
+			Street:     pe.Street,
+			City:       pe.City,
+			PostalCode: pe.PostalCode,
+			Country:    pe.Country,
+		}
+
+	case *event.PlayerEmergencyContactsChanged:
+		p.contacts = nil
+		for _, c := range pe.Contacts {
+			p.contacts = append(p.contacts, EmergencyContact{
+				Name:         c.Name,
+				Relationship: c.Relationship,
+				Phone:        c.Phone,
+			})
+		}
+
+	case *event.PlayerMedicalNotesChanged:
+		p.medical = pe.Notes
+
+	case *event.GuardianAddedToPlayer:
+		p.guardians = append(p.guardians, &Guardian{
+			User:         &entity.Person{ID: pe.UserId, Name: pe.UserName},
+			Relationship: pe.Relationship,
+		})
+
+	case *event.GuardianRemovedFromPlayer:
+		for i, g := range p.guardians {
+			if g.User.ID == pe.UserId {
+				p.guardians = append(p.guardians[:i], p.guardians[i+1:]...)
+				break
+			}
+		}
+
+	case *event.PlayerHouseholdChanged:
+		p.household = pe.HouseholdId
+
+	case *event.PlayerMarkedUnavailable:
+		u := Unavailability{
+			ID:         pe.PeriodId,
+			Reason:     UnavailabilityReason(pe.Reason),
+			From:       pe.From,
+			To:         pe.To,
+			Visibility: Visibility(pe.Visibility),
+		}
+		i := len(p.absences)
+		for i > 0 && p.absences[i-1].From.After(u.From) {
+			i--
+		}
+		p.absences = append(p.absences[:i:i], append([]Unavailability{u}, p.absences[i:]...)...)
+
+	case *event.PlayerUnavailabilityCleared:
+		for i, u := range p.absences {
+			if u.ID == pe.PeriodId {
+				p.absences = append(p.absences[:i:i], p.absences[i+1:]...)
+				break
+			}
+		}
+
+	case *event.PlayerErased:
+		p.person.Name = entity.ErasedName
+		p.birth = time.Time{}
+		p.gender = GenderUnspecified
+		p.phone = ""
+		p.address = Address{}
+		p.contacts = nil
+		p.medical = ""
+		p.invite = ""
+		p.claimed = ""
+		for i := range p.absences {
+			p.absences[i].Reason = ""
+		}
+		p.erased = true
 	}
 
 	if !new {
@@ -2,6 +2,7 @@ package model
 
 import (
 	"testing"
+	"time"
 
 	"git.sr.ht/~loges/teammate/internal/entity"
 	"git.sr.ht/~loges/teammate/internal/team/domain/event"
@@ -15,6 +16,12 @@ var (
 	playerCreated     = &event.PlayerCreated{ID: examplePlayerUUID, Name: examplePlayerName}
 	playerDeactivated = &event.PlayerDeactivated{ID: examplePlayerUUID}
 	teamAssigned      = &event.TeamAssignedToPlayer{ID: examplePlayerUUID, TeamId: exampleTeamUUID, TeamName: exampleTeamName}
+	exampleUser       = &entity.Person{ID: uuid.MustParse("c15e93f8-c952-11ed-afa1-0242ac120002"), Name: examplePlayerName}
+	exampleInvite     = "a1b2c3"
+	playerInvited     = &event.PlayerInvited{ID: examplePlayerUUID, Code: exampleInvite}
+	userLinked        = &event.UserLinkedToPlayer{ID: examplePlayerUUID, UserId: exampleUser.ID, UserName: exampleUser.Name}
+	exampleJoinedAt   = time.Date(2022, time.September, 1, 0, 0, 0, 0, time.UTC)
+	exampleLeftAt     = time.Date(2023, time.June, 1, 0, 0, 0, 0, time.UTC)
 )
 
 func TestPlayer_NewPlayer(t *testing.T) {
@@ -137,7 +144,7 @@ func TestPlayer_AssignTeam(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.test, func(t *testing.T) {
 			is := is.New(t)
-			err := tc.player.AssignTeam(tc.team)
+			err := tc.player.AssignTeam(tc.team, exampleJoinedAt)
 			is.Equal(err, tc.expectedErr)
 		})
 	}
@@ -149,31 +156,63 @@ func TestPlayer_UnassignTeam(t *testing.T) {
 		test        string
 		player      *Player
 		team        *Team
+		reason      LeaveReason
 		expectedErr error
 	}{
 		{
 			"Unassign team to player",
 			NewPlayerFromEvents([]event.Event{playerCreated, teamAssigned}),
 			NewTeamFromEvents([]event.Event{teamCreated}),
+			LeaveReasonTransfer,
 			nil,
 		},
 		{
 			"Try to unassigned a team that is not assigned to player",
 			NewPlayerFromEvents([]event.Event{playerCreated}),
 			NewTeamFromEvents([]event.Event{teamCreated}),
+			LeaveReasonTransfer,
 			ErrPlayerUpdateFailed,
 		},
+		{
+			"Unknown leave reason",
+			NewPlayerFromEvents([]event.Event{playerCreated, teamAssigned}),
+			NewTeamFromEvents([]event.Event{teamCreated}),
+			LeaveReason("bored"),
+			ErrInvalidLeaveReason,
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.test, func(t *testing.T) {
 			is := is.New(t)
-			err := tc.player.UnassignTeam(tc.team)
+			err := tc.player.UnassignTeam(tc.team, tc.reason, exampleLeftAt)
 			is.Equal(err, tc.expectedErr)
 		})
 	}
 }
 
+func TestPlayer_GetMembershipHistory(t *testing.T) {
+	is := is.New(t)
+	team := NewTeamFromEvents([]event.Event{teamCreated})
+	p := NewPlayerFromEvents([]event.Event{playerCreated})
+
+	is.NoErr(p.AssignTeam(team, exampleJoinedAt))
+	is.NoErr(p.UnassignTeam(team, LeaveReasonInjury, exampleLeftAt))
+	is.NoErr(p.AssignTeam(team, exampleLeftAt.AddDate(0, 3, 0)))
+
+	history := p.GetMembershipHistory()
+	is.Equal(len(history), 2)
+	is.Equal(history[0], Membership{
+		Team:     &entity.Group{ID: exampleTeamUUID, Name: exampleTeamName},
+		JoinedAt: exampleJoinedAt,
+		LeftAt:   exampleLeftAt,
+		Reason:   LeaveReasonInjury,
+	})
+	is.True(history[1].Current)
+	is.Equal(history[1].JoinedAt, exampleLeftAt.AddDate(0, 3, 0))
+	is.Equal(len(p.GetTeams()), 1)
+}
+
 func TestPlayer_GetTeams(t *testing.T) {
 	testCases := []struct {
 		test      string
@@ -204,6 +243,76 @@ func TestPlayer_GetTeams(t *testing.T) {
 	}
 }
 
+func TestPlayer_Invite(t *testing.T) {
+	testCases := []struct {
+		test        string
+		player      *Player
+		code        string
+		expectedErr error
+	}{
+		{"Invite player", NewPlayerFromEvents([]event.Event{playerCreated}), exampleInvite, nil},
+		{"Invite player again", NewPlayerFromEvents([]event.Event{playerCreated, playerInvited}), "d4e5f6", nil},
+		{"Empty invite code", NewPlayerFromEvents([]event.Event{playerCreated}), "", ErrInvalidInvite},
+		{"Invite linked player", NewPlayerFromEvents([]event.Event{playerCreated, playerInvited, userLinked}), exampleInvite, ErrPlayerUpdateFailed},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			err := tc.player.Invite(tc.code)
+			is.Equal(err, tc.expectedErr)
+		})
+	}
+}
+
+func TestPlayer_LinkUser(t *testing.T) {
+	testCases := []struct {
+		test        string
+		player      *Player
+		code        string
+		expectedErr error
+	}{
+		{"Link user with invite", NewPlayerFromEvents([]event.Event{playerCreated, playerInvited}), exampleInvite, nil},
+		{"Link user with wrong invite", NewPlayerFromEvents([]event.Event{playerCreated, playerInvited}), "d4e5f6", ErrInvalidInvite},
+		{"Link user without invite", NewPlayerFromEvents([]event.Event{playerCreated}), "", ErrInvalidInvite},
+		{"Link already linked player", NewPlayerFromEvents([]event.Event{playerCreated, playerInvited, userLinked}), exampleInvite, ErrPlayerUpdateFailed},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			err := tc.player.LinkUser(exampleUser, tc.code)
+			is.Equal(err, tc.expectedErr)
+			if tc.expectedErr == nil {
+				is.Equal(tc.player.GetUserID(), exampleUser.ID)
+				is.Equal(tc.player.HasInvite(tc.code), false)
+				is.True(tc.player.HasClaimed(tc.code))
+			}
+		})
+	}
+}
+
+func TestPlayer_UnlinkUser(t *testing.T) {
+	testCases := []struct {
+		test        string
+		player      *Player
+		expectedErr error
+	}{
+		{"Unlink user", NewPlayerFromEvents([]event.Event{playerCreated, playerInvited, userLinked}), nil},
+		{"Unlink player without user", NewPlayerFromEvents([]event.Event{playerCreated}), ErrPlayerUpdateFailed},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			err := tc.player.UnlinkUser()
+			is.Equal(err, tc.expectedErr)
+			is.Equal(tc.player.IsLinked(), false)
+			is.Equal(tc.player.HasClaimed(exampleInvite), false)
+		})
+	}
+}
+
 func TestPlayer_Events(t *testing.T) {
 	t.Run("Event log is populated", func(t *testing.T) {
 		is := is.New(t)
@@ -225,3 +334,207 @@ func TestPlayer_Version(t *testing.T) {
 		is.Equal(p.Version(), 2)
 	})
 }
+
+func TestPlayer_ChangeDetails(t *testing.T) {
+	birth := time.Date(2012, time.February, 29, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		test        string
+		gender      Gender
+		expectedErr error
+	}{
+		{"Change details", GenderFemale, nil},
+		{"Unknown gender", Gender("unknown"), ErrInvalidGender},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			p := NewPlayerFromEvents([]event.Event{playerCreated})
+
+			err := p.ChangeDetails(birth, tc.gender)
+
+			is.Equal(err, tc.expectedErr)
+		})
+	}
+
+	t.Run("Age on a day", func(t *testing.T) {
+		is := is.New(t)
+		p := NewPlayerFromEvents([]event.Event{playerCreated})
+		_, known := p.AgeOn(birth)
+		is.Equal(known, false)
+
+		is.NoErr(p.ChangeDetails(birth, GenderFemale))
+		is.Equal(p.ChangeDetails(birth, GenderFemale), ErrPlayerUpdateFailed)
+
+		age, _ := p.AgeOn(time.Date(2024, time.February, 28, 0, 0, 0, 0, time.UTC))
+		is.Equal(age, 11)
+		age, _ = p.AgeOn(time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC))
+		is.Equal(age, 12)
+	})
+}
+
+func TestPlayer_Profile(t *testing.T) {
+	address := Address{Street: "1 Main St", City: "Syracuse", PostalCode: "13202", Country: "US"}
+	testCases := []struct {
+		test        string
+		contacts    []EmergencyContact
+		expectedErr error
+	}{
+		{"Change emergency contacts", []EmergencyContact{{Name: "Ann", Relationship: "mother", Phone: "555-0101"}}, nil},
+		{"Emergency contact without phone", []EmergencyContact{{Name: "Ann"}}, ErrInvalidEmergencyContact},
+		{"Emergency contact without name", []EmergencyContact{{Phone: "555-0101"}}, ErrInvalidEmergencyContact},
+		{"No emergency contacts to clear", nil, ErrPlayerUpdateFailed},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			p := NewPlayerFromEvents([]event.Event{playerCreated})
+
+			err := p.ChangeEmergencyContacts(tc.contacts)
+
+			is.Equal(err, tc.expectedErr)
+		})
+	}
+
+	t.Run("Replay profile", func(t *testing.T) {
+		is := is.New(t)
+		p := NewPlayerFromEvents([]event.Event{playerCreated})
+		contacts := []EmergencyContact{{Name: "Ann", Relationship: "mother", Phone: "555-0101"}}
+		is.NoErr(p.ChangeContactDetails("555-0100", address))
+		is.Equal(p.ChangeContactDetails("555-0100", address), ErrPlayerUpdateFailed)
+		is.NoErr(p.ChangeEmergencyContacts(contacts))
+		is.Equal(p.ChangeEmergencyContacts(contacts), ErrPlayerUpdateFailed)
+		is.NoErr(p.ChangeMedicalNotes("asthma"))
+		is.Equal(p.ChangeMedicalNotes("asthma"), ErrPlayerUpdateFailed)
+
+		replayed := NewPlayerFromEvents(append([]event.Event{playerCreated}, p.Events()...))
+
+		profile := replayed.GetProfile()
+		is.Equal(profile.Phone, "555-0100")
+		is.Equal(profile.Address, address)
+		is.Equal(profile.EmergencyContacts, contacts)
+		is.Equal(profile.MedicalNotes, "asthma")
+
+		redacted := profile.Redact()
+		is.Equal(redacted.Phone, "")
+		is.Equal(redacted.Address, Address{})
+		is.Equal(redacted.EmergencyContacts, nil)
+		is.Equal(redacted.MedicalNotes, "")
+	})
+}
+
+func TestPlayer_Guardians(t *testing.T) {
+	testCases := []struct {
+		test        string
+		guardian    *entity.Person
+		expectedErr error
+	}{
+		{"Add guardian", exampleUser, nil},
+		{"Guardian without user", &entity.Person{Name: "Ann"}, ErrInvalidGuardian},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			p := NewPlayerFromEvents([]event.Event{playerCreated})
+
+			err := p.AddGuardian(tc.guardian, "mother")
+
+			is.Equal(err, tc.expectedErr)
+			is.Equal(p.IsGuardian(tc.guardian.ID), err == nil)
+		})
+	}
+
+	t.Run("Replay guardians and household", func(t *testing.T) {
+		is := is.New(t)
+		household := uuid.MustParse("a15e93f8-c952-11ed-afa1-0242ac120002")
+		p := NewPlayerFromEvents([]event.Event{playerCreated})
+		is.NoErr(p.AddGuardian(exampleUser, "mother"))
+		is.Equal(p.AddGuardian(exampleUser, "mother"), ErrPlayerUpdateFailed)
+		is.NoErr(p.ChangeHousehold(household))
+		is.Equal(p.ChangeHousehold(household), ErrPlayerUpdateFailed)
+
+		replayed := NewPlayerFromEvents(append([]event.Event{playerCreated}, p.Events()...))
+
+		is.Equal(replayed.GetGuardians(), []Guardian{{User: exampleUser, Relationship: "mother"}})
+		is.Equal(replayed.GetHouseholdID(), household)
+		is.NoErr(replayed.RemoveGuardian(exampleUser))
+		is.Equal(replayed.RemoveGuardian(exampleUser), ErrGuardianNotFound)
+		is.Equal(replayed.RemoveGuardian(nil), ErrInvalidGuardian)
+		is.Equal(replayed.GetGuardians(), []Guardian{})
+	})
+}
+
+func TestPlayer_Erase(t *testing.T) {
+	is := is.New(t)
+	p := NewPlayerFromEvents([]event.Event{playerCreated, teamAssigned, playerInvited})
+	is.NoErr(p.ChangeMedicalNotes("asthma"))
+	is.NoErr(p.MarkUnavailable(Unavailability{ID: uuid.New(), Reason: UnavailabilityReasonIll, From: exampleJoinedAt, To: exampleLeftAt}))
+
+	is.NoErr(p.Erase())
+	is.Equal(p.Erase(), ErrPlayerUpdateFailed)
+
+	is.True(p.IsErased())
+	is.Equal(p.GetName(), entity.ErasedName)
+	is.Equal(p.GetProfile().MedicalNotes, "")
+	is.Equal(p.HasInvite(exampleInvite), false)
+	is.Equal(len(p.GetTeams()), 1)
+	_, ok := p.UnavailableDuring(exampleJoinedAt, exampleLeftAt)
+	is.True(ok) // still unavailable, only the reason is gone
+	is.Equal(p.GetUnavailability()[0].Reason, UnavailabilityReason(""))
+}
+
+func TestPlayer_MarkUnavailable(t *testing.T) {
+	period := uuid.MustParse("a15e93f8-c952-11ed-afa1-0242ac120002")
+	testCases := []struct {
+		test        string
+		u           Unavailability
+		expectedErr error
+	}{
+		{"Missing ID", Unavailability{Reason: UnavailabilityReasonInjured, From: exampleJoinedAt, To: exampleLeftAt}, ErrInvalidUnavailability},
+		{"Unknown reason", Unavailability{ID: period, Reason: "bored", From: exampleJoinedAt, To: exampleLeftAt}, ErrInvalidUnavailability},
+		{"Ends before it starts", Unavailability{ID: period, Reason: UnavailabilityReasonInjured, From: exampleLeftAt, To: exampleJoinedAt}, ErrInvalidUnavailability},
+		{"Unknown visibility", Unavailability{ID: period, Reason: UnavailabilityReasonInjured, From: exampleJoinedAt, To: exampleLeftAt, Visibility: "public"}, ErrInvalidUnavailability},
+		{"Valid period", Unavailability{ID: period, Reason: UnavailabilityReasonInjured, From: exampleJoinedAt, To: exampleLeftAt}, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			p := NewPlayerFromEvents([]event.Event{playerCreated})
+
+			err := p.MarkUnavailable(tc.u)
+
+			is.Equal(err, tc.expectedErr)
+			if err == nil {
+				is.Equal(len(p.Events()), 1)
+				is.Equal(p.GetUnavailability()[0].Visibility, VisibilityStaff) // staff only by default
+				is.Equal(p.MarkUnavailable(tc.u), ErrPlayerUpdateFailed)
+			}
+		})
+	}
+}
+
+func TestPlayer_UnavailableDuring(t *testing.T) {
+	is := is.New(t)
+	p := NewPlayerFromEvents([]event.Event{playerCreated})
+	later := Unavailability{ID: uuid.New(), Reason: UnavailabilityReasonHoliday, From: exampleLeftAt, To: exampleLeftAt.AddDate(0, 0, 14), Visibility: VisibilityStaff}
+	earlier := Unavailability{ID: uuid.New(), Reason: UnavailabilityReasonInjured, From: exampleJoinedAt, To: exampleJoinedAt.AddDate(0, 0, 7), Visibility: VisibilityTeam}
+	is.NoErr(p.MarkUnavailable(later))
+	is.NoErr(p.MarkUnavailable(earlier))
+
+	is.Equal(p.GetUnavailability(), []Unavailability{earlier, later}) // in the order they start
+
+	u, ok := p.UnavailableDuring(exampleJoinedAt.AddDate(0, 0, 6), exampleJoinedAt.AddDate(0, 0, 8))
+	is.True(ok)
+	is.Equal(u, earlier)
+	_, ok = p.UnavailableDuring(exampleJoinedAt.AddDate(0, 0, 7), exampleJoinedAt.AddDate(0, 0, 8))
+	is.True(!ok) // back when the period ends
+
+	is.Equal(p.ClearUnavailability(uuid.New()), ErrUnavailabilityNotFound)
+	is.NoErr(p.ClearUnavailability(earlier.ID))
+	_, ok = p.UnavailableDuring(exampleJoinedAt, exampleJoinedAt.AddDate(0, 0, 1))
+	is.True(!ok)
+	is.Equal(p.GetUnavailability(), []Unavailability{later})
+}
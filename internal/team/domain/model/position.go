@@ -0,0 +1,54 @@
+package model
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidPosition   = errors.New("model: position is not valid")
+	ErrDuplicatePosition = errors.New("model: position is listed more than once")
+	ErrPlayerNotRostered = errors.New("model: player is not on the roster")
+)
+
+// Position is where a player plays on the field.
+type Position string
+
+// PlayerPositions are the positions a rostered player plays.
+type PlayerPositions struct {
+	Primary   Position
+	Secondary []Position
+}
+
+// PositionCatalogue are the positions of each sport. Sports without a
+// catalogue accept any position.
+var PositionCatalogue = map[Sport][]Position{
+	SportSoccer:     {"goalkeeper", "defender", "midfielder", "forward"},
+	SportBasketball: {"point_guard", "shooting_guard", "small_forward", "power_forward", "center"},
+	SportHockey:     {"goaltender", "defense", "center", "left_wing", "right_wing"},
+	SportLacrosse:   {"goalie", "defense", "midfield", "attack"},
+}
+
+// HasPosition returns whether the position is played in the sport.
+func (s Sport) HasPosition(p Position) bool {
+	if p == "" {
+		return false
+	}
+	catalogue, ok := PositionCatalogue[s]
+	if !ok {
+		return true
+	}
+	for _, position := range catalogue {
+		if position == p {
+			return true
+		}
+	}
+	return false
+}
+
+// DepthChart is the order players are picked in for a position.
+type DepthChart struct {
+	Position  Position
+	PlayerIds []uuid.UUID
+}
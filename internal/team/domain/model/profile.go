@@ -0,0 +1,79 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrInvalidEmergencyContact = errors.New("model: emergency contact needs a name and phone")
+
+// Address is a postal address.
+type Address struct {
+	Street     string
+	City       string
+	PostalCode string
+	Country    string
+}
+
+// EmergencyContact is someone to call when something happens to a player.
+type EmergencyContact struct {
+	Name         string
+	Relationship string
+	Phone        string
+}
+
+// ProfileField is a field of the player profile.
+type ProfileField string
+
+const (
+	ProfileFieldDateOfBirth       ProfileField = "date_of_birth"
+	ProfileFieldGender            ProfileField = "gender"
+	ProfileFieldPhone             ProfileField = "phone"
+	ProfileFieldAddress           ProfileField = "address"
+	ProfileFieldEmergencyContacts ProfileField = "emergency_contacts"
+	ProfileFieldMedicalNotes      ProfileField = "medical_notes"
+)
+
+// SensitiveProfileFields are only visible to those allowed to view
+// sensitive player data.
+var SensitiveProfileFields = map[ProfileField]bool{
+	ProfileFieldDateOfBirth:       true,
+	ProfileFieldPhone:             true,
+	ProfileFieldAddress:           true,
+	ProfileFieldEmergencyContacts: true,
+	ProfileFieldMedicalNotes:      true,
+}
+
+// Profile is the personal information held about a player.
+type Profile struct {
+	Name              string
+	DateOfBirth       time.Time
+	Gender            Gender
+	Phone             string
+	Address           Address
+	EmergencyContacts []EmergencyContact
+	MedicalNotes      string
+}
+
+// Redact returns the profile without the sensitive fields.
+func (p Profile) Redact() Profile {
+	if SensitiveProfileFields[ProfileFieldDateOfBirth] {
+		p.DateOfBirth = time.Time{}
+	}
+	if SensitiveProfileFields[ProfileFieldGender] {
+		p.Gender = GenderUnspecified
+	}
+	if SensitiveProfileFields[ProfileFieldPhone] {
+		p.Phone = ""
+	}
+	if SensitiveProfileFields[ProfileFieldAddress] {
+		p.Address = Address{}
+	}
+	if SensitiveProfileFields[ProfileFieldEmergencyContacts] {
+		p.EmergencyContacts = nil
+	}
+	if SensitiveProfileFields[ProfileFieldMedicalNotes] {
+		p.MedicalNotes = ""
+	}
+	return p
+}
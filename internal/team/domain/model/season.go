@@ -0,0 +1,137 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"git.sr.ht/~loges/teammate/internal/team/domain/event"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidSeason = errors.New("model: season has to have a name and end after it starts")
+	ErrSeasonClosed  = errors.New("model: season is closed")
+	ErrSeasonNotNext = errors.New("model: the team can only move on to a later season")
+)
+
+// NoSeason is the season of teams that have not started a season.
+var NoSeason = uuid.Nil
+
+// Season is a aggregate that represents a period rosters are kept for.
+// Once closed, the rosters of the season can no longer change.
+type Season struct {
+	id       uuid.UUID
+	name     string
+	startsOn time.Time
+	endsOn   time.Time
+	closed   bool
+
+	changes []event.Event
+	version int
+}
+
+// NewSeason is a factory to create a new Season aggregate.
+func NewSeason(id uuid.UUID, name string, startsOn, endsOn time.Time) (*Season, error) {
+	s := &Season{}
+
+	if id == NoSeason || name == "" || !endsOn.After(startsOn) {
+		return s, ErrInvalidSeason
+	}
+
+	s.register(&event.SeasonCreated{
+		ID:       id,
+		Name:     name,
+		StartsOn: startsOn,
+		EndsOn:   endsOn,
+	})
+
+	return s, nil
+}
+
+// NewSeasonFromEvents is a helper method that creates a new season from
+// a series of events.
+func NewSeasonFromEvents(events []event.Event) *Season {
+	s := &Season{}
+
+	for _, event := range events {
+		s.Apply(event, false)
+	}
+
+	return s
+}
+
+// GetID returns the season root entity ID.
+func (s *Season) GetID() uuid.UUID {
+	return s.id
+}
+
+// GetName returns the name of the season.
+func (s *Season) GetName() string {
+	return s.name
+}
+
+// GetStartsOn returns when the season starts.
+func (s *Season) GetStartsOn() time.Time {
+	return s.startsOn
+}
+
+// GetEndsOn returns when the season ends.
+func (s *Season) GetEndsOn() time.Time {
+	return s.endsOn
+}
+
+// Contains returns whether t falls within the season.
+func (s *Season) Contains(t time.Time) bool {
+	return !t.Before(s.startsOn) && t.Before(s.endsOn)
+}
+
+// IsClosed returns whether the season is closed.
+func (s *Season) IsClosed() bool {
+	return s.closed
+}
+
+// Close closes the season, which makes its rosters read-only.
+func (s *Season) Close() error {
+	if s.closed {
+		return ErrSeasonClosed
+	}
+
+	s.register(&event.SeasonClosed{
+		ID: s.id,
+	})
+
+	return nil
+}
+
+// Apply applies season events to the season aggregate.
+func (s *Season) Apply(e event.Event, new bool) {
+	switch se := e.(type) {
+	case *event.SeasonCreated:
+		s.id = se.ID
+		s.name = se.Name
+		s.startsOn = se.StartsOn
+		s.endsOn = se.EndsOn
+
+	case *event.SeasonClosed:
+		s.closed = true
+	}
+
+	if !new {
+		s.version++
+	}
+}
+
+// Events returns the uncommitted events from the season aggregate.
+func (s Season) Events() []event.Event {
+	return s.changes
+}
+
+// Version returns the last version of the aggregate before changes.
+func (s Season) Version() int {
+	return s.version
+}
+
+func (s *Season) register(event event.Event) {
+	s.changes = append(s.changes, event)
+	s.Apply(event, true)
+}
@@ -0,0 +1,105 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"git.sr.ht/~loges/teammate/internal/team/domain/event"
+	"github.com/google/uuid"
+	"github.com/matryer/is"
+)
+
+var (
+	exampleSeasonUUID = uuid.MustParse("a15e93f8-c952-11ed-afa1-0242ac120002")
+	exampleSeasonName = "2023/24"
+	seasonStartsOn    = time.Date(2023, time.August, 1, 0, 0, 0, 0, time.UTC)
+	seasonEndsOn      = time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+	seasonCreated     = &event.SeasonCreated{ID: exampleSeasonUUID, Name: exampleSeasonName, StartsOn: seasonStartsOn, EndsOn: seasonEndsOn}
+	seasonClosed      = &event.SeasonClosed{ID: exampleSeasonUUID}
+)
+
+func TestSeason_NewSeason(t *testing.T) {
+	testCases := []struct {
+		test        string
+		id          uuid.UUID
+		name        string
+		startsOn    time.Time
+		endsOn      time.Time
+		expectedErr error
+	}{
+		{"Valid season", exampleSeasonUUID, exampleSeasonName, seasonStartsOn, seasonEndsOn, nil},
+		{"Missing ID", NoSeason, exampleSeasonName, seasonStartsOn, seasonEndsOn, ErrInvalidSeason},
+		{"Empty name", exampleSeasonUUID, "", seasonStartsOn, seasonEndsOn, ErrInvalidSeason},
+		{"Ends before it starts", exampleSeasonUUID, exampleSeasonName, seasonEndsOn, seasonStartsOn, ErrInvalidSeason},
+		{"Ends when it starts", exampleSeasonUUID, exampleSeasonName, seasonStartsOn, seasonStartsOn, ErrInvalidSeason},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			s, err := NewSeason(tc.id, tc.name, tc.startsOn, tc.endsOn)
+			is.Equal(err, tc.expectedErr)
+			if err == nil {
+				is.Equal(s.GetID(), tc.id)
+				is.Equal(s.GetName(), tc.name)
+				is.Equal(s.GetStartsOn(), tc.startsOn)
+				is.Equal(s.GetEndsOn(), tc.endsOn)
+				is.True(!s.IsClosed())
+			}
+		})
+	}
+}
+
+func TestSeason_Contains(t *testing.T) {
+	testCases := []struct {
+		test     string
+		at       time.Time
+		expected bool
+	}{
+		{"Before the season", seasonStartsOn.Add(-time.Nanosecond), false},
+		{"First day", seasonStartsOn, true},
+		{"During the season", seasonStartsOn.AddDate(0, 3, 0), true},
+		{"After the season", seasonEndsOn, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			s := NewSeasonFromEvents([]event.Event{seasonCreated})
+			is.Equal(s.Contains(tc.at), tc.expected)
+		})
+	}
+}
+
+func TestSeason_Close(t *testing.T) {
+	testCases := []struct {
+		test        string
+		events      []event.Event
+		expectedErr error
+	}{
+		{"Close season", []event.Event{seasonCreated}, nil},
+		{"Season already closed", []event.Event{seasonCreated, seasonClosed}, ErrSeasonClosed},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			s := NewSeasonFromEvents(tc.events)
+
+			err := s.Close()
+
+			is.Equal(err, tc.expectedErr)
+			is.True(s.IsClosed())
+		})
+	}
+}
+
+func TestSeason_Version(t *testing.T) {
+	is := is.New(t)
+	s := NewSeasonFromEvents([]event.Event{seasonCreated})
+
+	is.NoErr(s.Close())
+
+	is.Equal(s.Version(), 1)
+	is.Equal(len(s.Events()), 1)
+}
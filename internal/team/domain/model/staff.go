@@ -0,0 +1,68 @@
+package model
+
+import (
+	"errors"
+
+	"git.sr.ht/~loges/teammate/internal/entity"
+)
+
+var (
+	ErrInvalidStaffRole   = errors.New("model: staff role is not valid")
+	ErrInvalidDesignation = errors.New("model: designation is not valid")
+	ErrLimitReached       = errors.New("model: the team has reached the limit for this role")
+)
+
+// StaffRole is the job of a non-playing member of the team staff.
+type StaffRole string
+
+const (
+	StaffRoleHeadCoach      StaffRole = "head_coach"
+	StaffRoleAssistantCoach StaffRole = "assistant_coach"
+	StaffRoleManager        StaffRole = "manager"
+	StaffRolePhysio         StaffRole = "physio"
+)
+
+// StaffLimits is how many staff members of a role a team can have. Roles
+// without a limit are unlimited.
+var StaffLimits = map[StaffRole]int{
+	StaffRoleHeadCoach: 1,
+}
+
+// IsValid returns whether the role is a known role.
+func (r StaffRole) IsValid() bool {
+	switch r {
+	case StaffRoleHeadCoach, StaffRoleAssistantCoach, StaffRoleManager, StaffRolePhysio:
+		return true
+	}
+	return false
+}
+
+// Designation is a leadership designation of a rostered player.
+type Designation string
+
+const (
+	DesignationNone        Designation = ""
+	DesignationCaptain     Designation = "captain"
+	DesignationViceCaptain Designation = "vice_captain"
+)
+
+// DesignationLimits is how many players of a team can hold a designation.
+var DesignationLimits = map[Designation]int{
+	DesignationCaptain:     1,
+	DesignationViceCaptain: 2,
+}
+
+// IsValid returns whether the designation is a known designation.
+func (d Designation) IsValid() bool {
+	switch d {
+	case DesignationCaptain, DesignationViceCaptain:
+		return true
+	}
+	return false
+}
+
+// StaffMember is a non-playing member of the team staff.
+type StaffMember struct {
+	Person *entity.Person
+	Role   StaffRole
+}
@@ -2,6 +2,7 @@ package model
 
 import (
 	"errors"
+	"sort"
 
 	"git.sr.ht/~loges/teammate/internal/entity"
 	"git.sr.ht/~loges/teammate/internal/team/domain/event"
@@ -13,11 +14,38 @@ var (
 	ErrTeamUpdateFailed = errors.New("model: team update failed")
 )
 
+// Compensation records what a team deactivation cascaded to, so that it
+// can be reverted when the team is activated again.
+type Compensation struct {
+	PlayerIds     []uuid.UUID
+	SessionIds    []uuid.UUID
+	JerseyNumbers map[uuid.UUID]int
+}
+
+// RosterSpot is a player on the roster, the jersey number they wear and
+// their designation.
+type RosterSpot struct {
+	Player       *entity.Person
+	JerseyNumber int
+	Designation  Designation
+}
+
 // Team is a aggregate that combines all entities needed to represent a team.
 type Team struct {
-	group     *entity.Group
-	activated bool
-	players   map[uuid.UUID]*entity.Person
+	group        *entity.Group
+	activated    bool
+	players      map[uuid.UUID]*entity.Person
+	numbers      map[uuid.UUID]int
+	positions    map[uuid.UUID]PlayerPositions
+	depthCharts  map[Position][]uuid.UUID
+	designations map[uuid.UUID]Designation
+	staff        map[uuid.UUID]*StaffMember
+	rules        RosterRules
+	sport        Sport
+	compensation *Compensation
+	season       uuid.UUID
+	seasons      map[uuid.UUID][]RosterSpot
+	released     []uuid.UUID
 
 	changes []event.Event
 	version int
@@ -68,6 +96,92 @@ func (t *Team) GetPlayers() (players []*entity.Person) {
 	return players
 }
 
+// GetRoster returns the players on the team and their jersey numbers,
+// ordered by name.
+func (t *Team) GetRoster() []RosterSpot {
+	roster := make([]RosterSpot, 0, len(t.players))
+	for id, player := range t.players {
+		roster = append(roster, RosterSpot{
+			Player:       player,
+			JerseyNumber: t.GetJerseyNumber(id),
+			Designation:  t.designations[id],
+		})
+	}
+	sort.Slice(roster, func(i, j int) bool {
+		return roster[i].Player.Name < roster[j].Player.Name
+	})
+	return roster
+}
+
+// GetSeason returns the season the team is playing, which is NoSeason if
+// the team has not started one.
+func (t *Team) GetSeason() uuid.UUID {
+	return t.season
+}
+
+// GetReleased returns the players released when the team started the
+// season it is playing.
+func (t *Team) GetReleased() []uuid.UUID {
+	return t.released
+}
+
+// GetSeasonRoster returns the roster of the team in the season, ordered by
+// name. The rosters of past seasons are kept as they were when the team
+// moved on to the next season.
+func (t *Team) GetSeasonRoster(seasonId uuid.UUID) ([]RosterSpot, bool) {
+	if seasonId == t.season {
+		return t.GetRoster(), true
+	}
+	roster, ok := t.seasons[seasonId]
+	return roster, ok
+}
+
+// GetStaff returns the staff of the team ordered by name.
+func (t *Team) GetStaff() []StaffMember {
+	staff := make([]StaffMember, 0, len(t.staff))
+	for _, member := range t.staff {
+		staff = append(staff, *member)
+	}
+	sort.Slice(staff, func(i, j int) bool {
+		return staff[i].Person.Name < staff[j].Person.Name
+	})
+	return staff
+}
+
+// GetJerseyNumber returns the jersey number of the player, which is
+// NoJerseyNumber if the player has none or is not on the team.
+func (t *Team) GetJerseyNumber(playerId uuid.UUID) int {
+	if number, ok := t.numbers[playerId]; ok {
+		return number
+	}
+	return NoJerseyNumber
+}
+
+// GetPositions returns the positions of a player on the team.
+func (t *Team) GetPositions(playerId uuid.UUID) (PlayerPositions, bool) {
+	positions, ok := t.positions[playerId]
+	return positions, ok
+}
+
+// GetDepthChart returns the order the players on the team are picked in
+// for the position.
+func (t *Team) GetDepthChart(position Position) DepthChart {
+	return DepthChart{
+		Position:  position,
+		PlayerIds: append([]uuid.UUID{}, t.depthCharts[position]...),
+	}
+}
+
+// GetRules returns who is eligible for the team's roster.
+func (t *Team) GetRules() RosterRules {
+	return t.rules
+}
+
+// GetSport returns the sport the team plays.
+func (t *Team) GetSport() Sport {
+	return t.sport
+}
+
 // IsActivated returns whether the team is activated.
 func (t *Team) IsActivated() bool {
 	return t.activated
@@ -99,21 +213,328 @@ func (t *Team) Deactivate() error {
 	return nil
 }
 
-// AssignPlayer assigns player to team.
-func (t *Team) AssignPlayer(p *Player) error {
+// GetCompensation returns the pending compensation of a cascaded
+// deactivation, if any.
+func (t *Team) GetCompensation() (*Compensation, bool) {
+	return t.compensation, t.compensation != nil
+}
+
+// RecordDeactivationCascade records what the deactivation of the team
+// cascaded to.
+func (t *Team) RecordDeactivationCascade(c *Compensation) error {
+	if t.activated || t.compensation != nil {
+		return ErrTeamUpdateFailed
+	}
+
+	t.register(&event.TeamDeactivationCascaded{
+		ID:            t.group.ID,
+		PlayerIds:     c.PlayerIds,
+		SessionIds:    c.SessionIds,
+		JerseyNumbers: c.JerseyNumbers,
+	})
+
+	return nil
+}
+
+// RevertDeactivationCascade records that the pending compensation has
+// been applied.
+func (t *Team) RevertDeactivationCascade() error {
+	if !t.activated || t.compensation == nil {
+		return ErrTeamUpdateFailed
+	}
+
+	t.register(&event.TeamDeactivationReverted{
+		ID: t.group.ID,
+	})
+
+	return nil
+}
+
+// AssignPlayer assigns player to team wearing the jersey number, which
+// can be NoJerseyNumber.
+func (t *Team) AssignPlayer(p *Player, number int) error {
 	if _, ok := t.players[p.person.ID]; ok {
 		return ErrTeamUpdateFailed
 	}
+	if err := t.checkJerseyNumber(number); err != nil {
+		return err
+	}
 
 	t.register(&event.PlayerAssignedToTeam{
+		ID:           t.group.ID,
+		PlayerId:     p.person.ID,
+		PlayerName:   p.person.Name,
+		JerseyNumber: number,
+	})
+
+	return nil
+}
+
+// ChangeJerseyNumber changes the jersey number of a player on the team.
+func (t *Team) ChangeJerseyNumber(p *Player, number int) error {
+	if _, ok := t.players[p.person.ID]; !ok {
+		return ErrTeamUpdateFailed
+	}
+	previous := t.GetJerseyNumber(p.person.ID)
+	if number == previous {
+		return ErrTeamUpdateFailed
+	}
+	if err := t.checkJerseyNumber(number); err != nil {
+		return err
+	}
+
+	t.register(&event.JerseyNumberChanged{
+		ID:       t.group.ID,
+		PlayerId: p.person.ID,
+		Number:   number,
+		Previous: previous,
+	})
+
+	return nil
+}
+
+// AssignPositions records the primary and secondary positions of a player
+// on the team. The positions have to be played in the team's sport, and
+// each is listed only once.
+func (t *Team) AssignPositions(p *Player, primary Position, secondary ...Position) error {
+	if _, ok := t.players[p.person.ID]; !ok {
+		return ErrPlayerNotRostered
+	}
+
+	e := &event.PlayerPositionsAssigned{
+		ID:       t.group.ID,
+		PlayerId: p.person.ID,
+		Primary:  string(primary),
+	}
+	seen := make(map[Position]bool)
+	for _, position := range append([]Position{primary}, secondary...) {
+		if !t.sport.HasPosition(position) {
+			return ErrInvalidPosition
+		}
+		if seen[position] {
+			return ErrDuplicatePosition
+		}
+		seen[position] = true
+	}
+	for _, position := range secondary {
+		e.Secondary = append(e.Secondary, string(position))
+	}
+
+	t.register(e)
+
+	return nil
+}
+
+// ChangeDepthChart orders the players picked for the position. Every
+// player has to be on the roster and appear only once.
+func (t *Team) ChangeDepthChart(position Position, playerIds []uuid.UUID) error {
+	if !t.sport.HasPosition(position) {
+		return ErrInvalidPosition
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	for _, id := range playerIds {
+		if _, ok := t.players[id]; !ok {
+			return ErrPlayerNotRostered
+		}
+		if seen[id] {
+			return ErrTeamUpdateFailed
+		}
+		seen[id] = true
+	}
+
+	t.register(&event.DepthChartChanged{
+		ID:        t.group.ID,
+		Position:  string(position),
+		PlayerIds: playerIds,
+	})
+
+	return nil
+}
+
+// AddStaffMember adds a person to the team staff in the role.
+func (t *Team) AddStaffMember(p *entity.Person, role StaffRole) error {
+	if !role.IsValid() {
+		return ErrInvalidStaffRole
+	}
+	if _, ok := t.staff[p.ID]; ok {
+		return ErrTeamUpdateFailed
+	}
+	if limit, ok := StaffLimits[role]; ok && t.countStaff(role) >= limit {
+		return ErrLimitReached
+	}
+
+	t.register(&event.StaffMemberAdded{
 		ID:         t.group.ID,
-		PlayerId:   p.person.ID,
-		PlayerName: p.person.Name,
+		PersonId:   p.ID,
+		PersonName: p.Name,
+		Role:       string(role),
+	})
+
+	return nil
+}
+
+// RemoveStaffMember removes a person from the team staff.
+func (t *Team) RemoveStaffMember(p *entity.Person) error {
+	if _, ok := t.staff[p.ID]; !ok {
+		return ErrTeamUpdateFailed
+	}
+
+	t.register(&event.StaffMemberRemoved{
+		ID:       t.group.ID,
+		PersonId: p.ID,
+	})
+
+	return nil
+}
+
+// EraseStaffMember erases the name of a person on the team staff, who
+// stays on the staff.
+func (t *Team) EraseStaffMember(p *entity.Person) error {
+	member, ok := t.staff[p.ID]
+	if !ok || member.Person.Name == entity.ErasedName {
+		return ErrTeamUpdateFailed
+	}
+
+	t.register(&event.StaffMemberErased{
+		ID:       t.group.ID,
+		PersonId: p.ID,
+	})
+
+	return nil
+}
+
+// Designate gives a player on the roster the designation, replacing any
+// designation the player held before.
+func (t *Team) Designate(p *Player, d Designation) error {
+	if !d.IsValid() {
+		return ErrInvalidDesignation
+	}
+	if _, ok := t.players[p.person.ID]; !ok {
+		return ErrPlayerNotRostered
+	}
+	if t.designations[p.person.ID] == d {
+		return ErrTeamUpdateFailed
+	}
+	if limit, ok := DesignationLimits[d]; ok && t.countDesignations(d) >= limit {
+		return ErrLimitReached
+	}
+
+	t.register(&event.PlayerDesignated{
+		ID:          t.group.ID,
+		PlayerId:    p.person.ID,
+		Designation: string(d),
+	})
+
+	return nil
+}
+
+// RemoveDesignation removes the designation of a player on the roster.
+func (t *Team) RemoveDesignation(p *Player) error {
+	if _, ok := t.designations[p.person.ID]; !ok {
+		return ErrTeamUpdateFailed
+	}
+
+	t.register(&event.PlayerDesignationRemoved{
+		ID:       t.group.ID,
+		PlayerId: p.person.ID,
 	})
 
 	return nil
 }
 
+func (t *Team) countStaff(role StaffRole) (n int) {
+	for _, member := range t.staff {
+		if member.Role == role {
+			n++
+		}
+	}
+	return n
+}
+
+func (t *Team) countDesignations(d Designation) (n int) {
+	for _, designation := range t.designations {
+		if designation == d {
+			n++
+		}
+	}
+	return n
+}
+
+// ChangeRules changes who is eligible for the team's roster. Players
+// already on the roster are not affected.
+func (t *Team) ChangeRules(r RosterRules) error {
+	if !r.IsValid() {
+		return ErrInvalidRosterRules
+	}
+	if r == t.rules {
+		return ErrTeamUpdateFailed
+	}
+
+	t.register(&event.TeamRulesChanged{
+		ID:                t.group.ID,
+		MaxRosterSize:     r.MaxRosterSize,
+		MinAge:            r.MinAge,
+		MaxAge:            r.MaxAge,
+		Division:          string(r.Division),
+		MaxTeamsPerPlayer: r.MaxTeamsPerPlayer,
+	})
+
+	return nil
+}
+
+// ChangeSport changes the sport the team plays. Every jersey number and
+// position on the roster has to be allowed in the new sport.
+func (t *Team) ChangeSport(s Sport) error {
+	if !s.IsValid() {
+		return ErrInvalidSport
+	}
+	if s == t.sport {
+		return ErrTeamUpdateFailed
+	}
+	for _, number := range t.numbers {
+		if !s.NumberRange().Contains(number) {
+			return ErrInvalidJerseyNumber
+		}
+	}
+	for _, positions := range t.positions {
+		for _, position := range append([]Position{positions.Primary}, positions.Secondary...) {
+			if !s.HasPosition(position) {
+				return ErrInvalidPosition
+			}
+		}
+	}
+	for position, ids := range t.depthCharts {
+		if len(ids) > 0 && !s.HasPosition(position) {
+			return ErrInvalidPosition
+		}
+	}
+
+	t.register(&event.TeamSportChanged{
+		ID:    t.group.ID,
+		Sport: string(s),
+	})
+
+	return nil
+}
+
+// checkJerseyNumber returns an error if the number is not allowed in the
+// team's sport or is worn by another player on the roster.
+func (t *Team) checkJerseyNumber(number int) error {
+	if number == NoJerseyNumber {
+		return nil
+	}
+	if !t.sport.NumberRange().Contains(number) {
+		return ErrInvalidJerseyNumber
+	}
+	for _, taken := range t.numbers {
+		if taken == number {
+			return ErrJerseyNumberTaken
+		}
+	}
+	return nil
+}
+
 // UassignPlayer assigns player from team.
 func (t *Team) UnassignPlayer(p *Player) error {
 	if _, ok := t.players[p.person.ID]; !ok {
@@ -129,6 +550,50 @@ func (t *Team) UnassignPlayer(p *Player) error {
 	return nil
 }
 
+// StartSeason moves the team on to the season, keeping the given players
+// on the roster along with their numbers, positions and designations. The
+// other players are released and returned, and the roster of the previous
+// season is kept as it was. A season the team has played before cannot be
+// started again, as its roster would be overwritten.
+func (t *Team) StartSeason(s *Season, keep []uuid.UUID) ([]*entity.Person, error) {
+	if s.IsClosed() {
+		return nil, ErrSeasonClosed
+	}
+	if s.GetID() == t.season {
+		return nil, ErrTeamUpdateFailed
+	}
+	if _, ok := t.seasons[s.GetID()]; ok {
+		return nil, ErrSeasonNotNext
+	}
+
+	kept := make(map[uuid.UUID]bool, len(keep))
+	for _, id := range keep {
+		if _, ok := t.players[id]; !ok {
+			return nil, ErrPlayerNotRostered
+		}
+		kept[id] = true
+	}
+
+	released := []*entity.Person{}
+	for _, spot := range t.GetRoster() {
+		if !kept[spot.Player.ID] {
+			released = append(released, spot.Player)
+		}
+	}
+
+	ids := make([]uuid.UUID, len(released))
+	for i, player := range released {
+		ids[i] = player.ID
+	}
+	t.register(&event.TeamSeasonStarted{
+		ID:       t.group.ID,
+		SeasonId: s.GetID(),
+		Released: ids,
+	})
+
+	return released, nil
+}
+
 // Apply applies team events to the team aggregate.
 func (t *Team) Apply(e event.Event, new bool) {
 	switch te := e.(type) {
@@ -139,6 +604,12 @@ func (t *Team) Apply(e event.Event, new bool) {
 		}
 		t.activated = true
 		t.players = make(map[uuid.UUID]*entity.Person)
+		t.numbers = make(map[uuid.UUID]int)
+		t.positions = make(map[uuid.UUID]PlayerPositions)
+		t.depthCharts = make(map[Position][]uuid.UUID)
+		t.designations = make(map[uuid.UUID]Designation)
+		t.staff = make(map[uuid.UUID]*StaffMember)
+		t.seasons = make(map[uuid.UUID][]RosterSpot)
 
 	case *event.TeamDeactivated:
 		t.activated = false
@@ -148,9 +619,81 @@ func (t *Team) Apply(e event.Event, new bool) {
 
 	case *event.PlayerAssignedToTeam:
 		t.players[te.PlayerId] = &entity.Person{ID: te.PlayerId, Name: te.PlayerName}
+		if te.JerseyNumber != NoJerseyNumber {
+			t.numbers[te.PlayerId] = te.JerseyNumber
+		}
 
 	case *event.PlayerUnassignedFromTeam:
-		delete(t.players, te.PlayerId)
+		t.remove(te.PlayerId)
+
+	case *event.PlayerPositionsAssigned:
+		positions := PlayerPositions{Primary: Position(te.Primary)}
+		for _, position := range te.Secondary {
+			positions.Secondary = append(positions.Secondary, Position(position))
+		}
+		t.positions[te.PlayerId] = positions
+
+	case *event.DepthChartChanged:
+		t.depthCharts[Position(te.Position)] = te.PlayerIds
+
+	case *event.StaffMemberAdded:
+		t.staff[te.PersonId] = &StaffMember{
+			Person: &entity.Person{ID: te.PersonId, Name: te.PersonName},
+			Role:   StaffRole(te.Role),
+		}
+
+	case *event.StaffMemberRemoved:
+		delete(t.staff, te.PersonId)
+
+	case *event.StaffMemberErased:
+		if member, ok := t.staff[te.PersonId]; ok {
+			member.Person = &entity.Person{ID: te.PersonId, Name: entity.ErasedName}
+		}
+
+	case *event.PlayerDesignated:
+		t.designations[te.PlayerId] = Designation(te.Designation)
+
+	case *event.PlayerDesignationRemoved:
+		delete(t.designations, te.PlayerId)
+
+	case *event.TeamRulesChanged:
+		t.rules = RosterRules{
+			MaxRosterSize:     te.MaxRosterSize,
+			MinAge:            te.MinAge,
+			MaxAge:            te.MaxAge,
+			Division:          Division(te.Division),
+			MaxTeamsPerPlayer: te.MaxTeamsPerPlayer,
+		}
+
+	case *event.JerseyNumberChanged:
+		if te.Number == NoJerseyNumber {
+			delete(t.numbers, te.PlayerId)
+		} else {
+			t.numbers[te.PlayerId] = te.Number
+		}
+
+	case *event.TeamSportChanged:
+		t.sport = Sport(te.Sport)
+
+	case *event.TeamDeactivationCascaded:
+		t.compensation = &Compensation{
+			PlayerIds:     te.PlayerIds,
+			SessionIds:    te.SessionIds,
+			JerseyNumbers: te.JerseyNumbers,
+		}
+
+	case *event.TeamDeactivationReverted:
+		t.compensation = nil
+
+	case *event.TeamSeasonStarted:
+		if t.season != NoSeason {
+			t.seasons[t.season] = t.GetRoster()
+		}
+		for _, id := range te.Released {
+			t.remove(id)
+		}
+		t.season = te.SeasonId
+		t.released = te.Released
 	}
 
 	if !new {
@@ -172,3 +715,25 @@ func (t *Team) register(event event.Event) {
 	t.changes = append(t.changes, event)
 	t.Apply(event, true)
 }
+
+// remove takes the player off the roster and every depth chart.
+func (t *Team) remove(playerId uuid.UUID) {
+	delete(t.players, playerId)
+	delete(t.numbers, playerId)
+	delete(t.positions, playerId)
+	delete(t.designations, playerId)
+	for position, ids := range t.depthCharts {
+		t.depthCharts[position] = without(ids, playerId)
+	}
+}
+
+// without returns the ids except id.
+func without(ids []uuid.UUID, id uuid.UUID) []uuid.UUID {
+	var kept []uuid.UUID
+	for _, other := range ids {
+		if other != id {
+			kept = append(kept, other)
+		}
+	}
+	return kept
+}
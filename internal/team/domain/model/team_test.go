@@ -14,7 +14,12 @@ var (
 	exampleTeamName = "Virginia"
 	teamCreated     = &event.TeamCreated{ID: exampleTeamUUID, Name: exampleTeamName}
 	teamDeactivated = &event.TeamDeactivated{ID: exampleTeamUUID}
-	playerAssigned  = &event.PlayerAssignedToTeam{ID: exampleTeamUUID, PlayerId: examplePlayerUUID, PlayerName: examplePlayerName}
+	playerAssigned  = &event.PlayerAssignedToTeam{ID: exampleTeamUUID, PlayerId: examplePlayerUUID, PlayerName: examplePlayerName, JerseyNumber: 10}
+	anotherPlayer   = &event.PlayerCreated{ID: uuid.MustParse("e35e93f8-c952-11ed-afa1-0242ac120002"), Name: "Emily"}
+	soccerPlayed    = &event.TeamSportChanged{ID: exampleTeamUUID, Sport: "soccer"}
+	teamActivated   = &event.TeamActivated{ID: exampleTeamUUID}
+	cascaded        = &event.TeamDeactivationCascaded{ID: exampleTeamUUID, PlayerIds: []uuid.UUID{examplePlayerUUID}}
+	reverted        = &event.TeamDeactivationReverted{ID: exampleTeamUUID}
 )
 
 func TestTeam_NewTeam(t *testing.T) {
@@ -118,31 +123,134 @@ func TestTeam_AssignPlayer(t *testing.T) {
 		test        string
 		player      *Player
 		team        *Team
+		number      int
 		expectedErr error
 	}{
 		{
 			"Add player to team",
 			NewPlayerFromEvents([]event.Event{playerCreated}),
 			NewTeamFromEvents([]event.Event{teamCreated}),
+			10,
+			nil,
+		},
+		{
+			"Add player without jersey number",
+			NewPlayerFromEvents([]event.Event{playerCreated}),
+			NewTeamFromEvents([]event.Event{teamCreated}),
+			NoJerseyNumber,
 			nil,
 		},
 		{
 			"Add player that is already assigned to team",
 			NewPlayerFromEvents([]event.Event{playerCreated}),
 			NewTeamFromEvents([]event.Event{teamCreated, playerAssigned}),
+			11,
 			ErrTeamUpdateFailed,
 		},
+		{
+			"Jersey number is taken",
+			NewPlayerFromEvents([]event.Event{anotherPlayer}),
+			NewTeamFromEvents([]event.Event{teamCreated, playerAssigned}),
+			10,
+			ErrJerseyNumberTaken,
+		},
+		{
+			"Jersey number is not allowed in the sport",
+			NewPlayerFromEvents([]event.Event{playerCreated}),
+			NewTeamFromEvents([]event.Event{teamCreated, soccerPlayed}),
+			0,
+			ErrInvalidJerseyNumber,
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.test, func(t *testing.T) {
 			is := is.New(t)
-			err := tc.team.AssignPlayer(tc.player)
+			err := tc.team.AssignPlayer(tc.player, tc.number)
+			is.Equal(err, tc.expectedErr)
+			if err == nil {
+				is.Equal(tc.team.GetJerseyNumber(tc.player.GetID()), tc.number)
+			}
+		})
+	}
+}
+
+func TestTeam_ChangeJerseyNumber(t *testing.T) {
+	testCases := []struct {
+		test        string
+		player      *Player
+		number      int
+		expectedErr error
+	}{
+		{"Change jersey number", NewPlayerFromEvents([]event.Event{playerCreated}), 11, nil},
+		{"Remove jersey number", NewPlayerFromEvents([]event.Event{playerCreated}), NoJerseyNumber, nil},
+		{"Same jersey number", NewPlayerFromEvents([]event.Event{playerCreated}), 10, ErrTeamUpdateFailed},
+		{"Jersey number out of range", NewPlayerFromEvents([]event.Event{playerCreated}), 100, ErrInvalidJerseyNumber},
+		{"Player not on the team", NewPlayerFromEvents([]event.Event{anotherPlayer}), 11, ErrTeamUpdateFailed},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			team := NewTeamFromEvents([]event.Event{teamCreated, playerAssigned})
+
+			err := team.ChangeJerseyNumber(tc.player, tc.number)
+
 			is.Equal(err, tc.expectedErr)
+			if err == nil {
+				is.Equal(team.GetJerseyNumber(tc.player.GetID()), tc.number)
+			}
 		})
 	}
 }
 
+func TestTeam_ChangeSport(t *testing.T) {
+	testCases := []struct {
+		test        string
+		events      []event.Event
+		sport       Sport
+		expectedErr error
+	}{
+		{"Change sport", []event.Event{teamCreated, playerAssigned}, SportSoccer, nil},
+		{"Same sport", []event.Event{teamCreated, soccerPlayed}, SportSoccer, ErrTeamUpdateFailed},
+		{"Unknown sport", []event.Event{teamCreated}, Sport("quidditch"), ErrInvalidSport},
+		{
+			"Roster number not allowed in the sport",
+			[]event.Event{teamCreated, &event.PlayerAssignedToTeam{ID: exampleTeamUUID, PlayerId: examplePlayerUUID, JerseyNumber: 99}},
+			SportHockey,
+			ErrInvalidJerseyNumber,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			team := NewTeamFromEvents(tc.events)
+
+			err := team.ChangeSport(tc.sport)
+
+			is.Equal(err, tc.expectedErr)
+		})
+	}
+}
+
+func TestTeam_GetRoster(t *testing.T) {
+	is := is.New(t)
+	team := NewTeamFromEvents([]event.Event{
+		teamCreated,
+		playerAssigned,
+		&event.PlayerAssignedToTeam{ID: exampleTeamUUID, PlayerId: anotherPlayer.ID, PlayerName: anotherPlayer.Name, JerseyNumber: NoJerseyNumber},
+	})
+
+	roster := team.GetRoster()
+
+	is.Equal(len(roster), 2)
+	is.Equal(roster[0].Player.Name, "Emily")
+	is.Equal(roster[0].JerseyNumber, NoJerseyNumber)
+	is.Equal(roster[1].Player.Name, examplePlayerName)
+	is.Equal(roster[1].JerseyNumber, 10)
+}
+
 func TestTeam_Unassignplayer(t *testing.T) {
 	testCases := []struct {
 		test        string
@@ -202,6 +310,70 @@ func TestTeam_GetPlayers(t *testing.T) {
 	}
 }
 
+func TestTeam_RecordDeactivationCascade(t *testing.T) {
+	testCases := []struct {
+		test        string
+		team        *Team
+		expectedErr error
+	}{
+		{
+			"Record cascade of deactivated team",
+			NewTeamFromEvents([]event.Event{teamCreated, playerAssigned, teamDeactivated}),
+			nil,
+		},
+		{
+			"Record cascade of active team",
+			NewTeamFromEvents([]event.Event{teamCreated, playerAssigned}),
+			ErrTeamUpdateFailed,
+		},
+		{
+			"Record cascade twice",
+			NewTeamFromEvents([]event.Event{teamCreated, playerAssigned, teamDeactivated, cascaded}),
+			ErrTeamUpdateFailed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			err := tc.team.RecordDeactivationCascade(&Compensation{PlayerIds: []uuid.UUID{examplePlayerUUID}})
+			is.Equal(err, tc.expectedErr)
+		})
+	}
+}
+
+func TestTeam_RevertDeactivationCascade(t *testing.T) {
+	testCases := []struct {
+		test        string
+		team        *Team
+		expectedErr error
+	}{
+		{
+			"Revert cascade of activated team",
+			NewTeamFromEvents([]event.Event{teamCreated, teamDeactivated, cascaded, teamActivated}),
+			nil,
+		},
+		{
+			"Revert cascade of deactivated team",
+			NewTeamFromEvents([]event.Event{teamCreated, teamDeactivated, cascaded}),
+			ErrTeamUpdateFailed,
+		},
+		{
+			"Revert without pending compensation",
+			NewTeamFromEvents([]event.Event{teamCreated, teamDeactivated, cascaded, teamActivated, reverted}),
+			ErrTeamUpdateFailed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			err := tc.team.RevertDeactivationCascade()
+			is.Equal(err, tc.expectedErr)
+		})
+	}
+}
+
 func TestTeam_Events(t *testing.T) {
 	t.Run("Event log is populated", func(t *testing.T) {
 		is := is.New(t)
@@ -223,3 +395,255 @@ func TestTeam_Version(t *testing.T) {
 		is.Equal(team.Version(), 2)
 	})
 }
+
+func TestTeam_AssignPositions(t *testing.T) {
+	testCases := []struct {
+		test        string
+		events      []event.Event
+		player      *Player
+		primary     Position
+		secondary   []Position
+		expectedErr error
+	}{
+		{"Assign positions", []event.Event{teamCreated, soccerPlayed, playerAssigned}, NewPlayerFromEvents([]event.Event{playerCreated}), "defender", []Position{"midfielder"}, nil},
+		{"Any position without a catalogue", []event.Event{teamCreated, playerAssigned}, NewPlayerFromEvents([]event.Event{playerCreated}), "sweeper", nil, nil},
+		{"Position not in the sport", []event.Event{teamCreated, soccerPlayed, playerAssigned}, NewPlayerFromEvents([]event.Event{playerCreated}), "center", nil, ErrInvalidPosition},
+		{"Secondary repeats primary", []event.Event{teamCreated, soccerPlayed, playerAssigned}, NewPlayerFromEvents([]event.Event{playerCreated}), "defender", []Position{"defender"}, ErrDuplicatePosition},
+		{"Secondary listed twice", []event.Event{teamCreated, soccerPlayed, playerAssigned}, NewPlayerFromEvents([]event.Event{playerCreated}), "defender", []Position{"midfielder", "midfielder"}, ErrDuplicatePosition},
+		{"Player not on the roster", []event.Event{teamCreated, soccerPlayed}, NewPlayerFromEvents([]event.Event{playerCreated}), "defender", nil, ErrPlayerNotRostered},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			team := NewTeamFromEvents(tc.events)
+
+			err := team.AssignPositions(tc.player, tc.primary, tc.secondary...)
+
+			is.Equal(err, tc.expectedErr)
+			if err == nil {
+				positions, ok := team.GetPositions(tc.player.GetID())
+				is.True(ok)
+				is.Equal(positions, PlayerPositions{Primary: tc.primary, Secondary: tc.secondary})
+			}
+		})
+	}
+}
+
+func TestTeam_ChangeDepthChart(t *testing.T) {
+	testCases := []struct {
+		test        string
+		position    Position
+		playerIds   []uuid.UUID
+		expectedErr error
+	}{
+		{"Change depth chart", "defender", []uuid.UUID{examplePlayerUUID}, nil},
+		{"Clear depth chart", "defender", nil, nil},
+		{"Position not in the sport", "center", []uuid.UUID{examplePlayerUUID}, ErrInvalidPosition},
+		{"Player not on the roster", "defender", []uuid.UUID{anotherPlayer.ID}, ErrPlayerNotRostered},
+		{"Player listed twice", "defender", []uuid.UUID{examplePlayerUUID, examplePlayerUUID}, ErrTeamUpdateFailed},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			team := NewTeamFromEvents([]event.Event{teamCreated, soccerPlayed, playerAssigned})
+
+			err := team.ChangeDepthChart(tc.position, tc.playerIds)
+
+			is.Equal(err, tc.expectedErr)
+		})
+	}
+
+	t.Run("Unassigned player leaves the depth chart", func(t *testing.T) {
+		is := is.New(t)
+		team := NewTeamFromEvents([]event.Event{
+			teamCreated,
+			soccerPlayed,
+			playerAssigned,
+			&event.PlayerPositionsAssigned{ID: exampleTeamUUID, PlayerId: examplePlayerUUID, Primary: "defender"},
+			&event.DepthChartChanged{ID: exampleTeamUUID, Position: "defender", PlayerIds: []uuid.UUID{examplePlayerUUID}},
+			&event.PlayerUnassignedFromTeam{ID: exampleTeamUUID, PlayerId: examplePlayerUUID},
+		})
+
+		is.Equal(len(team.GetDepthChart("defender").PlayerIds), 0)
+		_, ok := team.GetPositions(examplePlayerUUID)
+		is.Equal(ok, false)
+	})
+}
+
+func TestTeam_AddStaffMember(t *testing.T) {
+	coach := &entity.Person{ID: uuid.MustParse("b35e93f8-c952-11ed-afa1-0242ac120002"), Name: "Dean"}
+	headCoach := &event.StaffMemberAdded{ID: exampleTeamUUID, PersonId: coach.ID, PersonName: coach.Name, Role: "head_coach"}
+	testCases := []struct {
+		test        string
+		events      []event.Event
+		person      *entity.Person
+		role        StaffRole
+		expectedErr error
+	}{
+		{"Add head coach", []event.Event{teamCreated}, coach, StaffRoleHeadCoach, nil},
+		{"Unknown role", []event.Event{teamCreated}, coach, StaffRole("mascot"), ErrInvalidStaffRole},
+		{"Already on the staff", []event.Event{teamCreated, headCoach}, coach, StaffRolePhysio, ErrTeamUpdateFailed},
+		{"Only one head coach", []event.Event{teamCreated, headCoach}, exampleUser, StaffRoleHeadCoach, ErrLimitReached},
+		{"Many assistants", []event.Event{teamCreated, headCoach}, exampleUser, StaffRoleAssistantCoach, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			team := NewTeamFromEvents(tc.events)
+
+			err := team.AddStaffMember(tc.person, tc.role)
+
+			is.Equal(err, tc.expectedErr)
+		})
+	}
+
+	t.Run("Removed staff member frees the role", func(t *testing.T) {
+		is := is.New(t)
+		team := NewTeamFromEvents([]event.Event{teamCreated, headCoach})
+
+		is.NoErr(team.RemoveStaffMember(coach))
+		is.Equal(team.RemoveStaffMember(coach), ErrTeamUpdateFailed)
+		is.NoErr(team.AddStaffMember(exampleUser, StaffRoleHeadCoach))
+		is.Equal(team.GetStaff(), []StaffMember{{Person: exampleUser, Role: StaffRoleHeadCoach}})
+	})
+
+	t.Run("Erased staff member stays on the staff", func(t *testing.T) {
+		is := is.New(t)
+		team := NewTeamFromEvents([]event.Event{teamCreated, headCoach})
+
+		is.Equal(team.EraseStaffMember(exampleUser), ErrTeamUpdateFailed)
+		is.NoErr(team.EraseStaffMember(coach))
+		is.Equal(team.EraseStaffMember(coach), ErrTeamUpdateFailed)
+
+		replayed := NewTeamFromEvents(append([]event.Event{teamCreated, headCoach}, team.Events()...))
+		is.Equal(replayed.GetStaff(), []StaffMember{{Person: &entity.Person{ID: coach.ID, Name: entity.ErasedName}, Role: StaffRoleHeadCoach}})
+	})
+}
+
+func TestTeam_Designate(t *testing.T) {
+	captain := &event.PlayerDesignated{ID: exampleTeamUUID, PlayerId: anotherPlayer.ID, Designation: "captain"}
+	anotherAssigned := &event.PlayerAssignedToTeam{ID: exampleTeamUUID, PlayerId: anotherPlayer.ID, PlayerName: anotherPlayer.Name, JerseyNumber: NoJerseyNumber}
+	testCases := []struct {
+		test        string
+		events      []event.Event
+		designation Designation
+		expectedErr error
+	}{
+		{"Designate captain", []event.Event{teamCreated, playerAssigned}, DesignationCaptain, nil},
+		{"Unknown designation", []event.Event{teamCreated, playerAssigned}, Designation("coach"), ErrInvalidDesignation},
+		{"Player not on the roster", []event.Event{teamCreated}, DesignationCaptain, ErrPlayerNotRostered},
+		{"Only one captain", []event.Event{teamCreated, playerAssigned, anotherAssigned, captain}, DesignationCaptain, ErrLimitReached},
+		{"Vice-captain next to a captain", []event.Event{teamCreated, playerAssigned, anotherAssigned, captain}, DesignationViceCaptain, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			team := NewTeamFromEvents(tc.events)
+			p := NewPlayerFromEvents([]event.Event{playerCreated})
+
+			err := team.Designate(p, tc.designation)
+
+			is.Equal(err, tc.expectedErr)
+		})
+	}
+
+	t.Run("Unassigned captain loses the designation", func(t *testing.T) {
+		is := is.New(t)
+		team := NewTeamFromEvents([]event.Event{teamCreated, anotherAssigned, captain})
+		p := NewPlayerFromEvents([]event.Event{anotherPlayer})
+
+		is.NoErr(team.UnassignPlayer(p))
+		is.NoErr(team.AssignPlayer(p, NoJerseyNumber))
+		is.Equal(team.GetRoster()[0].Designation, DesignationNone)
+		is.Equal(team.RemoveDesignation(p), ErrTeamUpdateFailed)
+	})
+}
+
+func TestTeam_ChangeRules(t *testing.T) {
+	testCases := []struct {
+		test        string
+		rules       RosterRules
+		expectedErr error
+	}{
+		{"Change rules", RosterRules{MaxRosterSize: 18, MaxAge: 12, Division: DivisionFemale}, nil},
+		{"Same rules", RosterRules{}, ErrTeamUpdateFailed},
+		{"Negative roster size", RosterRules{MaxRosterSize: -1}, ErrInvalidRosterRules},
+		{"Empty age band", RosterRules{MinAge: 12, MaxAge: 12}, ErrInvalidRosterRules},
+		{"Unknown division", RosterRules{Division: Division("coed")}, ErrInvalidRosterRules},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			team := NewTeamFromEvents([]event.Event{teamCreated})
+
+			err := team.ChangeRules(tc.rules)
+
+			is.Equal(err, tc.expectedErr)
+			if err == nil {
+				is.Equal(team.GetRules(), tc.rules)
+			}
+		})
+	}
+}
+
+func TestTeam_StartSeason(t *testing.T) {
+	nextSeason := NewSeasonFromEvents([]event.Event{
+		&event.SeasonCreated{ID: uuid.MustParse("b15e93f8-c952-11ed-afa1-0242ac120002"), Name: "2024/25", StartsOn: seasonEndsOn, EndsOn: seasonEndsOn.AddDate(1, 0, 0)},
+	})
+	anotherAssigned := &event.PlayerAssignedToTeam{ID: exampleTeamUUID, PlayerId: anotherPlayer.ID, PlayerName: anotherPlayer.Name, JerseyNumber: 7}
+	inSeason := &event.TeamSeasonStarted{ID: exampleTeamUUID, SeasonId: exampleSeasonUUID, Released: []uuid.UUID{}}
+	testCases := []struct {
+		test             string
+		events           []event.Event
+		season           *Season
+		keep             []uuid.UUID
+		expectedReleased int
+		expectedErr      error
+	}{
+		{"First season keeps everyone", []event.Event{teamCreated, playerAssigned, anotherAssigned}, NewSeasonFromEvents([]event.Event{seasonCreated}), []uuid.UUID{examplePlayerUUID, anotherPlayer.ID}, 0, nil},
+		{"Roll over releases players not kept", []event.Event{teamCreated, playerAssigned, anotherAssigned, inSeason}, nextSeason, []uuid.UUID{examplePlayerUUID}, 1, nil},
+		{"Same season", []event.Event{teamCreated, inSeason}, NewSeasonFromEvents([]event.Event{seasonCreated}), nil, 0, ErrTeamUpdateFailed},
+		{"Closed season", []event.Event{teamCreated}, NewSeasonFromEvents([]event.Event{seasonCreated, seasonClosed}), nil, 0, ErrSeasonClosed},
+		{"Kept player not on the roster", []event.Event{teamCreated, inSeason}, nextSeason, []uuid.UUID{examplePlayerUUID}, 0, ErrPlayerNotRostered},
+		{"Season played before", []event.Event{teamCreated, inSeason, &event.TeamSeasonStarted{ID: exampleTeamUUID, SeasonId: nextSeason.GetID(), Released: []uuid.UUID{}}}, NewSeasonFromEvents([]event.Event{seasonCreated}), nil, 0, ErrSeasonNotNext},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			team := NewTeamFromEvents(tc.events)
+
+			released, err := team.StartSeason(tc.season, tc.keep)
+
+			is.Equal(err, tc.expectedErr)
+			is.Equal(len(released), tc.expectedReleased)
+			if err == nil {
+				is.Equal(team.GetSeason(), tc.season.GetID())
+				is.Equal(len(team.GetRoster()), len(tc.keep))
+			}
+		})
+	}
+
+	t.Run("Past season roster is kept", func(t *testing.T) {
+		is := is.New(t)
+		team := NewTeamFromEvents([]event.Event{teamCreated, playerAssigned, anotherAssigned, inSeason})
+
+		_, err := team.StartSeason(nextSeason, []uuid.UUID{examplePlayerUUID})
+		is.NoErr(err)
+
+		past, ok := team.GetSeasonRoster(exampleSeasonUUID)
+		is.True(ok)
+		is.Equal(len(past), 2)
+		current, ok := team.GetSeasonRoster(nextSeason.GetID())
+		is.True(ok)
+		is.Equal(len(current), 1)
+		is.Equal(current[0].JerseyNumber, 10)
+		_, ok = team.GetSeasonRoster(uuid.New())
+		is.True(!ok)
+	})
+}
@@ -0,0 +1,149 @@
+package model
+
+import (
+	"errors"
+
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"git.sr.ht/~loges/teammate/internal/team/domain/bracket"
+	"git.sr.ht/~loges/teammate/internal/team/domain/event"
+	"github.com/google/uuid"
+)
+
+var ErrInvalidTournament = errors.New("model: tournament has to have a name")
+
+// Tournament is a aggregate that represents a tournament and the results
+// of its matches.
+type Tournament struct {
+	id      uuid.UUID
+	name    string
+	teams   []*entity.Group
+	options bracket.Options
+	results []bracket.Result
+
+	changes []event.Event
+	version int
+}
+
+// NewTournament is a factory to create a new Tournament aggregate of the
+// teams, given in seeding order. The teams have to be active.
+func NewTournament(id uuid.UUID, name string, teams []*Team, o bracket.Options) (*Tournament, error) {
+	t := &Tournament{}
+
+	if id == uuid.Nil || name == "" {
+		return t, ErrInvalidTournament
+	}
+	groups := make([]*entity.Group, len(teams))
+	seeded := make([]event.TournamentTeam, len(teams))
+	for i, team := range teams {
+		if !team.IsActivated() {
+			return t, ErrTeamNotActive
+		}
+		groups[i] = &entity.Group{ID: team.GetID(), Name: team.GetName()}
+		seeded[i] = event.TournamentTeam{ID: team.GetID(), Name: team.GetName()}
+	}
+	if _, err := bracket.Build(groups, o, nil); err != nil {
+		return t, err
+	}
+
+	t.register(&event.TournamentCreated{
+		ID:         id,
+		Name:       name,
+		Format:     string(o.Format),
+		ThirdPlace: o.ThirdPlace,
+		Groups:     o.Groups,
+		Advance:    o.Advance,
+		Teams:      seeded,
+	})
+
+	return t, nil
+}
+
+// NewTournamentFromEvents is a helper method that creates a new tournament
+// from a series of events.
+func NewTournamentFromEvents(events []event.Event) *Tournament {
+	t := &Tournament{}
+
+	for _, event := range events {
+		t.Apply(event, false)
+	}
+
+	return t
+}
+
+// GetID returns the tournament root entity ID.
+func (t *Tournament) GetID() uuid.UUID {
+	return t.id
+}
+
+// GetName returns the name of the tournament.
+func (t *Tournament) GetName() string {
+	return t.name
+}
+
+// GetBracket returns the bracket with the teams advanced by the results.
+func (t *Tournament) GetBracket() (*bracket.Bracket, error) {
+	return bracket.Build(t.teams, t.options, t.results)
+}
+
+// RecordResult records the score of the match, which advances the teams
+// through the bracket.
+func (t *Tournament) RecordResult(matchId string, homeScore, awayScore int) error {
+	b, err := t.GetBracket()
+	if err != nil {
+		return err
+	}
+	r := bracket.Result{MatchID: matchId, HomeScore: homeScore, AwayScore: awayScore}
+	if err = b.Check(r); err != nil {
+		return err
+	}
+
+	t.register(&event.TournamentResultRecorded{
+		ID:        t.id,
+		MatchId:   matchId,
+		HomeScore: homeScore,
+		AwayScore: awayScore,
+	})
+
+	return nil
+}
+
+// Apply applies tournament events to the tournament aggregate.
+func (t *Tournament) Apply(e event.Event, new bool) {
+	switch te := e.(type) {
+	case *event.TournamentCreated:
+		t.id = te.ID
+		t.name = te.Name
+		t.options = bracket.Options{
+			Format:     bracket.Format(te.Format),
+			ThirdPlace: te.ThirdPlace,
+			Groups:     te.Groups,
+			Advance:    te.Advance,
+		}
+		t.teams = make([]*entity.Group, len(te.Teams))
+		for i, team := range te.Teams {
+			t.teams[i] = &entity.Group{ID: team.ID, Name: team.Name}
+		}
+
+	case *event.TournamentResultRecorded:
+		t.results = append(t.results, bracket.Result{MatchID: te.MatchId, HomeScore: te.HomeScore, AwayScore: te.AwayScore})
+	}
+
+	if !new {
+		t.version++
+	}
+}
+
+// Events returns the uncommitted events from the tournament aggregate.
+func (t Tournament) Events() []event.Event {
+	return t.changes
+}
+
+// Version returns the last version of the aggregate before changes.
+func (t Tournament) Version() int {
+	return t.version
+}
+
+func (t *Tournament) register(event event.Event) {
+	t.changes = append(t.changes, event)
+	t.Apply(event, true)
+}
@@ -0,0 +1,91 @@
+package model
+
+import (
+	"testing"
+
+	"git.sr.ht/~loges/teammate/internal/team/domain/bracket"
+	"git.sr.ht/~loges/teammate/internal/team/domain/event"
+	"github.com/google/uuid"
+	"github.com/matryer/is"
+)
+
+var (
+	exampleTournamentUUID = uuid.MustParse("b25e93f8-c952-11ed-afa1-0242ac120002")
+	knockout              = bracket.Options{Format: bracket.SingleElimination}
+	tournamentCreated     = &event.TournamentCreated{
+		ID:     exampleTournamentUUID,
+		Name:   "Spring Cup",
+		Format: string(bracket.SingleElimination),
+		Teams:  []event.TournamentTeam{{ID: exampleTeamUUID, Name: exampleTeamName}, {ID: awayTeamUUID, Name: "Bears"}},
+	}
+)
+
+func TestTournament_NewTournament(t *testing.T) {
+	bears := []event.Event{&event.TeamCreated{ID: awayTeamUUID, Name: "Bears"}}
+	testCases := []struct {
+		test        string
+		id          uuid.UUID
+		name        string
+		teams       [][]event.Event
+		o           bracket.Options
+		expectedErr error
+	}{
+		{"Valid tournament", exampleTournamentUUID, "Spring Cup", [][]event.Event{{teamCreated}, bears}, knockout, nil},
+		{"Missing ID", uuid.Nil, "Spring Cup", [][]event.Event{{teamCreated}, bears}, knockout, ErrInvalidTournament},
+		{"Missing name", exampleTournamentUUID, "", [][]event.Event{{teamCreated}, bears}, knockout, ErrInvalidTournament},
+		{"Team is not active", exampleTournamentUUID, "Spring Cup", [][]event.Event{{teamCreated, teamDeactivated}, bears}, knockout, ErrTeamNotActive},
+		{"Too few teams", exampleTournamentUUID, "Spring Cup", [][]event.Event{{teamCreated}}, knockout, bracket.ErrNotEnoughTeams},
+		{"Invalid format", exampleTournamentUUID, "Spring Cup", [][]event.Event{{teamCreated}, bears}, bracket.Options{}, bracket.ErrInvalidOptions},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			var teams []*Team
+			for _, events := range tc.teams {
+				teams = append(teams, NewTeamFromEvents(events))
+			}
+
+			tournament, err := NewTournament(tc.id, tc.name, teams, tc.o)
+
+			is.Equal(err, tc.expectedErr)
+			if err == nil {
+				is.Equal(tournament.GetName(), tc.name)
+				b, err := tournament.GetBracket()
+				is.NoErr(err)
+				is.Equal(b.Winners[0].Matches[0].Away.Name, "Bears")
+				is.Equal(len(tournament.Events()), 1)
+			}
+		})
+	}
+}
+
+func TestTournament_RecordResult(t *testing.T) {
+	testCases := []struct {
+		test        string
+		events      []event.Event
+		match       string
+		expectedErr error
+	}{
+		{"Result recorded", []event.Event{tournamentCreated}, "R1-1", nil},
+		{"Match not found", []event.Event{tournamentCreated}, "R2-1", bracket.ErrMatchNotFound},
+		{"Match already played", []event.Event{tournamentCreated, &event.TournamentResultRecorded{ID: exampleTournamentUUID, MatchId: "R1-1", HomeScore: 1}}, "R1-1", bracket.ErrMatchPlayed},
+		{"Bracket can't be drawn", []event.Event{&event.TournamentCreated{ID: exampleTournamentUUID, Name: "Spring Cup", Format: "swiss", Teams: tournamentCreated.Teams}}, "R1-1", bracket.ErrInvalidOptions},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			tournament := NewTournamentFromEvents(tc.events)
+
+			err := tournament.RecordResult(tc.match, 0, 2)
+
+			is.Equal(err, tc.expectedErr)
+			if err == nil {
+				b, _ := tournament.GetBracket()
+				is.Equal(b.Champion.ID, awayTeamUUID)
+				is.Equal(tournament.Version(), 1)
+			}
+		})
+	}
+}
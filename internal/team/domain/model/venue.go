@@ -0,0 +1,295 @@
+package model
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"git.sr.ht/~loges/teammate/internal/team/domain/event"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidVenue        = errors.New("model: venue has to have a name")
+	ErrInvalidField        = errors.New("model: field has to have a name")
+	ErrFieldAlreadyExists  = errors.New("model: venue already has a field with this name")
+	ErrFieldNotFound       = errors.New("model: venue has no field with this name")
+	ErrInvalidOpeningHours = errors.New("model: venue has to open before it closes on the same day")
+	ErrVenueClosed         = errors.New("model: venue is closed at this time")
+	ErrDoubleBooked        = errors.New("model: field is already booked at this time")
+	ErrFieldBooked         = errors.New("model: field has games booked")
+	ErrVenueUpdateFailed   = errors.New("model: venue update failed")
+)
+
+// Field is a field or court of a venue that one game or session can be
+// played on at a time.
+type Field struct {
+	Name    string
+	Surface string
+}
+
+// OpeningHours are the times of day a venue opens and closes. The zero
+// value means the venue is closed all day.
+type OpeningHours struct {
+	Opens  time.Duration
+	Closes time.Duration
+}
+
+// IsClosed returns whether the venue is closed all day.
+func (h OpeningHours) IsClosed() bool {
+	return h == OpeningHours{}
+}
+
+// Booking is the use of a field of a venue for a time.
+type Booking struct {
+	VenueID  uuid.UUID
+	Field    string
+	StartsAt time.Time
+	EndsAt   time.Time
+}
+
+// Overlaps returns whether both bookings use the same field at the same
+// time.
+func (b Booking) Overlaps(o Booking) bool {
+	return b.VenueID == o.VenueID && b.Field == o.Field && b.StartsAt.Before(o.EndsAt) && o.StartsAt.Before(b.EndsAt)
+}
+
+// BookingConflict is a pair of games booked on the same field at the same
+// time.
+type BookingConflict struct {
+	Location Location
+	First    ScheduledGame
+	Second   ScheduledGame
+}
+
+// FindConflicts returns the games booked on the same field at the same
+// time, ordered by when the first game starts.
+func FindConflicts(games []*Game) []BookingConflict {
+	sorted := append([]*Game{}, games...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].GetStartsAt().Before(sorted[j].GetStartsAt())
+	})
+
+	conflicts := []BookingConflict{}
+	for i, a := range sorted {
+		for _, b := range sorted[i+1:] {
+			if a.GetBooking().VenueID == uuid.Nil || !a.GetBooking().Overlaps(b.GetBooking()) {
+				continue
+			}
+			first := a.GetScheduledGame()
+			conflicts = append(conflicts, BookingConflict{Location: first.Location, First: first, Second: b.GetScheduledGame()})
+		}
+	}
+	return conflicts
+}
+
+// Venue is a aggregate that represents a place with fields or courts
+// that games and sessions are played on.
+type Venue struct {
+	id      uuid.UUID
+	name    string
+	address Address
+	fields  []Field
+	hours   map[time.Weekday]OpeningHours
+
+	changes []event.Event
+	version int
+}
+
+// NewVenue is a factory to create a new Venue aggregate. A venue is open
+// at all times until its opening hours are set.
+func NewVenue(id uuid.UUID, name string, a Address) (*Venue, error) {
+	v := &Venue{}
+
+	if id == uuid.Nil || name == "" {
+		return v, ErrInvalidVenue
+	}
+
+	v.register(&event.VenueCreated{
+		ID:         id,
+		Name:       name,
+		Street:     a.Street,
+		City:       a.City,
+		PostalCode: a.PostalCode,
+		Country:    a.Country,
+	})
+
+	return v, nil
+}
+
+// NewVenueFromEvents is a helper method that creates a new venue from a
+// series of events.
+func NewVenueFromEvents(events []event.Event) *Venue {
+	v := &Venue{}
+
+	for _, event := range events {
+		v.Apply(event, false)
+	}
+
+	return v
+}
+
+// GetID returns the venue root entity ID.
+func (v *Venue) GetID() uuid.UUID {
+	return v.id
+}
+
+// GetName returns the name of the venue.
+func (v *Venue) GetName() string {
+	return v.name
+}
+
+// GetAddress returns the address of the venue.
+func (v *Venue) GetAddress() Address {
+	return v.address
+}
+
+// GetFields returns the fields of the venue in the order they were added.
+func (v *Venue) GetFields() []Field {
+	return append([]Field{}, v.fields...)
+}
+
+// GetOpeningHours returns the opening hours of the venue on the day and
+// whether they are set.
+func (v *Venue) GetOpeningHours(day time.Weekday) (OpeningHours, bool) {
+	h, ok := v.hours[day]
+	return h, ok
+}
+
+// GetLocation returns the location of the field of the venue.
+func (v *Venue) GetLocation(field string) Location {
+	return Location{VenueID: v.id, VenueName: v.name, Field: field}
+}
+
+// AddField adds a field or court to the venue.
+func (v *Venue) AddField(f Field) error {
+	if f.Name == "" {
+		return ErrInvalidField
+	}
+	if v.hasField(f.Name) {
+		return ErrFieldAlreadyExists
+	}
+
+	v.register(&event.VenueFieldAdded{ID: v.id, Field: f.Name, Surface: f.Surface})
+
+	return nil
+}
+
+// RemoveField removes a field or court from the venue.
+func (v *Venue) RemoveField(name string) error {
+	if !v.hasField(name) {
+		return ErrFieldNotFound
+	}
+
+	v.register(&event.VenueFieldRemoved{ID: v.id, Field: name})
+
+	return nil
+}
+
+// SetOpeningHours sets when the venue opens and closes on the day. Days
+// without opening hours are closed once the hours of any day are set.
+func (v *Venue) SetOpeningHours(day time.Weekday, h OpeningHours) error {
+	if !h.IsClosed() && (h.Opens < 0 || h.Opens >= h.Closes || h.Closes > 24*time.Hour) {
+		return ErrInvalidOpeningHours
+	}
+	if current, ok := v.hours[day]; ok && current == h {
+		return ErrVenueUpdateFailed
+	}
+
+	v.register(&event.VenueOpeningHoursChanged{
+		ID:       v.id,
+		Weekday:  int(day),
+		OpensAt:  int(h.Opens / time.Minute),
+		ClosesAt: int(h.Closes / time.Minute),
+	})
+
+	return nil
+}
+
+// IsOpen returns whether the venue is open for the whole time.
+func (v *Venue) IsOpen(startsAt, endsAt time.Time) bool {
+	if len(v.hours) == 0 {
+		return true
+	}
+
+	h, ok := v.hours[startsAt.Weekday()]
+	if !ok || h.IsClosed() {
+		return false
+	}
+	y, m, d := startsAt.Date()
+	midnight := time.Date(y, m, d, 0, 0, 0, 0, startsAt.Location())
+	return !startsAt.Before(midnight.Add(h.Opens)) && !endsAt.After(midnight.Add(h.Closes))
+}
+
+// CheckBooking returns whether the field can be booked, which needs the
+// venue to be open and the field not to be booked already.
+func (v *Venue) CheckBooking(b Booking, booked []Booking) error {
+	if !v.hasField(b.Field) {
+		return ErrFieldNotFound
+	}
+	if !v.IsOpen(b.StartsAt, b.EndsAt) {
+		return ErrVenueClosed
+	}
+	for _, other := range booked {
+		if b.Overlaps(other) {
+			return ErrDoubleBooked
+		}
+	}
+	return nil
+}
+
+// Apply applies venue events to the venue aggregate.
+func (v *Venue) Apply(e event.Event, new bool) {
+	switch ve := e.(type) {
+	case *event.VenueCreated:
+		v.id = ve.ID
+		v.name = ve.Name
+		v.address = Address{Street: ve.Street, City: ve.City, PostalCode: ve.PostalCode, Country: ve.Country}
+		v.hours = make(map[time.Weekday]OpeningHours)
+
+	case *event.VenueFieldAdded:
+		v.fields = append(v.fields, Field{Name: ve.Field, Surface: ve.Surface})
+
+	case *event.VenueFieldRemoved:
+		for i, f := range v.fields {
+			if f.Name == ve.Field {
+				v.fields = append(v.fields[:i:i], v.fields[i+1:]...)
+				break
+			}
+		}
+
+	case *event.VenueOpeningHoursChanged:
+		v.hours[time.Weekday(ve.Weekday)] = OpeningHours{
+			Opens:  time.Duration(ve.OpensAt) * time.Minute,
+			Closes: time.Duration(ve.ClosesAt) * time.Minute,
+		}
+	}
+
+	if !new {
+		v.version++
+	}
+}
+
+// Events returns the uncommitted events from the venue aggregate.
+func (v Venue) Events() []event.Event {
+	return v.changes
+}
+
+// Version returns the last version of the aggregate before changes.
+func (v Venue) Version() int {
+	return v.version
+}
+
+func (v *Venue) hasField(name string) bool {
+	for _, f := range v.fields {
+		if f.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (v *Venue) register(event event.Event) {
+	v.changes = append(v.changes, event)
+	v.Apply(event, true)
+}
@@ -0,0 +1,186 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"git.sr.ht/~loges/teammate/internal/team/domain/event"
+	"github.com/google/uuid"
+	"github.com/matryer/is"
+)
+
+var (
+	exampleVenueUUID = exampleLocation.VenueID
+	venueCreated     = &event.VenueCreated{ID: exampleVenueUUID, Name: "Riverside Park", City: "Syracuse"}
+	northFieldAdded  = &event.VenueFieldAdded{ID: exampleVenueUUID, Field: "North Field", Surface: "grass"}
+	openOnSundays    = &event.VenueOpeningHoursChanged{ID: exampleVenueUUID, Weekday: int(time.Sunday), OpensAt: 9 * 60, ClosesAt: 18 * 60}
+)
+
+func booking(field string, startsAt time.Time, d time.Duration) Booking {
+	return Booking{VenueID: exampleVenueUUID, Field: field, StartsAt: startsAt, EndsAt: startsAt.Add(d)}
+}
+
+func TestVenue_NewVenue(t *testing.T) {
+	testCases := []struct {
+		test        string
+		id          uuid.UUID
+		name        string
+		expectedErr error
+	}{
+		{"Valid venue", exampleVenueUUID, "Riverside Park", nil},
+		{"Missing ID", uuid.Nil, "Riverside Park", ErrInvalidVenue},
+		{"Missing name", exampleVenueUUID, "", ErrInvalidVenue},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+
+			v, err := NewVenue(tc.id, tc.name, Address{City: "Syracuse"})
+
+			is.Equal(err, tc.expectedErr)
+			if err == nil {
+				is.Equal(v.GetName(), tc.name)
+				is.Equal(v.GetAddress().City, "Syracuse")
+				is.Equal(v.GetLocation("North Field"), exampleLocation)
+			}
+		})
+	}
+}
+
+func TestVenue_Fields(t *testing.T) {
+	testCases := []struct {
+		test        string
+		add         Field
+		remove      string
+		expected    []Field
+		expectedErr error
+	}{
+		{"Add a court", Field{Name: "Court 1", Surface: "hardwood"}, "", []Field{{"North Field", "grass"}, {"Court 1", "hardwood"}}, nil},
+		{"Field without a name", Field{Surface: "grass"}, "", nil, ErrInvalidField},
+		{"Field already exists", Field{Name: "North Field"}, "", nil, ErrFieldAlreadyExists},
+		{"Remove a field", Field{}, "North Field", []Field{}, nil},
+		{"Field not found", Field{}, "South Field", nil, ErrFieldNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			v := NewVenueFromEvents([]event.Event{venueCreated, northFieldAdded})
+
+			var err error
+			if tc.remove != "" {
+				err = v.RemoveField(tc.remove)
+			} else {
+				err = v.AddField(tc.add)
+			}
+
+			is.Equal(err, tc.expectedErr)
+			if err == nil {
+				is.Equal(v.GetFields(), tc.expected)
+				is.Equal(len(v.Events()), 1)
+			}
+		})
+	}
+}
+
+func TestVenue_SetOpeningHours(t *testing.T) {
+	testCases := []struct {
+		test        string
+		hours       OpeningHours
+		expectedErr error
+	}{
+		{"Open during the day", OpeningHours{Opens: 9 * time.Hour, Closes: 18 * time.Hour}, nil},
+		{"Closed all day", OpeningHours{}, nil},
+		{"Closes before it opens", OpeningHours{Opens: 18 * time.Hour, Closes: 9 * time.Hour}, ErrInvalidOpeningHours},
+		{"Closes the next day", OpeningHours{Opens: 18 * time.Hour, Closes: 25 * time.Hour}, ErrInvalidOpeningHours},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			v := NewVenueFromEvents([]event.Event{venueCreated})
+
+			err := v.SetOpeningHours(time.Saturday, tc.hours)
+
+			is.Equal(err, tc.expectedErr)
+			if err == nil {
+				h, ok := v.GetOpeningHours(time.Saturday)
+				is.True(ok)
+				is.Equal(h, tc.hours)
+			}
+		})
+	}
+
+	t.Run("Hours unchanged", func(t *testing.T) {
+		is := is.New(t)
+		v := NewVenueFromEvents([]event.Event{venueCreated})
+		is.NoErr(v.SetOpeningHours(time.Saturday, OpeningHours{}))
+
+		is.Equal(v.SetOpeningHours(time.Saturday, OpeningHours{}), ErrVenueUpdateFailed)
+		is.Equal(len(v.Events()), 1)
+	})
+}
+
+func TestVenue_CheckBooking(t *testing.T) {
+	sunday := time.Date(2023, time.September, 3, 0, 0, 0, 0, time.UTC)
+	booked := []Booking{booking("North Field", sunday.Add(10*time.Hour), 90*time.Minute)}
+	testCases := []struct {
+		test        string
+		events      []event.Event
+		booking     Booking
+		expectedErr error
+	}{
+		{"Field is free", []event.Event{venueCreated, northFieldAdded}, booking("North Field", sunday.Add(12*time.Hour), time.Hour), nil},
+		{"Games back to back", []event.Event{venueCreated, northFieldAdded}, booking("North Field", sunday.Add(690*time.Minute), time.Hour), nil},
+		{"Field not found", []event.Event{venueCreated, northFieldAdded}, booking("South Field", sunday.Add(12*time.Hour), time.Hour), ErrFieldNotFound},
+		{"Overlaps the start", []event.Event{venueCreated, northFieldAdded}, booking("North Field", sunday.Add(9*time.Hour), 90*time.Minute), ErrDoubleBooked},
+		{"Within another booking", []event.Event{venueCreated, northFieldAdded}, booking("North Field", sunday.Add(10*time.Hour+15*time.Minute), time.Hour), ErrDoubleBooked},
+		{"Within opening hours", []event.Event{venueCreated, northFieldAdded, openOnSundays}, booking("North Field", sunday.Add(16*time.Hour), 2*time.Hour), nil},
+		{"Ends after closing", []event.Event{venueCreated, northFieldAdded, openOnSundays}, booking("North Field", sunday.Add(17*time.Hour), 2*time.Hour), ErrVenueClosed},
+		{"Starts before opening", []event.Event{venueCreated, northFieldAdded, openOnSundays}, booking("North Field", sunday.Add(8*time.Hour), 2*time.Hour), ErrVenueClosed},
+		{"Closed on the day", []event.Event{venueCreated, northFieldAdded, openOnSundays}, booking("North Field", sunday.AddDate(0, 0, 1).Add(12*time.Hour), time.Hour), ErrVenueClosed},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			v := NewVenueFromEvents(tc.events)
+
+			err := v.CheckBooking(tc.booking, booked)
+
+			is.Equal(err, tc.expectedErr)
+		})
+	}
+}
+
+func TestFindConflicts(t *testing.T) {
+	is := is.New(t)
+	s := NewSeasonFromEvents([]event.Event{seasonCreated})
+	home := NewTeamFromEvents([]event.Event{teamCreated})
+	away := NewTeamFromEvents([]event.Event{&event.TeamCreated{ID: awayTeamUUID, Name: "Bears"}})
+	southField := exampleLocation
+	southField.Field = "South Field"
+	game := func(startsAt time.Time, l Location) *Game {
+		g, err := NewGame(uuid.New(), s, 1, home, away, startsAt, startsAt.Add(90*time.Minute), l)
+		is.NoErr(err)
+		return g
+	}
+	first := game(gameStartsAt.Add(time.Hour), exampleLocation)
+	second := game(gameStartsAt, exampleLocation)
+	games := []*Game{
+		first,
+		second,
+		game(gameStartsAt, southField), // another field
+		game(gameStartsAt.Add(3*time.Hour), exampleLocation), // later on the day
+		game(gameStartsAt, Location{}),                       // no venue
+		game(gameStartsAt, Location{}),
+	}
+
+	conflicts := FindConflicts(games)
+
+	is.Equal(len(conflicts), 1)
+	is.Equal(conflicts[0].Location, exampleLocation)
+	is.Equal(conflicts[0].First.ID, second.GetID())
+	is.Equal(conflicts[0].Second.ID, first.GetID())
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"errors"
+
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"git.sr.ht/~loges/teammate/internal/team/domain/model"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrGameNotFound      = errors.New("repository: the game was not found")
+	ErrGameAlreadyExists = errors.New("repository: game already exists")
+	ErrGameHasNoUpdates  = errors.New("repository: failed to update game")
+	ErrGameConflict      = errors.New("repository: game was changed concurrently")
+)
+
+// GameRepository defines the interface for the game repository.
+type GameRepository interface {
+	Get(uuid.UUID) (*model.Game, error)
+	GetBySeason(uuid.UUID) ([]*model.Game, error)
+	GetByTeam(*entity.Group) ([]*model.Game, error)
+	GetByVenue(uuid.UUID) ([]*model.Game, error)
+	Add(*model.Game) error
+	Update(*model.Game) error
+}
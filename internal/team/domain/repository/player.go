@@ -2,21 +2,40 @@ package repository
 
 import (
 	"errors"
+	"time"
 
 	"git.sr.ht/~loges/teammate/internal/entity"
+	"git.sr.ht/~loges/teammate/internal/team/domain/event"
 	"git.sr.ht/~loges/teammate/internal/team/domain/model"
+	"github.com/google/uuid"
 )
 
 var (
 	ErrPlayerNotFound      = errors.New("repository: the player was not found")
 	ErrPlayerAlreadyExists = errors.New("repository: player already exists")
 	ErrPlayerHasNoUpdates  = errors.New("repository: failed to update player")
+	ErrPlayerConflict      = errors.New("repository: player was changed concurrently")
 )
 
+// Change is an event stored for a player, as it was recorded.
+type Change struct {
+	Version    int
+	RecordedAt time.Time
+	Event      event.Event
+}
+
 // PlayerRepository defines the interface for the player repository.
 type PlayerRepository interface {
 	Get(*entity.Person) (*model.Player, error)
+	GetByInvite(string) (*model.Player, error)
+	GetByUser(*entity.Person) (*model.Player, error)
+	GetByGuardian(*entity.Person) ([]*model.Player, error)
+	GetByHousehold(uuid.UUID) ([]*model.Player, error)
 	GetTeams(*entity.Person) ([]*entity.Group, error)
+	GetHistory(*entity.Person) ([]Change, error)
+	GetAsOf(*entity.Person, time.Time) (*model.Player, error)
+	GetAtVersion(*entity.Person, int) (*model.Player, error)
 	Add(*model.Player) error
 	Update(*model.Player) error
+	Erase(*model.Player) error
 }
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"errors"
+
+	"git.sr.ht/~loges/teammate/internal/team/domain/model"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrSeasonNotFound      = errors.New("repository: the season was not found")
+	ErrSeasonAlreadyExists = errors.New("repository: season already exists")
+	ErrSeasonHasNoUpdates  = errors.New("repository: failed to update season")
+	ErrSeasonConflict      = errors.New("repository: season was changed concurrently")
+)
+
+// SeasonRepository defines the interface for the season repository.
+type SeasonRepository interface {
+	Get(uuid.UUID) (*model.Season, error)
+	Add(*model.Season) error
+	Update(*model.Season) error
+}
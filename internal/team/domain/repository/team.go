@@ -2,6 +2,7 @@ package repository
 
 import (
 	"errors"
+	"time"
 
 	"git.sr.ht/~loges/teammate/internal/entity"
 	"git.sr.ht/~loges/teammate/internal/team/domain/model"
@@ -11,12 +12,16 @@ var (
 	ErrTeamNotFound      = errors.New("repository: the team was not found")
 	ErrTeamAlreadyExists = errors.New("repository: team already exists")
 	ErrTeamHasNoUpdates  = errors.New("repository: failed to update team")
+	ErrTeamConflict      = errors.New("repository: team was changed concurrently")
 )
 
 // TeamRepository defines the interface for the team repository.
 type TeamRepository interface {
 	Get(*entity.Group) (*model.Team, error)
 	GetPlayers(*entity.Group) ([]*entity.Person, error)
+	GetByStaffMember(*entity.Person) ([]*model.Team, error)
+	GetAsOf(*entity.Group, time.Time) (*model.Team, error)
+	GetAtVersion(*entity.Group, int) (*model.Team, error)
 	Add(*model.Team) error
 	Update(*model.Team) error
 }
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"errors"
+
+	"git.sr.ht/~loges/teammate/internal/team/domain/model"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrTournamentNotFound      = errors.New("repository: the tournament was not found")
+	ErrTournamentAlreadyExists = errors.New("repository: tournament already exists")
+	ErrTournamentHasNoUpdates  = errors.New("repository: failed to update tournament")
+	ErrTournamentConflict      = errors.New("repository: tournament was changed concurrently")
+)
+
+// TournamentRepository defines the interface for the tournament repository.
+type TournamentRepository interface {
+	Get(uuid.UUID) (*model.Tournament, error)
+	Add(*model.Tournament) error
+	Update(*model.Tournament) error
+}
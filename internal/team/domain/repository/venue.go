@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"errors"
+
+	"git.sr.ht/~loges/teammate/internal/team/domain/model"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrVenueNotFound      = errors.New("repository: the venue was not found")
+	ErrVenueAlreadyExists = errors.New("repository: venue already exists")
+	ErrVenueHasNoUpdates  = errors.New("repository: failed to update venue")
+	ErrVenueConflict      = errors.New("repository: venue was changed concurrently")
+)
+
+// VenueRepository defines the interface for the venue repository.
+type VenueRepository interface {
+	Get(uuid.UUID) (*model.Venue, error)
+	GetAll() ([]*model.Venue, error)
+	Add(*model.Venue) error
+	Update(*model.Venue) error
+}
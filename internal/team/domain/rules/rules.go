@@ -0,0 +1,190 @@
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"git.sr.ht/~loges/teammate/internal/team/domain/model"
+)
+
+var ErrUnknownRule = errors.New("rules: the rule is unknown")
+
+// Candidate is a player about to be assigned to a team.
+type Candidate struct {
+	Team   *model.Team
+	Player *model.Player
+	// Season is the season the team is playing, or nil if it has not
+	// started one.
+	Season *model.Season
+	// Teams are the teams the player is already on.
+	Teams []*model.Team
+	// At is when the player joins.
+	At time.Time
+}
+
+// AgeCutoff returns the day the age of the player is counted on, which is
+// the day the team's season starts, or the day the player joins outside
+// of a season.
+func (c Candidate) AgeCutoff() time.Time {
+	if c.Season == nil {
+		return c.At
+	}
+	return c.Season.GetStartsOn()
+}
+
+// Violation describes why a candidate is not eligible.
+type Violation struct {
+	Rule   string
+	Reason string
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%s: %s", v.Rule, v.Reason)
+}
+
+// ViolationError is returned when a candidate breaks one or more rules.
+type ViolationError struct {
+	Violations []Violation
+}
+
+func (e *ViolationError) Error() string {
+	reasons := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		reasons[i] = v.String()
+	}
+	return "rules: player is not eligible: " + strings.Join(reasons, "; ")
+}
+
+// Rule decides whether a candidate is eligible for the team.
+type Rule interface {
+	// Name identifies the rule in violations.
+	Name() string
+	// Check returns why the candidate breaks the rule, if it does.
+	Check(c Candidate) (Violation, bool)
+}
+
+// Defaults are the rules enforcing the team's roster rules.
+var Defaults = []Rule{
+	MaxRosterSize{},
+	AgeBand{},
+	GenderDivision{},
+	MaxTeamsPerPlayer{},
+}
+
+// Named returns the default rules with the names, in the order given.
+func Named(names ...string) ([]Rule, error) {
+	var named []Rule
+	for _, name := range names {
+		found := false
+		for _, r := range Defaults {
+			if r.Name() == name {
+				named = append(named, r)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, ErrUnknownRule
+		}
+	}
+	return named, nil
+}
+
+// Evaluate checks the candidate against every rule and returns a
+// ViolationError listing every broken rule, or nil if none is broken.
+func Evaluate(rules []Rule, c Candidate) error {
+	var violations []Violation
+	for _, r := range rules {
+		if v, broken := r.Check(c); broken {
+			violations = append(violations, v)
+		}
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	return &ViolationError{Violations: violations}
+}
+
+// MaxRosterSize limits how many players a team can roster.
+type MaxRosterSize struct{}
+
+func (MaxRosterSize) Name() string {
+	return "max_roster_size"
+}
+
+func (r MaxRosterSize) Check(c Candidate) (Violation, bool) {
+	limit := c.Team.GetRules().MaxRosterSize
+	if limit == 0 || len(c.Team.GetPlayers()) < limit {
+		return Violation{}, false
+	}
+	return Violation{r.Name(), fmt.Sprintf("the roster is full with %d players", limit)}, true
+}
+
+// AgeBand limits the ages of the players on a team.
+type AgeBand struct{}
+
+func (AgeBand) Name() string {
+	return "age_band"
+}
+
+func (r AgeBand) Check(c Candidate) (Violation, bool) {
+	rules := c.Team.GetRules()
+	if rules.MinAge == 0 && rules.MaxAge == 0 {
+		return Violation{}, false
+	}
+
+	age, ok := c.Player.AgeOn(c.AgeCutoff())
+	switch {
+	case !ok:
+		return Violation{r.Name(), "the date of birth of the player is unknown"}, true
+	case age < rules.MinAge:
+		return Violation{r.Name(), fmt.Sprintf("the player is %d but has to be at least %d", age, rules.MinAge)}, true
+	case rules.MaxAge > 0 && age >= rules.MaxAge:
+		return Violation{r.Name(), fmt.Sprintf("the player is %d but has to be under %d", age, rules.MaxAge)}, true
+	}
+	return Violation{}, false
+}
+
+// GenderDivision limits the players on a team to those admitted to its
+// division.
+type GenderDivision struct{}
+
+func (GenderDivision) Name() string {
+	return "gender_division"
+}
+
+func (r GenderDivision) Check(c Candidate) (Violation, bool) {
+	division := c.Team.GetRules().Division
+	if division.Admits(c.Player.GetGender()) {
+		return Violation{}, false
+	}
+	return Violation{r.Name(), fmt.Sprintf("the team plays in the %s division", division)}, true
+}
+
+// MaxTeamsPerPlayer limits how many teams a player joining the team can
+// be on in the team's season.
+type MaxTeamsPerPlayer struct{}
+
+func (MaxTeamsPerPlayer) Name() string {
+	return "max_teams_per_player"
+}
+
+func (r MaxTeamsPerPlayer) Check(c Candidate) (Violation, bool) {
+	limit := c.Team.GetRules().MaxTeamsPerPlayer
+	if limit == 0 {
+		return Violation{}, false
+	}
+
+	teams := 0
+	for _, t := range c.Teams {
+		if t.GetID() != c.Team.GetID() && t.GetSeason() == c.Team.GetSeason() {
+			teams++
+		}
+	}
+	if teams < limit {
+		return Violation{}, false
+	}
+	return Violation{r.Name(), fmt.Sprintf("the player is already on %d teams this season", teams)}, true
+}
@@ -0,0 +1,129 @@
+package rules
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"git.sr.ht/~loges/teammate/internal/team/domain/event"
+	"git.sr.ht/~loges/teammate/internal/team/domain/model"
+	"github.com/google/uuid"
+	"github.com/matryer/is"
+)
+
+var (
+	exampleTeamUUID   = uuid.MustParse("f55e93f8-c952-11ed-afa1-0242ac120002")
+	examplePlayerUUID = uuid.MustParse("f47ac10b-58cc-0372-8567-0e02b2c3d479")
+	anotherPlayerUUID = uuid.MustParse("d38ad10b-58cc-0372-8567-0e02b2c3d479")
+	exampleJoinedAt   = time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC)
+	teamCreated       = &event.TeamCreated{ID: exampleTeamUUID, Name: "Tigers"}
+	playerCreated     = &event.PlayerCreated{ID: examplePlayerUUID, Name: "Matt"}
+	elevenYearOldGirl = &event.PlayerDetailsChanged{ID: examplePlayerUUID, DateOfBirth: time.Date(2011, time.March, 2, 0, 0, 0, 0, time.UTC), Gender: "female"}
+	twelveYearOldBoy  = &event.PlayerDetailsChanged{ID: examplePlayerUUID, DateOfBirth: time.Date(2011, time.March, 1, 0, 0, 0, 0, time.UTC), Gender: "male"}
+	anotherAssigned   = &event.PlayerAssignedToTeam{ID: exampleTeamUUID, PlayerId: anotherPlayerUUID, PlayerName: "Jackie", JerseyNumber: model.NoJerseyNumber}
+	exampleSeasonUUID = uuid.MustParse("a38ad10b-58cc-0372-8567-0e02b2c3d479")
+	exampleSeason     = model.NewSeasonFromEvents([]event.Event{&event.SeasonCreated{ID: exampleSeasonUUID, Name: "2023", StartsOn: time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC), EndsOn: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)}})
+	otherTeams        = []*model.Team{
+		model.NewTeamFromEvents([]event.Event{&event.TeamCreated{ID: uuid.New(), Name: "Bears"}}),
+		model.NewTeamFromEvents([]event.Event{&event.TeamCreated{ID: uuid.New(), Name: "Lions"}}),
+	}
+	pastSeasonTeams = []*model.Team{
+		model.NewTeamFromEvents([]event.Event{&event.TeamCreated{ID: uuid.New(), Name: "Bears"}, &event.TeamSeasonStarted{SeasonId: uuid.New()}}),
+		model.NewTeamFromEvents([]event.Event{&event.TeamCreated{ID: uuid.New(), Name: "Lions"}, &event.TeamSeasonStarted{SeasonId: uuid.New()}}),
+	}
+)
+
+func TestEvaluate(t *testing.T) {
+	testCases := []struct {
+		test         string
+		rules        *event.TeamRulesChanged
+		team         []event.Event
+		player       []event.Event
+		teams        []*model.Team
+		expectedRule []string
+	}{
+		{"No rules configured", &event.TeamRulesChanged{}, nil, nil, otherTeams, nil},
+		{"Roster has room", &event.TeamRulesChanged{MaxRosterSize: 2}, []event.Event{anotherAssigned}, nil, nil, nil},
+		{"Roster is full", &event.TeamRulesChanged{MaxRosterSize: 1}, []event.Event{anotherAssigned}, nil, nil, []string{"max_roster_size"}},
+		{"Player is in the age band", &event.TeamRulesChanged{MinAge: 10, MaxAge: 12}, nil, []event.Event{elevenYearOldGirl}, nil, nil},
+		{"Player is too old", &event.TeamRulesChanged{MaxAge: 12}, nil, []event.Event{twelveYearOldBoy}, nil, []string{"age_band"}},
+		{"Player is too young", &event.TeamRulesChanged{MinAge: 12}, nil, []event.Event{elevenYearOldGirl}, nil, []string{"age_band"}},
+		{"Date of birth is unknown", &event.TeamRulesChanged{MaxAge: 12}, nil, nil, nil, []string{"age_band"}},
+		{"Player is in the division", &event.TeamRulesChanged{Division: "female"}, nil, []event.Event{elevenYearOldGirl}, nil, nil},
+		{"Player is not in the division", &event.TeamRulesChanged{Division: "female"}, nil, []event.Event{twelveYearOldBoy}, nil, []string{"gender_division"}},
+		{"Player is on too many teams", &event.TeamRulesChanged{MaxTeamsPerPlayer: 2}, nil, nil, otherTeams, []string{"max_teams_per_player"}},
+		{"Teams of other seasons are not counted", &event.TeamRulesChanged{MaxTeamsPerPlayer: 2}, nil, nil, pastSeasonTeams, nil},
+		{
+			"Every broken rule is reported",
+			&event.TeamRulesChanged{MaxRosterSize: 1, MaxAge: 12, Division: "female", MaxTeamsPerPlayer: 1},
+			[]event.Event{anotherAssigned},
+			[]event.Event{twelveYearOldBoy},
+			otherTeams,
+			[]string{"max_roster_size", "age_band", "gender_division", "max_teams_per_player"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			tc.rules.ID = exampleTeamUUID
+			team := model.NewTeamFromEvents(append([]event.Event{teamCreated, tc.rules}, tc.team...))
+			player := model.NewPlayerFromEvents(append([]event.Event{playerCreated}, tc.player...))
+
+			err := Evaluate(Defaults, Candidate{Team: team, Player: player, Teams: tc.teams, At: exampleJoinedAt})
+
+			var rules []string
+			var violations *ViolationError
+			if errors.As(err, &violations) {
+				for _, v := range violations.Violations {
+					rules = append(rules, v.Rule)
+				}
+			}
+			is.Equal(rules, tc.expectedRule)
+		})
+	}
+}
+
+func TestNamed(t *testing.T) {
+	testCases := []struct {
+		test        string
+		names       []string
+		expected    []Rule
+		expectedErr error
+	}{
+		{"No rules", nil, nil, nil},
+		{"Rules in the order given", []string{"gender_division", "max_roster_size"}, []Rule{GenderDivision{}, MaxRosterSize{}}, nil},
+		{"Unknown rule", []string{"age_band", "salary_cap"}, nil, ErrUnknownRule},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			named, err := Named(tc.names...)
+			is.Equal(err, tc.expectedErr)
+			is.Equal(named, tc.expected)
+		})
+	}
+}
+
+func TestAgeBand_SeasonCutoff(t *testing.T) {
+	is := is.New(t)
+	team := model.NewTeamFromEvents([]event.Event{teamCreated, &event.TeamRulesChanged{ID: exampleTeamUUID, MaxAge: 12}})
+	player := model.NewPlayerFromEvents([]event.Event{playerCreated, twelveYearOldBoy})
+
+	_, broken := AgeBand{}.Check(Candidate{Team: team, Player: player, At: exampleJoinedAt})
+	is.True(broken)
+
+	_, broken = AgeBand{}.Check(Candidate{Team: team, Player: player, Season: exampleSeason, At: exampleJoinedAt})
+	is.True(!broken) // the player was 11 when the season started
+}
+
+func TestViolationError(t *testing.T) {
+	is := is.New(t)
+	err := &ViolationError{Violations: []Violation{
+		{"max_roster_size", "the roster is full with 1 players"},
+		{"age_band", "the player is 12 but has to be under 12"},
+	}}
+
+	is.Equal(err.Error(), "rules: player is not eligible: max_roster_size: the roster is full with 1 players; age_band: the player is 12 but has to be under 12")
+}
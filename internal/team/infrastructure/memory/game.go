@@ -0,0 +1,102 @@
+package memory
+
+import (
+	"sync"
+
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"git.sr.ht/~loges/teammate/internal/eventstore"
+	"git.sr.ht/~loges/teammate/internal/team/domain/model"
+	"git.sr.ht/~loges/teammate/internal/team/domain/repository"
+	"github.com/google/uuid"
+)
+
+// gameCategory is the event store category of game streams.
+const gameCategory = "game"
+
+// MemoryGameRepository is an in-memory game repository.
+type MemoryGameRepository struct {
+	events events
+	sync.Mutex
+}
+
+// NewMemoryGameRepository intializes an in-memory game repository.
+func NewMemoryGameRepository(cfgs ...Configuration) *MemoryGameRepository {
+	return &MemoryGameRepository{
+		events: newEvents(cfgs),
+	}
+}
+
+// Get retrieves a game by ID.
+func (r *MemoryGameRepository) Get(id uuid.UUID) (*model.Game, error) {
+	if events, err := r.events.Load(r.events.Stream(gameCategory, id)); err == nil {
+		return model.NewGameFromEvents(events), nil
+	}
+
+	return &model.Game{}, repository.ErrGameNotFound
+}
+
+// GetBySeason retrieves the games played in the season.
+func (r *MemoryGameRepository) GetBySeason(id uuid.UUID) ([]*model.Game, error) {
+	return r.filter(func(g *model.Game) bool { return g.GetSeasonID() == id })
+}
+
+// GetByTeam retrieves the games the team plays.
+func (r *MemoryGameRepository) GetByTeam(t *entity.Group) ([]*model.Game, error) {
+	return r.filter(func(g *model.Game) bool { return g.Involves(t.ID) })
+}
+
+// GetByVenue retrieves the games played at the venue.
+func (r *MemoryGameRepository) GetByVenue(id uuid.UUID) ([]*model.Game, error) {
+	return r.filter(func(g *model.Game) bool { return g.GetBooking().VenueID == id })
+}
+
+// Add stores a new game in the repository.
+func (r *MemoryGameRepository) Add(g *model.Game) error {
+	if r.events.Exists(r.events.Stream(gameCategory, g.GetID())) {
+		return repository.ErrGameAlreadyExists
+	}
+
+	err := r.events.Commit(r, r.events.Stream(gameCategory, g.GetID()), 0, g.Events())
+	if err == eventstore.ErrConcurrencyConflict {
+		return repository.ErrGameAlreadyExists
+	}
+	return err
+}
+
+// Update stores the new events of a game in the repository.
+func (r *MemoryGameRepository) Update(g *model.Game) error {
+	if !r.events.Exists(r.events.Stream(gameCategory, g.GetID())) {
+		return repository.ErrGameNotFound
+	}
+
+	newEvents := g.Events()
+	if len(newEvents) == 0 {
+		return repository.ErrGameHasNoUpdates
+	}
+
+	err := r.events.Commit(r, r.events.Stream(gameCategory, g.GetID()), g.Version(), newEvents)
+	if err == eventstore.ErrConcurrencyConflict {
+		return repository.ErrGameConflict
+	}
+	return err
+}
+
+func (r *MemoryGameRepository) filter(keep func(*model.Game) bool) ([]*model.Game, error) {
+	streams, err := r.events.Streams(gameCategory)
+	if err != nil {
+		return []*model.Game{}, err
+	}
+
+	games := []*model.Game{}
+	for _, s := range streams {
+		events, err := r.events.Load(s)
+		if err != nil {
+			return []*model.Game{}, err
+		}
+		if g := model.NewGameFromEvents(events); keep(g) {
+			games = append(games, g)
+		}
+	}
+
+	return games, nil
+}
@@ -0,0 +1,200 @@
+package memory
+
+import (
+	"testing"
+	"time"
+
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"git.sr.ht/~loges/teammate/internal/team/domain/event"
+	"git.sr.ht/~loges/teammate/internal/team/domain/model"
+	"git.sr.ht/~loges/teammate/internal/team/domain/repository"
+	"github.com/google/uuid"
+	"github.com/matryer/is"
+)
+
+var (
+	exampleGameUUID = uuid.MustParse("c15e93f8-c952-11ed-afa1-0242ac120002")
+	awayTeamUUID    = uuid.MustParse("d15e93f8-c952-11ed-afa1-0242ac120002")
+	gameScheduled   = &event.GameScheduled{
+		ID:           exampleGameUUID,
+		SeasonId:     exampleSeasonUUID,
+		Round:        1,
+		HomeTeamId:   exampleTeamUUID,
+		HomeTeamName: exampleTeamName,
+		AwayTeamId:   awayTeamUUID,
+		AwayTeamName: "Cornell",
+		StartsAt:     time.Date(2023, time.September, 3, 10, 0, 0, 0, time.UTC),
+		EndsAt:       time.Date(2023, time.September, 3, 11, 30, 0, 0, time.UTC),
+		VenueId:      exampleVenueUUID,
+		VenueName:    "Riverside Park",
+		Field:        "North Field",
+	}
+)
+
+func TestMemoryGameRepository_Get(t *testing.T) {
+	testCases := []struct {
+		test        string
+		id          uuid.UUID
+		expectedErr error
+	}{
+		{"Game found", exampleGameUUID, nil},
+		{"No game found with this ID", uuid.New(), repository.ErrGameNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			r := NewMemoryGameRepository()
+			seedGame(r, exampleGameUUID, gameScheduled)
+
+			g, err := r.Get(tc.id)
+
+			is.Equal(err, tc.expectedErr)
+			if err == nil {
+				is.Equal(g.GetScheduledGame().Away.Name, gameScheduled.AwayTeamName)
+				is.Equal(g.GetStartsAt(), gameScheduled.StartsAt)
+			}
+		})
+	}
+}
+
+func TestMemoryGameRepository_GetBySeason(t *testing.T) {
+	testCases := []struct {
+		test     string
+		id       uuid.UUID
+		expected int
+	}{
+		{"Season has games", exampleSeasonUUID, 1},
+		{"Season has no games", anotherSeasonUUID, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			r := NewMemoryGameRepository()
+			seedGame(r, exampleGameUUID, gameScheduled)
+
+			games, err := r.GetBySeason(tc.id)
+
+			is.NoErr(err)
+			is.Equal(len(games), tc.expected)
+		})
+	}
+}
+
+func TestMemoryGameRepository_GetByTeam(t *testing.T) {
+	testCases := []struct {
+		test     string
+		id       uuid.UUID
+		expected int
+	}{
+		{"Home team", exampleTeamUUID, 1},
+		{"Away team", awayTeamUUID, 1},
+		{"Team doesn't play", uuid.New(), 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			r := NewMemoryGameRepository()
+			seedGame(r, exampleGameUUID, gameScheduled)
+
+			games, err := r.GetByTeam(&entity.Group{ID: tc.id})
+
+			is.NoErr(err)
+			is.Equal(len(games), tc.expected)
+		})
+	}
+}
+
+func TestMemoryGameRepository_GetByVenue(t *testing.T) {
+	testCases := []struct {
+		test     string
+		id       uuid.UUID
+		expected int
+	}{
+		{"Venue has games", exampleVenueUUID, 1},
+		{"Venue has no games", uuid.New(), 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			r := NewMemoryGameRepository()
+			seedGame(r, exampleGameUUID, gameScheduled)
+
+			games, err := r.GetByVenue(tc.id)
+
+			is.NoErr(err)
+			is.Equal(len(games), tc.expected)
+		})
+	}
+}
+
+func TestMemoryGameRepository_Add(t *testing.T) {
+	testCases := []struct {
+		test        string
+		id          uuid.UUID
+		expectedErr error
+	}{
+		{"Successfully add a game", uuid.New(), nil},
+		{"Game already exists error", exampleGameUUID, repository.ErrGameAlreadyExists},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			r := NewMemoryGameRepository()
+			seedGame(r, exampleGameUUID, gameScheduled)
+			s := model.NewSeasonFromEvents([]event.Event{seasonCreated})
+			home := model.NewTeamFromEvents([]event.Event{&event.TeamCreated{ID: exampleTeamUUID, Name: exampleTeamName}})
+			away := model.NewTeamFromEvents([]event.Event{&event.TeamCreated{ID: awayTeamUUID, Name: "Cornell"}})
+			g, err := model.NewGame(tc.id, s, 1, home, away, gameScheduled.StartsAt, gameScheduled.EndsAt, model.Location{})
+			is.NoErr(err)
+
+			err = r.Add(g)
+
+			is.Equal(err, tc.expectedErr)
+		})
+	}
+}
+
+func TestMemoryGameRepository_Update(t *testing.T) {
+	testCases := []struct {
+		test        string
+		register    bool
+		respond     bool
+		expectedErr error
+	}{
+		{"Update game", true, true, nil},
+		{"Game has no changes", true, false, repository.ErrGameHasNoUpdates},
+		{"Game not found", false, true, repository.ErrGameNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			r := NewMemoryGameRepository()
+			g := model.NewGameFromEvents([]event.Event{gameScheduled})
+			if tc.register {
+				seedGame(r, exampleGameUUID, gameScheduled)
+			}
+			if tc.respond {
+				p := model.NewPlayerFromEvents([]event.Event{playerCreated, teamAssigned})
+				is.NoErr(g.Respond(p, model.RSVPAttending))
+			}
+
+			err := r.Update(g)
+
+			is.Equal(err, tc.expectedErr)
+		})
+	}
+}
+
+func seedGame(r *MemoryGameRepository, id uuid.UUID, events ...event.Event) {
+	stored := make([]any, len(events))
+	for i, e := range events {
+		stored[i] = e
+	}
+	_ = r.events.Store().Append(r.events.Stream(gameCategory, id), 0, stored...)
+}
@@ -2,62 +2,126 @@ package memory
 
 import (
 	"sync"
+	"time"
 
 	"git.sr.ht/~loges/teammate/internal/entity"
-	"git.sr.ht/~loges/teammate/internal/team/domain/event"
+	"git.sr.ht/~loges/teammate/internal/eventstore"
 	"git.sr.ht/~loges/teammate/internal/team/domain/model"
 	"git.sr.ht/~loges/teammate/internal/team/domain/repository"
 	"github.com/google/uuid"
 )
 
+// playerCategory is the event store category of player streams.
+const playerCategory = "player"
+
 // MemoryPlayerRepository is an in-memory player repository.
 type MemoryPlayerRepository struct {
-	players map[uuid.UUID][]event.Event
+	events events
 	sync.Mutex
 }
 
 // NewMemoryPlayerRepository intializes an in-memory player repository.
-func NewMemoryPlayerRepository() *MemoryPlayerRepository {
+func NewMemoryPlayerRepository(cfgs ...Configuration) *MemoryPlayerRepository {
 	return &MemoryPlayerRepository{
-		players: make(map[uuid.UUID][]event.Event),
+		events: newEvents(cfgs),
 	}
 }
 
 // Get retrieves a player by ID.
 func (r *MemoryPlayerRepository) Get(p *entity.Person) (*model.Player, error) {
-	if events, ok := r.players[p.ID]; ok {
+	if events, err := r.events.Load(r.events.Stream(playerCategory, p.ID)); err == nil {
 		return model.NewPlayerFromEvents(events), nil
 	}
 
 	return &model.Player{}, repository.ErrPlayerNotFound
 }
 
+// GetByInvite retrieves the player with the pending invite code.
+func (r *MemoryPlayerRepository) GetByInvite(code string) (*model.Player, error) {
+	return r.find(func(p *model.Player) bool {
+		return p.HasInvite(code)
+	})
+}
+
+// GetByUser retrieves the player linked to the user.
+func (r *MemoryPlayerRepository) GetByUser(u *entity.Person) (*model.Player, error) {
+	return r.find(func(p *model.Player) bool {
+		return p.IsLinked() && p.GetUserID() == u.ID
+	})
+}
+
+// GetByGuardian retrieves the players the user is a guardian of.
+func (r *MemoryPlayerRepository) GetByGuardian(u *entity.Person) ([]*model.Player, error) {
+	return r.filter(func(p *model.Player) bool {
+		return p.IsGuardian(u.ID)
+	})
+}
+
+// GetByHousehold retrieves the players grouped in the household. Players
+// without a household are never grouped together.
+func (r *MemoryPlayerRepository) GetByHousehold(household uuid.UUID) ([]*model.Player, error) {
+	if household == model.NoHousehold {
+		return []*model.Player{}, nil
+	}
+	return r.filter(func(p *model.Player) bool {
+		return p.GetHouseholdID() == household
+	})
+}
+
 // GetTeams retrieves teams assigned to players.
 func (r *MemoryPlayerRepository) GetTeams(p *entity.Person) ([]*entity.Group, error) {
-	events, ok := r.players[p.ID]
-	if !ok {
+	events, err := r.events.Load(r.events.Stream(playerCategory, p.ID))
+	if err != nil {
 		return []*entity.Group{}, repository.ErrPlayerNotFound
 	}
 	return model.NewPlayerFromEvents(events).GetTeams(), nil
 }
 
+// GetHistory retrieves the events stored for the player.
+func (r *MemoryPlayerRepository) GetHistory(p *entity.Person) ([]repository.Change, error) {
+	if changes, err := history(r.events, r.events.Stream(playerCategory, p.ID)); err == nil {
+		return changes, nil
+	}
+
+	return []repository.Change{}, repository.ErrPlayerNotFound
+}
+
+// GetAsOf retrieves a player as it was at the given time. The player is
+// not found if it was created afterwards.
+func (r *MemoryPlayerRepository) GetAsOf(p *entity.Person, t time.Time) (*model.Player, error) {
+	if events, err := r.events.LoadAsOf(r.events.Stream(playerCategory, p.ID), t); err == nil {
+		return model.NewPlayerFromEvents(events), nil
+	}
+
+	return &model.Player{}, repository.ErrPlayerNotFound
+}
+
+// GetAtVersion retrieves a player as it was at the given version. The
+// player is not found if it has not reached the version.
+func (r *MemoryPlayerRepository) GetAtVersion(p *entity.Person, version int) (*model.Player, error) {
+	if events, err := r.events.LoadAtVersion(r.events.Stream(playerCategory, p.ID), version); err == nil {
+		return model.NewPlayerFromEvents(events), nil
+	}
+
+	return &model.Player{}, repository.ErrPlayerNotFound
+}
+
 // Add stores a new player in the repository.
 func (r *MemoryPlayerRepository) Add(p *model.Player) error {
-	if _, ok := r.players[p.GetID()]; ok {
+	if r.events.Exists(r.events.Stream(playerCategory, p.GetID())) {
 		return repository.ErrPlayerAlreadyExists
 	}
 
-	r.Lock()
-	r.players[p.GetID()] = p.Events()
-	defer r.Unlock()
-
-	return nil
+	err := r.events.Commit(r, r.events.Stream(playerCategory, p.GetID()), 0, p.Events())
+	if err == eventstore.ErrConcurrencyConflict {
+		return repository.ErrPlayerAlreadyExists
+	}
+	return err
 }
 
 // Update appends changes to player in the repository.
 func (r *MemoryPlayerRepository) Update(p *model.Player) error {
-	storedEvents, ok := r.players[p.GetID()]
-	if !ok {
+	if !r.events.Exists(r.events.Stream(playerCategory, p.GetID())) {
 		return repository.ErrPlayerNotFound
 	}
 
@@ -66,9 +130,62 @@ func (r *MemoryPlayerRepository) Update(p *model.Player) error {
 		return repository.ErrPlayerHasNoUpdates
 	}
 
-	r.Lock()
-	r.players[p.GetID()] = append(storedEvents, newEvents...)
-	defer r.Unlock()
+	err := r.events.Commit(r, r.events.Stream(playerCategory, p.GetID()), p.Version(), newEvents)
+	if err == eventstore.ErrConcurrencyConflict {
+		return repository.ErrPlayerConflict
+	}
+	return err
+}
+
+// Erase stores the erasure of the player and destroys the key the
+// personal data of the player is encrypted with.
+func (r *MemoryPlayerRepository) Erase(p *model.Player) error {
+	if !p.IsErased() {
+		return repository.ErrPlayerHasNoUpdates
+	}
+	if err := r.Update(p); err != nil {
+		return err
+	}
+	return r.events.Keys().Erase(p.GetID())
+}
+
+// find returns the first player matching the predicate.
+func (r *MemoryPlayerRepository) find(match func(p *model.Player) bool) (*model.Player, error) {
+	streams, err := r.events.Streams(playerCategory)
+	if err != nil {
+		return &model.Player{}, err
+	}
+
+	for _, s := range streams {
+		events, err := r.events.Load(s)
+		if err != nil {
+			return &model.Player{}, err
+		}
+		if p := model.NewPlayerFromEvents(events); match(p) {
+			return p, nil
+		}
+	}
+
+	return &model.Player{}, repository.ErrPlayerNotFound
+}
+
+// filter returns every player matching the predicate.
+func (r *MemoryPlayerRepository) filter(match func(p *model.Player) bool) ([]*model.Player, error) {
+	streams, err := r.events.Streams(playerCategory)
+	if err != nil {
+		return []*model.Player{}, err
+	}
+
+	players := []*model.Player{}
+	for _, s := range streams {
+		events, err := r.events.Load(s)
+		if err != nil {
+			return []*model.Player{}, err
+		}
+		if p := model.NewPlayerFromEvents(events); match(p) {
+			players = append(players, p)
+		}
+	}
 
-	return nil
+	return players, nil
 }
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"git.sr.ht/~loges/teammate/internal/entity"
+	"git.sr.ht/~loges/teammate/internal/eventstore"
 	"git.sr.ht/~loges/teammate/internal/team/domain/event"
 	"git.sr.ht/~loges/teammate/internal/team/domain/model"
 	"git.sr.ht/~loges/teammate/internal/team/domain/repository"
@@ -19,6 +20,9 @@ var (
 	playerCreated        = &event.PlayerCreated{ID: examplePlayerUUID, Name: examplePlayerName}
 	anotherPlayerCreated = &event.PlayerCreated{ID: anotherPlayerUUID, Name: anotherPlayerName}
 	teamAssigned         = &event.TeamAssignedToPlayer{ID: examplePlayerUUID, TeamId: exampleTeamUUID, TeamName: exampleTeamName}
+	exampleUserUUID      = uuid.MustParse("c15e93f8-c952-11ed-afa1-0242ac120002")
+	playerInvited        = &event.PlayerInvited{ID: examplePlayerUUID, Code: "a1b2c3"}
+	userLinked           = &event.UserLinkedToPlayer{ID: examplePlayerUUID, UserId: exampleUserUUID, UserName: examplePlayerName}
 )
 
 func TestMemoryPlayerRepository_Get(t *testing.T) {
@@ -43,7 +47,7 @@ func TestMemoryPlayerRepository_Get(t *testing.T) {
 		t.Run(tc.test, func(t *testing.T) {
 			is := is.New(t)
 			repo := NewMemoryPlayerRepository()
-			repo.players[examplePlayerUUID] = []event.Event{playerCreated}
+			seedPlayer(repo, examplePlayerUUID, playerCreated)
 
 			_, err := repo.Get(tc.person)
 
@@ -52,6 +56,115 @@ func TestMemoryPlayerRepository_Get(t *testing.T) {
 	}
 }
 
+func TestMemoryPlayerRepository_GetByInvite(t *testing.T) {
+	testCases := []struct {
+		test        string
+		code        string
+		events      []event.Event
+		expectedErr error
+	}{
+		{"Player not invited", "a1b2c3", []event.Event{playerCreated}, repository.ErrPlayerNotFound},
+		{"Unknown invite code", "d4e5f6", []event.Event{playerCreated, playerInvited}, repository.ErrPlayerNotFound},
+		{"Invite already claimed", "a1b2c3", []event.Event{playerCreated, playerInvited, userLinked}, repository.ErrPlayerNotFound},
+		{"Player found", "a1b2c3", []event.Event{playerCreated, playerInvited}, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			repo := NewMemoryPlayerRepository()
+			seedPlayer(repo, examplePlayerUUID, tc.events...)
+
+			_, err := repo.GetByInvite(tc.code)
+
+			is.Equal(err, tc.expectedErr)
+		})
+	}
+}
+
+func TestMemoryPlayerRepository_GetByUser(t *testing.T) {
+	testCases := []struct {
+		test        string
+		user        *entity.Person
+		events      []event.Event
+		expectedErr error
+	}{
+		{"No linked user", &entity.Person{ID: uuid.Nil}, []event.Event{playerCreated}, repository.ErrPlayerNotFound},
+		{"Other user linked", &entity.Person{ID: anotherPlayerUUID}, []event.Event{playerCreated, playerInvited, userLinked}, repository.ErrPlayerNotFound},
+		{"Player found", &entity.Person{ID: exampleUserUUID}, []event.Event{playerCreated, playerInvited, userLinked}, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			repo := NewMemoryPlayerRepository()
+			seedPlayer(repo, examplePlayerUUID, tc.events...)
+
+			_, err := repo.GetByUser(tc.user)
+
+			is.Equal(err, tc.expectedErr)
+		})
+	}
+}
+
+func TestMemoryPlayerRepository_GetByUserUnreadable(t *testing.T) {
+	is := is.New(t)
+	store := eventstore.NewMemoryStore()
+	repo := NewMemoryPlayerRepository(WithEventStore(store))
+	seedPlayer(repo, examplePlayerUUID, playerCreated)
+	_ = store.Append(repo.events.Stream(playerCategory, anotherPlayerUUID), 0, &eventstore.Payload{Type: "PlayerCreated", SchemaVersion: 99})
+
+	_, err := repo.GetByUser(&entity.Person{ID: exampleUserUUID})
+	is.Equal(err, eventstore.ErrUnsupportedSchemaVersion)
+
+	_, err = repo.GetByGuardian(&entity.Person{ID: exampleUserUUID})
+	is.Equal(err, eventstore.ErrUnsupportedSchemaVersion)
+}
+
+func TestMemoryPlayerRepository_GetByGuardian(t *testing.T) {
+	guardianAdded := &event.GuardianAddedToPlayer{ID: examplePlayerUUID, UserId: exampleUserUUID, UserName: "Ann"}
+	testCases := []struct {
+		test     string
+		events   []event.Event
+		expected int
+	}{
+		{"No guardian", []event.Event{playerCreated}, 0},
+		{"Guardian added", []event.Event{playerCreated, guardianAdded}, 1},
+		{"Guardian removed", []event.Event{playerCreated, guardianAdded, &event.GuardianRemovedFromPlayer{ID: examplePlayerUUID, UserId: exampleUserUUID}}, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			repo := NewMemoryPlayerRepository()
+			seedPlayer(repo, examplePlayerUUID, tc.events...)
+			seedPlayer(repo, anotherPlayerUUID, anotherPlayerCreated)
+
+			players, err := repo.GetByGuardian(&entity.Person{ID: exampleUserUUID})
+
+			is.NoErr(err)
+			is.Equal(len(players), tc.expected)
+		})
+	}
+}
+
+func TestMemoryPlayerRepository_GetByHousehold(t *testing.T) {
+	is := is.New(t)
+	household := uuid.MustParse("a15e93f8-c952-11ed-afa1-0242ac120002")
+	repo := NewMemoryPlayerRepository()
+	seedPlayer(repo, examplePlayerUUID, playerCreated, &event.PlayerHouseholdChanged{ID: examplePlayerUUID, HouseholdId: household})
+	seedPlayer(repo, anotherPlayerUUID, anotherPlayerCreated)
+
+	players, err := repo.GetByHousehold(household)
+	is.NoErr(err)
+	is.Equal(len(players), 1)
+	is.Equal(players[0].GetID(), examplePlayerUUID)
+
+	players, err = repo.GetByHousehold(model.NoHousehold)
+	is.NoErr(err)
+	is.Equal(len(players), 0)
+}
+
 func TestMemoryPlayerRepository_GetTeams(t *testing.T) {
 	testCases := []struct {
 		test        string
@@ -87,7 +200,7 @@ func TestMemoryPlayerRepository_GetTeams(t *testing.T) {
 		t.Run(tc.test, func(t *testing.T) {
 			is := is.New(t)
 			repo := NewMemoryPlayerRepository()
-			repo.players[tc.playerId] = tc.events
+			seedPlayer(repo, tc.playerId, tc.events...)
 
 			teams, err := repo.GetTeams(&entity.Person{ID: examplePlayerUUID, Name: examplePlayerName})
 
@@ -115,7 +228,7 @@ func TestMemoryPlayerRepository_Add(t *testing.T) {
 			p := model.NewPlayerFromEvents([]event.Event{
 				&event.PlayerCreated{ID: tc.id, Name: tc.name},
 			})
-			r.players[examplePlayerUUID] = p.Events()
+			seedPlayer(r, examplePlayerUUID, playerCreated)
 
 			err := r.Add(p)
 
@@ -124,6 +237,35 @@ func TestMemoryPlayerRepository_Add(t *testing.T) {
 	}
 }
 
+func TestMemoryPlayerRepository_GetHistory(t *testing.T) {
+	is := is.New(t)
+	repo := NewMemoryPlayerRepository()
+	seedPlayer(repo, examplePlayerUUID, playerCreated, teamAssigned)
+
+	changes, err := repo.GetHistory(&entity.Person{ID: examplePlayerUUID})
+	is.NoErr(err)
+	is.Equal(len(changes), 2)
+	is.Equal(changes[1].Event, teamAssigned)
+
+	_, err = repo.GetHistory(&entity.Person{ID: anotherPlayerUUID})
+	is.Equal(err, repository.ErrPlayerNotFound)
+}
+
+func TestMemoryPlayerRepository_Erase(t *testing.T) {
+	is := is.New(t)
+	repo := NewMemoryPlayerRepository()
+	p, _ := model.NewPlayer(&entity.Person{ID: examplePlayerUUID, Name: examplePlayerName})
+	is.NoErr(repo.Add(p))
+	p, _ = repo.Get(&entity.Person{ID: examplePlayerUUID})
+	is.Equal(repo.Erase(p), repository.ErrPlayerHasNoUpdates)
+
+	is.NoErr(p.Erase())
+	is.NoErr(repo.Erase(p))
+
+	records, _ := repo.events.Store().ReadStream(repo.events.Stream(playerCategory, examplePlayerUUID))
+	is.Equal(records[0].Event, &event.PlayerCreated{ID: examplePlayerUUID, Name: entity.ErasedName})
+}
+
 func TestMemoryPlayerRepository_Update(t *testing.T) {
 	testCases := []struct {
 		test        string
@@ -142,7 +284,7 @@ func TestMemoryPlayerRepository_Update(t *testing.T) {
 			r := NewMemoryPlayerRepository()
 			p := model.NewPlayerFromEvents([]event.Event{playerCreated})
 			if tc.register {
-				r.players[examplePlayerUUID] = p.Events()
+				seedPlayer(r, examplePlayerUUID, playerCreated)
 			}
 			if tc.deactivate {
 				p.Deactivate()
@@ -154,3 +296,12 @@ func TestMemoryPlayerRepository_Update(t *testing.T) {
 		})
 	}
 }
+
+// seedPlayer stores events as if the player had been added before.
+func seedPlayer(r *MemoryPlayerRepository, id uuid.UUID, events ...event.Event) {
+	stored := make([]any, len(events))
+	for i, e := range events {
+		stored[i] = e
+	}
+	_ = r.events.Store().Append(r.events.Stream(playerCategory, id), 0, stored...)
+}
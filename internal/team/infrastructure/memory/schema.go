@@ -0,0 +1,98 @@
+package memory
+
+import (
+	"time"
+
+	"git.sr.ht/~loges/teammate/internal/eventstore"
+	"git.sr.ht/~loges/teammate/internal/team/domain/event"
+	"git.sr.ht/~loges/teammate/internal/team/domain/model"
+)
+
+// Schemas lists how every team event is stored. When the fields of an
+// event change, bump its version and append an upcaster from the
+// previous version, and add a fixture of the new version to the tests.
+// Fields holding personal data are listed with the person they are about.
+var Schemas = []eventstore.Schema{
+	{Version: 1, New: func() any { return &event.TeamCreated{} }},
+	{Version: 1, New: func() any { return &event.TeamActivated{} }},
+	{Version: 1, New: func() any { return &event.TeamDeactivated{} }},
+	{Version: 2, New: func() any { return &event.PlayerAssignedToTeam{} }, Upcasters: []eventstore.Upcaster{
+		// version 2 added the jersey number, which players had none of before
+		func(fields map[string]any) error {
+			fields["jersey_number"] = model.NoJerseyNumber
+			return nil
+		},
+	}, Personal: []eventstore.PersonalData{{Subject: "player_id", Names: []string{"player_name"}}}},
+	{Version: 1, New: func() any { return &event.PlayerUnassignedFromTeam{} }, Personal: []eventstore.PersonalData{{Subject: "player_id", Names: []string{"player_name"}}}},
+	{Version: 2, New: func() any { return &event.TeamDeactivationCascaded{} }, Upcasters: []eventstore.Upcaster{
+		// version 2 added the jersey numbers of the unassigned players
+		func(fields map[string]any) error {
+			fields["jersey_numbers"] = map[string]any{}
+			return nil
+		},
+	}},
+	{Version: 1, New: func() any { return &event.TeamDeactivationReverted{} }},
+	{Version: 1, New: func() any { return &event.TeamSportChanged{} }},
+	{Version: 1, New: func() any { return &event.JerseyNumberChanged{} }},
+	{Version: 1, New: func() any { return &event.PlayerPositionsAssigned{} }},
+	{Version: 1, New: func() any { return &event.DepthChartChanged{} }},
+	{Version: 1, New: func() any { return &event.StaffMemberAdded{} }, Personal: []eventstore.PersonalData{{Subject: "person_id", Names: []string{"person_name"}}}},
+	{Version: 1, New: func() any { return &event.StaffMemberRemoved{} }},
+	{Version: 1, New: func() any { return &event.StaffMemberErased{} }},
+	{Version: 1, New: func() any { return &event.PlayerDesignated{} }},
+	{Version: 1, New: func() any { return &event.PlayerDesignationRemoved{} }},
+	{Version: 1, New: func() any { return &event.TeamRulesChanged{} }},
+	{Version: 1, New: func() any { return &event.TeamSeasonStarted{} }},
+	{Version: 1, New: func() any { return &event.PlayerCreated{} }, Personal: []eventstore.PersonalData{{Subject: "id", Names: []string{"name"}}}},
+	{Version: 1, New: func() any { return &event.PlayerActivated{} }},
+	{Version: 1, New: func() any { return &event.PlayerDeactivated{} }},
+	{Version: 2, New: func() any { return &event.TeamAssignedToPlayer{} }, Upcasters: []eventstore.Upcaster{
+		// version 2 added when the player joined, which is unknown before
+		func(fields map[string]any) error {
+			fields["joined_at"] = time.Time{}
+			return nil
+		},
+	}},
+	{Version: 2, New: func() any { return &event.TeamUnassignedFromPlayer{} }, Upcasters: []eventstore.Upcaster{
+		// version 2 added when and why the player left, which is unknown before
+		func(fields map[string]any) error {
+			fields["left_at"] = time.Time{}
+			fields["reason"] = ""
+			return nil
+		},
+	}},
+	{Version: 1, New: func() any { return &event.PlayerInvited{} }, Personal: []eventstore.PersonalData{{Subject: "id", Fields: []string{"code"}}}},
+	{Version: 1, New: func() any { return &event.UserLinkedToPlayer{} }, Personal: []eventstore.PersonalData{{Subject: "user_id", Names: []string{"user_name"}}}},
+	{Version: 1, New: func() any { return &event.UserUnlinkedFromPlayer{} }},
+	{Version: 1, New: func() any { return &event.PlayerDetailsChanged{} }, Personal: []eventstore.PersonalData{{Subject: "id", Fields: []string{"date_of_birth", "gender"}}}},
+	{Version: 1, New: func() any { return &event.PlayerContactDetailsChanged{} }, Personal: []eventstore.PersonalData{{Subject: "id", Fields: []string{"phone", "street", "city", "postal_code", "country"}}}},
+	{Version: 1, New: func() any { return &event.PlayerEmergencyContactsChanged{} }, Personal: []eventstore.PersonalData{{Subject: "id", Fields: []string{"contacts"}}}},
+	{Version: 1, New: func() any { return &event.PlayerMedicalNotesChanged{} }, Personal: []eventstore.PersonalData{{Subject: "id", Fields: []string{"notes"}}}},
+	{Version: 1, New: func() any { return &event.GuardianAddedToPlayer{} }, Personal: []eventstore.PersonalData{{Subject: "user_id", Names: []string{"user_name"}}}},
+	{Version: 1, New: func() any { return &event.GuardianRemovedFromPlayer{} }},
+	{Version: 1, New: func() any { return &event.PlayerHouseholdChanged{} }},
+	{Version: 1, New: func() any { return &event.PlayerMarkedUnavailable{} }, Personal: []eventstore.PersonalData{{Subject: "id", Fields: []string{"reason"}}}},
+	{Version: 1, New: func() any { return &event.PlayerUnavailabilityCleared{} }},
+	{Version: 1, New: func() any { return &event.PlayerErased{} }},
+	{Version: 1, New: func() any { return &event.SeasonCreated{} }},
+	{Version: 1, New: func() any { return &event.SeasonClosed{} }},
+	{Version: 2, New: func() any { return &event.GameScheduled{} }, Upcasters: []eventstore.Upcaster{
+		// version 2 added the field and when the game ends, which is unknown
+		// before, so older games take no time and never overlap
+		func(fields map[string]any) error {
+			fields["ends_at"] = fields["starts_at"]
+			fields["field"] = ""
+			return nil
+		},
+	}},
+	{Version: 1, New: func() any { return &event.PlayerRespondedToGame{} }},
+	{Version: 1, New: func() any { return &event.GameResultRecorded{} }},
+	{Version: 1, New: func() any { return &event.GameCancelled{} }},
+	{Version: 1, New: func() any { return &event.GameRestored{} }},
+	{Version: 1, New: func() any { return &event.TournamentCreated{} }},
+	{Version: 1, New: func() any { return &event.TournamentResultRecorded{} }},
+	{Version: 1, New: func() any { return &event.VenueCreated{} }},
+	{Version: 1, New: func() any { return &event.VenueFieldAdded{} }},
+	{Version: 1, New: func() any { return &event.VenueFieldRemoved{} }},
+	{Version: 1, New: func() any { return &event.VenueOpeningHoursChanged{} }},
+}
@@ -0,0 +1,356 @@
+package memory
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"git.sr.ht/~loges/teammate/internal/eventstore"
+	"git.sr.ht/~loges/teammate/internal/team/domain/event"
+	"git.sr.ht/~loges/teammate/internal/team/domain/model"
+	"github.com/google/uuid"
+	"github.com/matryer/is"
+)
+
+var (
+	fixtureTeamId   = `"f55e93f8-c952-11ed-afa1-0242ac120002"`
+	fixturePlayerId = `"f47ac10b-58cc-0372-8567-0e02b2c3d479"`
+	fixtureSession  = uuid.MustParse("a55e93f8-c952-11ed-afa1-0242ac120002")
+	fixtureJoinedAt = time.Date(2022, time.September, 1, 0, 0, 0, 0, time.UTC)
+	fixtureLeftAt   = time.Date(2023, time.June, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// TestSchemas replays a stored fixture of every historical version of
+// every event.
+func TestSchemas(t *testing.T) {
+	testCases := []struct {
+		test     string
+		payload  *eventstore.Payload
+		expected any
+	}{
+		{
+			"TeamCreated version 1",
+			&eventstore.Payload{Type: "TeamCreated", SchemaVersion: 1, Data: []byte(`{"id":` + fixtureTeamId + `,"name":"Syracuse"}`)},
+			&event.TeamCreated{ID: exampleTeamUUID, Name: exampleTeamName},
+		},
+		{
+			"TeamActivated version 1",
+			&eventstore.Payload{Type: "TeamActivated", SchemaVersion: 1, Data: []byte(`{"id":` + fixtureTeamId + `}`)},
+			&event.TeamActivated{ID: exampleTeamUUID},
+		},
+		{
+			"TeamDeactivated version 1",
+			&eventstore.Payload{Type: "TeamDeactivated", SchemaVersion: 1, Data: []byte(`{"id":` + fixtureTeamId + `}`)},
+			&event.TeamDeactivated{ID: exampleTeamUUID},
+		},
+		{
+			"PlayerAssignedToTeam version 1",
+			&eventstore.Payload{Type: "PlayerAssignedToTeam", SchemaVersion: 1, Data: []byte(`{"id":` + fixtureTeamId + `,"player_id":` + fixturePlayerId + `,"player_name":"Emily"}`)},
+			&event.PlayerAssignedToTeam{ID: exampleTeamUUID, PlayerId: anotherPlayerUUID, PlayerName: anotherPlayerName, JerseyNumber: model.NoJerseyNumber},
+		},
+		{
+			"PlayerAssignedToTeam version 2",
+			&eventstore.Payload{Type: "PlayerAssignedToTeam", SchemaVersion: 2, Data: []byte(`{"id":` + fixtureTeamId + `,"player_id":` + fixturePlayerId + `,"player_name":"Emily","jersey_number":7}`)},
+			&event.PlayerAssignedToTeam{ID: exampleTeamUUID, PlayerId: anotherPlayerUUID, PlayerName: anotherPlayerName, JerseyNumber: 7},
+		},
+		{
+			"PlayerUnassignedFromTeam version 1",
+			&eventstore.Payload{Type: "PlayerUnassignedFromTeam", SchemaVersion: 1, Data: []byte(`{"id":` + fixtureTeamId + `,"player_id":` + fixturePlayerId + `,"player_name":"Emily"}`)},
+			&event.PlayerUnassignedFromTeam{ID: exampleTeamUUID, PlayerId: anotherPlayerUUID, PlayerName: anotherPlayerName},
+		},
+		{
+			"TeamDeactivationCascaded version 1",
+			&eventstore.Payload{Type: "TeamDeactivationCascaded", SchemaVersion: 1, Data: []byte(`{"id":` + fixtureTeamId + `,"player_ids":[` + fixturePlayerId + `],"session_ids":["a55e93f8-c952-11ed-afa1-0242ac120002"]}`)},
+			&event.TeamDeactivationCascaded{ID: exampleTeamUUID, PlayerIds: []uuid.UUID{anotherPlayerUUID}, SessionIds: []uuid.UUID{fixtureSession}, JerseyNumbers: map[uuid.UUID]int{}},
+		},
+		{
+			"TeamDeactivationCascaded version 2",
+			&eventstore.Payload{Type: "TeamDeactivationCascaded", SchemaVersion: 2, Data: []byte(`{"id":` + fixtureTeamId + `,"player_ids":[` + fixturePlayerId + `],"session_ids":[],"jersey_numbers":{` + fixturePlayerId + `:7}}`)},
+			&event.TeamDeactivationCascaded{ID: exampleTeamUUID, PlayerIds: []uuid.UUID{anotherPlayerUUID}, SessionIds: []uuid.UUID{}, JerseyNumbers: map[uuid.UUID]int{anotherPlayerUUID: 7}},
+		},
+		{
+			"TeamDeactivationReverted version 1",
+			&eventstore.Payload{Type: "TeamDeactivationReverted", SchemaVersion: 1, Data: []byte(`{"id":` + fixtureTeamId + `}`)},
+			&event.TeamDeactivationReverted{ID: exampleTeamUUID},
+		},
+		{
+			"TeamSportChanged version 1",
+			&eventstore.Payload{Type: "TeamSportChanged", SchemaVersion: 1, Data: []byte(`{"id":` + fixtureTeamId + `,"sport":"soccer"}`)},
+			&event.TeamSportChanged{ID: exampleTeamUUID, Sport: "soccer"},
+		},
+		{
+			"JerseyNumberChanged version 1",
+			&eventstore.Payload{Type: "JerseyNumberChanged", SchemaVersion: 1, Data: []byte(`{"id":` + fixtureTeamId + `,"player_id":` + fixturePlayerId + `,"number":9,"previous":7}`)},
+			&event.JerseyNumberChanged{ID: exampleTeamUUID, PlayerId: anotherPlayerUUID, Number: 9, Previous: 7},
+		},
+		{
+			"PlayerPositionsAssigned version 1",
+			&eventstore.Payload{Type: "PlayerPositionsAssigned", SchemaVersion: 1, Data: []byte(`{"id":` + fixtureTeamId + `,"player_id":` + fixturePlayerId + `,"primary":"defender","secondary":["midfielder"]}`)},
+			&event.PlayerPositionsAssigned{ID: exampleTeamUUID, PlayerId: anotherPlayerUUID, Primary: "defender", Secondary: []string{"midfielder"}},
+		},
+		{
+			"DepthChartChanged version 1",
+			&eventstore.Payload{Type: "DepthChartChanged", SchemaVersion: 1, Data: []byte(`{"id":` + fixtureTeamId + `,"position":"defender","player_ids":[` + fixturePlayerId + `]}`)},
+			&event.DepthChartChanged{ID: exampleTeamUUID, Position: "defender", PlayerIds: []uuid.UUID{anotherPlayerUUID}},
+		},
+		{
+			"StaffMemberAdded version 1",
+			&eventstore.Payload{Type: "StaffMemberAdded", SchemaVersion: 1, Data: []byte(`{"id":` + fixtureTeamId + `,"person_id":` + fixturePlayerId + `,"person_name":"Emily","role":"physio"}`)},
+			&event.StaffMemberAdded{ID: exampleTeamUUID, PersonId: anotherPlayerUUID, PersonName: anotherPlayerName, Role: "physio"},
+		},
+		{
+			"StaffMemberRemoved version 1",
+			&eventstore.Payload{Type: "StaffMemberRemoved", SchemaVersion: 1, Data: []byte(`{"id":` + fixtureTeamId + `,"person_id":` + fixturePlayerId + `}`)},
+			&event.StaffMemberRemoved{ID: exampleTeamUUID, PersonId: anotherPlayerUUID},
+		},
+		{
+			"StaffMemberErased version 1",
+			&eventstore.Payload{Type: "StaffMemberErased", SchemaVersion: 1, Data: []byte(`{"id":` + fixtureTeamId + `,"person_id":` + fixturePlayerId + `}`)},
+			&event.StaffMemberErased{ID: exampleTeamUUID, PersonId: anotherPlayerUUID},
+		},
+		{
+			"PlayerDesignated version 1",
+			&eventstore.Payload{Type: "PlayerDesignated", SchemaVersion: 1, Data: []byte(`{"id":` + fixtureTeamId + `,"player_id":` + fixturePlayerId + `,"designation":"captain"}`)},
+			&event.PlayerDesignated{ID: exampleTeamUUID, PlayerId: anotherPlayerUUID, Designation: "captain"},
+		},
+		{
+			"PlayerDesignationRemoved version 1",
+			&eventstore.Payload{Type: "PlayerDesignationRemoved", SchemaVersion: 1, Data: []byte(`{"id":` + fixtureTeamId + `,"player_id":` + fixturePlayerId + `}`)},
+			&event.PlayerDesignationRemoved{ID: exampleTeamUUID, PlayerId: anotherPlayerUUID},
+		},
+		{
+			"TeamRulesChanged version 1",
+			&eventstore.Payload{Type: "TeamRulesChanged", SchemaVersion: 1, Data: []byte(`{"id":` + fixtureTeamId + `,"max_roster_size":18,"min_age":10,"max_age":12,"division":"female","max_teams_per_player":2}`)},
+			&event.TeamRulesChanged{ID: exampleTeamUUID, MaxRosterSize: 18, MinAge: 10, MaxAge: 12, Division: "female", MaxTeamsPerPlayer: 2},
+		},
+		{
+			"TeamSeasonStarted version 1",
+			&eventstore.Payload{Type: "TeamSeasonStarted", SchemaVersion: 1, Data: []byte(`{"id":` + fixtureTeamId + `,"season_id":"a15e93f8-c952-11ed-afa1-0242ac120002","released":[` + fixturePlayerId + `]}`)},
+			&event.TeamSeasonStarted{ID: exampleTeamUUID, SeasonId: exampleSeasonUUID, Released: []uuid.UUID{anotherPlayerUUID}},
+		},
+		{
+			"PlayerDetailsChanged version 1",
+			&eventstore.Payload{Type: "PlayerDetailsChanged", SchemaVersion: 1, Data: []byte(`{"id":` + fixturePlayerId + `,"date_of_birth":"2022-09-01T00:00:00Z","gender":"female"}`)},
+			&event.PlayerDetailsChanged{ID: anotherPlayerUUID, DateOfBirth: fixtureJoinedAt, Gender: "female"},
+		},
+		{
+			"PlayerContactDetailsChanged version 1",
+			&eventstore.Payload{Type: "PlayerContactDetailsChanged", SchemaVersion: 1, Data: []byte(`{"id":` + fixturePlayerId + `,"phone":"555-0100","street":"1 Main St","city":"Syracuse","postal_code":"13202","country":"US"}`)},
+			&event.PlayerContactDetailsChanged{ID: anotherPlayerUUID, Phone: "555-0100", Street: "1 Main St", City: "Syracuse", PostalCode: "13202", Country: "US"},
+		},
+		{
+			"PlayerEmergencyContactsChanged version 1",
+			&eventstore.Payload{Type: "PlayerEmergencyContactsChanged", SchemaVersion: 1, Data: []byte(`{"id":` + fixturePlayerId + `,"contacts":[{"name":"Ann","relationship":"mother","phone":"555-0101"}]}`)},
+			&event.PlayerEmergencyContactsChanged{ID: anotherPlayerUUID, Contacts: []event.EmergencyContact{{Name: "Ann", Relationship: "mother", Phone: "555-0101"}}},
+		},
+		{
+			"PlayerMedicalNotesChanged version 1",
+			&eventstore.Payload{Type: "PlayerMedicalNotesChanged", SchemaVersion: 1, Data: []byte(`{"id":` + fixturePlayerId + `,"notes":"asthma"}`)},
+			&event.PlayerMedicalNotesChanged{ID: anotherPlayerUUID, Notes: "asthma"},
+		},
+		{
+			"GuardianAddedToPlayer version 1",
+			&eventstore.Payload{Type: "GuardianAddedToPlayer", SchemaVersion: 1, Data: []byte(`{"id":` + fixturePlayerId + `,"user_id":` + fixtureTeamId + `,"user_name":"Ann","relationship":"mother"}`)},
+			&event.GuardianAddedToPlayer{ID: anotherPlayerUUID, UserId: exampleTeamUUID, UserName: "Ann", Relationship: "mother"},
+		},
+		{
+			"GuardianRemovedFromPlayer version 1",
+			&eventstore.Payload{Type: "GuardianRemovedFromPlayer", SchemaVersion: 1, Data: []byte(`{"id":` + fixturePlayerId + `,"user_id":` + fixtureTeamId + `}`)},
+			&event.GuardianRemovedFromPlayer{ID: anotherPlayerUUID, UserId: exampleTeamUUID},
+		},
+		{
+			"PlayerHouseholdChanged version 1",
+			&eventstore.Payload{Type: "PlayerHouseholdChanged", SchemaVersion: 1, Data: []byte(`{"id":` + fixturePlayerId + `,"household_id":` + fixtureTeamId + `}`)},
+			&event.PlayerHouseholdChanged{ID: anotherPlayerUUID, HouseholdId: exampleTeamUUID},
+		},
+		{
+			"PlayerMarkedUnavailable version 1",
+			&eventstore.Payload{Type: "PlayerMarkedUnavailable", SchemaVersion: 1, Data: []byte(`{"id":` + fixturePlayerId + `,"period_id":"a55e93f8-c952-11ed-afa1-0242ac120002","reason":"injured","from":"2022-09-01T00:00:00Z","to":"2023-06-01T00:00:00Z","visibility":"staff"}`)},
+			&event.PlayerMarkedUnavailable{ID: anotherPlayerUUID, PeriodId: fixtureSession, Reason: "injured", From: fixtureJoinedAt, To: fixtureLeftAt, Visibility: "staff"},
+		},
+		{
+			"PlayerUnavailabilityCleared version 1",
+			&eventstore.Payload{Type: "PlayerUnavailabilityCleared", SchemaVersion: 1, Data: []byte(`{"id":` + fixturePlayerId + `,"period_id":"a55e93f8-c952-11ed-afa1-0242ac120002"}`)},
+			&event.PlayerUnavailabilityCleared{ID: anotherPlayerUUID, PeriodId: fixtureSession},
+		},
+		{
+			"PlayerErased version 1",
+			&eventstore.Payload{Type: "PlayerErased", SchemaVersion: 1, Data: []byte(`{"id":` + fixturePlayerId + `}`)},
+			&event.PlayerErased{ID: anotherPlayerUUID},
+		},
+		{
+			"SeasonCreated version 1",
+			&eventstore.Payload{Type: "SeasonCreated", SchemaVersion: 1, Data: []byte(`{"id":"a15e93f8-c952-11ed-afa1-0242ac120002","name":"2023/24","starts_on":"2023-08-01T00:00:00Z","ends_on":"2024-06-01T00:00:00Z"}`)},
+			seasonCreated,
+		},
+		{
+			"SeasonClosed version 1",
+			&eventstore.Payload{Type: "SeasonClosed", SchemaVersion: 1, Data: []byte(`{"id":"a15e93f8-c952-11ed-afa1-0242ac120002"}`)},
+			&event.SeasonClosed{ID: exampleSeasonUUID},
+		},
+		{
+			"GameScheduled version 1",
+			&eventstore.Payload{Type: "GameScheduled", SchemaVersion: 1, Data: []byte(`{"id":"c15e93f8-c952-11ed-afa1-0242ac120002","season_id":"a15e93f8-c952-11ed-afa1-0242ac120002","round":1,"home_team_id":` + fixtureTeamId + `,"home_team_name":"Syracuse","away_team_id":"d15e93f8-c952-11ed-afa1-0242ac120002","away_team_name":"Cornell","starts_at":"2023-09-03T10:00:00Z","venue_id":"e15e93f8-c952-11ed-afa1-0242ac120002","venue_name":"Riverside Park"}`)},
+			&event.GameScheduled{
+				ID:           exampleGameUUID,
+				SeasonId:     exampleSeasonUUID,
+				Round:        1,
+				HomeTeamId:   exampleTeamUUID,
+				HomeTeamName: exampleTeamName,
+				AwayTeamId:   awayTeamUUID,
+				AwayTeamName: "Cornell",
+				StartsAt:     gameScheduled.StartsAt,
+				EndsAt:       gameScheduled.StartsAt,
+				VenueId:      exampleVenueUUID,
+				VenueName:    "Riverside Park",
+			},
+		},
+		{
+			"GameScheduled version 2",
+			&eventstore.Payload{Type: "GameScheduled", SchemaVersion: 2, Data: []byte(`{"id":"c15e93f8-c952-11ed-afa1-0242ac120002","season_id":"a15e93f8-c952-11ed-afa1-0242ac120002","round":1,"home_team_id":` + fixtureTeamId + `,"home_team_name":"Syracuse","away_team_id":"d15e93f8-c952-11ed-afa1-0242ac120002","away_team_name":"Cornell","starts_at":"2023-09-03T10:00:00Z","ends_at":"2023-09-03T11:30:00Z","venue_id":"e15e93f8-c952-11ed-afa1-0242ac120002","venue_name":"Riverside Park","field":"North Field"}`)},
+			gameScheduled,
+		},
+		{
+			"PlayerRespondedToGame version 1",
+			&eventstore.Payload{Type: "PlayerRespondedToGame", SchemaVersion: 1, Data: []byte(`{"id":"c15e93f8-c952-11ed-afa1-0242ac120002","player_id":` + fixturePlayerId + `,"response":"attending"}`)},
+			&event.PlayerRespondedToGame{ID: exampleGameUUID, PlayerId: anotherPlayerUUID, Response: "attending"},
+		},
+		{
+			"GameResultRecorded version 1",
+			&eventstore.Payload{Type: "GameResultRecorded", SchemaVersion: 1, Data: []byte(`{"id":"c15e93f8-c952-11ed-afa1-0242ac120002","home_score":2,"away_score":1}`)},
+			&event.GameResultRecorded{ID: exampleGameUUID, HomeScore: 2, AwayScore: 1},
+		},
+		{
+			"GameCancelled version 1",
+			&eventstore.Payload{Type: "GameCancelled", SchemaVersion: 1, Data: []byte(`{"id":"c15e93f8-c952-11ed-afa1-0242ac120002"}`)},
+			&event.GameCancelled{ID: exampleGameUUID},
+		},
+		{
+			"GameRestored version 1",
+			&eventstore.Payload{Type: "GameRestored", SchemaVersion: 1, Data: []byte(`{"id":"c15e93f8-c952-11ed-afa1-0242ac120002"}`)},
+			&event.GameRestored{ID: exampleGameUUID},
+		},
+		{
+			"TournamentCreated version 1",
+			&eventstore.Payload{Type: "TournamentCreated", SchemaVersion: 1, Data: []byte(`{"id":"b25e93f8-c952-11ed-afa1-0242ac120002","name":"Spring Cup","format":"single_elimination","third_place":false,"groups":0,"advance":0,"teams":[{"id":` + fixtureTeamId + `,"name":"Syracuse"},{"id":"d15e93f8-c952-11ed-afa1-0242ac120002","name":"Cornell"}]}`)},
+			tournamentCreated,
+		},
+		{
+			"TournamentResultRecorded version 1",
+			&eventstore.Payload{Type: "TournamentResultRecorded", SchemaVersion: 1, Data: []byte(`{"id":"b25e93f8-c952-11ed-afa1-0242ac120002","match_id":"R1-1","home_score":3,"away_score":1}`)},
+			&event.TournamentResultRecorded{ID: exampleTournamentUUID, MatchId: "R1-1", HomeScore: 3, AwayScore: 1},
+		},
+		{
+			"VenueCreated version 1",
+			&eventstore.Payload{Type: "VenueCreated", SchemaVersion: 1, Data: []byte(`{"id":"e15e93f8-c952-11ed-afa1-0242ac120002","name":"Riverside Park","street":"1 River Rd","city":"Syracuse","postal_code":"13202","country":"US"}`)},
+			venueCreated,
+		},
+		{
+			"VenueFieldAdded version 1",
+			&eventstore.Payload{Type: "VenueFieldAdded", SchemaVersion: 1, Data: []byte(`{"id":"e15e93f8-c952-11ed-afa1-0242ac120002","field":"North Field","surface":"grass"}`)},
+			&event.VenueFieldAdded{ID: exampleVenueUUID, Field: "North Field", Surface: "grass"},
+		},
+		{
+			"VenueFieldRemoved version 1",
+			&eventstore.Payload{Type: "VenueFieldRemoved", SchemaVersion: 1, Data: []byte(`{"id":"e15e93f8-c952-11ed-afa1-0242ac120002","field":"North Field"}`)},
+			&event.VenueFieldRemoved{ID: exampleVenueUUID, Field: "North Field"},
+		},
+		{
+			"VenueOpeningHoursChanged version 1",
+			&eventstore.Payload{Type: "VenueOpeningHoursChanged", SchemaVersion: 1, Data: []byte(`{"id":"e15e93f8-c952-11ed-afa1-0242ac120002","weekday":6,"opens_at":480,"closes_at":1320}`)},
+			&event.VenueOpeningHoursChanged{ID: exampleVenueUUID, Weekday: 6, OpensAt: 480, ClosesAt: 1320},
+		},
+		{
+			"PlayerCreated version 1",
+			&eventstore.Payload{Type: "PlayerCreated", SchemaVersion: 1, Data: []byte(`{"id":` + fixturePlayerId + `,"name":"Emily"}`)},
+			&event.PlayerCreated{ID: anotherPlayerUUID, Name: anotherPlayerName},
+		},
+		{
+			"PlayerActivated version 1",
+			&eventstore.Payload{Type: "PlayerActivated", SchemaVersion: 1, Data: []byte(`{"id":` + fixturePlayerId + `}`)},
+			&event.PlayerActivated{ID: anotherPlayerUUID},
+		},
+		{
+			"PlayerDeactivated version 1",
+			&eventstore.Payload{Type: "PlayerDeactivated", SchemaVersion: 1, Data: []byte(`{"id":` + fixturePlayerId + `}`)},
+			&event.PlayerDeactivated{ID: anotherPlayerUUID},
+		},
+		{
+			"TeamAssignedToPlayer version 1",
+			&eventstore.Payload{Type: "TeamAssignedToPlayer", SchemaVersion: 1, Data: []byte(`{"id":` + fixturePlayerId + `,"team_id":` + fixtureTeamId + `,"team_name":"Syracuse"}`)},
+			&event.TeamAssignedToPlayer{ID: anotherPlayerUUID, TeamId: exampleTeamUUID, TeamName: exampleTeamName},
+		},
+		{
+			"TeamUnassignedFromPlayer version 1",
+			&eventstore.Payload{Type: "TeamUnassignedFromPlayer", SchemaVersion: 1, Data: []byte(`{"id":` + fixturePlayerId + `,"team_id":` + fixtureTeamId + `,"team_name":"Syracuse"}`)},
+			&event.TeamUnassignedFromPlayer{ID: anotherPlayerUUID, TeamId: exampleTeamUUID, TeamName: exampleTeamName},
+		},
+		{
+			"TeamAssignedToPlayer version 2",
+			&eventstore.Payload{Type: "TeamAssignedToPlayer", SchemaVersion: 2, Data: []byte(`{"id":` + fixturePlayerId + `,"team_id":` + fixtureTeamId + `,"team_name":"Syracuse","joined_at":"2022-09-01T00:00:00Z"}`)},
+			&event.TeamAssignedToPlayer{ID: anotherPlayerUUID, TeamId: exampleTeamUUID, TeamName: exampleTeamName, JoinedAt: fixtureJoinedAt},
+		},
+		{
+			"TeamUnassignedFromPlayer version 2",
+			&eventstore.Payload{Type: "TeamUnassignedFromPlayer", SchemaVersion: 2, Data: []byte(`{"id":` + fixturePlayerId + `,"team_id":` + fixtureTeamId + `,"team_name":"Syracuse","left_at":"2023-06-01T00:00:00Z","reason":"transfer"}`)},
+			&event.TeamUnassignedFromPlayer{ID: anotherPlayerUUID, TeamId: exampleTeamUUID, TeamName: exampleTeamName, LeftAt: fixtureLeftAt, Reason: "transfer"},
+		},
+		{
+			"PlayerInvited version 1",
+			&eventstore.Payload{Type: "PlayerInvited", SchemaVersion: 1, Data: []byte(`{"id":` + fixturePlayerId + `,"code":"a1b2c3"}`)},
+			&event.PlayerInvited{ID: anotherPlayerUUID, Code: "a1b2c3"},
+		},
+		{
+			"UserLinkedToPlayer version 1",
+			&eventstore.Payload{Type: "UserLinkedToPlayer", SchemaVersion: 1, Data: []byte(`{"id":` + fixturePlayerId + `,"user_id":` + fixtureTeamId + `,"user_name":"Emily"}`)},
+			&event.UserLinkedToPlayer{ID: anotherPlayerUUID, UserId: exampleTeamUUID, UserName: anotherPlayerName},
+		},
+		{
+			"UserUnlinkedFromPlayer version 1",
+			&eventstore.Payload{Type: "UserUnlinkedFromPlayer", SchemaVersion: 1, Data: []byte(`{"id":` + fixturePlayerId + `,"user_id":` + fixtureTeamId + `}`)},
+			&event.UserUnlinkedFromPlayer{ID: anotherPlayerUUID, UserId: exampleTeamUUID},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			c := eventstore.NewCodec(Schemas...)
+
+			e, err := c.Decode(tc.payload)
+
+			is.NoErr(err)
+			is.Equal(e, tc.expected)
+		})
+	}
+}
+
+func TestSchemas_Erasure(t *testing.T) {
+	is := is.New(t)
+	keys := eventstore.NewMemoryKeyStore()
+	c := eventstore.NewEncryptingCodec(keys, Schemas...)
+	assigned := &event.PlayerAssignedToTeam{ID: exampleTeamUUID, PlayerId: anotherPlayerUUID, PlayerName: anotherPlayerName, JerseyNumber: 7}
+	notes := &event.PlayerMedicalNotesChanged{ID: anotherPlayerUUID, Notes: "asthma"}
+	invited := &event.PlayerInvited{ID: anotherPlayerUUID, Code: "ABCD1234"}
+
+	p, err := c.Encode(assigned)
+	is.NoErr(err)
+	is.True(!strings.Contains(string(p.Data), anotherPlayerName))
+	q, err := c.Encode(notes)
+	is.NoErr(err)
+	r, err := c.Encode(invited)
+	is.NoErr(err)
+	is.True(!strings.Contains(string(r.Data), invited.Code))
+
+	is.NoErr(keys.Erase(anotherPlayerUUID))
+	e, err := c.Decode(p)
+	is.NoErr(err)
+	is.Equal(e, &event.PlayerAssignedToTeam{ID: exampleTeamUUID, PlayerId: anotherPlayerUUID, PlayerName: entity.ErasedName, JerseyNumber: 7})
+	e, err = c.Decode(q)
+	is.NoErr(err)
+	is.Equal(e, &event.PlayerMedicalNotesChanged{ID: anotherPlayerUUID})
+	e, err = c.Decode(r)
+	is.NoErr(err)
+	is.Equal(e, &event.PlayerInvited{ID: anotherPlayerUUID})
+}
@@ -0,0 +1,66 @@
+package memory
+
+import (
+	"sync"
+
+	"git.sr.ht/~loges/teammate/internal/eventstore"
+	"git.sr.ht/~loges/teammate/internal/team/domain/model"
+	"git.sr.ht/~loges/teammate/internal/team/domain/repository"
+	"github.com/google/uuid"
+)
+
+// seasonCategory is the event store category of season streams.
+const seasonCategory = "season"
+
+// MemorySeasonRepository is an in-memory season repository.
+type MemorySeasonRepository struct {
+	events events
+	sync.Mutex
+}
+
+// NewMemorySeasonRepository intializes an in-memory season repository.
+func NewMemorySeasonRepository(cfgs ...Configuration) *MemorySeasonRepository {
+	return &MemorySeasonRepository{
+		events: newEvents(cfgs),
+	}
+}
+
+// Get retrieves a season by ID.
+func (r *MemorySeasonRepository) Get(id uuid.UUID) (*model.Season, error) {
+	if events, err := r.events.Load(r.events.Stream(seasonCategory, id)); err == nil {
+		return model.NewSeasonFromEvents(events), nil
+	}
+
+	return &model.Season{}, repository.ErrSeasonNotFound
+}
+
+// Add stores a new season in the repository.
+func (r *MemorySeasonRepository) Add(s *model.Season) error {
+	if r.events.Exists(r.events.Stream(seasonCategory, s.GetID())) {
+		return repository.ErrSeasonAlreadyExists
+	}
+
+	err := r.events.Commit(r, r.events.Stream(seasonCategory, s.GetID()), 0, s.Events())
+	if err == eventstore.ErrConcurrencyConflict {
+		return repository.ErrSeasonAlreadyExists
+	}
+	return err
+}
+
+// Update appends changes to season in the repository.
+func (r *MemorySeasonRepository) Update(s *model.Season) error {
+	if !r.events.Exists(r.events.Stream(seasonCategory, s.GetID())) {
+		return repository.ErrSeasonNotFound
+	}
+
+	newEvents := s.Events()
+	if len(newEvents) == 0 {
+		return repository.ErrSeasonHasNoUpdates
+	}
+
+	err := r.events.Commit(r, r.events.Stream(seasonCategory, s.GetID()), s.Version(), newEvents)
+	if err == eventstore.ErrConcurrencyConflict {
+		return repository.ErrSeasonConflict
+	}
+	return err
+}
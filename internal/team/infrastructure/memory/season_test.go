@@ -0,0 +1,127 @@
+package memory
+
+import (
+	"testing"
+	"time"
+
+	"git.sr.ht/~loges/teammate/internal/team/domain/event"
+	"git.sr.ht/~loges/teammate/internal/team/domain/model"
+	"git.sr.ht/~loges/teammate/internal/team/domain/repository"
+	"github.com/google/uuid"
+	"github.com/matryer/is"
+)
+
+var (
+	exampleSeasonUUID = uuid.MustParse("a15e93f8-c952-11ed-afa1-0242ac120002")
+	anotherSeasonUUID = uuid.MustParse("b15e93f8-c952-11ed-afa1-0242ac120002")
+	seasonCreated     = &event.SeasonCreated{
+		ID:       exampleSeasonUUID,
+		Name:     "2023/24",
+		StartsOn: time.Date(2023, time.August, 1, 0, 0, 0, 0, time.UTC),
+		EndsOn:   time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC),
+	}
+)
+
+func TestMemorySeasonRepository_Get(t *testing.T) {
+	testCases := []struct {
+		test        string
+		id          uuid.UUID
+		expectedErr error
+	}{
+		{"Season found", exampleSeasonUUID, nil},
+		{"No season found with this ID", anotherSeasonUUID, repository.ErrSeasonNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			r := NewMemorySeasonRepository()
+			seedSeason(r, exampleSeasonUUID, seasonCreated)
+
+			s, err := r.Get(tc.id)
+
+			is.Equal(err, tc.expectedErr)
+			if err == nil {
+				is.Equal(s.GetName(), seasonCreated.Name)
+				is.Equal(s.GetStartsOn(), seasonCreated.StartsOn)
+			}
+		})
+	}
+}
+
+func TestMemorySeasonRepository_Add(t *testing.T) {
+	testCases := []struct {
+		test        string
+		id          uuid.UUID
+		expectedErr error
+	}{
+		{"Successfully add a season", anotherSeasonUUID, nil},
+		{"Season already exists error", exampleSeasonUUID, repository.ErrSeasonAlreadyExists},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			r := NewMemorySeasonRepository()
+			seedSeason(r, exampleSeasonUUID, seasonCreated)
+			s, _ := model.NewSeason(tc.id, "2024/25", seasonCreated.EndsOn, seasonCreated.EndsOn.AddDate(1, 0, 0))
+
+			err := r.Add(s)
+
+			is.Equal(err, tc.expectedErr)
+		})
+	}
+}
+
+func TestMemorySeasonRepository_Update(t *testing.T) {
+	testCases := []struct {
+		test        string
+		register    bool
+		close       bool
+		expectedErr error
+	}{
+		{"Update season", true, true, nil},
+		{"Season has no changes", true, false, repository.ErrSeasonHasNoUpdates},
+		{"Season not found", false, true, repository.ErrSeasonNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			r := NewMemorySeasonRepository()
+			s := model.NewSeasonFromEvents([]event.Event{seasonCreated})
+			if tc.register {
+				seedSeason(r, exampleSeasonUUID, seasonCreated)
+			}
+			if tc.close {
+				is.NoErr(s.Close())
+			}
+
+			err := r.Update(s)
+
+			is.Equal(err, tc.expectedErr)
+		})
+	}
+
+	t.Run("Closed season stays closed", func(t *testing.T) {
+		is := is.New(t)
+		r := NewMemorySeasonRepository()
+		seedSeason(r, exampleSeasonUUID, seasonCreated)
+		s, _ := r.Get(exampleSeasonUUID)
+		is.NoErr(s.Close())
+		is.NoErr(r.Update(s))
+
+		s, err := r.Get(exampleSeasonUUID)
+
+		is.NoErr(err)
+		is.True(s.IsClosed())
+	})
+}
+
+func seedSeason(r *MemorySeasonRepository, id uuid.UUID, events ...event.Event) {
+	stored := make([]any, len(events))
+	for i, e := range events {
+		stored[i] = e
+	}
+	_ = r.events.Store().Append(r.events.Stream(seasonCategory, id), 0, stored...)
+}
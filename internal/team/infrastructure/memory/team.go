@@ -2,30 +2,33 @@ package memory
 
 import (
 	"sync"
+	"time"
 
 	"git.sr.ht/~loges/teammate/internal/entity"
-	"git.sr.ht/~loges/teammate/internal/team/domain/event"
+	"git.sr.ht/~loges/teammate/internal/eventstore"
 	"git.sr.ht/~loges/teammate/internal/team/domain/model"
 	"git.sr.ht/~loges/teammate/internal/team/domain/repository"
-	"github.com/google/uuid"
 )
 
+// teamCategory is the event store category of team streams.
+const teamCategory = "team"
+
 // MemoryTeamRepository is an in-memory team repository.
 type MemoryTeamRepository struct {
-	teams map[uuid.UUID][]event.Event
+	events events
 	sync.Mutex
 }
 
 // NewMemoryTeamRepository intializes an in-memory team repository.
-func NewMemoryTeamRepository() *MemoryTeamRepository {
+func NewMemoryTeamRepository(cfgs ...Configuration) *MemoryTeamRepository {
 	return &MemoryTeamRepository{
-		teams: make(map[uuid.UUID][]event.Event),
+		events: newEvents(cfgs),
 	}
 }
 
 // Get retrieves a team by ID.
 func (r *MemoryTeamRepository) Get(g *entity.Group) (*model.Team, error) {
-	if events, ok := r.teams[g.ID]; ok {
+	if events, err := r.events.Load(r.events.Stream(teamCategory, g.ID)); err == nil {
 		return model.NewTeamFromEvents(events), nil
 	}
 
@@ -34,30 +37,74 @@ func (r *MemoryTeamRepository) Get(g *entity.Group) (*model.Team, error) {
 
 // GetPlayers retrieves a team by ID.
 func (r *MemoryTeamRepository) GetPlayers(p *entity.Group) ([]*entity.Person, error) {
-	events, ok := r.teams[p.ID]
-	if !ok {
+	events, err := r.events.Load(r.events.Stream(teamCategory, p.ID))
+	if err != nil {
 		return []*entity.Person{}, repository.ErrTeamNotFound
 	}
 	return model.NewTeamFromEvents(events).GetPlayers(), nil
 }
 
+// GetByStaffMember retrieves the teams the person is on the staff of.
+func (r *MemoryTeamRepository) GetByStaffMember(p *entity.Person) ([]*model.Team, error) {
+	streams, err := r.events.Streams(teamCategory)
+	if err != nil {
+		return []*model.Team{}, err
+	}
+
+	teams := []*model.Team{}
+	for _, s := range streams {
+		events, err := r.events.Load(s)
+		if err != nil {
+			return []*model.Team{}, err
+		}
+		t := model.NewTeamFromEvents(events)
+		for _, member := range t.GetStaff() {
+			if member.Person.ID == p.ID {
+				teams = append(teams, t)
+				break
+			}
+		}
+	}
+
+	return teams, nil
+}
+
+// GetAsOf retrieves a team as it was at the given time. The team is not
+// found if it was created afterwards.
+func (r *MemoryTeamRepository) GetAsOf(g *entity.Group, t time.Time) (*model.Team, error) {
+	if events, err := r.events.LoadAsOf(r.events.Stream(teamCategory, g.ID), t); err == nil {
+		return model.NewTeamFromEvents(events), nil
+	}
+
+	return &model.Team{}, repository.ErrTeamNotFound
+}
+
+// GetAtVersion retrieves a team as it was at the given version. The team
+// is not found if it has not reached the version.
+func (r *MemoryTeamRepository) GetAtVersion(g *entity.Group, version int) (*model.Team, error) {
+	if events, err := r.events.LoadAtVersion(r.events.Stream(teamCategory, g.ID), version); err == nil {
+		return model.NewTeamFromEvents(events), nil
+	}
+
+	return &model.Team{}, repository.ErrTeamNotFound
+}
+
 // Add stores a new team in the repository.
 func (r *MemoryTeamRepository) Add(t *model.Team) error {
-	if _, ok := r.teams[t.GetID()]; ok {
+	if r.events.Exists(r.events.Stream(teamCategory, t.GetID())) {
 		return repository.ErrTeamAlreadyExists
 	}
 
-	r.Lock()
-	r.teams[t.GetID()] = t.Events()
-	defer r.Unlock()
-
-	return nil
+	err := r.events.Commit(r, r.events.Stream(teamCategory, t.GetID()), 0, t.Events())
+	if err == eventstore.ErrConcurrencyConflict {
+		return repository.ErrTeamAlreadyExists
+	}
+	return err
 }
 
 // Update appends changes to team in the repository.
 func (r *MemoryTeamRepository) Update(t *model.Team) error {
-	storedEvents, ok := r.teams[t.GetID()]
-	if !ok {
+	if !r.events.Exists(r.events.Stream(teamCategory, t.GetID())) {
 		return repository.ErrTeamNotFound
 	}
 
@@ -66,9 +113,9 @@ func (r *MemoryTeamRepository) Update(t *model.Team) error {
 		return repository.ErrTeamHasNoUpdates
 	}
 
-	r.Lock()
-	r.teams[t.GetID()] = append(storedEvents, newEvents...)
-	defer r.Unlock()
-
-	return nil
+	err := r.events.Commit(r, r.events.Stream(teamCategory, t.GetID()), t.Version(), newEvents)
+	if err == eventstore.ErrConcurrencyConflict {
+		return repository.ErrTeamConflict
+	}
+	return err
 }
@@ -1,12 +1,18 @@
 package memory
 
 import (
+	"errors"
 	"testing"
+	"time"
 
+	"git.sr.ht/~loges/teammate/internal/bus"
 	"git.sr.ht/~loges/teammate/internal/entity"
+	"git.sr.ht/~loges/teammate/internal/eventstore"
+	"git.sr.ht/~loges/teammate/internal/outbox"
 	"git.sr.ht/~loges/teammate/internal/team/domain/event"
 	"git.sr.ht/~loges/teammate/internal/team/domain/model"
 	"git.sr.ht/~loges/teammate/internal/team/domain/repository"
+	"git.sr.ht/~loges/teammate/internal/tenantid"
 	"github.com/google/uuid"
 	"github.com/matryer/is"
 )
@@ -42,9 +48,7 @@ func TestMemoryTeamRepository_Get(t *testing.T) {
 		t.Run(tc.test, func(t *testing.T) {
 			is := is.New(t)
 			repo := NewMemoryTeamRepository()
-			repo.teams[exampleTeamUUID] = []event.Event{
-				&event.TeamCreated{ID: exampleTeamUUID, Name: exampleTeamName},
-			}
+			seedTeam(repo, exampleTeamUUID, teamCreated)
 
 			_, err := repo.Get(tc.group)
 
@@ -70,7 +74,7 @@ func TestMemoryTeamRepository_GetPlayers(t *testing.T) {
 		t.Run(tc.test, func(t *testing.T) {
 			is := is.New(t)
 			repo := NewMemoryTeamRepository()
-			repo.teams[tc.teamId] = tc.events
+			seedTeam(repo, tc.teamId, tc.events...)
 
 			players, err := repo.GetPlayers(&entity.Group{ID: exampleTeamUUID, Name: exampleTeamName})
 
@@ -98,7 +102,7 @@ func TestMemoryTeamRepository_Add(t *testing.T) {
 			team := model.NewTeamFromEvents([]event.Event{
 				&event.TeamCreated{ID: tc.id, Name: tc.name},
 			})
-			r.teams[exampleTeamUUID] = team.Events()
+			seedTeam(r, exampleTeamUUID, teamCreated)
 
 			err := r.Add(team)
 
@@ -127,7 +131,7 @@ func TestMemoryTeamRepository_Update(t *testing.T) {
 				&event.TeamCreated{ID: exampleTeamUUID, Name: exampleTeamName},
 			})
 			if tc.register {
-				r.teams[exampleTeamUUID] = team.Events()
+				seedTeam(r, exampleTeamUUID, teamCreated)
 			}
 			if tc.deactivate {
 				team.Deactivate()
@@ -139,3 +143,188 @@ func TestMemoryTeamRepository_Update(t *testing.T) {
 		})
 	}
 }
+
+func TestMemoryTeamRepository_Publish(t *testing.T) {
+	is := is.New(t)
+	b := bus.New()
+	var published []event.Event
+	bus.Subscribe(b, "riverside", func(e event.Event) error {
+		published = append(published, e)
+		return nil
+	})
+	r := NewMemoryTeamRepository(WithPublisher(b), WithTenant("riverside"))
+	team, _ := model.NewTeam(&entity.Group{ID: exampleTeamUUID, Name: exampleTeamName})
+
+	is.NoErr(r.Add(team))
+	is.Equal(len(published), 1)
+
+	team, _ = r.Get(&entity.Group{ID: exampleTeamUUID})
+	is.NoErr(team.Deactivate())
+	is.NoErr(r.Update(team))
+	is.Equal(len(published), 2)
+
+	is.Equal(r.Add(team), repository.ErrTeamAlreadyExists)
+	is.Equal(len(published), 2)
+}
+
+// failingOutbox rejects every append.
+type failingOutbox struct{}
+
+func (failingOutbox) Append(tenantid.ID, ...any) error {
+	return errOutboxUnavailable
+}
+
+var errOutboxUnavailable = errors.New("outbox unavailable")
+
+func TestMemoryTeamRepository_Outbox(t *testing.T) {
+	t.Run("Events are recorded in the outbox", func(t *testing.T) {
+		is := is.New(t)
+		o := outbox.NewMemoryStore()
+		r := NewMemoryTeamRepository(WithOutbox(o))
+		team, _ := model.NewTeam(&entity.Group{ID: exampleTeamUUID, Name: exampleTeamName})
+
+		is.NoErr(r.Add(team))
+
+		pending, _ := o.Pending(time.Now(), 0)
+		is.Equal(len(pending), 1)
+	})
+
+	t.Run("Conflicting write leaves no outbox entry", func(t *testing.T) {
+		is := is.New(t)
+		o := outbox.NewMemoryStore()
+		store := eventstore.NewMemoryStore()
+		r := NewMemoryTeamRepository(WithEventStore(store), WithOutbox(o))
+		other := NewMemoryTeamRepository(WithEventStore(store))
+		seedTeam(other, exampleTeamUUID, teamCreated)
+		stale, _ := r.Get(&entity.Group{ID: exampleTeamUUID})
+		current, _ := other.Get(&entity.Group{ID: exampleTeamUUID})
+		is.NoErr(current.Deactivate())
+		is.NoErr(other.Update(current))
+
+		is.NoErr(stale.Deactivate())
+		is.Equal(r.Update(stale), repository.ErrTeamConflict)
+
+		pending, _ := o.Pending(time.Now(), 0)
+		is.Equal(len(pending), 0)
+	})
+
+	t.Run("Outbox failure is reported", func(t *testing.T) {
+		is := is.New(t)
+		r := NewMemoryTeamRepository(WithOutbox(failingOutbox{}))
+		team, _ := model.NewTeam(&entity.Group{ID: exampleTeamUUID, Name: exampleTeamName})
+
+		is.Equal(r.Add(team), errOutboxUnavailable)
+		_, err := r.Get(&entity.Group{ID: exampleTeamUUID})
+		is.Equal(err, repository.ErrTeamNotFound) // not stored without its outbox entry
+	})
+}
+
+func TestMemoryTeamRepository_GetByStaffMember(t *testing.T) {
+	is := is.New(t)
+	repo := NewMemoryTeamRepository()
+	seedTeam(repo, exampleTeamUUID, teamCreated, &event.StaffMemberAdded{ID: exampleTeamUUID, PersonId: examplePlayerUUID, PersonName: examplePlayerName, Role: "manager"})
+	seedTeam(repo, anotherTeamUUID, anotherTeamCreated)
+
+	teams, err := repo.GetByStaffMember(&entity.Person{ID: examplePlayerUUID})
+
+	is.NoErr(err)
+	is.Equal(len(teams), 1)
+	is.Equal(teams[0].GetID(), exampleTeamUUID)
+}
+
+func TestMemoryTeamRepository_GetByStaffMemberUnreadable(t *testing.T) {
+	is := is.New(t)
+	store := eventstore.NewMemoryStore()
+	repo := NewMemoryTeamRepository(WithEventStore(store))
+	seedTeam(repo, exampleTeamUUID, teamCreated)
+	_ = store.Append(repo.events.Stream(teamCategory, anotherTeamUUID), 0, &eventstore.Payload{Type: "TeamCreated", SchemaVersion: 99})
+
+	_, err := repo.GetByStaffMember(&entity.Person{ID: examplePlayerUUID})
+
+	is.Equal(err, eventstore.ErrUnsupportedSchemaVersion)
+}
+
+// seedTeam stores events as if the team had been added before.
+func seedTeam(r *MemoryTeamRepository, id uuid.UUID, events ...event.Event) {
+	stored := make([]any, len(events))
+	for i, e := range events {
+		stored[i] = e
+	}
+	_ = r.events.Store().Append(r.events.Stream(teamCategory, id), 0, stored...)
+}
+
+func TestMemoryTeamRepository_UpdateConflict(t *testing.T) {
+	is := is.New(t)
+	r := NewMemoryTeamRepository()
+	seedTeam(r, exampleTeamUUID, teamCreated)
+	first, _ := r.Get(&entity.Group{ID: exampleTeamUUID})
+	second, _ := r.Get(&entity.Group{ID: exampleTeamUUID})
+
+	first.Deactivate()
+	is.NoErr(r.Update(first))
+
+	second.Deactivate()
+	is.Equal(r.Update(second), repository.ErrTeamConflict)
+}
+
+func TestMemoryTeamRepository_GetAsOf(t *testing.T) {
+	created := time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		test        string
+		at          time.Time
+		playerCount int
+		expectedErr error
+	}{
+		{"Team not created yet", created.Add(-time.Hour), 0, repository.ErrTeamNotFound},
+		{"Team as created", created, 0, nil},
+		{"Team after assignment", created.Add(time.Hour), 1, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			now := created
+			r := NewMemoryTeamRepository(WithEventStore(eventstore.NewMemoryStore(
+				eventstore.WithClock(func() time.Time { return now }),
+			)))
+			seedTeam(r, exampleTeamUUID, teamCreated)
+			now = now.Add(time.Hour)
+			_ = r.events.Store().Append(r.events.Stream(teamCategory, exampleTeamUUID), 1, playerAssigned)
+
+			team, err := r.GetAsOf(&entity.Group{ID: exampleTeamUUID}, tc.at)
+
+			is.Equal(err, tc.expectedErr)
+			is.Equal(len(team.GetPlayers()), tc.playerCount)
+		})
+	}
+}
+
+func TestMemoryTeamRepository_GetAtVersion(t *testing.T) {
+	testCases := []struct {
+		test        string
+		version     int
+		playerCount int
+		expectedErr error
+	}{
+		{"Version before creation", 0, 0, repository.ErrTeamNotFound},
+		{"Team as created", 1, 0, nil},
+		{"Team after assignment", 2, 1, nil},
+		{"Version not reached", 3, 0, repository.ErrTeamNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			r := NewMemoryTeamRepository()
+			seedTeam(r, exampleTeamUUID, teamCreated, playerAssigned)
+
+			team, err := r.GetAtVersion(&entity.Group{ID: exampleTeamUUID}, tc.version)
+
+			is.Equal(err, tc.expectedErr)
+			is.Equal(len(team.GetPlayers()), tc.playerCount)
+			if err == nil {
+				is.Equal(team.Version(), tc.version)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package memory
+
+import (
+	"sync"
+
+	"git.sr.ht/~loges/teammate/internal/eventstore"
+	"git.sr.ht/~loges/teammate/internal/team/domain/model"
+	"git.sr.ht/~loges/teammate/internal/team/domain/repository"
+	"github.com/google/uuid"
+)
+
+// tournamentCategory is the event store category of tournament streams.
+const tournamentCategory = "tournament"
+
+// MemoryTournamentRepository is an in-memory tournament repository.
+type MemoryTournamentRepository struct {
+	events events
+	sync.Mutex
+}
+
+// NewMemoryTournamentRepository intializes an in-memory tournament repository.
+func NewMemoryTournamentRepository(cfgs ...Configuration) *MemoryTournamentRepository {
+	return &MemoryTournamentRepository{
+		events: newEvents(cfgs),
+	}
+}
+
+// Get retrieves a tournament by ID.
+func (r *MemoryTournamentRepository) Get(id uuid.UUID) (*model.Tournament, error) {
+	if events, err := r.events.Load(r.events.Stream(tournamentCategory, id)); err == nil {
+		return model.NewTournamentFromEvents(events), nil
+	}
+
+	return &model.Tournament{}, repository.ErrTournamentNotFound
+}
+
+// Add stores a new tournament in the repository.
+func (r *MemoryTournamentRepository) Add(t *model.Tournament) error {
+	if r.events.Exists(r.events.Stream(tournamentCategory, t.GetID())) {
+		return repository.ErrTournamentAlreadyExists
+	}
+
+	err := r.events.Commit(r, r.events.Stream(tournamentCategory, t.GetID()), 0, t.Events())
+	if err == eventstore.ErrConcurrencyConflict {
+		return repository.ErrTournamentAlreadyExists
+	}
+	return err
+}
+
+// Update appends changes to tournament in the repository.
+func (r *MemoryTournamentRepository) Update(t *model.Tournament) error {
+	if !r.events.Exists(r.events.Stream(tournamentCategory, t.GetID())) {
+		return repository.ErrTournamentNotFound
+	}
+
+	newEvents := t.Events()
+	if len(newEvents) == 0 {
+		return repository.ErrTournamentHasNoUpdates
+	}
+
+	err := r.events.Commit(r, r.events.Stream(tournamentCategory, t.GetID()), t.Version(), newEvents)
+	if err == eventstore.ErrConcurrencyConflict {
+		return repository.ErrTournamentConflict
+	}
+	return err
+}
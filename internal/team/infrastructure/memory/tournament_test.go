@@ -0,0 +1,114 @@
+package memory
+
+import (
+	"testing"
+
+	"git.sr.ht/~loges/teammate/internal/team/domain/bracket"
+	"git.sr.ht/~loges/teammate/internal/team/domain/event"
+	"git.sr.ht/~loges/teammate/internal/team/domain/model"
+	"git.sr.ht/~loges/teammate/internal/team/domain/repository"
+	"github.com/google/uuid"
+	"github.com/matryer/is"
+)
+
+var (
+	exampleTournamentUUID = uuid.MustParse("b25e93f8-c952-11ed-afa1-0242ac120002")
+	tournamentCreated     = &event.TournamentCreated{
+		ID:     exampleTournamentUUID,
+		Name:   "Spring Cup",
+		Format: string(bracket.SingleElimination),
+		Teams:  []event.TournamentTeam{{ID: exampleTeamUUID, Name: exampleTeamName}, {ID: awayTeamUUID, Name: "Cornell"}},
+	}
+)
+
+func TestMemoryTournamentRepository_Get(t *testing.T) {
+	testCases := []struct {
+		test        string
+		id          uuid.UUID
+		expectedErr error
+	}{
+		{"Tournament found", exampleTournamentUUID, nil},
+		{"No tournament found with this ID", uuid.New(), repository.ErrTournamentNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			r := NewMemoryTournamentRepository()
+			seedTournament(r, exampleTournamentUUID, tournamentCreated)
+
+			tournament, err := r.Get(tc.id)
+
+			is.Equal(err, tc.expectedErr)
+			if err == nil {
+				is.Equal(tournament.GetName(), tournamentCreated.Name)
+			}
+		})
+	}
+}
+
+func TestMemoryTournamentRepository_Add(t *testing.T) {
+	testCases := []struct {
+		test        string
+		id          uuid.UUID
+		expectedErr error
+	}{
+		{"Successfully add a tournament", uuid.New(), nil},
+		{"Tournament already exists error", exampleTournamentUUID, repository.ErrTournamentAlreadyExists},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			r := NewMemoryTournamentRepository()
+			seedTournament(r, exampleTournamentUUID, tournamentCreated)
+			home := model.NewTeamFromEvents([]event.Event{&event.TeamCreated{ID: exampleTeamUUID, Name: exampleTeamName}})
+			away := model.NewTeamFromEvents([]event.Event{&event.TeamCreated{ID: awayTeamUUID, Name: "Cornell"}})
+			tournament, err := model.NewTournament(tc.id, "Fall Cup", []*model.Team{home, away}, bracket.Options{Format: bracket.SingleElimination})
+			is.NoErr(err)
+
+			err = r.Add(tournament)
+
+			is.Equal(err, tc.expectedErr)
+		})
+	}
+}
+
+func TestMemoryTournamentRepository_Update(t *testing.T) {
+	testCases := []struct {
+		test        string
+		register    bool
+		record      bool
+		expectedErr error
+	}{
+		{"Update tournament", true, true, nil},
+		{"Tournament has no changes", true, false, repository.ErrTournamentHasNoUpdates},
+		{"Tournament not found", false, true, repository.ErrTournamentNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			r := NewMemoryTournamentRepository()
+			tournament := model.NewTournamentFromEvents([]event.Event{tournamentCreated})
+			if tc.register {
+				seedTournament(r, exampleTournamentUUID, tournamentCreated)
+			}
+			if tc.record {
+				is.NoErr(tournament.RecordResult("R1-1", 3, 1))
+			}
+
+			err := r.Update(tournament)
+
+			is.Equal(err, tc.expectedErr)
+		})
+	}
+}
+
+func seedTournament(r *MemoryTournamentRepository, id uuid.UUID, events ...event.Event) {
+	stored := make([]any, len(events))
+	for i, e := range events {
+		stored[i] = e
+	}
+	_ = r.events.Store().Append(r.events.Stream(tournamentCategory, id), 0, stored...)
+}
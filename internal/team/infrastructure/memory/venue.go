@@ -0,0 +1,85 @@
+package memory
+
+import (
+	"sync"
+
+	"git.sr.ht/~loges/teammate/internal/eventstore"
+	"git.sr.ht/~loges/teammate/internal/team/domain/model"
+	"git.sr.ht/~loges/teammate/internal/team/domain/repository"
+	"github.com/google/uuid"
+)
+
+// venueCategory is the event store category of venue streams.
+const venueCategory = "venue"
+
+// MemoryVenueRepository is an in-memory venue repository.
+type MemoryVenueRepository struct {
+	events events
+	sync.Mutex
+}
+
+// NewMemoryVenueRepository intializes an in-memory venue repository.
+func NewMemoryVenueRepository(cfgs ...Configuration) *MemoryVenueRepository {
+	return &MemoryVenueRepository{
+		events: newEvents(cfgs),
+	}
+}
+
+// Get retrieves a venue by ID.
+func (r *MemoryVenueRepository) Get(id uuid.UUID) (*model.Venue, error) {
+	if events, err := r.events.Load(r.events.Stream(venueCategory, id)); err == nil {
+		return model.NewVenueFromEvents(events), nil
+	}
+
+	return &model.Venue{}, repository.ErrVenueNotFound
+}
+
+// GetAll retrieves every venue.
+func (r *MemoryVenueRepository) GetAll() ([]*model.Venue, error) {
+	streams, err := r.events.Streams(venueCategory)
+	if err != nil {
+		return []*model.Venue{}, err
+	}
+
+	venues := []*model.Venue{}
+	for _, s := range streams {
+		events, err := r.events.Load(s)
+		if err != nil {
+			return []*model.Venue{}, err
+		}
+		venues = append(venues, model.NewVenueFromEvents(events))
+	}
+
+	return venues, nil
+}
+
+// Add stores a new venue in the repository.
+func (r *MemoryVenueRepository) Add(v *model.Venue) error {
+	if r.events.Exists(r.events.Stream(venueCategory, v.GetID())) {
+		return repository.ErrVenueAlreadyExists
+	}
+
+	err := r.events.Commit(r, r.events.Stream(venueCategory, v.GetID()), 0, v.Events())
+	if err == eventstore.ErrConcurrencyConflict {
+		return repository.ErrVenueAlreadyExists
+	}
+	return err
+}
+
+// Update appends changes to venue in the repository.
+func (r *MemoryVenueRepository) Update(v *model.Venue) error {
+	if !r.events.Exists(r.events.Stream(venueCategory, v.GetID())) {
+		return repository.ErrVenueNotFound
+	}
+
+	newEvents := v.Events()
+	if len(newEvents) == 0 {
+		return repository.ErrVenueHasNoUpdates
+	}
+
+	err := r.events.Commit(r, r.events.Stream(venueCategory, v.GetID()), v.Version(), newEvents)
+	if err == eventstore.ErrConcurrencyConflict {
+		return repository.ErrVenueConflict
+	}
+	return err
+}
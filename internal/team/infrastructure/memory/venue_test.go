@@ -0,0 +1,121 @@
+package memory
+
+import (
+	"testing"
+
+	"git.sr.ht/~loges/teammate/internal/team/domain/event"
+	"git.sr.ht/~loges/teammate/internal/team/domain/model"
+	"git.sr.ht/~loges/teammate/internal/team/domain/repository"
+	"github.com/google/uuid"
+	"github.com/matryer/is"
+)
+
+var (
+	exampleVenueUUID = uuid.MustParse("e15e93f8-c952-11ed-afa1-0242ac120002")
+	venueCreated     = &event.VenueCreated{ID: exampleVenueUUID, Name: "Riverside Park", Street: "1 River Rd", City: "Syracuse", PostalCode: "13202", Country: "US"}
+	fieldAdded       = &event.VenueFieldAdded{ID: exampleVenueUUID, Field: "North Field", Surface: "grass"}
+)
+
+func TestMemoryVenueRepository_Get(t *testing.T) {
+	testCases := []struct {
+		test        string
+		id          uuid.UUID
+		expectedErr error
+	}{
+		{"Venue found", exampleVenueUUID, nil},
+		{"No venue found with this ID", uuid.New(), repository.ErrVenueNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			r := NewMemoryVenueRepository()
+			seedVenue(r, exampleVenueUUID, venueCreated, fieldAdded)
+
+			v, err := r.Get(tc.id)
+
+			is.Equal(err, tc.expectedErr)
+			if err == nil {
+				is.Equal(v.GetName(), venueCreated.Name)
+				is.Equal(v.GetAddress().City, venueCreated.City)
+				is.Equal(v.GetFields(), []model.Field{{Name: "North Field", Surface: "grass"}})
+			}
+		})
+	}
+}
+
+func TestMemoryVenueRepository_GetAll(t *testing.T) {
+	is := is.New(t)
+	r := NewMemoryVenueRepository()
+	seedVenue(r, exampleVenueUUID, venueCreated)
+	another := uuid.New()
+	seedVenue(r, another, &event.VenueCreated{ID: another, Name: "Lakeside Courts"})
+
+	venues, err := r.GetAll()
+
+	is.NoErr(err)
+	is.Equal(len(venues), 2)
+}
+
+func TestMemoryVenueRepository_Add(t *testing.T) {
+	testCases := []struct {
+		test        string
+		id          uuid.UUID
+		expectedErr error
+	}{
+		{"Successfully add a venue", uuid.New(), nil},
+		{"Venue already exists error", exampleVenueUUID, repository.ErrVenueAlreadyExists},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			r := NewMemoryVenueRepository()
+			seedVenue(r, exampleVenueUUID, venueCreated)
+			v, _ := model.NewVenue(tc.id, "Lakeside Courts", model.Address{})
+
+			err := r.Add(v)
+
+			is.Equal(err, tc.expectedErr)
+		})
+	}
+}
+
+func TestMemoryVenueRepository_Update(t *testing.T) {
+	testCases := []struct {
+		test        string
+		register    bool
+		addField    bool
+		expectedErr error
+	}{
+		{"Update venue", true, true, nil},
+		{"Venue has no changes", true, false, repository.ErrVenueHasNoUpdates},
+		{"Venue not found", false, true, repository.ErrVenueNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			r := NewMemoryVenueRepository()
+			v := model.NewVenueFromEvents([]event.Event{venueCreated})
+			if tc.register {
+				seedVenue(r, exampleVenueUUID, venueCreated)
+			}
+			if tc.addField {
+				is.NoErr(v.AddField(model.Field{Name: "Court 1", Surface: "hardwood"}))
+			}
+
+			err := r.Update(v)
+
+			is.Equal(err, tc.expectedErr)
+		})
+	}
+}
+
+func seedVenue(r *MemoryVenueRepository, id uuid.UUID, events ...event.Event) {
+	stored := make([]any, len(events))
+	for i, e := range events {
+		stored[i] = e
+	}
+	_ = r.events.Store().Append(r.events.Stream(venueCategory, id), 0, stored...)
+}
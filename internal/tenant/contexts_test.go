@@ -0,0 +1,106 @@
+package tenant_test
+
+import (
+	"testing"
+
+	accessservices "git.sr.ht/~loges/teammate/internal/access/application/services"
+	accessmodel "git.sr.ht/~loges/teammate/internal/access/domain/model"
+	accessmemory "git.sr.ht/~loges/teammate/internal/access/infrastructure/memory"
+	"git.sr.ht/~loges/teammate/internal/bus"
+	"git.sr.ht/~loges/teammate/internal/entity"
+	"git.sr.ht/~loges/teammate/internal/eventstore"
+	teamapplication "git.sr.ht/~loges/teammate/internal/team/application"
+	teamservices "git.sr.ht/~loges/teammate/internal/team/application/services"
+	teammemory "git.sr.ht/~loges/teammate/internal/team/infrastructure/memory"
+	"git.sr.ht/~loges/teammate/internal/tenant"
+	"github.com/google/uuid"
+	"github.com/matryer/is"
+)
+
+// club holds the services of a tenant.
+type club struct {
+	roster *teamservices.RosterService
+	users  *accessmemory.MemoryUserRepository
+}
+
+func TestDirectory_AcrossContexts(t *testing.T) {
+	is := is.New(t)
+	store := eventstore.NewMemoryStore()
+	keys := eventstore.NewMemoryKeyStore()
+	b := bus.New()
+	registry := tenant.NewRegistry()
+	is.NoErr(registry.Add(&tenant.Tenant{ID: "riverside", Name: "Riverside FC"}))
+	is.NoErr(registry.Add(&tenant.Tenant{ID: "lakeside", Name: "Lakeside FC"}))
+	clubs := tenant.NewDirectory(registry, func(t *tenant.Tenant) (*club, error) {
+		users := accessmemory.NewMemoryUserRepository(
+			accessmemory.WithTenant(t.ID),
+			accessmemory.WithEventStore(store),
+			accessmemory.WithKeyStore(keys),
+		)
+		cfgs, err := teamapplication.TenantConfigs(t,
+			teammemory.WithEventStore(store),
+			teammemory.WithKeyStore(keys),
+			teammemory.WithPublisher(b),
+		)
+		if err != nil {
+			return nil, err
+		}
+		rs, err := teamservices.NewRosterService(append(cfgs, teamservices.WithAuthorizer(accessservices.NewAuthorizationService(users)))...)
+		if err != nil {
+			return nil, err
+		}
+		teamservices.NewDeactivationCascade(rs, teamservices.WithPlayerUnassignment()).Subscribe(b)
+		return &club{roster: rs, users: users}, nil
+	})
+	riverside, err := clubs.Get("riverside")
+	is.NoErr(err)
+	lakeside, err := clubs.Get("lakeside")
+	is.NoErr(err)
+	team := &entity.Group{ID: uuid.New(), Name: "Tigers"}
+	player := &entity.Person{ID: uuid.New(), Name: "Matt"}
+	admin := &entity.Person{ID: uuid.New(), Name: "Ann"}
+	for _, c := range []*club{riverside, lakeside} {
+		u, _ := accessmodel.NewUser(admin, "ann@teammate.com")
+		is.NoErr(u.GrantRole(accessmodel.RoleClubAdmin, entity.Organization))
+		is.NoErr(c.users.Add(u))
+		c.roster = c.roster.As(admin)
+	}
+
+	is.NoErr(riverside.roster.AddTeam(team))
+	is.NoErr(riverside.roster.AddPlayer(player))
+	is.NoErr(riverside.roster.AssignPlayerToTeam(team, player, 7))
+	is.NoErr(lakeside.roster.AddTeam(team))
+	u, _ := accessmodel.NewUser(player, "matt@teammate.com")
+	is.NoErr(riverside.users.Add(u))
+
+	roster, err := riverside.roster.GetRoster(team)
+	is.NoErr(err)
+	is.Equal(len(roster), 1)
+	roster, err = lakeside.roster.GetRoster(team)
+	is.NoErr(err)
+	is.Equal(len(roster), 0)
+	_, err = lakeside.roster.GetPlayerProfile(team, player)
+	is.True(err != nil)
+	_, err = lakeside.users.Get(player)
+	is.True(err != nil)
+	u, _ = accessmodel.NewUser(player, "matt@teammate.com")
+	is.NoErr(lakeside.users.Add(u))
+
+	// the deactivation of a team only cascades within its tenant
+	is.NoErr(lakeside.roster.AddPlayer(player))
+	is.NoErr(lakeside.roster.AssignPlayerToTeam(team, player, 7))
+	is.NoErr(riverside.roster.DeactivateTeam(team))
+	roster, _ = riverside.roster.GetRoster(team)
+	is.Equal(len(roster), 0)
+	roster, _ = lakeside.roster.GetRoster(team)
+	is.Equal(len(roster), 1)
+
+	// erasing a person only destroys the key the tenant holds
+	is.NoErr(riverside.roster.ErasePlayer(player))
+	profile, err := lakeside.roster.GetPlayerProfile(team, player)
+	is.NoErr(err)
+	is.Equal(profile.Name, "Matt")
+	found, err := lakeside.users.Get(player)
+	is.NoErr(err)
+	is.Equal(found.GetName(), "Matt")
+}
@@ -0,0 +1,44 @@
+package tenant
+
+import "sync"
+
+// Directory keeps an instance of T per tenant, such as the applications
+// of the tenant, built on first use from the settings of the tenant.
+type Directory[T any] struct {
+	registry *Registry
+	build    func(t *Tenant) (T, error)
+	built    map[ID]T
+	sync.Mutex
+}
+
+// NewDirectory initializes a directory building instances for the tenants
+// in the registry.
+func NewDirectory[T any](reg *Registry, build func(t *Tenant) (T, error)) *Directory[T] {
+	return &Directory[T]{
+		registry: reg,
+		build:    build,
+		built:    make(map[ID]T),
+	}
+}
+
+// Get returns the instance of the tenant, building it if needed. A failed
+// build is retried on the next call.
+func (d *Directory[T]) Get(id ID) (T, error) {
+	d.Lock()
+	defer d.Unlock()
+
+	if instance, ok := d.built[id]; ok {
+		return instance, nil
+	}
+
+	var instance T
+	t, err := d.registry.Get(id)
+	if err != nil {
+		return instance, err
+	}
+	if instance, err = d.build(t); err != nil {
+		return instance, err
+	}
+	d.built[id] = instance
+	return instance, nil
+}
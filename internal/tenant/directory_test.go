@@ -0,0 +1,39 @@
+package tenant
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+var errBuild = errors.New("build failed")
+
+func TestDirectory_Get(t *testing.T) {
+	is := is.New(t)
+	builds := 0
+	fail := true
+	d := NewDirectory(newRegistry(is), func(t *Tenant) (string, error) {
+		builds++
+		if t.ID == "lakeside" && fail {
+			return "", errBuild
+		}
+		return t.Name + " plays " + t.Setting("sport", "basketball"), nil
+	})
+
+	app, err := d.Get("riverside")
+	is.NoErr(err)
+	is.Equal(app, "Riverside FC plays soccer")
+	_, _ = d.Get("riverside")
+	is.Equal(builds, 1)
+
+	_, err = d.Get("hillside")
+	is.Equal(err, ErrTenantNotFound)
+
+	_, err = d.Get("lakeside")
+	is.Equal(err, errBuild)
+	fail = false
+	app, err = d.Get("lakeside")
+	is.NoErr(err)
+	is.Equal(app, "Lakeside FC plays basketball")
+}
@@ -0,0 +1,62 @@
+package tenant
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying the tenant.
+func NewContext(ctx context.Context, t *Tenant) context.Context {
+	return context.WithValue(ctx, contextKey{}, t)
+}
+
+// FromContext returns the tenant carried by ctx.
+func FromContext(ctx context.Context) (*Tenant, bool) {
+	t, ok := ctx.Value(contextKey{}).(*Tenant)
+	return t, ok
+}
+
+// Resolver finds the tenant a request is for, and the path of the request
+// within the tenant.
+type Resolver func(r *http.Request) (*Tenant, string, error)
+
+// ByHost resolves the tenant from the hostname the request was sent to.
+func ByHost(reg *Registry) Resolver {
+	return func(r *http.Request) (*Tenant, string, error) {
+		t, err := reg.GetByHost(r.Host)
+		return t, r.URL.Path, err
+	}
+}
+
+// ByPath resolves the tenant from the first segment of the request path,
+// so /riverside/teams is the teams path of the riverside tenant.
+func ByPath(reg *Registry) Resolver {
+	return func(r *http.Request) (*Tenant, string, error) {
+		id, rest, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+		t, err := reg.Get(ID(id))
+		return t, "/" + rest, err
+	}
+}
+
+// Middleware resolves the tenant of every request and serves it with the
+// tenant in its context and the path within the tenant. Requests for
+// unknown tenants are not found.
+func Middleware(resolve Resolver, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t, path, err := resolve(r)
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+
+		r2 := r.WithContext(NewContext(r.Context(), t))
+		u := *r.URL
+		u.Path = path
+		u.RawPath = ""
+		r2.URL = &u
+		next.ServeHTTP(w, r2)
+	})
+}
@@ -0,0 +1,54 @@
+package tenant
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+// echo writes the tenant and path of the request.
+var echo = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	t, ok := FromContext(r.Context())
+	if !ok {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	_, _ = w.Write([]byte(string(t.ID) + " " + r.URL.Path))
+})
+
+func TestMiddleware(t *testing.T) {
+	testCases := []struct {
+		test         string
+		resolver     func(r *Registry) Resolver
+		url          string
+		expectedCode int
+		expectedBody string
+	}{
+		{"Tenant by host", ByHost, "http://riverside.example.com/teams", http.StatusOK, "riverside /teams"},
+		{"Unknown host", ByHost, "http://example.com/teams", http.StatusNotFound, ""},
+		{"Tenant by path", ByPath, "http://example.com/lakeside/teams", http.StatusOK, "lakeside /teams"},
+		{"Tenant root by path", ByPath, "http://example.com/lakeside", http.StatusOK, "lakeside /"},
+		{"Unknown path", ByPath, "http://example.com/hillside/teams", http.StatusNotFound, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			h := Middleware(tc.resolver(newRegistry(is)), echo)
+			w := httptest.NewRecorder()
+
+			r := httptest.NewRequest(http.MethodGet, tc.url, nil)
+			path := r.URL.Path
+
+			h.ServeHTTP(w, r)
+
+			is.Equal(w.Code, tc.expectedCode)
+			is.Equal(r.URL.Path, path) // the caller's request is left alone
+			if tc.expectedCode == http.StatusOK {
+				is.Equal(w.Body.String(), tc.expectedBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package tenant
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"sync"
+
+	"git.sr.ht/~loges/teammate/internal/tenantid"
+)
+
+var (
+	ErrInvalidTenant       = errors.New("tenant: tenant has to have a name and an id usable in paths")
+	ErrTenantNotFound      = errors.New("tenant: the tenant was not found")
+	ErrTenantAlreadyExists = errors.New("tenant: tenant already exists")
+	ErrHostAlreadyTaken    = errors.New("tenant: host is already served for another tenant")
+)
+
+// ID identifies a tenant hosted in the deployment.
+type ID = tenantid.ID
+
+// None is the tenant of deployments hosting a single club, whose data is
+// not scoped to a tenant.
+const None = tenantid.None
+
+// Tenant is a club hosted in a deployment shared with other clubs.
+type Tenant struct {
+	ID   ID
+	Name string
+	// Hosts are the hostnames the tenant is served on.
+	Hosts []string
+	// Settings configure the applications built for the tenant.
+	Settings map[string]string
+}
+
+// Setting returns the value of the setting, or fallback if the tenant
+// does not configure it.
+func (t *Tenant) Setting(key, fallback string) string {
+	if value, ok := t.Settings[key]; ok {
+		return value
+	}
+	return fallback
+}
+
+// Registry keeps the tenants of the deployment.
+type Registry struct {
+	tenants map[ID]*Tenant
+	hosts   map[string]ID
+	sync.RWMutex
+}
+
+// NewRegistry initializes an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		tenants: make(map[ID]*Tenant),
+		hosts:   make(map[string]ID),
+	}
+}
+
+// Add registers the tenant and the hosts it is served on.
+func (r *Registry) Add(t *Tenant) error {
+	if t.ID == None || t.Name == "" || strings.ContainsAny(string(t.ID), "/?#") {
+		return ErrInvalidTenant
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	if _, ok := r.tenants[t.ID]; ok {
+		return ErrTenantAlreadyExists
+	}
+	for _, host := range t.Hosts {
+		if _, ok := r.hosts[normalize(host)]; ok {
+			return ErrHostAlreadyTaken
+		}
+	}
+
+	r.tenants[t.ID] = t
+	for _, host := range t.Hosts {
+		r.hosts[normalize(host)] = t.ID
+	}
+	return nil
+}
+
+// Get returns the tenant with the ID.
+func (r *Registry) Get(id ID) (*Tenant, error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	t, ok := r.tenants[id]
+	if !ok {
+		return nil, ErrTenantNotFound
+	}
+	return t, nil
+}
+
+// GetByHost returns the tenant served on the host. The port and case of
+// the host are ignored.
+func (r *Registry) GetByHost(host string) (*Tenant, error) {
+	r.RLock()
+	id, ok := r.hosts[normalize(host)]
+	r.RUnlock()
+
+	if !ok {
+		return nil, ErrTenantNotFound
+	}
+	return r.Get(id)
+}
+
+// normalize strips the port from the host and lowercases it.
+func normalize(host string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.ToLower(host)
+}
@@ -0,0 +1,77 @@
+package tenant
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+var (
+	riverside = &Tenant{ID: "riverside", Name: "Riverside FC", Hosts: []string{"riverside.example.com"}, Settings: map[string]string{"sport": "soccer"}}
+	lakeside  = &Tenant{ID: "lakeside", Name: "Lakeside FC", Hosts: []string{"lakeside.example.com", "teammate.lakeside.org"}}
+)
+
+func newRegistry(is *is.I) *Registry {
+	r := NewRegistry()
+	is.NoErr(r.Add(riverside))
+	is.NoErr(r.Add(lakeside))
+	return r
+}
+
+func TestRegistry_Add(t *testing.T) {
+	testCases := []struct {
+		test        string
+		tenant      *Tenant
+		expectedErr error
+	}{
+		{"Tenant added", &Tenant{ID: "hillside", Name: "Hillside FC", Hosts: []string{"hillside.example.com"}}, nil},
+		{"Tenant without ID", &Tenant{Name: "Hillside FC"}, ErrInvalidTenant},
+		{"Tenant without name", &Tenant{ID: "hillside"}, ErrInvalidTenant},
+		{"ID unusable in paths", &Tenant{ID: "hill/side", Name: "Hillside FC"}, ErrInvalidTenant},
+		{"Tenant already exists", &Tenant{ID: "riverside", Name: "Riverside FC"}, ErrTenantAlreadyExists},
+		{"Host already taken", &Tenant{ID: "hillside", Name: "Hillside FC", Hosts: []string{"Riverside.example.com"}}, ErrHostAlreadyTaken},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			r := newRegistry(is)
+
+			err := r.Add(tc.tenant)
+
+			is.Equal(err, tc.expectedErr)
+		})
+	}
+}
+
+func TestRegistry_GetByHost(t *testing.T) {
+	testCases := []struct {
+		test        string
+		host        string
+		expected    *Tenant
+		expectedErr error
+	}{
+		{"Tenant served on host", "riverside.example.com", riverside, nil},
+		{"Host with port", "riverside.example.com:8080", riverside, nil},
+		{"Host in another case", "Teammate.Lakeside.org", lakeside, nil},
+		{"Unknown host", "example.com", nil, ErrTenantNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			is := is.New(t)
+			r := newRegistry(is)
+
+			tenant, err := r.GetByHost(tc.host)
+
+			is.Equal(err, tc.expectedErr)
+			is.Equal(tenant, tc.expected)
+		})
+	}
+}
+
+func TestTenant_Setting(t *testing.T) {
+	is := is.New(t)
+	is.Equal(riverside.Setting("sport", "basketball"), "soccer")
+	is.Equal(lakeside.Setting("sport", "basketball"), "basketball")
+}
@@ -0,0 +1,11 @@
+// Package tenantid identifies the tenants of a deployment. It has no
+// dependencies, so storage and messaging can scope their data to a tenant
+// without depending on how tenants are hosted.
+package tenantid
+
+// ID identifies a tenant hosted in the deployment.
+type ID string
+
+// None is the tenant of deployments hosting a single club, whose data is
+// not scoped to a tenant.
+const None ID = ""